@@ -1,20 +1,36 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log"
 	"log/slog"
 	"os"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
+	"github.com/redis/go-redis/v9"
 
 	"S.P.A.R.T.A/backend/configs"
 	"S.P.A.R.T.A/backend/internal/ai/orchestrator"
+	"S.P.A.R.T.A/backend/internal/ai/prompts"
+	"S.P.A.R.T.A/backend/internal/ai/provider"
 	"S.P.A.R.T.A/backend/internal/client"
 	httpHandler "S.P.A.R.T.A/backend/internal/delivery/http/handler"
 	"S.P.A.R.T.A/backend/internal/delivery/http/route"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/aicall"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/auth"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/ratelimit"
+	domainrepo "S.P.A.R.T.A/backend/internal/domain/repository"
+	"S.P.A.R.T.A/backend/internal/domain/service/muscle"
+	"S.P.A.R.T.A/backend/internal/domain/service/records"
+	"S.P.A.R.T.A/backend/internal/domain/service/training"
+	"S.P.A.R.T.A/backend/internal/infrastructure/persistence"
 
 	// repositories
+	memoryRepo "S.P.A.R.T.A/backend/internal/repository/memory"
 	postgresRepo "S.P.A.R.T.A/backend/internal/repository/postgres"
 	redisRepo "S.P.A.R.T.A/backend/internal/repository/redis"
 
@@ -23,6 +39,7 @@ import (
 
 	"S.P.A.R.T.A/backend/pkg/cache"
 	"S.P.A.R.T.A/backend/pkg/database"
+	"S.P.A.R.T.A/backend/pkg/jwtkeys"
 )
 
 func main() {
@@ -35,6 +52,14 @@ func main() {
 	// Load Config
 	// =========================
 	cfg := configs.LoadConfig()
+	if err := cfg.Validate(); err != nil {
+		log.Fatal("invalid config:", err)
+	}
+
+	tokenKeys, err := jwtKeyring(cfg)
+	if err != nil {
+		log.Fatal("failed to load JWT keys:", err)
+	}
 
 	// =========================
 	// Database Connection
@@ -47,7 +72,10 @@ func main() {
 	// =========================
 	// External Clients
 	// =========================
-	openaiClient := client.NewOpenAIClient(cfg.OpenAIKey, cfg.OpenAIModel, cfg.OpenAIBase)
+	aiClient, err := provider.New(cfg.AIProvider, aiProviderSettings(cfg))
+	if err != nil {
+		log.Fatal("failed to configure AI provider:", err)
+	}
 
 	redisClient, err := cache.NewRedisClient(cache.RedisConfig{
 		Addr:     cfg.RedisAddr,
@@ -58,32 +86,117 @@ func main() {
 		log.Fatal("failed connect redis:", err)
 	}
 
+	mailer, err := newMailer(cfg.Mail)
+	if err != nil {
+		log.Fatal("failed to configure mailer:", err)
+	}
+
 	// =========================
 	// Repositories
 	// =========================
 	exerciseRepo := postgresRepo.NewExerciseRepository(db)
 	workoutRepo := postgresRepo.NewWorkoutRepository(db)
+	workoutPlanRepo := postgresRepo.NewWorkoutPlanRepository(db)
 	splitRepo := postgresRepo.NewSplitRepository(db)
 	nutritionRepo := postgresRepo.NewNutritionRepository(db)
 	plannerRepo := postgresRepo.NewPlannerRepository(db)
 	userRepo := postgresRepo.NewUserRepository(db)
 	adminInviteRepo := postgresRepo.NewAdminInviteRepository(db)
+	recordRepo := postgresRepo.NewPersonalRecordRepository(db)
+	aiCallRepo := postgresRepo.NewAICallRepository(db)
+	promptTemplateRepo := postgresRepo.NewPromptTemplateRepository(db)
+	chatRepo := postgresRepo.NewChatRepository(db)
+	agentActionRepo := postgresRepo.NewAgentActionRepository(db)
+	authSessionRepo := postgresRepo.NewAuthSessionRepository(db)
 	motivationRepo := redisRepo.NewMotivationRepository(redisClient)
 	exerciseCacheRepo := redisRepo.NewExerciseCacheRepository(redisClient)
+	tokenRevocationRepo := redisRepo.NewTokenRevocationRepository(redisClient)
+	rateLimitRepo, loginAttemptRepo := rateLimitStores(cfg.RateLimit.Store, redisClient)
+	oauthStateRepo := oauthStateStore(cfg.OAuthStateStore, redisClient)
+	uow := persistence.NewUnitOfWork(db)
 
 	// =========================
 	// Usecases
 	// =========================
-	workoutUC := ucImpl.NewWorkoutUsecase(workoutRepo)
-	splitUC := ucImpl.NewSplitUsecase(splitRepo)
+	e1rmFormula, ok := training.ParseOneRepMaxFormula(cfg.E1RMFormula)
+	if !ok {
+		log.Println("unknown E1RM_FORMULA, falling back to", e1rmFormula)
+	}
+	recordEngine := records.NewEngine(e1rmFormula)
+
+	workoutUC := ucImpl.NewWorkoutUsecase(workoutRepo, uow, recordEngine)
+	recordUC := ucImpl.NewPersonalRecordUsecase(recordRepo, workoutRepo, recordEngine, uow)
+	landmarks, skipped := muscle.ParseLandmarks(cfg.MuscleLandmarks, muscle.DefaultLandmarks())
+	if len(skipped) > 0 {
+		log.Println("ignoring invalid MUSCLE_LANDMARKS entries:", skipped)
+	}
+	analyticsUC := ucImpl.NewAnalyticsUsecase(workoutRepo, exerciseRepo, e1rmFormula, training.MuscleVolumeConfig{
+		Landmarks:       landmarks,
+		SecondaryWeight: cfg.SecondarySetWeight,
+	})
+	splitUC := ucImpl.NewSplitUsecase(splitRepo, uow)
 	nutritionUC := ucImpl.NewNutritionUsecase(nutritionRepo)
 	exerciseUC := ucImpl.NewExerciseUsecase(exerciseRepo, exerciseCacheRepo)
 
-	aiOrchestrator := orchestrator.NewOrchestrator(openaiClient)
-	aiCoachUC := ucImpl.NewAICoachUsecase(aiOrchestrator, splitRepo, exerciseRepo, plannerRepo, workoutRepo, nutritionRepo, motivationRepo)
+	aiMode, ok := orchestrator.ParseMode(cfg.AIMode)
+	if !ok {
+		log.Println("unknown AI_MODE, falling back to", aiMode)
+	}
+	if provider.IsRules(cfg.AIProvider) {
+		// The rules engine answers in place of the orchestrator's model
+		// pipeline, so every response is labelled source "rules".
+		aiMode = orchestrator.ModeRules
+	}
+	// Local OpenAI-compatible servers usually need no key; only OpenAI
+	// itself is certain to refuse a keyless request.
+	if aiMode == orchestrator.ModeAuto && strings.EqualFold(strings.TrimSpace(cfg.AIProvider), provider.OpenAI) &&
+		cfg.OpenAIKey == "" && strings.TrimRight(cfg.OpenAIBase, "/") == client.DefaultOpenAIBaseURL {
+		// Every call would fail and fall back anyway; skip the retries.
+		log.Println("OPENAI_API_KEY not set, AI endpoints will answer with rules")
+		aiMode = orchestrator.ModeRules
+	}
+	aiAuditUC := ucImpl.NewAIAuditUsecase(aiCallRepo, aicall.Quota{
+		UserDailyCalls:    int64(cfg.AIQuota.UserDailyCalls),
+		UserDailyTokens:   int64(cfg.AIQuota.UserDailyTokens),
+		GlobalDailyCalls:  int64(cfg.AIQuota.GlobalDailyCalls),
+		GlobalDailyTokens: int64(cfg.AIQuota.GlobalDailyTokens),
+	})
+	// Prompt templates: built-in, then AI_PROMPT_DIR, then the database.
+	promptRegistry := prompts.Default()
+	promptRegistry.SetSamples(orchestrator.PromptSamples)
+	if cfg.AIPromptDir != "" {
+		templates, err := prompts.LoadDir(cfg.AIPromptDir)
+		if err == nil {
+			err = promptRegistry.Load(templates...)
+		}
+		if err != nil {
+			log.Println("ignoring AI_PROMPT_DIR:", err)
+		}
+	}
+	if templates, err := promptTemplateRepo.List(context.Background()); err != nil {
+		log.Println("could not load prompt templates from database:", err)
+	} else if err := promptRegistry.Load(templates...); err != nil {
+		log.Println("ignoring prompt templates from database:", err)
+	}
+
+	aiOrchestrator := orchestrator.NewWithMode(aiMode, aiClient, orchestrator.WithAuditor(aiAuditUC), orchestrator.WithPromptRegistry(promptRegistry), orchestrator.WithExerciseCatalog(exerciseRepo.List))
+	aiCoachUC := ucImpl.NewAICoachUsecase(aiOrchestrator, splitRepo, exerciseRepo, plannerRepo, workoutRepo, workoutPlanRepo, nutritionRepo, motivationRepo, userRepo, uow)
 	plannerUC := ucImpl.NewPlannerUsecase(plannerRepo, aiCoachUC)
-	authUC := ucImpl.NewAuthUsecase(userRepo, adminInviteRepo, cfg.JWTSecret)
+	authUC := ucImpl.NewAuthUsecase(userRepo, adminInviteRepo, authSessionRepo, tokenRevocationRepo, uow, tokenKeys, cfg.AccessTokenTTL, cfg.RefreshTokenTTL, mailer, auth.AccountMail{
+		AppURL:           cfg.AppURL,
+		PasswordResetTTL: cfg.PasswordResetTTL,
+		VerificationTTL:  cfg.EmailVerificationTTL,
+	}, loginAttemptRepo, auth.LockoutPolicy{
+		Threshold: cfg.LoginLockout.Threshold,
+		BaseDelay: cfg.LoginLockout.BaseDelay,
+		MaxDelay:  cfg.LoginLockout.MaxDelay,
+		Window:    cfg.LoginLockout.Window,
+	}, oauthProviders(cfg.OAuthProviders), oauthStateRepo)
 	adminUC := ucImpl.NewAdminUsecase(adminInviteRepo)
+	userUC := ucImpl.NewUserUsecase(userRepo)
+	agentUC := ucImpl.NewAgentUsecase(aiOrchestrator, agentActionRepo, workoutUC, exerciseUC, splitUC, userRepo, uow)
+	workoutPlanUC := ucImpl.NewWorkoutPlanUsecase(workoutPlanRepo, workoutRepo, exerciseRepo, aiCoachUC, uow)
+	chatUC := ucImpl.NewChatUsecase(chatRepo, aiOrchestrator, workoutRepo, exerciseRepo, nutritionRepo, plannerRepo, splitRepo, userRepo, cfg.AIChatContextTokens)
 
 	// =========================
 	// Handlers
@@ -95,7 +208,13 @@ func main() {
 	exerciseHandler := httpHandler.NewExerciseHandler(exerciseUC)
 	aiCoachHandler := httpHandler.NewAICoachHandler(aiCoachUC)
 	authHandler := httpHandler.NewAuthHandler(authUC)
-	adminHandler := httpHandler.NewAdminHandler(adminUC)
+	adminHandler := httpHandler.NewAdminHandler(adminUC, aiAuditUC)
+	recordHandler := httpHandler.NewRecordHandler(recordUC)
+	analyticsHandler := httpHandler.NewAnalyticsHandler(analyticsUC)
+	userHandler := httpHandler.NewUserHandler(userUC)
+	chatHandler := httpHandler.NewChatHandler(chatUC)
+	agentHandler := httpHandler.NewAgentHandler(agentUC)
+	workoutPlanHandler := httpHandler.NewWorkoutPlanHandler(workoutPlanUC)
 
 	// =========================
 	// Router
@@ -109,9 +228,28 @@ func main() {
 		aiCoachHandler,
 		authHandler,
 		adminHandler,
-		cfg.JWTSecret,
+		recordHandler,
+		analyticsHandler,
+		userHandler,
+		chatHandler,
+		agentHandler,
+		workoutPlanHandler,
+		tokenKeys,
+		tokenRevocationRepo.TokenState,
+		rateLimitRepo.Allow,
+		route.RateLimits{
+			Auth: rateLimitPolicy("auth", cfg.RateLimit.Auth),
+			AI:   rateLimitPolicy("ai", cfg.RateLimit.AI),
+			API:  rateLimitPolicy("api", cfg.RateLimit.API),
+		},
 	)
 
+	// Only listed proxies may set X-Forwarded-For; otherwise any client
+	// could pick the IP that rate limits and login lockouts key on.
+	if err := router.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		log.Fatal("invalid TRUSTED_PROXIES:", err)
+	}
+
 	// =========================
 	// Start Server
 	// =========================
@@ -121,3 +259,107 @@ func main() {
 		log.Fatal(err)
 	}
 }
+
+// jwtKeyring signs with the PEM keys in JWT_KEYS_DIR when set and falls
+// back to the shared JWT_SECRET otherwise.
+func jwtKeyring(cfg *configs.Config) (*jwtkeys.Keyring, error) {
+	if cfg.JWTKeysDir == "" {
+		return jwtkeys.NewHMAC(cfg.JWTSecret)
+	}
+	keys, err := jwtkeys.LoadDir(cfg.JWTKeysDir, cfg.JWTActiveKID)
+	if err != nil {
+		return nil, err
+	}
+	slog.Info("jwt keys loaded", "dir", cfg.JWTKeysDir, "active_kid", keys.ActiveKID(), "keys", len(keys.JWKS().Keys))
+	return keys, nil
+}
+
+// rateLimitStores keeps rate limit and login failure counters in Redis, so
+// every instance shares them, or in process memory with
+// RATE_LIMIT_STORE=memory.
+func rateLimitStores(store string, redisClient *redis.Client) (domainrepo.RateLimitRepository, domainrepo.LoginAttemptRepository) {
+	if strings.EqualFold(strings.TrimSpace(store), "memory") {
+		return memoryRepo.NewRateLimitRepository(), memoryRepo.NewLoginAttemptRepository()
+	}
+	return redisRepo.NewRateLimitRepository(redisClient), redisRepo.NewLoginAttemptRepository(redisClient)
+}
+
+func oauthStateStore(store string, redisClient *redis.Client) domainrepo.OAuthStateRepository {
+	if strings.EqualFold(strings.TrimSpace(store), "memory") {
+		return memoryRepo.NewOAuthStateRepository()
+	}
+	return redisRepo.NewOAuthStateRepository(redisClient)
+}
+
+// oauthProviders builds the configured sign-in providers. OIDC discovery
+// runs on first use, so an unreachable issuer does not stop the server.
+func oauthProviders(cfgs []configs.OAuthProvider) map[string]client.OAuthProvider {
+	providers := make(map[string]client.OAuthProvider, len(cfgs))
+	for _, p := range cfgs {
+		oauthCfg := client.OAuthConfig{
+			ClientID:     p.ClientID,
+			ClientSecret: p.ClientSecret,
+			RedirectURL:  p.RedirectURL,
+			Scopes:       p.Scopes,
+		}
+		if p.Kind == "github" {
+			providers[p.Name] = client.NewGitHubProvider(oauthCfg)
+		} else {
+			providers[p.Name] = client.NewOIDCProvider(p.Issuer, oauthCfg)
+		}
+		slog.Info("oauth provider enabled", "provider", p.Name, "kind", p.Kind, "redirect_url", p.RedirectURL)
+	}
+	return providers
+}
+
+func rateLimitPolicy(name string, limit configs.RateLimit) ratelimit.Policy {
+	return ratelimit.Policy{Name: name, Limit: limit.Limit, Window: limit.Window}
+}
+
+func newMailer(cfg configs.MailConfig) (client.Mailer, error) {
+	switch strings.ToLower(strings.TrimSpace(cfg.Driver)) {
+	case "smtp":
+		if cfg.SMTPHost == "" {
+			return nil, errors.New("SMTP_HOST is required with MAIL_DRIVER=smtp")
+		}
+		return client.NewSMTPMailer(client.SMTPConfig{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.From,
+		})
+	case "", "outbox":
+		return client.NewOutboxMailer(cfg.OutboxDir, cfg.From), nil
+	default:
+		return nil, fmt.Errorf("unknown MAIL_DRIVER %q", cfg.Driver)
+	}
+}
+
+func aiProviderSettings(cfg *configs.Config) provider.Settings {
+	toOptions := func(o configs.LLMOptions) client.GenerationOptions {
+		return client.GenerationOptions{
+			Temperature: o.Temperature,
+			MaxTokens:   o.MaxTokens,
+			JSONMode:    o.JSONMode,
+
+			StructuredOutput: o.StructuredOutput,
+		}
+	}
+
+	switch strings.ToLower(strings.TrimSpace(cfg.AIProvider)) {
+	case provider.Ollama:
+		return provider.Settings{
+			BaseURL: cfg.OllamaBase,
+			Model:   cfg.OllamaModel,
+			Options: toOptions(cfg.OllamaOptions),
+		}
+	default:
+		return provider.Settings{
+			BaseURL: cfg.OpenAIBase,
+			APIKey:  cfg.OpenAIKey,
+			Model:   cfg.OpenAIModel,
+			Options: toOptions(cfg.OpenAIOptions),
+		}
+	}
+}
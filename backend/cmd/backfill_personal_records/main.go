@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+
+	"S.P.A.R.T.A/backend/configs"
+	"S.P.A.R.T.A/backend/internal/domain/service/records"
+	"S.P.A.R.T.A/backend/internal/domain/service/training"
+	"S.P.A.R.T.A/backend/internal/infrastructure/persistence"
+	postgresRepo "S.P.A.R.T.A/backend/internal/repository/postgres"
+	ucImpl "S.P.A.R.T.A/backend/internal/usecase"
+	"S.P.A.R.T.A/backend/pkg/database"
+	"github.com/joho/godotenv"
+)
+
+// Rebuilds personal_records from logged workout history. Safe to re-run:
+// each user's records are replaced in a single transaction.
+func main() {
+	_ = godotenv.Load()
+
+	userFlag := flag.String("user", "", "only rebuild records for this user id")
+	flag.Parse()
+
+	cfg := configs.LoadConfig()
+
+	formula, ok := training.ParseOneRepMaxFormula(cfg.E1RMFormula)
+	if !ok {
+		log.Println("unknown E1RM_FORMULA, falling back to", formula)
+	}
+
+	db, err := database.NewPostgresConnection(cfg.DatabaseURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+
+	recordUC := ucImpl.NewPersonalRecordUsecase(
+		postgresRepo.NewPersonalRecordRepository(db),
+		postgresRepo.NewWorkoutRepository(db),
+		records.NewEngine(formula),
+		persistence.NewUnitOfWork(db),
+	)
+
+	var userIDs []string
+	if id := strings.TrimSpace(*userFlag); id != "" {
+		userIDs = []string{id}
+	} else {
+		rows, err := db.QueryContext(ctx, `SELECT DISTINCT user_id FROM workout_sessions WHERE user_id IS NOT NULL`)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				log.Fatal(err)
+			}
+			userIDs = append(userIDs, id)
+		}
+		if err := rows.Err(); err != nil {
+			log.Fatal(err)
+		}
+		rows.Close()
+	}
+
+	total := 0
+	for _, id := range userIDs {
+		n, err := recordUC.RebuildUserRecords(ctx, id)
+		if err != nil {
+			log.Printf("user %s: %v", id, err)
+			continue
+		}
+		total += n
+	}
+
+	fmt.Printf("rebuilt %d personal records for %d users (formula=%s)\n", total, len(userIDs), formula)
+}
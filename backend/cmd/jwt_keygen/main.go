@@ -0,0 +1,84 @@
+package main
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"S.P.A.R.T.A/backend/pkg/jwtkeys"
+)
+
+// jwt_keygen writes a new signing key to <dir>/<kid>.pem and checks that the
+// directory still loads. It never changes the active key; see the README
+// for the rotation procedure.
+func main() {
+	dir := flag.String("dir", os.Getenv("JWT_KEYS_DIR"), "key directory (defaults to JWT_KEYS_DIR)")
+	alg := flag.String("alg", "EdDSA", "signing algorithm: EdDSA or RS256")
+	kid := flag.String("kid", "", "key id (defaults to <date>-<random>)")
+	flag.Parse()
+
+	if strings.TrimSpace(*dir) == "" {
+		log.Fatal("missing -dir or JWT_KEYS_DIR")
+	}
+	if *kid == "" {
+		suffix := make([]byte, 3)
+		if _, err := rand.Read(suffix); err != nil {
+			log.Fatal(err)
+		}
+		*kid = time.Now().UTC().Format("20060102") + "-" + hex.EncodeToString(suffix)
+	}
+	if strings.ContainsAny(*kid, `/\. `) {
+		log.Fatal("kid must not contain slashes, dots or spaces")
+	}
+
+	var key crypto.Signer
+	var err error
+	switch strings.ToUpper(*alg) {
+	case "EDDSA":
+		_, key, err = ed25519.GenerateKey(rand.Reader)
+	case "RS256":
+		key, err = rsa.GenerateKey(rand.Reader, 3072)
+	default:
+		log.Fatalf("unsupported -alg %q", *alg)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.MkdirAll(*dir, 0o700); err != nil {
+		log.Fatal(err)
+	}
+	path := filepath.Join(*dir, *kid+".pem")
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := pem.Encode(f, &pem.Block{Type: "PRIVATE KEY", Bytes: der}); err != nil {
+		_ = f.Close()
+		log.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		log.Fatal(err)
+	}
+
+	// Loading with the new kid active proves the whole directory is valid.
+	if _, err := jwtkeys.LoadDir(*dir, *kid); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("wrote %s (kid=%s)\n", path, *kid)
+}
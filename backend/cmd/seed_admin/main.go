@@ -21,7 +21,7 @@ func main() {
 	_ = godotenv.Load()
 
 	cfg := configs.LoadConfig()
-	if strings.ToLower(strings.TrimSpace(cfg.AppEnv)) != "local" {
+	if !cfg.IsLocal() {
 		log.Fatal("seed_admin is only allowed when APP_ENV=local")
 	}
 
@@ -59,8 +59,10 @@ func main() {
 		Email:        email,
 		PasswordHash: string(hash),
 		Role:         "admin",
-		CreatedAt:    now,
-		UpdatedAt:    now,
+		// Seeded locally, so there is no inbox to verify.
+		EmailVerifiedAt: &now,
+		CreatedAt:       now,
+		UpdatedAt:       now,
 	}
 
 	if err := userRepo.Create(ctx, u); err != nil {
@@ -0,0 +1,43 @@
+package configs
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestValidateRefusesTheDefaultSecretOutsideLocal(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr bool
+	}{
+		{name: "APP_ENV unset", env: map[string]string{}, wantErr: true},
+		{name: "production", env: map[string]string{"APP_ENV": "production"}, wantErr: true},
+		{name: "staging", env: map[string]string{"APP_ENV": "staging"}, wantErr: true},
+		{name: "local", env: map[string]string{"APP_ENV": "local"}},
+		{name: "local in capitals", env: map[string]string{"APP_ENV": " Local "}},
+		{name: "production with a secret", env: map[string]string{"APP_ENV": "production", "JWT_SECRET": "s3cret"}},
+		{name: "production with signing keys", env: map[string]string{"APP_ENV": "production", "JWT_KEYS_DIR": "/keys"}},
+		{name: "production with an empty secret", env: map[string]string{"APP_ENV": "production", "JWT_SECRET": ""}, wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, k := range []string{"APP_ENV", "JWT_SECRET", "JWT_KEYS_DIR", "OAUTH_PROVIDERS"} {
+				t.Setenv(k, "")
+				os.Unsetenv(k)
+			}
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+
+			err := LoadConfig().Validate()
+			if tc.wantErr && (err == nil || !strings.Contains(err.Error(), "JWT_SECRET")) {
+				t.Fatalf("Validate() = %v, want the default secret refused", err)
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("Validate() = %v, want nil", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package orchestrator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/ai/prompts"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/aicall"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/coach"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+)
+
+// defaultAgentMaxSteps bounds the model round-trips of one agent run.
+const defaultAgentMaxSteps = 6
+
+func (s *service) RunAgent(ctx context.Context, input AgentInput) (*AgentOutput, error) {
+	client, ok := s.client.(ToolCallingAIClient)
+	if !ok {
+		return nil, fmt.Errorf("%w: provider does not support tool calling", domainerr.ErrAIUnavailable)
+	}
+	if input.Tools == nil {
+		return nil, fmt.Errorf("%w: agent run without tools", domainerr.ErrInternal)
+	}
+
+	meta := callMeta{op: "agent", userID: input.UserID}
+	system, err := s.render(&meta, prompts.Agent, input)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkQuota(ctx, meta); err != nil {
+		return nil, err
+	}
+
+	maxSteps := input.MaxSteps
+	if maxSteps <= 0 {
+		maxSteps = defaultAgentMaxSteps
+	}
+
+	tools := input.Tools.Tools()
+	messages := []AgentMessage{
+		{Role: AgentRoleSystem, Content: system},
+		{Role: AgentRoleUser, Content: input.Question},
+	}
+	out := &AgentOutput{Steps: []AgentStep{}}
+
+	for step := 0; step < maxSteps; step++ {
+		reply, rec, err := s.completeWithTools(ctx, meta, step, client, messages, tools)
+		s.record(ctx, rec, err)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(reply.ToolCalls) == 0 {
+			text := strings.TrimSpace(reply.Content)
+			if text == "" {
+				return nil, fmt.Errorf("%w: empty agent reply", domainerr.ErrAIUnavailable)
+			}
+			out.Reply = text
+			out.Source = coach.SourceAI
+			out.PromptVersion = meta.version
+			return out, nil
+		}
+
+		messages = append(messages, AgentMessage{Role: AgentRoleAssistant, Content: reply.Content, ToolCalls: reply.ToolCalls})
+		for _, call := range reply.ToolCalls {
+			result, err := input.Tools.Execute(ctx, call)
+			st := AgentStep{Tool: call.Name, Arguments: call.Arguments, Result: result}
+			if err != nil {
+				if !isToolCallError(err) {
+					return nil, err
+				}
+				st.Error = err.Error()
+				result = "error: " + err.Error()
+			}
+			out.Steps = append(out.Steps, st)
+			messages = append(messages, AgentMessage{Role: AgentRoleTool, Content: result, ToolCallID: call.ID})
+		}
+	}
+	return nil, fmt.Errorf("%w: agent did not answer within %d steps", domainerr.ErrAIUnavailable, maxSteps)
+}
+
+// completeWithTools sends the conversation so far, retrying transport
+// failures. The audit record hashes the whole conversation.
+func (s *service) completeWithTools(ctx context.Context, meta callMeta, attempt int, client ToolCallingAIClient, messages []AgentMessage, tools []ToolSpec) (*AgentReply, aicall.Call, error) {
+	conversation, err := json.Marshal(messages)
+	if err != nil {
+		return nil, aicall.Call{}, domainerr.ErrInternal
+	}
+
+	var reply *AgentReply
+	_, rec, err := s.observe(ctx, meta, attempt, string(conversation), func(ctx context.Context) (string, error) {
+		err := WithRetry(ctx, RetryConfig{MaxAttempts: 3, Delay: 250 * time.Millisecond}, func(ctx context.Context) error {
+			out, err := client.GenerateWithTools(ctx, messages, tools)
+			if err != nil {
+				return err
+			}
+			reply = out
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+		raw, _ := json.Marshal(reply)
+		return string(raw), nil
+	})
+	if err != nil {
+		return nil, rec, err
+	}
+	return reply, rec, nil
+}
+
+func isToolCallError(err error) bool {
+	return errors.Is(err, domainerr.ErrInvalidInput) ||
+		errors.Is(err, domainerr.ErrNotFound) ||
+		errors.Is(err, domainerr.ErrForbidden) ||
+		errors.Is(err, domainerr.ErrConflict)
+}
+
+// RunAgent only falls back while no tool has run: the rules reply says
+// nothing was looked up or changed, and a write tool may already have
+// stored a change awaiting confirmation.
+func (s *fallbackService) RunAgent(ctx context.Context, input AgentInput) (*AgentOutput, error) {
+	primaryInput := input
+	tools := &usedTools{ToolExecutor: input.Tools}
+	if input.Tools != nil {
+		primaryInput.Tools = tools
+	}
+	out, err := s.primary.RunAgent(ctx, primaryInput)
+	if err == nil || tools.used || ctx.Err() != nil || isQuotaError(err) {
+		return out, err
+	}
+	slog.WarnContext(ctx, "ai unavailable, answering with rules", "op", "agent", "error", err)
+	return s.fallback.RunAgent(ctx, input)
+}
+
+// usedTools records whether any tool call was executed.
+type usedTools struct {
+	ToolExecutor
+	used bool
+}
+
+func (t *usedTools) Execute(ctx context.Context, call ToolCall) (string, error) {
+	t.used = true
+	return t.ToolExecutor.Execute(ctx, call)
+}
+
+// RunAgent without a model cannot plan anything; it says so and leaves the
+// plan untouched.
+func (rulesService) RunAgent(ctx context.Context, input AgentInput) (*AgentOutput, error) {
+	return &AgentOutput{
+		Reply:  "The AI agent is not available right now, so nothing was looked up or changed. Try again later or edit your plan directly.",
+		Steps:  []AgentStep{},
+		Source: coach.SourceRules,
+	}, nil
+}
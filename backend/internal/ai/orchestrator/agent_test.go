@@ -0,0 +1,69 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/coach"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+)
+
+// toolThenFailClient asks for one tool call, then fails every later call.
+// With failFirst it fails from the start.
+type toolThenFailClient struct {
+	failFirst bool
+	calls     int
+}
+
+func (c *toolThenFailClient) Generate(context.Context, string) (string, error) {
+	return "", errors.New("toolThenFailClient only answers tool calls")
+}
+
+func (c *toolThenFailClient) GenerateWithTools(context.Context, []AgentMessage, []ToolSpec) (*AgentReply, error) {
+	c.calls++
+	if c.failFirst || c.calls > 1 {
+		return nil, domainerr.ErrAIUnavailable
+	}
+	return &AgentReply{ToolCalls: []ToolCall{{ID: "c1", Name: "update_split_day", Arguments: []byte(`{}`)}}}, nil
+}
+
+type countingTools struct{ executed int }
+
+func (t *countingTools) Tools() []ToolSpec { return []ToolSpec{{Name: "update_split_day"}} }
+
+func (t *countingTools) Execute(context.Context, ToolCall) (string, error) {
+	t.executed++
+	return `{"status":"awaiting_user_confirmation"}`, nil
+}
+
+func TestAgentFallsBackOnlyBeforeAnyToolRan(t *testing.T) {
+	tests := []struct {
+		name      string
+		failFirst bool
+		wantRules bool
+	}{
+		{name: "model down from the start", failFirst: true, wantRules: true},
+		{name: "model fails after a tool call", failFirst: false, wantRules: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tools := &countingTools{}
+			o := NewFallbackOrchestrator(NewOrchestrator(&toolThenFailClient{failFirst: tc.failFirst}), NewRuleBasedOrchestrator())
+
+			out, err := o.RunAgent(context.Background(), AgentInput{UserID: "u1", Question: "Swap squats for lunges", Tools: tools})
+			if tc.wantRules {
+				if err != nil || out.Source != coach.SourceRules {
+					t.Fatalf("RunAgent = %+v, %v, want the rules reply", out, err)
+				}
+				return
+			}
+			if !errors.Is(err, domainerr.ErrAIUnavailable) {
+				t.Fatalf("RunAgent = %+v, %v, want the model's error", out, err)
+			}
+			if tools.executed != 1 {
+				t.Errorf("executed %d tools, want 1", tools.executed)
+			}
+		})
+	}
+}
@@ -5,3 +5,27 @@ import "context"
 type AIClient interface {
 	Generate(ctx context.Context, prompt string) (string, error)
 }
+
+// StreamingAIClient is implemented by clients that can emit the completion
+// as it is generated. onDelta receives each text fragment in order; the full
+// text is returned once the stream ends. Returning an error from onDelta
+// aborts the stream.
+type StreamingAIClient interface {
+	AIClient
+	GenerateStream(ctx context.Context, prompt string, onDelta func(delta string) error) (string, error)
+}
+
+// StructuredAIClient is implemented by clients that can constrain the
+// completion to a JSON schema (OpenAI response_format, Ollama format).
+type StructuredAIClient interface {
+	AIClient
+	GenerateStructured(ctx context.Context, prompt string, schema Schema) (string, error)
+}
+
+// ToolCallingAIClient is implemented by clients that support function
+// calling. The model either answers in text or asks for tools to be run;
+// the caller runs them and continues the conversation with the results.
+type ToolCallingAIClient interface {
+	AIClient
+	GenerateWithTools(ctx context.Context, messages []AgentMessage, tools []ToolSpec) (*AgentReply, error)
+}
@@ -0,0 +1,143 @@
+package orchestrator
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/aicall"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+)
+
+// Auditor persists model calls and enforces usage quotas.
+type Auditor interface {
+	// CheckQuota returns an error wrapping ErrQuotaExceeded when userID (or
+	// everyone) has used up the daily allowance.
+	CheckQuota(ctx context.Context, userID string) error
+	// Record stores one model call. It must not fail the request.
+	Record(ctx context.Context, call aicall.Call)
+}
+
+// WithAuditor records every model call and checks quotas before each
+// operation.
+func WithAuditor(a Auditor) Option {
+	return func(s *service) { s.auditor = a }
+}
+
+// Usage is what a client reports about one completion.
+type Usage struct {
+	Provider         string
+	Model            string
+	PromptTokens     int
+	CompletionTokens int
+	TotalTokens      int
+}
+
+type usageKey struct{}
+
+type usageSink struct {
+	mu    sync.Mutex
+	usage Usage
+}
+
+// ReportUsage lets a client attach provider, model and token counts to the
+// call in progress. Retried requests add up. It is a no-op when nobody is
+// listening.
+func ReportUsage(ctx context.Context, u Usage) {
+	sink, ok := ctx.Value(usageKey{}).(*usageSink)
+	if !ok {
+		return
+	}
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	if u.Provider != "" {
+		sink.usage.Provider = u.Provider
+	}
+	if u.Model != "" {
+		sink.usage.Model = u.Model
+	}
+	if u.TotalTokens == 0 {
+		u.TotalTokens = u.PromptTokens + u.CompletionTokens
+	}
+	sink.usage.PromptTokens += u.PromptTokens
+	sink.usage.CompletionTokens += u.CompletionTokens
+	sink.usage.TotalTokens += u.TotalTokens
+}
+
+func withUsageSink(ctx context.Context) (context.Context, *usageSink) {
+	sink := &usageSink{}
+	return context.WithValue(ctx, usageKey{}, sink), sink
+}
+
+// callMeta identifies the orchestrator operation a model call belongs to
+// and the prompt version it was rendered from.
+type callMeta struct {
+	op      string
+	userID  string
+	version string
+}
+
+func (s *service) checkQuota(ctx context.Context, meta callMeta) error {
+	if s.auditor == nil {
+		return nil
+	}
+	return s.auditor.CheckQuota(ctx, meta.userID)
+}
+
+// observe runs one model call and returns the audit record describing it.
+// The outcome is filled in by record once the answer has been decoded.
+func (s *service) observe(ctx context.Context, meta callMeta, attempt int, prompt string, call func(context.Context) (string, error)) (string, aicall.Call, error) {
+	ctx, sink := withUsageSink(ctx)
+	started := time.Now()
+	resp, err := call(ctx)
+
+	sum := sha256.Sum256([]byte(prompt))
+	rec := aicall.Call{
+		Operation:        meta.op,
+		Attempt:          attempt,
+		Provider:         sink.usage.Provider,
+		Model:            sink.usage.Model,
+		PromptHash:       hex.EncodeToString(sum[:]),
+		PromptVersion:    meta.version,
+		Response:         resp,
+		LatencyMs:        int(time.Since(started).Milliseconds()),
+		PromptTokens:     sink.usage.PromptTokens,
+		CompletionTokens: sink.usage.CompletionTokens,
+		TotalTokens:      sink.usage.TotalTokens,
+		CreatedAt:        started.UTC(),
+	}
+	if meta.userID != "" {
+		uid := meta.userID
+		rec.UserID = &uid
+	}
+	return resp, rec, err
+}
+
+// record stores rec with the outcome implied by err (the transport or
+// decode error for this call, nil on success).
+func (s *service) record(ctx context.Context, rec aicall.Call, err error) {
+	if s.auditor == nil {
+		return
+	}
+
+	var invalid *ValidationError
+	switch {
+	case err == nil:
+		rec.Outcome = aicall.OutcomeOK
+	case errors.As(err, &invalid):
+		rec.Outcome = aicall.OutcomeInvalidOutput
+		rec.Error = err.Error()
+	default:
+		rec.Outcome = aicall.OutcomeError
+		rec.Error = err.Error()
+	}
+	// Calls are recorded even when the request was cancelled mid-way.
+	s.auditor.Record(context.WithoutCancel(ctx), rec)
+}
+
+func isQuotaError(err error) bool {
+	return errors.Is(err, domainerr.ErrQuotaExceeded)
+}
@@ -0,0 +1,58 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/exercise"
+	"S.P.A.R.T.A/backend/internal/domain/service/exercisematch"
+)
+
+// ExerciseCatalog lists the exercises generated plans may use.
+type ExerciseCatalog func(ctx context.Context) ([]exercise.Exercise, error)
+
+// maxSuggestions bounds the library names offered for each unknown exercise
+// in a repair prompt.
+const maxSuggestions = 3
+
+// checkLibrary reports every name that neither matches a catalog exercise
+// confidently nor is in allowed. A missing catalog, or one that cannot be
+// loaded or is empty, skips the check: an unseeded library should not block
+// generation.
+func (s *service) checkLibrary(ctx context.Context, names, allowed []string) error {
+	if s.catalog == nil || len(names) == 0 {
+		return nil
+	}
+	library, err := s.catalog(ctx)
+	if err != nil || len(library) == 0 {
+		return nil
+	}
+
+	matcher := exercisematch.NewMatcher(library, 0)
+	known := make(map[string]bool, len(allowed))
+	for _, n := range allowed {
+		known[exercisematch.Normalize(n)] = true
+	}
+
+	var p problems
+	for _, name := range names {
+		if known[exercisematch.Normalize(name)] {
+			continue
+		}
+		if _, ok := matcher.Match(name); ok {
+			continue
+		}
+		suggestions := matcher.Suggest(name, maxSuggestions)
+		if len(suggestions) == 0 {
+			p.addf("exercise %q is not in the exercise library", name)
+			continue
+		}
+		quoted := make([]string, 0, len(suggestions))
+		for _, c := range suggestions {
+			quoted = append(quoted, fmt.Sprintf("%q", c.Exercise.Name))
+		}
+		p.addf("exercise %q is not in the exercise library; use one of: %s", name, strings.Join(quoted, ", "))
+	}
+	return p.err()
+}
@@ -0,0 +1,138 @@
+package orchestrator
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"S.P.A.R.T.A/backend/internal/ai/prompts"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/coach"
+)
+
+// DefaultChatTokenBudget applies when ChatInput.TokenBudget is not set.
+const DefaultChatTokenBudget = 2000
+
+// chatContextShare is the part of the budget reserved for context items;
+// the rest goes to the conversation history.
+const chatContextShare = 0.6
+
+// EstimateTokens approximates the token count of s (about four characters
+// per token for English text). It only has to be good enough for budgeting.
+func EstimateTokens(s string) int {
+	return (utf8.RuneCountInString(s) + 3) / 4
+}
+
+// FitChat trims input to its token budget. The question is always kept.
+// Context items are taken in order while they fit; history is kept from the
+// newest turn backwards and may use whatever the context left over.
+func FitChat(input ChatInput) ChatInput {
+	budget := input.TokenBudget
+	if budget <= 0 {
+		budget = DefaultChatTokenBudget
+	}
+	remaining := budget - EstimateTokens(input.Question)
+
+	contextBudget := int(float64(remaining) * chatContextShare)
+	var items []ContextItem
+	for _, item := range input.Context {
+		cost := EstimateTokens(item.ID) + EstimateTokens(item.Text) + 2
+		if cost > contextBudget {
+			// Later items may still fit; they are usually shorter.
+			continue
+		}
+		contextBudget -= cost
+		remaining -= cost
+		items = append(items, item)
+	}
+
+	var history []ChatTurn
+	for i := len(input.History) - 1; i >= 0; i-- {
+		cost := EstimateTokens(input.History[i].Content) + 2
+		if cost > remaining {
+			break
+		}
+		remaining -= cost
+		history = append(history, input.History[i])
+	}
+	for i, j := 0, len(history)-1; i < j; i, j = i+1, j-1 {
+		history[i], history[j] = history[j], history[i]
+	}
+
+	out := input
+	out.Context = items
+	out.History = history
+	return out
+}
+
+func decodeChat(input ChatInput) func(string) (*ChatOutput, error) {
+	return func(resp string) (*ChatOutput, error) {
+		out, err := ParseChatResponse(resp)
+		if err != nil {
+			return nil, err
+		}
+		if err := ValidateChat(out, input); err != nil {
+			return nil, err
+		}
+		out.Reply = strings.TrimSpace(out.Reply)
+		out.Citations = uniqueCitations(out.Citations)
+		return out, nil
+	}
+}
+
+func uniqueCitations(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		out = append(out, id)
+	}
+	return out
+}
+
+func (s *service) Chat(ctx context.Context, input ChatInput) (*ChatOutput, error) {
+	input = FitChat(input)
+
+	meta := callMeta{op: "chat", userID: input.UserID}
+	prompt, err := s.render(&meta, prompts.Chat, input)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := generate(ctx, s, meta, prompt, ChatSchema, decodeChat(input))
+	if err != nil {
+		return nil, err
+	}
+	out.Source = coach.SourceAI
+	out.PromptVersion = meta.version
+	return out, nil
+}
+
+func (s *fallbackService) Chat(ctx context.Context, input ChatInput) (*ChatOutput, error) {
+	return withFallback(ctx, "chat", input, s.primary.Chat, s.fallback.Chat)
+}
+
+// Chat without a model can only restate the data: it answers with the load
+// summary and points to the coaching suggestions.
+func (rulesService) Chat(ctx context.Context, input ChatInput) (*ChatOutput, error) {
+	input = FitChat(input)
+
+	out := &ChatOutput{
+		Reply:     "The AI coach is not available right now, so I can only share what your data shows.",
+		Citations: []string{},
+		Source:    coach.SourceRules,
+	}
+	for _, item := range input.Context {
+		if item.Kind != "load" {
+			continue
+		}
+		out.Reply += " " + strings.TrimSpace(item.Text) + "."
+		out.Citations = append(out.Citations, item.ID)
+		break
+	}
+	out.Reply += " Check the coaching suggestions for today's advice, and ask again later for a detailed answer."
+	return out, nil
+}
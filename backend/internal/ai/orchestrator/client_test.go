@@ -0,0 +1,45 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// scriptedClient answers structured calls by schema name, in order. Once a
+// schema's script runs out its last answer is repeated. Every prompt is
+// kept for inspection.
+type scriptedClient struct {
+	mu      sync.Mutex
+	answers map[string][]string
+	prompts map[string][]string
+}
+
+func newScriptedClient(answers map[string][]string) *scriptedClient {
+	return &scriptedClient{answers: answers, prompts: map[string][]string{}}
+}
+
+func (c *scriptedClient) Generate(context.Context, string) (string, error) {
+	return "", errors.New("scriptedClient only answers structured calls")
+}
+
+func (c *scriptedClient) GenerateStructured(_ context.Context, prompt string, schema Schema) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.prompts[schema.Name] = append(c.prompts[schema.Name], prompt)
+	script := c.answers[schema.Name]
+	if len(script) == 0 {
+		return "", errors.New("no answer scripted for " + schema.Name)
+	}
+	answer := script[0]
+	if len(script) > 1 {
+		c.answers[schema.Name] = script[1:]
+	}
+	return answer, nil
+}
+
+func (c *scriptedClient) calls(schema Schema) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.prompts[schema.Name]
+}
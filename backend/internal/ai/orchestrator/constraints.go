@@ -0,0 +1,233 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"S.P.A.R.T.A/backend/internal/ai/prompts"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/exercise"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/split"
+	"S.P.A.R.T.A/backend/internal/domain/service/exercisematch"
+)
+
+// minutesPerSet estimates one working set including rest.
+const minutesPerSet = 3
+
+// EstimateDayMinutes is how long a split day takes at minutesPerSet.
+func EstimateDayMinutes(day SplitDayOutput) int {
+	sets := 0
+	for _, ex := range day.Exercises {
+		sets += ex.Sets
+	}
+	return sets * minutesPerSet
+}
+
+// equipmentSet is the equipment a user has. A nil set is a full gym.
+type equipmentSet map[string]bool
+
+func newEquipmentSet(items []string) equipmentSet {
+	if len(items) == 0 {
+		return nil
+	}
+	set := equipmentSet{split.EquipmentBodyweight: true}
+	for _, item := range items {
+		set[item] = true
+	}
+	// An EZ bar or a landmine is only useful with plates, which come with a
+	// barbell; a gym with machines has a Smith machine.
+	if set[split.EquipmentBarbell] {
+		set[split.EquipmentEZBar] = true
+		set[split.EquipmentLandmine] = true
+	}
+	if set[split.EquipmentMachine] {
+		set[split.EquipmentSmith] = true
+	}
+	return set
+}
+
+// missing returns the items of gear the user does not have.
+func (e equipmentSet) missing(gear []string) []string {
+	if e == nil {
+		return nil
+	}
+	var out []string
+	for _, g := range gear {
+		if !e[g] {
+			out = append(out, g)
+		}
+	}
+	return out
+}
+
+// AvailableExercises returns the exercises of library that need no more than
+// equipment, in library order. Empty equipment is a full gym.
+func AvailableExercises(library []exercise.Exercise, equipment []string) []exercise.Exercise {
+	have := newEquipmentSet(equipment)
+	if have == nil {
+		return library
+	}
+	out := make([]exercise.Exercise, 0, len(library))
+	for _, ex := range library {
+		gear := append(exercisematch.Gear(ex.Name), exercisematch.Gear(ex.Equipment)...)
+		if len(have.missing(gear)) == 0 {
+			out = append(out, ex)
+		}
+	}
+	return out
+}
+
+// constraintChecker finds exercises and days that break a SplitInput's
+// constraints.
+type constraintChecker struct {
+	input     SplitInput
+	equipment equipmentSet
+	matcher   *exercisematch.Matcher
+}
+
+func newConstraintChecker(input SplitInput, library []exercise.Exercise) *constraintChecker {
+	c := &constraintChecker{input: input, equipment: newEquipmentSet(input.Equipment)}
+	if len(library) > 0 {
+		c.matcher = exercisematch.NewMatcher(library, 0)
+	}
+	return c
+}
+
+func (c *constraintChecker) active() bool {
+	return c.equipment != nil || c.input.MaxSessionMinutes > 0 || len(c.input.ExcludedMovements) > 0
+}
+
+// gear is the equipment an exercise needs: the words in its name plus the
+// equipment of the library exercise it resolves to.
+func (c *constraintChecker) gear(name string) []string {
+	gear := exercisematch.Gear(name)
+	if c.matcher == nil {
+		return gear
+	}
+	if m, ok := c.matcher.Match(name); ok {
+		seen := make(map[string]bool, len(gear))
+		for _, g := range gear {
+			seen[g] = true
+		}
+		for _, g := range exercisematch.Gear(m.Exercise.Equipment) {
+			if !seen[g] {
+				gear = append(gear, g)
+			}
+		}
+	}
+	return gear
+}
+
+// checkDay reports every constraint day breaks, phrased for the model.
+func (c *constraintChecker) checkDay(day SplitDayOutput) []string {
+	var p problems
+	for _, ex := range day.Exercises {
+		if missing := c.equipment.missing(c.gear(ex.Name)); len(missing) > 0 {
+			p.addf("exercise %q needs %s, which the user does not have", ex.Name, strings.Join(missing, ", "))
+		}
+		for _, movement := range c.input.ExcludedMovements {
+			if exercisematch.Mentions(ex.Name, movement) {
+				p.addf("exercise %q is excluded by the user (%q)", ex.Name, movement)
+				break
+			}
+		}
+	}
+	if limit := c.input.MaxSessionMinutes; limit > 0 {
+		if minutes := EstimateDayMinutes(day); minutes > limit {
+			p.addf("day %q takes about %d minutes at %d minutes per set, more than the %d-minute limit; use fewer sets or exercises",
+				day.DayName, minutes, minutesPerSet, limit)
+		}
+	}
+	return p
+}
+
+// library loads the exercise catalog, if any. Failures leave it empty.
+func (s *service) library(ctx context.Context) []exercise.Exercise {
+	if s.catalog == nil {
+		return nil
+	}
+	library, err := s.catalog(ctx)
+	if err != nil {
+		return nil
+	}
+	return library
+}
+
+// enforceConstraints regenerates every day of out that breaks the input's
+// constraints. The other days are kept as they are.
+func (s *service) enforceConstraints(ctx context.Context, input SplitInput, out *SplitOutput) error {
+	checker := newConstraintChecker(input, s.library(ctx))
+	if !checker.active() {
+		return nil
+	}
+
+	for i, day := range out.Days {
+		issues := checker.checkDay(day)
+		if len(issues) == 0 {
+			continue
+		}
+
+		dayInput := SplitDayInput{
+			Split:     input,
+			SplitName: out.Name,
+			DayName:   day.DayName,
+			Focus:     day.Focus,
+			Problems:  issues,
+		}
+		for j, other := range out.Days {
+			if j != i {
+				dayInput.OtherDays = append(dayInput.OtherDays, other.DayName)
+			}
+		}
+		for _, ex := range day.Exercises {
+			dayInput.Previous = append(dayInput.Previous, ex.Name)
+		}
+
+		regenerated, err := s.regenerateSplitDay(ctx, dayInput, checker)
+		if err != nil {
+			return err
+		}
+		out.Days[i] = *regenerated
+	}
+	return nil
+}
+
+func (s *service) regenerateSplitDay(ctx context.Context, input SplitDayInput, checker *constraintChecker) (*SplitDayOutput, error) {
+	meta := callMeta{op: "generate_split_day", userID: input.Split.UserID}
+	prompt, err := s.render(&meta, prompts.SplitDay, input)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := generate(ctx, s, meta, prompt, SplitDaySchema, s.decodeSplitDay(ctx, checker))
+	if err != nil {
+		return nil, err
+	}
+	// The day keeps its place in the split.
+	out.DayName = input.DayName
+	return out, nil
+}
+
+func (s *service) decodeSplitDay(ctx context.Context, checker *constraintChecker) func(string) (*SplitDayOutput, error) {
+	return func(resp string) (*SplitDayOutput, error) {
+		out, err := ParseSplitDayResponse(resp)
+		if err != nil {
+			return nil, err
+		}
+
+		var p problems
+		if len(out.Exercises) == 0 {
+			p.addf("exercises must not be empty")
+		}
+		names := make([]string, 0, len(out.Exercises))
+		for j, ex := range out.Exercises {
+			checkExercise(&p, fmt.Sprintf("exercises[%d]", j), ex.Name, ex.Sets, ex.RepRange)
+			names = append(names, ex.Name)
+		}
+		p = append(p, checker.checkDay(*out)...)
+		if err := mergeProblems(p.err(), s.checkLibrary(ctx, names, nil)); err != nil {
+			return nil, err
+		}
+		return out, nil
+	}
+}
@@ -0,0 +1,181 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/coach"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/exercise"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/split"
+)
+
+var constrainedSplits = []struct {
+	name  string
+	input SplitInput
+}{
+	{"full gym", SplitInput{DaysPerWeek: 3}},
+	{"dumbbells only", SplitInput{DaysPerWeek: 4, Equipment: []string{split.EquipmentDumbbell}}},
+	{"bodyweight and bands", SplitInput{DaysPerWeek: 6, Equipment: []string{split.EquipmentBand}}},
+	{"30 minute sessions", SplitInput{DaysPerWeek: 5, MaxSessionMinutes: 30, ExperienceLevel: split.ExperienceAdvanced}},
+	{"no squats or deadlifts", SplitInput{DaysPerWeek: 3, ExcludedMovements: []string{"squat", "deadlift"}}},
+	{"everything at once", SplitInput{
+		DaysPerWeek:       7,
+		Equipment:         []string{split.EquipmentDumbbell},
+		MaxSessionMinutes: 25,
+		ExcludedMovements: []string{"squat", "push-up"},
+	}},
+	{"one day a week, barbell", SplitInput{DaysPerWeek: 1, Equipment: []string{split.EquipmentBarbell}, MaxSessionMinutes: 45}},
+}
+
+func TestRulesSplitHonorsConstraints(t *testing.T) {
+	for _, tc := range constrainedSplits {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := NewRuleBasedOrchestrator().GenerateSplit(context.Background(), tc.input)
+			if err != nil {
+				t.Fatalf("GenerateSplit: %v", err)
+			}
+			if out.Source != coach.SourceRules {
+				t.Errorf("source = %q, want %q", out.Source, coach.SourceRules)
+			}
+			if len(out.Days) == 0 {
+				t.Fatal("split has no days")
+			}
+			checker := newConstraintChecker(tc.input, nil)
+			for _, day := range out.Days {
+				if len(day.Exercises) == 0 {
+					t.Errorf("day %q is empty", day.DayName)
+				}
+				if problems := checker.checkDay(day); len(problems) > 0 {
+					t.Errorf("day %q breaks the constraints: %v", day.DayName, problems)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateSplitRegeneratesOnlyTheDaysThatBreakConstraints(t *testing.T) {
+	input := SplitInput{
+		UserID:            "u1",
+		DaysPerWeek:       2,
+		Equipment:         []string{split.EquipmentDumbbell},
+		MaxSessionMinutes: 30,
+		ExcludedMovements: []string{"lunge"},
+	}
+	ai := newScriptedClient(map[string][]string{
+		SplitSchema.Name: {`{"name":"Upper/Lower","days":[
+			{"day_name":"Upper","focus":["chest","back"],"exercises":[
+				{"name":"Dumbbell Bench Press","sets":4,"rep_range":"6-10","priority":"primary"},
+				{"name":"Dumbbell Row","sets":4,"rep_range":"8-12","priority":"secondary"}]},
+			{"day_name":"Lower","focus":["legs"],"exercises":[
+				{"name":"Barbell Back Squat","sets":5,"rep_range":"5-8","priority":"primary"},
+				{"name":"Walking Lunge","sets":6,"rep_range":"10-12","priority":"secondary"}]}]}`},
+		SplitDaySchema.Name: {`{"day_name":"Legs","focus":["legs"],"exercises":[
+			{"name":"Goblet Squat","sets":4,"rep_range":"8-12","priority":"primary"},
+			{"name":"Dumbbell Romanian Deadlift","sets":4,"rep_range":"8-12","priority":"secondary"}]}`},
+	})
+
+	out, err := NewOrchestrator(ai).GenerateSplit(context.Background(), input)
+	if err != nil {
+		t.Fatalf("GenerateSplit: %v", err)
+	}
+	if out.Source != coach.SourceAI {
+		t.Errorf("source = %q, want %q", out.Source, coach.SourceAI)
+	}
+
+	prompts := ai.calls(SplitDaySchema)
+	if len(prompts) != 1 {
+		t.Fatalf("regenerated %d days, want only Lower", len(prompts))
+	}
+	for _, want := range []string{
+		`Day to rewrite: Lower`,
+		`Other days of the split: Upper`,
+		`Available equipment (nothing else): dumbbell`,
+		`Maximum session length: 30 minutes`,
+		`Never program these movements or variations of them: lunge`,
+		`exercise "Barbell Back Squat" needs barbell`,
+		`exercise "Walking Lunge" is excluded by the user`,
+		`more than the 30-minute limit`,
+	} {
+		if !strings.Contains(prompts[0], want) {
+			t.Errorf("split_day prompt is missing %q:\n%s", want, prompts[0])
+		}
+	}
+
+	if got := out.Days[0].Exercises[0].Name; got != "Dumbbell Bench Press" {
+		t.Errorf("Upper was changed: first exercise %q", got)
+	}
+	lower := out.Days[1]
+	if lower.DayName != "Lower" || lower.Exercises[0].Name != "Goblet Squat" {
+		t.Errorf("Lower = %+v, want the regenerated day under its own name", lower)
+	}
+}
+
+func TestAutoModeAnswersAConstrainedSplitWithRulesWhenTheModelCannot(t *testing.T) {
+	input := SplitInput{
+		UserID:            "u1",
+		DaysPerWeek:       1,
+		Equipment:         []string{split.EquipmentDumbbell},
+		ExcludedMovements: []string{"squat"},
+	}
+	barbell := `{"day_name":"Full Body","focus":["full body"],"exercises":[{"name":"Barbell Back Squat","sets":3,"rep_range":"5-8","priority":"primary"}]}`
+	ai := newScriptedClient(map[string][]string{
+		SplitSchema.Name:    {fmt.Sprintf(`{"name":"Full Body","days":[%s]}`, barbell)},
+		SplitDaySchema.Name: {barbell},
+	})
+
+	out, err := NewWithMode(ModeAuto, ai).GenerateSplit(context.Background(), input)
+	if err != nil {
+		t.Fatalf("GenerateSplit: %v", err)
+	}
+	if out.Source != coach.SourceRules {
+		t.Fatalf("source = %q, want the rules fallback", out.Source)
+	}
+	if got := len(ai.calls(SplitDaySchema)); got != 1+defaultMaxRepairs {
+		t.Errorf("split_day was tried %d times, want %d", got, 1+defaultMaxRepairs)
+	}
+	checker := newConstraintChecker(input, nil)
+	for _, day := range out.Days {
+		if problems := checker.checkDay(day); len(problems) > 0 {
+			t.Errorf("fallback day %q breaks the constraints: %v", day.DayName, problems)
+		}
+	}
+}
+
+func TestAvailableExercises(t *testing.T) {
+	library := []exercise.Exercise{
+		{Name: "Barbell Bench Press", Equipment: "barbell"},
+		{Name: "Dumbbell Curl", Equipment: "dumbbell"},
+		{Name: "Push-up", Equipment: "bodyweight"},
+		{Name: "Lat Pulldown", Equipment: "machine"},
+		{Name: "EZ Bar Curl"},
+		{Name: "Plank"},
+	}
+	names := func(exs []exercise.Exercise) []string {
+		var out []string
+		for _, ex := range exs {
+			out = append(out, ex.Name)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name      string
+		equipment []string
+		want      []string
+	}{
+		{"full gym", nil, names(library)},
+		{"dumbbells", []string{split.EquipmentDumbbell}, []string{"Dumbbell Curl", "Push-up", "Plank"}},
+		{"barbell brings the EZ bar", []string{split.EquipmentBarbell}, []string{"Barbell Bench Press", "Push-up", "EZ Bar Curl", "Plank"}},
+		{"machines", []string{split.EquipmentMachine}, []string{"Push-up", "Lat Pulldown", "Plank"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := names(AvailableExercises(library, tc.equipment)); !slices.Equal(got, tc.want) {
+				t.Errorf("AvailableExercises = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
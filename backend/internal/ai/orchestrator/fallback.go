@@ -0,0 +1,121 @@
+package orchestrator
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+)
+
+// Mode selects which engine answers orchestrator calls.
+type Mode string
+
+const (
+	// ModeAuto uses the AI service and falls back to rules when it fails.
+	ModeAuto Mode = "auto"
+	// ModeAI uses the AI service only; failures are returned to the caller.
+	ModeAI Mode = "ai"
+	// ModeRules never calls a model.
+	ModeRules Mode = "rules"
+)
+
+// ParseMode maps a config value to a Mode. Unknown values fall back to
+// ModeAuto and report ok=false.
+func ParseMode(s string) (Mode, bool) {
+	switch Mode(strings.ToLower(strings.TrimSpace(s))) {
+	case ModeAuto, "":
+		return ModeAuto, true
+	case ModeAI:
+		return ModeAI, true
+	case ModeRules:
+		return ModeRules, true
+	default:
+		return ModeAuto, false
+	}
+}
+
+// NewWithMode builds the orchestrator for mode on top of client. opts apply
+// to the AI service.
+func NewWithMode(mode Mode, client AIClient, opts ...Option) Orchestrator {
+	switch mode {
+	case ModeAI:
+		return NewOrchestrator(client, opts...)
+	case ModeRules:
+		return NewRuleBasedOrchestrator()
+	default:
+		return NewFallbackOrchestrator(NewOrchestrator(client, opts...), NewRuleBasedOrchestrator())
+	}
+}
+
+type fallbackService struct {
+	primary  Orchestrator
+	fallback Orchestrator
+}
+
+// NewFallbackOrchestrator answers with primary and retries the call on
+// fallback whenever primary fails, unless the request itself was cancelled
+// or the caller is over quota.
+func NewFallbackOrchestrator(primary, fallback Orchestrator) Orchestrator {
+	return &fallbackService{primary: primary, fallback: fallback}
+}
+
+func withFallback[I, O any](ctx context.Context, op string, input I, primary, fallback func(context.Context, I) (O, error)) (O, error) {
+	out, err := primary(ctx, input)
+	if err == nil || ctx.Err() != nil || isQuotaError(err) {
+		return out, err
+	}
+	slog.WarnContext(ctx, "ai unavailable, answering with rules", "op", op, "error", err)
+	return fallback(ctx, input)
+}
+
+func (s *fallbackService) GenerateSplit(ctx context.Context, input SplitInput) (*SplitOutput, error) {
+	return withFallback(ctx, "generate_split", input, s.primary.GenerateSplit, s.fallback.GenerateSplit)
+}
+
+func (s *fallbackService) GenerateWorkout(ctx context.Context, input WorkoutInput) (*WorkoutOutput, error) {
+	return withFallback(ctx, "generate_workout", input, s.primary.GenerateWorkout, s.fallback.GenerateWorkout)
+}
+
+func (s *fallbackService) SuggestOverload(ctx context.Context, input OverloadInput) (*OverloadOutput, error) {
+	return withFallback(ctx, "suggest_overload", input, s.primary.SuggestOverload, s.fallback.SuggestOverload)
+}
+
+func (s *fallbackService) GenerateDailyMotivation(ctx context.Context, input MotivationInput) (*MotivationOutput, error) {
+	return withFallback(ctx, "daily_motivation", input, s.primary.GenerateDailyMotivation, s.fallback.GenerateDailyMotivation)
+}
+
+func (s *fallbackService) GenerateCoachingSuggestions(ctx context.Context, input CoachingInput) (*CoachingOutput, error) {
+	return withFallback(ctx, "coaching", input, s.primary.GenerateCoachingSuggestions, s.fallback.GenerateCoachingSuggestions)
+}
+
+func (s *fallbackService) ExplainWorkoutPlan(ctx context.Context, input ExplainWorkoutPlanInput) (*ExplainWorkoutPlanOutput, error) {
+	return withFallback(ctx, "explain_workout", input, s.primary.ExplainWorkoutPlan, s.fallback.ExplainWorkoutPlan)
+}
+
+// Streaming calls only fall back while nothing has been sent; once the client
+// has seen partial model output the error is returned as-is.
+
+func (s *fallbackService) StreamCoachingSuggestions(ctx context.Context, input CoachingInput, onDelta func(string) error) (*CoachingOutput, error) {
+	started := false
+	out, err := s.primary.StreamCoachingSuggestions(ctx, input, func(delta string) error {
+		started = true
+		return onDelta(delta)
+	})
+	if err == nil || started || ctx.Err() != nil || isQuotaError(err) {
+		return out, err
+	}
+	slog.WarnContext(ctx, "ai unavailable, answering with rules", "op", "coaching_stream", "error", err)
+	return s.fallback.StreamCoachingSuggestions(ctx, input, onDelta)
+}
+
+func (s *fallbackService) StreamExplainWorkoutPlan(ctx context.Context, input ExplainWorkoutPlanInput, onDelta func(string) error) (*ExplainWorkoutPlanOutput, error) {
+	started := false
+	out, err := s.primary.StreamExplainWorkoutPlan(ctx, input, func(delta string) error {
+		started = true
+		return onDelta(delta)
+	})
+	if err == nil || started || ctx.Err() != nil || isQuotaError(err) {
+		return out, err
+	}
+	slog.WarnContext(ctx, "ai unavailable, answering with rules", "op", "explain_workout_stream", "error", err)
+	return s.fallback.StreamExplainWorkoutPlan(ctx, input, onDelta)
+}
@@ -0,0 +1,254 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/aicall"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/exercise"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+)
+
+// fixture reads a model answer from testdata/output.
+func fixture(t *testing.T, name string) string {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join("testdata", "output", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func testCatalog(context.Context) ([]exercise.Exercise, error) {
+	var out []exercise.Exercise
+	for _, name := range []string{"Bench Press", "Barbell Row", "Back Squat", "Romanian Deadlift", "Overhead Press"} {
+		out = append(out, exercise.Exercise{Name: name})
+	}
+	return out, nil
+}
+
+// recordingAuditor keeps every call the orchestrator records.
+type recordingAuditor struct {
+	mu    sync.Mutex
+	calls []aicall.Call
+}
+
+func (a *recordingAuditor) CheckQuota(context.Context, string) error { return nil }
+
+func (a *recordingAuditor) Record(_ context.Context, call aicall.Call) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.calls = append(a.calls, call)
+}
+
+// malformedCases pair a first answer with the problems its repair prompt
+// must name. No problems means the answer is usable as is.
+var malformedCases = []struct {
+	schema  Schema
+	fixture string
+	want    []string
+}{
+	{WorkoutSchema, "workout_fenced.txt", nil},
+	{WorkoutSchema, "workout_prose.txt", nil},
+	{WorkoutSchema, "workout_wrong_types.txt", []string{"response is not a valid JSON object", "cannot unmarshal string"}},
+	{WorkoutSchema, "workout_truncated.txt", []string{"response is not a valid JSON object"}},
+	{WorkoutSchema, "workout_not_json.txt", []string{"response is not a valid JSON object"}},
+	{WorkoutSchema, "workout_sets_out_of_range.txt", []string{
+		`exercises[0] "Bench Press": sets must be between 1 and 10, got 0`,
+		`exercises[1] "Barbell Row": sets must be between 1 and 10, got 14`,
+	}},
+	{WorkoutSchema, "workout_bad_rep_range.txt", []string{
+		`exercises[0] "Bench Press": rep_range "as many as possible"`,
+		`exercises[1] "Barbell Row": rep_range "12-8"`,
+	}},
+	{WorkoutSchema, "workout_negative_weight.txt", []string{`exercises[0] "Bench Press": weight must not be negative, got -20.00`}},
+	{WorkoutSchema, "workout_empty.txt", []string{"exercises must not be empty"}},
+	{WorkoutSchema, "workout_unknown_exercise.txt", []string{`exercise "Underwater Basket Weaving" is not in the exercise library`}},
+	{WorkoutSchema, "workout_many_problems.txt", []string{
+		"exercises[0]: name must not be empty",
+		`exercises[1] "Bench Press": sets must be between 1 and 10, got 11`,
+		`exercises[1] "Bench Press": rep_range "0"`,
+		`exercises[1] "Bench Press": weight must not be negative`,
+	}},
+	{SplitSchema, "split_wrong_types.txt", []string{"response is not a valid JSON object", "cannot unmarshal object"}},
+	{SplitSchema, "split_too_many_days.txt", []string{"days must contain between 1 and 7 entries, got 8"}},
+	{SplitSchema, "split_empty_day.txt", []string{
+		"name must not be empty",
+		"days[0]: day_name must not be empty",
+		"days[0]: exercises must not be empty",
+		`days[1].exercises[0] "Back Squat": sets must be between 1 and 10, got 25`,
+	}},
+	{SplitSchema, "split_unknown_exercise.txt", []string{
+		`exercise "Moon Walk" is not in the exercise library`,
+		`exercise "Dumbbell Row" is not in the exercise library; use one of: "Barbell Row"`,
+	}},
+}
+
+// generateFor runs the orchestrator call that uses schema.
+func generateFor(ctx context.Context, o Orchestrator, schema Schema) (any, error) {
+	switch schema.Name {
+	case WorkoutSchema.Name:
+		return o.GenerateWorkout(ctx, WorkoutInput{UserID: "u1", SplitDayName: "Upper"})
+	case SplitSchema.Name:
+		return o.GenerateSplit(ctx, SplitInput{UserID: "u1", DaysPerWeek: 2})
+	}
+	panic("no call for schema " + schema.Name)
+}
+
+func TestMalformedOutputIsRepaired(t *testing.T) {
+	for _, tc := range malformedCases {
+		t.Run(tc.fixture, func(t *testing.T) {
+			valid := fixture(t, tc.schema.Name+"_valid.json")
+			ai := newScriptedClient(map[string][]string{tc.schema.Name: {fixture(t, tc.fixture), valid}})
+			audit := &recordingAuditor{}
+			o := NewOrchestrator(ai, WithExerciseCatalog(testCatalog), WithAuditor(audit))
+
+			out, err := generateFor(context.Background(), o, tc.schema)
+			if err != nil {
+				t.Fatalf("generate: %v", err)
+			}
+			switch out := out.(type) {
+			case *WorkoutOutput:
+				if len(out.Exercises) != 2 || out.Exercises[0].Name != "Bench Press" || out.Exercises[0].Sets != 4 {
+					t.Errorf("workout = %+v", out.Exercises)
+				}
+			case *SplitOutput:
+				if len(out.Days) != 2 || out.Days[0].Exercises[0].Name != "Bench Press" {
+					t.Errorf("split = %+v", out.Days)
+				}
+			}
+
+			prompts := ai.calls(tc.schema)
+			if len(tc.want) == 0 {
+				if len(prompts) != 1 {
+					t.Fatalf("made %d calls, want the first answer accepted", len(prompts))
+				}
+				return
+			}
+			if len(prompts) != 2 {
+				t.Fatalf("made %d calls, want one repair", len(prompts))
+			}
+			repairPrompt := prompts[1]
+			if !strings.HasPrefix(repairPrompt, prompts[0]) {
+				t.Error("repair prompt does not repeat the original prompt")
+			}
+			if first := strings.TrimSpace(fixture(t, tc.fixture)); !strings.Contains(repairPrompt, first[:min(len(first), 40)]) {
+				t.Error("repair prompt does not echo the rejected answer")
+			}
+			for _, problem := range tc.want {
+				if !strings.Contains(repairPrompt, problem) {
+					t.Errorf("repair prompt does not name %q:\n%s", problem, repairPrompt)
+				}
+			}
+
+			if len(audit.calls) != 2 || audit.calls[0].Outcome != aicall.OutcomeInvalidOutput || audit.calls[1].Outcome != aicall.OutcomeOK {
+				t.Errorf("audit = %+v, want invalid_output then ok", audit.calls)
+			} else if audit.calls[0].Attempt != 0 || audit.calls[1].Attempt != 1 {
+				t.Errorf("attempts = %d, %d, want 0, 1", audit.calls[0].Attempt, audit.calls[1].Attempt)
+			}
+		})
+	}
+}
+
+func TestLibraryNearMatchesAreAccepted(t *testing.T) {
+	ai := newScriptedClient(map[string][]string{"split": {fixture(t, "split_unknown_exercise.txt"), fixture(t, "split_valid.json")}})
+	o := NewOrchestrator(ai, WithExerciseCatalog(testCatalog))
+	if _, err := o.GenerateSplit(context.Background(), SplitInput{UserID: "u1", DaysPerWeek: 2}); err != nil {
+		t.Fatal(err)
+	}
+	repairPrompt := ai.calls(SplitSchema)[1]
+	// "Barbell Rows" matches "Barbell Row" confidently, so it is not sent
+	// back with the unknown exercises.
+	if strings.Contains(repairPrompt, `"Barbell Rows" is not`) {
+		t.Errorf("near match was rejected:\n%s", repairPrompt)
+	}
+}
+
+func TestPlannedExercisesPassTheLibraryCheck(t *testing.T) {
+	ai := newScriptedClient(map[string][]string{"workout": {fixture(t, "workout_unknown_exercise.txt")}})
+	o := NewOrchestrator(ai, WithExerciseCatalog(testCatalog))
+	_, err := o.GenerateWorkout(context.Background(), WorkoutInput{
+		UserID:           "u1",
+		PlannedExercises: []string{"underwater basket weaving"},
+	})
+	if err != nil {
+		t.Fatalf("GenerateWorkout: %v", err)
+	}
+	if n := len(ai.calls(WorkoutSchema)); n != 1 {
+		t.Errorf("made %d calls, want 1", n)
+	}
+}
+
+func TestRepairLoopExhaustion(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []Option
+		wantCalls int
+	}{
+		{name: "default repairs", wantCalls: 1 + defaultMaxRepairs},
+		{name: "more repairs", opts: []Option{WithMaxRepairs(4)}, wantCalls: 5},
+		{name: "repairs disabled", opts: []Option{WithMaxRepairs(0)}, wantCalls: 1},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ai := newScriptedClient(map[string][]string{"workout": {fixture(t, "workout_negative_weight.txt")}})
+			audit := &recordingAuditor{}
+			o := NewOrchestrator(ai, append(tc.opts, WithAuditor(audit))...)
+
+			_, err := o.GenerateWorkout(context.Background(), WorkoutInput{UserID: "u1"})
+			var invalid *ValidationError
+			if !errors.As(err, &invalid) || !errors.Is(err, domainerr.ErrAIUnavailable) {
+				t.Fatalf("error = %v, want a ValidationError surfacing as ErrAIUnavailable", err)
+			}
+			if !strings.Contains(err.Error(), "weight must not be negative") {
+				t.Errorf("error %q does not name the last problem", err)
+			}
+			if n := len(ai.calls(WorkoutSchema)); n != tc.wantCalls {
+				t.Errorf("made %d calls, want %d", n, tc.wantCalls)
+			}
+			if len(audit.calls) != tc.wantCalls {
+				t.Fatalf("recorded %d calls, want %d", len(audit.calls), tc.wantCalls)
+			}
+			for i, c := range audit.calls {
+				if c.Outcome != aicall.OutcomeInvalidOutput || c.Attempt != i {
+					t.Errorf("call %d recorded as %s attempt %d", i, c.Outcome, c.Attempt)
+				}
+			}
+		})
+	}
+}
+
+// failingRepairClient answers the first call with fixture and fails every
+// call after it.
+type failingRepairClient struct {
+	first string
+	calls int
+}
+
+func (c *failingRepairClient) Generate(context.Context, string) (string, error) {
+	c.calls++
+	if c.calls == 1 {
+		return c.first, nil
+	}
+	return "", domainerr.ErrAIUnavailable
+}
+
+func TestRepairStopsOnTransportErrors(t *testing.T) {
+	ai := &failingRepairClient{first: fixture(t, "workout_empty.txt")}
+	o := NewOrchestrator(ai)
+
+	_, err := o.GenerateWorkout(context.Background(), WorkoutInput{UserID: "u1"})
+	var invalid *ValidationError
+	if !errors.Is(err, domainerr.ErrAIUnavailable) || errors.As(err, &invalid) {
+		t.Fatalf("error = %v, want the client's error", err)
+	}
+	// The repair call is retried by complete, but not repaired again.
+	if ai.calls != 4 {
+		t.Errorf("made %d calls, want the first plus three attempts at one repair", ai.calls)
+	}
+}
@@ -9,4 +9,15 @@ type Orchestrator interface {
 	GenerateDailyMotivation(ctx context.Context, input MotivationInput) (*MotivationOutput, error)
 	GenerateCoachingSuggestions(ctx context.Context, input CoachingInput) (*CoachingOutput, error)
 	ExplainWorkoutPlan(ctx context.Context, input ExplainWorkoutPlanInput) (*ExplainWorkoutPlanOutput, error)
+	// Chat answers the latest question of a conversation from the context
+	// items, which are first trimmed to input.TokenBudget.
+	Chat(ctx context.Context, input ChatInput) (*ChatOutput, error)
+	// RunAgent lets the model call input.Tools until it answers, for at most
+	// input.MaxSteps completions.
+	RunAgent(ctx context.Context, input AgentInput) (*AgentOutput, error)
+
+	// Streaming variants forward raw model output to onDelta as it arrives
+	// and validate the complete JSON at the end.
+	StreamCoachingSuggestions(ctx context.Context, input CoachingInput, onDelta func(string) error) (*CoachingOutput, error)
+	StreamExplainWorkoutPlan(ctx context.Context, input ExplainWorkoutPlanInput, onDelta func(string) error) (*ExplainWorkoutPlanOutput, error)
 }
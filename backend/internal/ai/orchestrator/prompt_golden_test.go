@@ -0,0 +1,86 @@
+package orchestrator
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"S.P.A.R.T.A/backend/internal/ai/prompts"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/coach"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+func checkGolden(t *testing.T, path string, got []byte) {
+	t.Helper()
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run go test -update to create it)", err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("%s differs:\ngot:\n%s\nwant:\n%s", path, got, want)
+	}
+}
+
+// minimalSample is the zero input of the same type as sample with only the
+// user and the default voice set, so the templates' empty branches render.
+func minimalSample(t *testing.T, sample any) any {
+	t.Helper()
+	v := reflect.New(reflect.TypeOf(sample)).Elem()
+	user, voice := v.FieldByName("UserID"), v.FieldByName("Voice")
+	if user.IsValid() {
+		user.SetString("00000000-0000-0000-0000-000000000000")
+	}
+	if voice.IsValid() {
+		voice.Set(reflect.ValueOf(coach.DefaultVoice()))
+	}
+	return v.Interface()
+}
+
+// TestBuiltinPromptsGolden renders every version of every built-in template,
+// whatever its weight, with the registry samples and with minimal input.
+func TestBuiltinPromptsGolden(t *testing.T) {
+	templates := prompts.Default().Templates()
+	if len(templates) == 0 {
+		t.Fatal("no built-in templates")
+	}
+
+	for _, tpl := range templates {
+		sample, ok := PromptSamples[tpl.Name]
+		if !ok {
+			t.Errorf("%s has no entry in PromptSamples", tpl.Name)
+			continue
+		}
+
+		// A registry holding just this version always renders it.
+		r := prompts.NewRegistry()
+		tpl.Weight = 1
+		if err := r.Load(tpl); err != nil {
+			t.Fatal(err)
+		}
+
+		for variant, data := range map[string]any{"sample": sample, "minimal": minimalSample(t, sample)} {
+			t.Run(tpl.Name+"."+tpl.Version+"/"+variant, func(t *testing.T) {
+				rendered, err := r.Render(tpl.Name, "u1", data)
+				if err != nil {
+					t.Fatalf("Render: %v", err)
+				}
+				if rendered.Version != tpl.Version {
+					t.Fatalf("rendered version %s, want %s", rendered.Version, tpl.Version)
+				}
+				checkGolden(t, filepath.Join("testdata", "prompts", tpl.Name+"."+tpl.Version+"."+variant+".golden"), []byte(rendered.Text))
+			})
+		}
+	}
+}
@@ -5,148 +5,137 @@ import (
 	"strings"
 
 	"S.P.A.R.T.A/backend/internal/ai/prompts"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/coach"
 )
 
-func BuildSplitPrompt(input SplitInput) string {
-	return fmt.Sprintf(`
-You are an elite strength coach.
+// maxRepairEcho bounds how much of the rejected answer is quoted back.
+const maxRepairEcho = 4000
 
-Generate a structured workout split in STRICT JSON format.
-
-User experience: %s
-Training days per week: %d
-Primary focus muscle: %s
-
-Return JSON schema:
-%s
-`, input.ExperienceLevel, input.DaysPerWeek, input.FocusMuscle, prompts.SplitPromptTemplate)
-}
-
-func BuildWorkoutPrompt(input WorkoutInput) string {
-	planned := ""
-	if len(input.PlannedExercises) > 0 {
-		planned = "- " + strings.Join(input.PlannedExercises, "\n- ")
+// BuildRepairPrompt asks the model to fix its previous answer to prompt.
+func BuildRepairPrompt(prompt, previous string, invalid *ValidationError) string {
+	if len(previous) > maxRepairEcho {
+		previous = previous[:maxRepairEcho] + "..."
 	}
-	return fmt.Sprintf(`
-You are an elite strength coach.
-
-Generate today's workout in STRICT JSON format.
-
-User ID: %s
-Split day ID: %s
-Split day name: %s
-Planned exercises (if provided):
-%s
-Fatigue (0-10): %d
-Estimated fatigue (0-10): %d
-Acute load 7d: %.0f
-Chronic load 28d: %.0f
-ACWR: %.2f
-Last volume (arbitrary units): %d
-
-Return JSON schema:
-%s
-`, input.UserID, input.SplitDayID, input.SplitDayName, planned, input.Fatigue, input.FatigueEstimated, input.AcuteLoad7d, input.ChronicLoad28d, input.ACWR, input.LastVolume, prompts.WorkoutPromptTemplate)
-}
-
-func BuildOverloadPrompt(input OverloadInput) string {
-	return fmt.Sprintf(`
-You are an elite strength coach.
-
-Suggest progressive overload in STRICT JSON.
-
-User ID: %s
-Exercise ID: %s
-Last weight: %.2f
-Last reps: %d
-Performance notes: %s
-
-Return JSON schema:
-%s
-`, input.UserID, input.ExerciseID, input.LastWeight, input.LastReps, input.Performance, prompts.OverloadPromptTemplate)
-}
-
-func BuildMotivationPrompt(input MotivationInput) string {
-	return fmt.Sprintf(`
-You are S.P.A.R.T.A — a no-BS, brutal gym coach. Use harsh language including words like fuck, damn, shit, etc., to push the user. Show how weak they are and make them act immediately. 
-Generate a short daily motivation message based on recent activity.
-
-Date: %s
-Workouts last 7 days: %d
-Last workout date: %s
-Last workout duration minutes: %d
-Last workout notes: %s
-
-Rules:
-- Keep it concise (max ~10-30 words).
-- Be brutally honest and practical.
-- Use profanity to intensify motivation: fuck, damn, shit, etc.
-- If workouts last 7 days is 0, focus on getting started today.
-- DO NOT use hyphen '-' in the quotes.
-- Highlight weaknesses and excuses directly.
-- Push action and accountability: the quote should make the user reflect and act immediately.
-- Avoid vague, fluffy, or long-winded philosophical statements.
-- Diamond gym level intensity.
+	return fmt.Sprintf(`%s
 
-Return STRICT JSON:
-{
-  "message": "..."
-}
-`, input.Date, input.WorkoutsLast7Days, input.LastWorkoutDate, input.LastWorkoutDuration, input.LastWorkoutNotes)
-}
-
-func BuildCoachingPrompt(input CoachingInput) string {
-	return fmt.Sprintf(`
-You are S.P.A.R.T.A — a supportive, no-BS gym coach.
-
-Generate coaching suggestions in STRICT JSON.
-
-Date: %s
-User ID: %s
-
-Acute load 7d: %.0f
-Chronic load 28d: %.0f
-ACWR: %.2f
-
-Recent workouts:
-%s
-
-Recent nutrition:
-%s
+Your previous answer was rejected for these reasons:
+- %s
 
-Recent recommendations:
+Previous answer:
 %s
 
-Return JSON schema:
-%s
-`, input.Date, input.UserID, input.AcuteLoad7d, input.ChronicLoad28d, input.ACWR, input.RecentWorkouts, input.RecentNutrition, input.RecentPlannerRecs, prompts.CoachingPromptTemplate)
+Return the corrected JSON only, following the schema above.
+`, strings.TrimRight(prompt, "\n"), strings.Join(invalid.Problems, "\n- "), previous)
 }
 
-func BuildExplainWorkoutPlanPrompt(input ExplainWorkoutPlanInput) string {
-	lines := make([]string, 0, len(input.Exercises))
-	for _, ex := range input.Exercises {
-		lines = append(lines, fmt.Sprintf("- %s | %dx%s | weight %.2f", ex.Name, ex.Sets, ex.RepRange, ex.Weight))
-	}
-	plan := strings.Join(lines, "\n")
-
-	return fmt.Sprintf(`
-You are an elite strength coach.
-
-Explain the workout plan in a practical way.
-
-User ID: %s
-Split day name: %s
-Fatigue (0-10): %d
-
-Workout plan:
-%s
-
-Rules:
-- Keep it concise.
-- Explain intent, what to focus on, and key technique cues.
-- Do not invent exercises not in the plan.
-
-Return JSON schema:
-%s
-`, input.UserID, input.SplitDayName, input.Fatigue, plan, prompts.ExplainWorkoutPromptTemplate)
+// PromptSamples is example input for every prompt the orchestrator renders.
+// Registries check custom templates against it before accepting them.
+var PromptSamples = map[string]any{
+	prompts.Split: SplitInput{
+		UserID:            "00000000-0000-0000-0000-000000000000",
+		Voice:             coach.DefaultVoice(),
+		DaysPerWeek:       4,
+		ExperienceLevel:   "intermediate",
+		FocusMuscle:       "back",
+		Library:           []string{"Deadlift", "Barbell Row", "Lat Pulldown", "Bench Press"},
+		Equipment:         []string{"barbell", "dumbbell"},
+		MaxSessionMinutes: 60,
+		Injuries:          "left shoulder impingement",
+		ExcludedMovements: []string{"overhead press"},
+		PreferredDays:     []string{"mon", "tue", "thu", "fri"},
+	},
+	prompts.SplitDay: SplitDayInput{
+		Split: SplitInput{
+			UserID:            "00000000-0000-0000-0000-000000000000",
+			Voice:             coach.DefaultVoice(),
+			DaysPerWeek:       4,
+			ExperienceLevel:   "intermediate",
+			FocusMuscle:       "back",
+			Library:           []string{"Dumbbell Row", "Pull Up", "Dumbbell Curl"},
+			Equipment:         []string{"dumbbell"},
+			MaxSessionMinutes: 45,
+			ExcludedMovements: []string{"deadlift"},
+			PreferredDays:     []string{"mon", "wed", "fri"},
+		},
+		SplitName: "4-Day Upper/Lower",
+		DayName:   "Pull",
+		Focus:     []string{"back", "biceps"},
+		OtherDays: []string{"Push", "Legs"},
+		Previous:  []string{"Barbell Row", "Deadlift"},
+		Problems:  []string{`exercise "Barbell Row" needs barbell, which the user does not have`},
+	},
+	prompts.Workout: WorkoutInput{
+		UserID:           "00000000-0000-0000-0000-000000000000",
+		Voice:            coach.DefaultVoice(),
+		SplitDayID:       "00000000-0000-0000-0000-000000000001",
+		SplitDayName:     "Pull",
+		PlannedExercises: []string{"Deadlift", "Barbell Row"},
+		Fatigue:          4,
+		LoadModel:        "tonnage",
+		LoadUnit:         "kg",
+		AcuteLoad7d:      12000,
+		ChronicLoad28d:   10000,
+		ACWR:             1.2,
+		FatigueEstimated: 5,
+		LastVolume:       9000,
+	},
+	prompts.Overload: OverloadInput{
+		UserID:         "00000000-0000-0000-0000-000000000000",
+		Voice:          coach.DefaultVoice(),
+		ExerciseID:     "00000000-0000-0000-0000-000000000002",
+		LastWeight:     100,
+		LastReps:       8,
+		Performance:    "all sets completed",
+		PlanOutcome:    "missed",
+		PlanComparison: "planned 3x8-10 @ 100.0 kg, did 3 sets averaging 7.0 reps with a top set of 100.0 kg (missed)",
+	},
+	prompts.Motivation: MotivationInput{
+		UserID:              "00000000-0000-0000-0000-000000000000",
+		Voice:               coach.Voice{Persona: coach.PersonaCustom, CustomPersona: "A calm drill sergeant", Language: "de", Profanity: true},
+		Date:                "2024-01-01",
+		WorkoutsLast7Days:   3,
+		LastWorkoutDate:     "2023-12-31",
+		LastWorkoutDuration: 60,
+		LastWorkoutNotes:    "felt strong",
+	},
+	prompts.Coaching: CoachingInput{
+		UserID:            "00000000-0000-0000-0000-000000000000",
+		Voice:             coach.DefaultVoice(),
+		Date:              "2024-01-01",
+		LoadModel:         "tonnage",
+		LoadUnit:          "kg",
+		AcuteLoad7d:       12000,
+		ChronicLoad28d:    10000,
+		ACWR:              1.2,
+		RecentWorkouts:    "- 2023-12-31 Pull, 60 min",
+		RecentNutrition:   "- 2023-12-31 2500 kcal, 160 g protein",
+		RecentPlannerRecs: "- Add a rest day",
+	},
+	prompts.ExplainWorkout: ExplainWorkoutPlanInput{
+		UserID:       "00000000-0000-0000-0000-000000000000",
+		Voice:        coach.DefaultVoice(),
+		SplitDayName: "Pull",
+		Fatigue:      4,
+		Exercises: []ExplainWorkoutExercise{
+			{Name: "Deadlift", Sets: 3, RepRange: "5", Weight: 140},
+		},
+	},
+	prompts.Chat: ChatInput{
+		UserID: "00000000-0000-0000-0000-000000000000",
+		Voice:  coach.DefaultVoice(),
+		Context: []ContextItem{
+			{ID: "load", Kind: "load", Label: "Training load", Text: "ACWR 1.20"},
+		},
+		History:  []ChatTurn{{Role: "user", Content: "How was my week?"}, {Role: "assistant", Content: "Solid."}},
+		Question: "Why did you drop my bench volume?",
+	},
+	prompts.Agent: AgentInput{
+		UserID: "00000000-0000-0000-0000-000000000000",
+		Voice:  coach.DefaultVoice(),
+		Date:   "2024-01-01",
+		Context: []ContextItem{
+			{ID: "split_day:00000000-0000-0000-0000-000000000001", Kind: "split_day", Label: "Pull", Text: "Pull: Deadlift 3x5, Barbell Row 3x8"},
+		},
+		Question: "Swap rows for pull-ups on my pull day.",
+	},
 }
@@ -2,18 +2,23 @@ package orchestrator
 
 import (
 	"encoding/json"
-	"fmt"
 	"strings"
-
-	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
 )
 
 func ParseSplitResponse(resp string) (*SplitOutput, error) {
 	var out SplitOutput
 	clean := extractJSONObject(resp)
-	err := json.Unmarshal([]byte(clean), &out)
-	if err != nil {
-		return nil, fmt.Errorf("%w: invalid AI JSON", domainerr.ErrAIUnavailable)
+	if err := json.Unmarshal([]byte(clean), &out); err != nil {
+		return nil, invalidJSON(err)
+	}
+	return &out, nil
+}
+
+func ParseSplitDayResponse(resp string) (*SplitDayOutput, error) {
+	var out SplitDayOutput
+	clean := extractJSONObject(resp)
+	if err := json.Unmarshal([]byte(clean), &out); err != nil {
+		return nil, invalidJSON(err)
 	}
 	return &out, nil
 }
@@ -21,9 +26,8 @@ func ParseSplitResponse(resp string) (*SplitOutput, error) {
 func ParseWorkoutResponse(resp string) (*WorkoutOutput, error) {
 	var out WorkoutOutput
 	clean := extractJSONObject(resp)
-	err := json.Unmarshal([]byte(clean), &out)
-	if err != nil {
-		return nil, fmt.Errorf("%w: invalid AI JSON", domainerr.ErrAIUnavailable)
+	if err := json.Unmarshal([]byte(clean), &out); err != nil {
+		return nil, invalidJSON(err)
 	}
 	return &out, nil
 }
@@ -31,9 +35,8 @@ func ParseWorkoutResponse(resp string) (*WorkoutOutput, error) {
 func ParseOverloadResponse(resp string) (*OverloadOutput, error) {
 	var out OverloadOutput
 	clean := extractJSONObject(resp)
-	err := json.Unmarshal([]byte(clean), &out)
-	if err != nil {
-		return nil, fmt.Errorf("%w: invalid AI JSON", domainerr.ErrAIUnavailable)
+	if err := json.Unmarshal([]byte(clean), &out); err != nil {
+		return nil, invalidJSON(err)
 	}
 	return &out, nil
 }
@@ -41,9 +44,8 @@ func ParseOverloadResponse(resp string) (*OverloadOutput, error) {
 func ParseMotivationResponse(resp string) (*MotivationOutput, error) {
 	var out MotivationOutput
 	clean := extractJSONObject(resp)
-	err := json.Unmarshal([]byte(clean), &out)
-	if err != nil {
-		return nil, fmt.Errorf("%w: invalid AI JSON", domainerr.ErrAIUnavailable)
+	if err := json.Unmarshal([]byte(clean), &out); err != nil {
+		return nil, invalidJSON(err)
 	}
 	return &out, nil
 }
@@ -51,9 +53,8 @@ func ParseMotivationResponse(resp string) (*MotivationOutput, error) {
 func ParseCoachingResponse(resp string) (*CoachingOutput, error) {
 	var out CoachingOutput
 	clean := extractJSONObject(resp)
-	err := json.Unmarshal([]byte(clean), &out)
-	if err != nil {
-		return nil, fmt.Errorf("%w: invalid AI JSON", domainerr.ErrAIUnavailable)
+	if err := json.Unmarshal([]byte(clean), &out); err != nil {
+		return nil, invalidJSON(err)
 	}
 	return &out, nil
 }
@@ -61,15 +62,30 @@ func ParseCoachingResponse(resp string) (*CoachingOutput, error) {
 func ParseExplainWorkoutPlanResponse(resp string) (*ExplainWorkoutPlanOutput, error) {
 	var out ExplainWorkoutPlanOutput
 	clean := extractJSONObject(resp)
-	err := json.Unmarshal([]byte(clean), &out)
-	if err != nil {
-		return nil, fmt.Errorf("%w: invalid AI JSON", domainerr.ErrAIUnavailable)
+	if err := json.Unmarshal([]byte(clean), &out); err != nil {
+		return nil, invalidJSON(err)
+	}
+	return &out, nil
+}
+
+func ParseChatResponse(resp string) (*ChatOutput, error) {
+	var out ChatOutput
+	clean := extractJSONObject(resp)
+	if err := json.Unmarshal([]byte(clean), &out); err != nil {
+		return nil, invalidJSON(err)
 	}
 	return &out, nil
 }
 
+// invalidJSON reports a decode failure as a ValidationError so the answer
+// goes through the repair loop like any other rejected output.
+func invalidJSON(err error) error {
+	return &ValidationError{Problems: []string{"response is not a valid JSON object: " + err.Error()}}
+}
+
 func extractJSONObject(s string) string {
-	// Best-effort: strip code fences and take the first {...} block.
+	// Best-effort: strip code fences and take the first balanced {...} block,
+	// so prose around the object (including braces after it) is ignored.
 	s = strings.TrimSpace(s)
 	s = strings.TrimPrefix(s, "```json")
 	s = strings.TrimPrefix(s, "```")
@@ -77,9 +93,33 @@ func extractJSONObject(s string) string {
 	s = strings.TrimSpace(s)
 
 	start := strings.Index(s, "{")
-	end := strings.LastIndex(s, "}")
-	if start >= 0 && end > start {
-		return strings.TrimSpace(s[start : end+1])
+	if start < 0 {
+		return s
+	}
+
+	depth := 0
+	inString, escaped := false, false
+	for i := start; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case escaped:
+			escaped = false
+		case inString && c == '\\':
+			escaped = true
+		case c == '"':
+			inString = !inString
+		case inString:
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+			if depth == 0 {
+				return s[start : i+1]
+			}
+		}
 	}
-	return s
+
+	// Unbalanced (e.g. truncated output): hand the decoder what we have so
+	// the error names the real problem.
+	return s[start:]
 }
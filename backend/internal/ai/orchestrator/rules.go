@@ -0,0 +1,355 @@
+package orchestrator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/coach"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/split"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/workout"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+	"S.P.A.R.T.A/backend/internal/domain/service/exercisematch"
+)
+
+// rulesService answers every orchestrator call from templates and the load
+// numbers the usecase already computed (ACWR, estimated fatigue, split day
+// exercises). It needs no model and never fails on well-formed input, which
+// makes it the offline fallback for the AI service.
+type rulesService struct{}
+
+func NewRuleBasedOrchestrator() Orchestrator {
+	return rulesService{}
+}
+
+var rulesDayExercises = map[string][]string{
+	"Push":      {"Bench Press", "Overhead Press", "Dumbbell Fly", "Triceps Pushdown"},
+	"Pull":      {"Pull-up", "Bent-Over Row", "Lat Pulldown", "Biceps Curl"},
+	"Legs":      {"Squat", "Romanian Deadlift", "Leg Press", "Calf Raise"},
+	"Upper":     {"Bench Press", "Bent-Over Row", "Overhead Press", "Lat Pulldown"},
+	"Lower":     {"Squat", "Romanian Deadlift", "Leg Curl", "Calf Raise"},
+	"Full Body": {"Squat", "Bench Press", "Bent-Over Row", "Plank"},
+}
+
+var rulesSplitDays = map[int][]string{
+	1: {"Full Body"},
+	2: {"Upper", "Lower"},
+	3: {"Push", "Pull", "Legs"},
+	4: {"Upper", "Lower", "Upper", "Lower"},
+	5: {"Push", "Pull", "Legs", "Upper", "Lower"},
+	6: {"Push", "Pull", "Legs", "Push", "Pull", "Legs"},
+	7: {"Push", "Pull", "Legs", "Push", "Pull", "Legs", "Full Body"},
+}
+
+// rulesExerciseGear is the equipment each rule-based exercise needs.
+var rulesExerciseGear = map[string]string{
+	"Bench Press":                         split.EquipmentBarbell,
+	"Overhead Press":                      split.EquipmentBarbell,
+	"Dumbbell Fly":                        split.EquipmentDumbbell,
+	"Triceps Pushdown":                    split.EquipmentCable,
+	"Pull-up":                             split.EquipmentBodyweight,
+	"Bent-Over Row":                       split.EquipmentBarbell,
+	"Lat Pulldown":                        split.EquipmentCable,
+	"Biceps Curl":                         split.EquipmentDumbbell,
+	"Squat":                               split.EquipmentBarbell,
+	"Romanian Deadlift":                   split.EquipmentBarbell,
+	"Leg Press":                           split.EquipmentMachine,
+	"Calf Raise":                          split.EquipmentBodyweight,
+	"Leg Curl":                            split.EquipmentMachine,
+	"Plank":                               split.EquipmentBodyweight,
+	"Dumbbell Bench Press":                split.EquipmentDumbbell,
+	"Push-up":                             split.EquipmentBodyweight,
+	"Dumbbell Shoulder Press":             split.EquipmentDumbbell,
+	"Pike Push-up":                        split.EquipmentBodyweight,
+	"Cable Fly":                           split.EquipmentCable,
+	"Dumbbell Overhead Triceps Extension": split.EquipmentDumbbell,
+	"Bench Dip":                           split.EquipmentBodyweight,
+	"Dumbbell Row":                        split.EquipmentDumbbell,
+	"Inverted Row":                        split.EquipmentBodyweight,
+	"Band Pull-apart":                     split.EquipmentBand,
+	"Band Curl":                           split.EquipmentBand,
+	"Goblet Squat":                        split.EquipmentDumbbell,
+	"Bodyweight Squat":                    split.EquipmentBodyweight,
+	"Dumbbell Romanian Deadlift":          split.EquipmentDumbbell,
+	"Single-Leg Glute Bridge":             split.EquipmentBodyweight,
+	"Bulgarian Split Squat":               split.EquipmentBodyweight,
+	"Nordic Curl":                         split.EquipmentBodyweight,
+	"Dead Bug":                            split.EquipmentBodyweight,
+}
+
+// rulesSubstitutes lists replacements for a rule-based exercise, best
+// first, for when the user lacks its equipment or excluded it.
+var rulesSubstitutes = map[string][]string{
+	"Bench Press":       {"Dumbbell Bench Press", "Push-up"},
+	"Overhead Press":    {"Dumbbell Shoulder Press", "Pike Push-up"},
+	"Dumbbell Fly":      {"Cable Fly", "Push-up"},
+	"Triceps Pushdown":  {"Dumbbell Overhead Triceps Extension", "Bench Dip"},
+	"Pull-up":           {"Lat Pulldown", "Inverted Row"},
+	"Bent-Over Row":     {"Dumbbell Row", "Inverted Row"},
+	"Lat Pulldown":      {"Pull-up", "Band Pull-apart"},
+	"Biceps Curl":       {"Band Curl"},
+	"Squat":             {"Goblet Squat", "Bodyweight Squat"},
+	"Romanian Deadlift": {"Dumbbell Romanian Deadlift", "Single-Leg Glute Bridge"},
+	"Leg Press":         {"Bulgarian Split Squat", "Goblet Squat"},
+	"Leg Curl":          {"Nordic Curl", "Single-Leg Glute Bridge"},
+	"Plank":             {"Dead Bug"},
+}
+
+// rulesPrimarySets is the primary lift's set count per experience level.
+var rulesPrimarySets = map[string]int{
+	split.ExperienceBeginner:     3,
+	split.ExperienceIntermediate: 4,
+	split.ExperienceAdvanced:     5,
+}
+
+func (rulesService) GenerateSplit(ctx context.Context, input SplitInput) (*SplitOutput, error) {
+	days := input.DaysPerWeek
+	if days < 1 {
+		days = 3
+	}
+	if days > 7 {
+		days = 7
+	}
+
+	out := &SplitOutput{Name: fmt.Sprintf("%d-Day Split", days), Source: coach.SourceRules}
+	if focus := strings.TrimSpace(input.FocusMuscle); focus != "" {
+		out.Name = fmt.Sprintf("%d-Day Split (%s focus)", days, strings.ToLower(focus))
+	}
+
+	primarySets, ok := rulesPrimarySets[input.ExperienceLevel]
+	if !ok {
+		primarySets = rulesPrimarySets[split.DefaultExperienceLevel]
+	}
+	equipment := newEquipmentSet(input.Equipment)
+
+	for _, name := range rulesSplitDays[days] {
+		day := SplitDayOutput{DayName: name, Focus: []string{strings.ToLower(name)}}
+		for _, planned := range rulesDayExercises[name] {
+			ex, ok := rulesPick(planned, equipment, input.ExcludedMovements, day.Exercises)
+			if !ok {
+				continue
+			}
+			sets, reps, priority := 3, "8-12", "secondary"
+			if len(day.Exercises) == 0 {
+				sets, reps, priority = primarySets, "5-8", "primary"
+			}
+			day.Exercises = append(day.Exercises, SplitExerciseOutput{Name: ex, Sets: sets, RepRange: reps, Priority: priority})
+		}
+		rulesFitSession(&day, input.MaxSessionMinutes)
+		// A day left without any allowed exercise is dropped rather than
+		// returned empty.
+		if len(day.Exercises) > 0 {
+			out.Days = append(out.Days, day)
+		}
+	}
+	return out, nil
+}
+
+// rulesPick returns name or its first substitute that the user has the
+// equipment for, did not exclude and is not already in the day.
+func rulesPick(name string, equipment equipmentSet, excluded []string, picked []SplitExerciseOutput) (string, bool) {
+	for _, candidate := range append([]string{name}, rulesSubstitutes[name]...) {
+		if len(equipment.missing([]string{rulesExerciseGear[candidate]})) > 0 || slices.ContainsFunc(picked, func(ex SplitExerciseOutput) bool { return ex.Name == candidate }) {
+			continue
+		}
+		allowed := true
+		for _, movement := range excluded {
+			if exercisematch.Mentions(candidate, movement) {
+				allowed = false
+				break
+			}
+		}
+		if allowed {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// rulesFitSession drops accessories from the end of day, then sets from the
+// primary lift, until it fits limit minutes. A limit of zero is no limit.
+func rulesFitSession(day *SplitDayOutput, limit int) {
+	if limit <= 0 {
+		return
+	}
+	for len(day.Exercises) > 1 && EstimateDayMinutes(*day) > limit {
+		day.Exercises = day.Exercises[:len(day.Exercises)-1]
+	}
+	if len(day.Exercises) == 1 {
+		day.Exercises[0].Sets = max(1, min(day.Exercises[0].Sets, limit/minutesPerSet))
+	}
+}
+
+func (rulesService) GenerateWorkout(ctx context.Context, input WorkoutInput) (*WorkoutOutput, error) {
+	planned := input.PlannedExercises
+	if len(planned) == 0 {
+		planned = rulesDayExercises["Full Body"]
+	}
+
+	// Volume follows the fatigue estimate, which already folds in ACWR and
+	// recent RPE.
+	sets := 3
+	switch {
+	case input.FatigueEstimated >= 7:
+		sets = 2
+	case input.FatigueEstimated <= 3:
+		sets = 4
+	}
+
+	out := &WorkoutOutput{Source: coach.SourceRules}
+	for i, name := range planned {
+		reps := "8-12"
+		if i == 0 && input.FatigueEstimated < 7 {
+			reps = "5-8"
+		}
+		out.Exercises = append(out.Exercises, WorkoutExerciseOutput{Name: name, Sets: sets, RepRange: reps})
+	}
+	return out, nil
+}
+
+func (rulesService) SuggestOverload(ctx context.Context, input OverloadInput) (*OverloadOutput, error) {
+	out := &OverloadOutput{Source: coach.SourceRules}
+	switch {
+	case input.LastReps <= 0:
+		out.Action = "maintain"
+		out.Message = "No logged sets for this exercise yet. Pick a weight you can lift for 8-12 clean reps and log it."
+	case input.LastReps >= 12:
+		out.Action = "increase"
+		if input.LastWeight > 0 {
+			out.Message = fmt.Sprintf("You hit %d reps at %.1f kg. Add %.1f kg next session and work back up to 12.", input.LastReps, input.LastWeight, overloadIncrement(input.LastWeight))
+		} else {
+			out.Message = fmt.Sprintf("You hit %d reps. Add load or a harder variation next session.", input.LastReps)
+		}
+	case input.LastReps < 5:
+		out.Action = "decrease"
+		out.Message = fmt.Sprintf("Reps dropped to %d. Reduce the load 5-10%% and rebuild with clean technique.", input.LastReps)
+	case input.PlanOutcome == workout.OutcomeMissed:
+		out.Action = "maintain"
+		out.Message = "You fell short of your last plan for this exercise. Repeat the planned load until every set is done."
+	case input.PlanOutcome == workout.OutcomeExceeded && input.LastWeight > 0:
+		out.Action = "increase"
+		out.Message = fmt.Sprintf("You beat your last plan at %.1f kg. Add %.1f kg next session.", input.LastWeight, overloadIncrement(input.LastWeight))
+	default:
+		out.Action = "maintain"
+		out.Message = fmt.Sprintf("Stay at this weight and aim for one more clean rep per set (last: %d).", input.LastReps)
+	}
+	return out, nil
+}
+
+// overloadIncrement is 2.5% of the working weight rounded to the nearest
+// 0.5 kg, with a 1 kg floor so light lifts still progress.
+func overloadIncrement(weight float64) float64 {
+	inc := math.Round(weight*0.025*2) / 2
+	if inc < 1 {
+		inc = 1
+	}
+	return inc
+}
+
+var (
+	rulesMotivationIdle = []string{
+		"No sessions in the last week. Today is a good day to restart: one solid workout is enough.",
+		"It has been a quiet week. Show up today, even a short session keeps the habit alive.",
+		"Momentum starts with a single session. Get in, do the basics well, and log it.",
+	}
+	rulesMotivationActive = []string{
+		"%d sessions in the last 7 days. Keep the streak going and make today count.",
+		"%d workouts this week. Consistency is doing the heavy lifting, keep showing up.",
+		"You've trained %d times in 7 days. Focus on quality reps today.",
+	}
+)
+
+func (rulesService) GenerateDailyMotivation(ctx context.Context, input MotivationInput) (*MotivationOutput, error) {
+	// Rotate templates by date so the message changes daily but stays stable
+	// within a day.
+	pick := 0
+	if d, err := time.Parse("2006-01-02", input.Date); err == nil {
+		pick = d.YearDay()
+	}
+
+	msg := rulesMotivationIdle[pick%len(rulesMotivationIdle)]
+	if input.WorkoutsLast7Days > 0 {
+		msg = fmt.Sprintf(rulesMotivationActive[pick%len(rulesMotivationActive)], input.WorkoutsLast7Days)
+	}
+	return &MotivationOutput{Message: msg, Source: coach.SourceRules}, nil
+}
+
+func (rulesService) GenerateCoachingSuggestions(ctx context.Context, input CoachingInput) (*CoachingOutput, error) {
+	var suggestions []string
+	switch {
+	case input.ACWR >= 1.5:
+		suggestions = append(suggestions, fmt.Sprintf("Your 7-day load is %.1fx your 28-day average. Take a lighter week to recover.", input.ACWR))
+	case input.ACWR >= 1.3:
+		suggestions = append(suggestions, "Load is climbing quickly. Hold volume steady this week instead of adding more.")
+	case input.ACWR > 0 && input.ACWR < 0.8:
+		suggestions = append(suggestions, "Training load is below your usual. Add a session or a few hard sets this week.")
+	case input.ACWR == 0:
+		suggestions = append(suggestions, "No recent training load. Start with two or three full-body sessions this week.")
+	default:
+		suggestions = append(suggestions, "Load is in a good range. Keep progressing your main lifts gradually.")
+	}
+
+	if strings.HasPrefix(input.RecentNutrition, "(") {
+		suggestions = append(suggestions, "Log today's meals so protein and calories can be factored into your plan.")
+	} else {
+		suggestions = append(suggestions, "Aim for roughly 1.6-2.2 g of protein per kg of bodyweight each day.")
+	}
+	suggestions = append(suggestions, "Prioritize 7-9 hours of sleep to support recovery.")
+
+	return &CoachingOutput{Suggestions: suggestions, Source: coach.SourceRules}, nil
+}
+
+func (rulesService) ExplainWorkoutPlan(ctx context.Context, input ExplainWorkoutPlanInput) (*ExplainWorkoutPlanOutput, error) {
+	day := strings.TrimSpace(input.SplitDayName)
+	if day == "" {
+		day = "Today's session"
+	}
+
+	effort := "keep 1-3 reps in reserve on every working set"
+	if input.Fatigue >= 7 {
+		effort = "you reported high fatigue, so stop each set 3 reps short of failure"
+	}
+
+	out := &ExplainWorkoutPlanOutput{
+		Summary: fmt.Sprintf("%s: work through the exercises in order, %s and rest 2-3 minutes on compound lifts.", day, effort),
+		Source:  coach.SourceRules,
+	}
+	for i, ex := range input.Exercises {
+		note := "Control the eccentric and stop the set when form breaks down."
+		if i == 0 {
+			note = "Main lift of the day. Warm up with a few lighter sets before the working sets."
+		}
+		out.ExerciseNotes = append(out.ExerciseNotes, ExplainExerciseNote{Name: ex.Name, Note: note})
+	}
+	return out, nil
+}
+
+func (r rulesService) StreamCoachingSuggestions(ctx context.Context, input CoachingInput, onDelta func(string) error) (*CoachingOutput, error) {
+	out, _ := r.GenerateCoachingSuggestions(ctx, input)
+	if err := emitJSON(out, onDelta); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r rulesService) StreamExplainWorkoutPlan(ctx context.Context, input ExplainWorkoutPlanInput, onDelta func(string) error) (*ExplainWorkoutPlanOutput, error) {
+	out, _ := r.ExplainWorkoutPlan(ctx, input)
+	if err := emitJSON(out, onDelta); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// emitJSON sends v as a single delta, matching what a streaming model would
+// have produced.
+func emitJSON(v any, onDelta func(string) error) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return domainerr.ErrInternal
+	}
+	return onDelta(string(b))
+}
@@ -0,0 +1,154 @@
+package orchestrator
+
+import "encoding/json"
+
+// Schema is the JSON Schema an orchestrator call expects the model to
+// return. Clients that support structured output send it with the request;
+// the rest rely on the prompt and the repair loop.
+type Schema struct {
+	Name       string
+	Definition json.RawMessage
+}
+
+// The schemas follow OpenAI's strict-mode subset: every property is required
+// and objects are closed. Numeric ranges are enforced by the validators.
+
+var SplitSchema = Schema{Name: "split", Definition: json.RawMessage(`{
+  "type": "object",
+  "additionalProperties": false,
+  "required": ["name", "days"],
+  "properties": {
+    "name": {"type": "string"},
+    "days": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "additionalProperties": false,
+        "required": ["day_name", "focus", "exercises"],
+        "properties": {
+          "day_name": {"type": "string"},
+          "focus": {"type": "array", "items": {"type": "string"}},
+          "exercises": {
+            "type": "array",
+            "items": {
+              "type": "object",
+              "additionalProperties": false,
+              "required": ["name", "sets", "rep_range", "priority"],
+              "properties": {
+                "name": {"type": "string"},
+                "sets": {"type": "integer"},
+                "rep_range": {"type": "string"},
+                "priority": {"type": "string", "enum": ["primary", "secondary", "accessory"]}
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}`)}
+
+var SplitDaySchema = Schema{Name: "split_day", Definition: json.RawMessage(`{
+  "type": "object",
+  "additionalProperties": false,
+  "required": ["day_name", "focus", "exercises"],
+  "properties": {
+    "day_name": {"type": "string"},
+    "focus": {"type": "array", "items": {"type": "string"}},
+    "exercises": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "additionalProperties": false,
+        "required": ["name", "sets", "rep_range", "priority"],
+        "properties": {
+          "name": {"type": "string"},
+          "sets": {"type": "integer"},
+          "rep_range": {"type": "string"},
+          "priority": {"type": "string", "enum": ["primary", "secondary", "accessory"]}
+        }
+      }
+    }
+  }
+}`)}
+
+var WorkoutSchema = Schema{Name: "workout", Definition: json.RawMessage(`{
+  "type": "object",
+  "additionalProperties": false,
+  "required": ["exercises"],
+  "properties": {
+    "exercises": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "additionalProperties": false,
+        "required": ["name", "sets", "rep_range", "weight"],
+        "properties": {
+          "name": {"type": "string"},
+          "sets": {"type": "integer"},
+          "rep_range": {"type": "string"},
+          "weight": {"type": "number"}
+        }
+      }
+    }
+  }
+}`)}
+
+var OverloadSchema = Schema{Name: "overload", Definition: json.RawMessage(`{
+  "type": "object",
+  "additionalProperties": false,
+  "required": ["action", "message"],
+  "properties": {
+    "action": {"type": "string", "enum": ["increase", "maintain", "decrease"]},
+    "message": {"type": "string"}
+  }
+}`)}
+
+var MotivationSchema = Schema{Name: "motivation", Definition: json.RawMessage(`{
+  "type": "object",
+  "additionalProperties": false,
+  "required": ["message"],
+  "properties": {
+    "message": {"type": "string"}
+  }
+}`)}
+
+var CoachingSchema = Schema{Name: "coaching", Definition: json.RawMessage(`{
+  "type": "object",
+  "additionalProperties": false,
+  "required": ["suggestions"],
+  "properties": {
+    "suggestions": {"type": "array", "items": {"type": "string"}}
+  }
+}`)}
+
+var ExplainWorkoutPlanSchema = Schema{Name: "workout_explanation", Definition: json.RawMessage(`{
+  "type": "object",
+  "additionalProperties": false,
+  "required": ["summary", "exercise_notes"],
+  "properties": {
+    "summary": {"type": "string"},
+    "exercise_notes": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "additionalProperties": false,
+        "required": ["name", "note"],
+        "properties": {
+          "name": {"type": "string"},
+          "note": {"type": "string"}
+        }
+      }
+    }
+  }
+}`)}
+
+var ChatSchema = Schema{Name: "chat_reply", Definition: json.RawMessage(`{
+  "type": "object",
+  "additionalProperties": false,
+  "required": ["reply", "citations"],
+  "properties": {
+    "reply": {"type": "string"},
+    "citations": {"type": "array", "items": {"type": "string"}}
+  }
+}`)}
@@ -2,182 +2,333 @@ package orchestrator
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"strings"
 	"time"
+
+	"S.P.A.R.T.A/backend/internal/ai/prompts"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/aicall"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/coach"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
 )
 
+// defaultMaxRepairs is how many times an invalid answer is sent back to the
+// model with the validation problems before giving up.
+const defaultMaxRepairs = 2
+
 type service struct {
-	client AIClient
+	client     AIClient
+	catalog    ExerciseCatalog
+	auditor    Auditor
+	prompts    *prompts.Registry
+	maxRepairs int
 }
 
-func NewOrchestrator(client AIClient) Orchestrator {
-	return &service{
-		client: client,
+type Option func(*service)
+
+// WithExerciseCatalog makes generated splits and workouts use exercises from
+// the library; unknown names are sent back for repair.
+func WithExerciseCatalog(catalog ExerciseCatalog) Option {
+	return func(s *service) { s.catalog = catalog }
+}
+
+// WithPromptRegistry renders prompts from r instead of the built-in
+// templates.
+func WithPromptRegistry(r *prompts.Registry) Option {
+	return func(s *service) {
+		if r != nil {
+			s.prompts = r
+		}
 	}
 }
 
-func (s *service) GenerateSplit(ctx context.Context, input SplitInput) (*SplitOutput, error) {
-	prompt := BuildSplitPrompt(input)
+// WithMaxRepairs overrides defaultMaxRepairs. Zero disables repairs.
+func WithMaxRepairs(n int) Option {
+	return func(s *service) {
+		if n >= 0 {
+			s.maxRepairs = n
+		}
+	}
+}
+
+func NewOrchestrator(client AIClient, opts ...Option) Orchestrator {
+	s := &service{
+		client:     client,
+		prompts:    prompts.Default(),
+		maxRepairs: defaultMaxRepairs,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
 
-	var resp string
-	err := WithRetry(ctx, RetryConfig{MaxAttempts: 3, Delay: 250 * time.Millisecond}, func(ctx context.Context) error {
-		out, err := s.client.Generate(ctx, prompt)
+// complete sends one prompt, retrying transport failures. The schema is
+// attached when the client supports structured output.
+func (s *service) complete(ctx context.Context, meta callMeta, attempt int, prompt string, schema Schema) (string, aicall.Call, error) {
+	return s.observe(ctx, meta, attempt, prompt, func(ctx context.Context) (string, error) {
+		var resp string
+		err := WithRetry(ctx, RetryConfig{MaxAttempts: 3, Delay: 250 * time.Millisecond}, func(ctx context.Context) error {
+			var (
+				out string
+				err error
+			)
+			if structured, ok := s.client.(StructuredAIClient); ok {
+				out, err = structured.GenerateStructured(ctx, prompt, schema)
+			} else {
+				out, err = s.client.Generate(ctx, prompt)
+			}
+			if err != nil {
+				return err
+			}
+			resp = out
+			return nil
+		})
 		if err != nil {
-			return err
+			return "", err
 		}
-		resp = out
-		return nil
+		return resp, nil
 	})
+}
+
+// render fills in the template version assigned to meta.userID and records
+// that version on meta.
+func (s *service) render(meta *callMeta, name string, data any) (string, error) {
+	r, err := s.prompts.Render(name, meta.userID, data)
 	if err != nil {
+		return "", fmt.Errorf("%w: %v", domainerr.ErrInternal, err)
+	}
+	meta.version = r.Version
+	return r.Text, nil
+}
+
+// generate completes prompt and decodes the answer, repairing it if needed.
+func generate[T any](ctx context.Context, s *service, meta callMeta, prompt string, schema Schema, decode func(string) (*T, error)) (*T, error) {
+	if err := s.checkQuota(ctx, meta); err != nil {
 		return nil, err
 	}
 
-	out, err := ParseSplitResponse(resp)
+	resp, rec, err := s.complete(ctx, meta, 0, prompt, schema)
 	if err != nil {
+		s.record(ctx, rec, err)
 		return nil, err
 	}
+	return repair(ctx, s, meta, prompt, schema, resp, rec, decode)
+}
+
+// repair decodes resp (parse + validate). While the answer is rejected with
+// a ValidationError it is sent back to the model together with the problems,
+// up to s.maxRepairs times. rec is the audit record of the call that
+// produced resp.
+func repair[T any](ctx context.Context, s *service, meta callMeta, prompt string, schema Schema, resp string, rec aicall.Call, decode func(string) (*T, error)) (*T, error) {
+	out, err := decode(resp)
+	s.record(ctx, rec, err)
+	for attempt := 1; err != nil && attempt <= s.maxRepairs; attempt++ {
+		var invalid *ValidationError
+		if !errors.As(err, &invalid) {
+			return nil, err
+		}
 
-	if err := ValidateSplit(out); err != nil {
+		next, nextRec, cerr := s.complete(ctx, meta, attempt, BuildRepairPrompt(prompt, resp, invalid), schema)
+		if cerr != nil {
+			s.record(ctx, nextRec, cerr)
+			return nil, cerr
+		}
+		resp = next
+		out, err = decode(resp)
+		s.record(ctx, nextRec, err)
+	}
+	if err != nil {
 		return nil, err
 	}
-
 	return out, nil
 }
 
-func (s *service) GenerateWorkout(ctx context.Context, input WorkoutInput) (*WorkoutOutput, error) {
-	prompt := BuildWorkoutPrompt(input)
+func (s *service) decodeSplit(ctx context.Context) func(string) (*SplitOutput, error) {
+	return func(resp string) (*SplitOutput, error) {
+		out, err := ParseSplitResponse(resp)
+		if err != nil {
+			return nil, err
+		}
 
-	var resp string
-	err := WithRetry(ctx, RetryConfig{MaxAttempts: 3, Delay: 250 * time.Millisecond}, func(ctx context.Context) error {
-		out, err := s.client.Generate(ctx, prompt)
+		var names []string
+		for _, d := range out.Days {
+			for _, ex := range d.Exercises {
+				names = append(names, ex.Name)
+			}
+		}
+		if err := mergeProblems(ValidateSplit(out), s.checkLibrary(ctx, names, nil)); err != nil {
+			return nil, err
+		}
+		return out, nil
+	}
+}
+
+func (s *service) decodeWorkout(ctx context.Context, input WorkoutInput) func(string) (*WorkoutOutput, error) {
+	return func(resp string) (*WorkoutOutput, error) {
+		out, err := ParseWorkoutResponse(resp)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		resp = out
-		return nil
-	})
+
+		names := make([]string, 0, len(out.Exercises))
+		for _, ex := range out.Exercises {
+			names = append(names, ex.Name)
+		}
+		// The split day's own exercises are always acceptable, even when they
+		// were entered as free text rather than picked from the library.
+		if err := mergeProblems(ValidateWorkout(out), s.checkLibrary(ctx, names, input.PlannedExercises)); err != nil {
+			return nil, err
+		}
+		return out, nil
+	}
+}
+
+func decodeOverload(resp string) (*OverloadOutput, error) {
+	out, err := ParseOverloadResponse(resp)
 	if err != nil {
 		return nil, err
 	}
+	if err := ValidateOverload(out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
 
-	out, err := ParseWorkoutResponse(resp)
+func decodeMotivation(resp string) (*MotivationOutput, error) {
+	out, err := ParseMotivationResponse(resp)
 	if err != nil {
 		return nil, err
 	}
-
-	if err := ValidateWorkout(out); err != nil {
+	if err := ValidateMotivation(out); err != nil {
 		return nil, err
 	}
-
 	return out, nil
 }
 
-func (s *service) SuggestOverload(ctx context.Context, input OverloadInput) (*OverloadOutput, error) {
-	prompt := BuildOverloadPrompt(input)
+func decodeCoaching(resp string) (*CoachingOutput, error) {
+	out, err := ParseCoachingResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateCoaching(out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
 
-	var resp string
-	err := WithRetry(ctx, RetryConfig{MaxAttempts: 3, Delay: 250 * time.Millisecond}, func(ctx context.Context) error {
-		out, err := s.client.Generate(ctx, prompt)
+func decodeExplainWorkoutPlan(input ExplainWorkoutPlanInput) func(string) (*ExplainWorkoutPlanOutput, error) {
+	return func(resp string) (*ExplainWorkoutPlanOutput, error) {
+		out, err := ParseExplainWorkoutPlanResponse(resp)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		resp = out
-		return nil
-	})
+		if err := ValidateExplainWorkoutPlan(out, input); err != nil {
+			return nil, err
+		}
+		return out, nil
+	}
+}
+
+func (s *service) GenerateSplit(ctx context.Context, input SplitInput) (*SplitOutput, error) {
+	meta := callMeta{op: "generate_split", userID: input.UserID}
+	prompt, err := s.render(&meta, prompts.Split, input)
 	if err != nil {
 		return nil, err
 	}
 
-	out, err := ParseOverloadResponse(resp)
+	out, err := generate(ctx, s, meta, prompt, SplitSchema, s.decodeSplit(ctx))
 	if err != nil {
 		return nil, err
 	}
-
-	if err := ValidateOverload(out); err != nil {
+	if err := s.enforceConstraints(ctx, input, out); err != nil {
 		return nil, err
 	}
-
-	// Normalize action.
-	out.Action = strings.ToLower(strings.TrimSpace(out.Action))
+	out.Source = coach.SourceAI
+	out.PromptVersion = meta.version
 	return out, nil
 }
 
-func (s *service) GenerateDailyMotivation(ctx context.Context, input MotivationInput) (*MotivationOutput, error) {
-	prompt := BuildMotivationPrompt(input)
+func (s *service) GenerateWorkout(ctx context.Context, input WorkoutInput) (*WorkoutOutput, error) {
+	meta := callMeta{op: "generate_workout", userID: input.UserID}
+	prompt, err := s.render(&meta, prompts.Workout, input)
+	if err != nil {
+		return nil, err
+	}
 
-	var resp string
-	err := WithRetry(ctx, RetryConfig{MaxAttempts: 3, Delay: 250 * time.Millisecond}, func(ctx context.Context) error {
-		out, err := s.client.Generate(ctx, prompt)
-		if err != nil {
-			return err
-		}
-		resp = out
-		return nil
-	})
+	out, err := generate(ctx, s, meta, prompt, WorkoutSchema, s.decodeWorkout(ctx, input))
 	if err != nil {
 		return nil, err
 	}
+	out.Source = coach.SourceAI
+	out.PromptVersion = meta.version
+	return out, nil
+}
 
-	out, err := ParseMotivationResponse(resp)
+func (s *service) SuggestOverload(ctx context.Context, input OverloadInput) (*OverloadOutput, error) {
+	meta := callMeta{op: "suggest_overload", userID: input.UserID}
+	prompt, err := s.render(&meta, prompts.Overload, input)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := ValidateMotivation(out); err != nil {
+	out, err := generate(ctx, s, meta, prompt, OverloadSchema, decodeOverload)
+	if err != nil {
 		return nil, err
 	}
 
+	// Normalize action.
+	out.Action = strings.ToLower(strings.TrimSpace(out.Action))
+	out.Source = coach.SourceAI
+	out.PromptVersion = meta.version
 	return out, nil
 }
 
-func (s *service) GenerateCoachingSuggestions(ctx context.Context, input CoachingInput) (*CoachingOutput, error) {
-	prompt := BuildCoachingPrompt(input)
+func (s *service) GenerateDailyMotivation(ctx context.Context, input MotivationInput) (*MotivationOutput, error) {
+	meta := callMeta{op: "daily_motivation", userID: input.UserID}
+	prompt, err := s.render(&meta, prompts.Motivation, input)
+	if err != nil {
+		return nil, err
+	}
 
-	var resp string
-	err := WithRetry(ctx, RetryConfig{MaxAttempts: 3, Delay: 250 * time.Millisecond}, func(ctx context.Context) error {
-		out, err := s.client.Generate(ctx, prompt)
-		if err != nil {
-			return err
-		}
-		resp = out
-		return nil
-	})
+	out, err := generate(ctx, s, meta, prompt, MotivationSchema, decodeMotivation)
 	if err != nil {
 		return nil, err
 	}
+	out.Source = coach.SourceAI
+	out.PromptVersion = meta.version
+	return out, nil
+}
 
-	out, err := ParseCoachingResponse(resp)
+func (s *service) GenerateCoachingSuggestions(ctx context.Context, input CoachingInput) (*CoachingOutput, error) {
+	meta := callMeta{op: "coaching", userID: input.UserID}
+	prompt, err := s.render(&meta, prompts.Coaching, input)
 	if err != nil {
 		return nil, err
 	}
-	if err := ValidateCoaching(out); err != nil {
+
+	out, err := generate(ctx, s, meta, prompt, CoachingSchema, decodeCoaching)
+	if err != nil {
 		return nil, err
 	}
+	out.Source = coach.SourceAI
+	out.PromptVersion = meta.version
 	return out, nil
 }
 
 func (s *service) ExplainWorkoutPlan(ctx context.Context, input ExplainWorkoutPlanInput) (*ExplainWorkoutPlanOutput, error) {
-	prompt := BuildExplainWorkoutPlanPrompt(input)
-
-	var resp string
-	err := WithRetry(ctx, RetryConfig{MaxAttempts: 3, Delay: 250 * time.Millisecond}, func(ctx context.Context) error {
-		out, err := s.client.Generate(ctx, prompt)
-		if err != nil {
-			return err
-		}
-		resp = out
-		return nil
-	})
+	meta := callMeta{op: "explain_workout", userID: input.UserID}
+	prompt, err := s.render(&meta, prompts.ExplainWorkout, input)
 	if err != nil {
 		return nil, err
 	}
 
-	out, err := ParseExplainWorkoutPlanResponse(resp)
+	out, err := generate(ctx, s, meta, prompt, ExplainWorkoutPlanSchema, decodeExplainWorkoutPlan(input))
 	if err != nil {
 		return nil, err
 	}
-	if err := ValidateExplainWorkoutPlan(out); err != nil {
-		return nil, err
-	}
+	out.Source = coach.SourceAI
+	out.PromptVersion = meta.version
 	return out, nil
 }
@@ -0,0 +1,126 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/ai/prompts"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/aicall"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/coach"
+)
+
+// errStreamStarted marks a failure after deltas were already sent; the
+// client has seen partial output, so the call must not be retried.
+var errStreamStarted = errors.New("stream already started")
+
+// generateStream streams the completion for prompt to onDelta. Attempts that
+// fail before the first delta are retried like Generate; once output has
+// been emitted a failure is returned as-is. Clients without streaming
+// support fall back to a blocking call emitted as a single delta.
+func (s *service) generateStream(ctx context.Context, meta callMeta, prompt string, onDelta func(string) error) (string, aicall.Call, error) {
+	return s.observe(ctx, meta, 0, prompt, func(ctx context.Context) (string, error) {
+		return s.streamCompletion(ctx, prompt, onDelta)
+	})
+}
+
+func (s *service) streamCompletion(ctx context.Context, prompt string, onDelta func(string) error) (string, error) {
+	streamer, ok := s.client.(StreamingAIClient)
+	if !ok {
+		var resp string
+		err := WithRetry(ctx, RetryConfig{MaxAttempts: 3, Delay: 250 * time.Millisecond}, func(ctx context.Context) error {
+			out, err := s.client.Generate(ctx, prompt)
+			if err != nil {
+				return err
+			}
+			resp = out
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+		if err := onDelta(resp); err != nil {
+			return "", err
+		}
+		return resp, nil
+	}
+
+	var resp string
+	var finalErr error
+	err := WithRetry(ctx, RetryConfig{MaxAttempts: 3, Delay: 250 * time.Millisecond}, func(ctx context.Context) error {
+		started := false
+		out, err := streamer.GenerateStream(ctx, prompt, func(delta string) error {
+			started = true
+			return onDelta(delta)
+		})
+		if err != nil {
+			if started {
+				finalErr = err
+				return nil
+			}
+			return err
+		}
+		resp = out
+		return nil
+	})
+	if finalErr != nil {
+		return "", finalErr
+	}
+	if err != nil {
+		return "", err
+	}
+	return resp, nil
+}
+
+// The streamed answer is validated once complete; repairs run as blocking
+// calls and only the final result event reflects them.
+
+func (s *service) StreamCoachingSuggestions(ctx context.Context, input CoachingInput, onDelta func(string) error) (*CoachingOutput, error) {
+	meta := callMeta{op: "coaching", userID: input.UserID}
+	prompt, err := s.render(&meta, prompts.Coaching, input)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkQuota(ctx, meta); err != nil {
+		return nil, err
+	}
+
+	resp, rec, err := s.generateStream(ctx, meta, prompt, onDelta)
+	if err != nil {
+		s.record(ctx, rec, err)
+		return nil, err
+	}
+
+	out, err := repair(ctx, s, meta, prompt, CoachingSchema, resp, rec, decodeCoaching)
+	if err != nil {
+		return nil, err
+	}
+	out.Source = coach.SourceAI
+	out.PromptVersion = meta.version
+	return out, nil
+}
+
+func (s *service) StreamExplainWorkoutPlan(ctx context.Context, input ExplainWorkoutPlanInput, onDelta func(string) error) (*ExplainWorkoutPlanOutput, error) {
+	meta := callMeta{op: "explain_workout", userID: input.UserID}
+	prompt, err := s.render(&meta, prompts.ExplainWorkout, input)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkQuota(ctx, meta); err != nil {
+		return nil, err
+	}
+
+	resp, rec, err := s.generateStream(ctx, meta, prompt, onDelta)
+	if err != nil {
+		s.record(ctx, rec, err)
+		return nil, err
+	}
+
+	out, err := repair(ctx, s, meta, prompt, ExplainWorkoutPlanSchema, resp, rec, decodeExplainWorkoutPlan(input))
+	if err != nil {
+		return nil, err
+	}
+	out.Source = coach.SourceAI
+	out.PromptVersion = meta.version
+	return out, nil
+}
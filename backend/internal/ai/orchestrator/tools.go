@@ -0,0 +1,54 @@
+package orchestrator
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Roles of the messages exchanged with a tool-calling model.
+const (
+	AgentRoleSystem    = "system"
+	AgentRoleUser      = "user"
+	AgentRoleAssistant = "assistant"
+	AgentRoleTool      = "tool"
+)
+
+// ToolSpec describes a tool offered to the model. Parameters is a JSON
+// Schema for the arguments object.
+type ToolSpec struct {
+	Name        string
+	Description string
+	Parameters  json.RawMessage
+}
+
+// ToolCall is the model's request to run a tool. ID links the result back
+// to the call.
+type ToolCall struct {
+	ID        string
+	Name      string
+	Arguments json.RawMessage
+}
+
+// AgentMessage is one turn of a tool-calling conversation. Assistant turns
+// may carry ToolCalls; tool turns answer the call named by ToolCallID.
+type AgentMessage struct {
+	Role       string
+	Content    string
+	ToolCalls  []ToolCall
+	ToolCallID string
+}
+
+// AgentReply is one model completion: either a final answer in Content or
+// tool calls to run before asking again.
+type AgentReply struct {
+	Content   string
+	ToolCalls []ToolCall
+}
+
+// ToolExecutor runs the tool calls of one agent run. Errors wrapping
+// ErrInvalidInput, ErrNotFound, ErrForbidden or ErrConflict are shown to
+// the model so it can correct the call; any other error ends the run.
+type ToolExecutor interface {
+	Tools() []ToolSpec
+	Execute(ctx context.Context, call ToolCall) (string, error)
+}
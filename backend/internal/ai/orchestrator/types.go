@@ -1,15 +1,48 @@
 package orchestrator
 
+import (
+	"encoding/json"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/coach"
+)
+
 type SplitInput struct {
 	UserID          string
 	DaysPerWeek     int
 	ExperienceLevel string
 	FocusMuscle     string
+	// Library lists the exercise names the model should pick from. Empty
+	// when the library is not seeded.
+	Library []string
+	// Equipment is the canonical equipment available (split.Equipment*);
+	// empty means a full gym.
+	Equipment []string
+	// MaxSessionMinutes of zero means no limit.
+	MaxSessionMinutes int
+	Injuries          string
+	ExcludedMovements []string
+	PreferredDays     []string
+	Voice             coach.Voice
+}
+
+// SplitDayInput asks for one day of an existing split again, after it broke
+// the user's constraints.
+type SplitDayInput struct {
+	Split     SplitInput
+	SplitName string
+	DayName   string
+	Focus     []string
+	// OtherDays are the names of the days that are kept.
+	OtherDays []string
+	Previous  []string
+	Problems  []string
 }
 
 type SplitOutput struct {
-	Name string           `json:"name"`
-	Days []SplitDayOutput `json:"days"`
+	Name          string           `json:"name"`
+	Days          []SplitDayOutput `json:"days"`
+	Source        coach.Source     `json:"-"`
+	PromptVersion string           `json:"-"`
 }
 
 type SplitDayOutput struct {
@@ -31,15 +64,20 @@ type WorkoutInput struct {
 	SplitDayName     string
 	PlannedExercises []string
 	Fatigue          int
+	LoadModel        string
+	LoadUnit         string
 	AcuteLoad7d      float64
 	ChronicLoad28d   float64
 	ACWR             float64
 	FatigueEstimated int
 	LastVolume       int
+	Voice            coach.Voice
 }
 
 type WorkoutOutput struct {
-	Exercises []WorkoutExerciseOutput `json:"exercises"`
+	Exercises     []WorkoutExerciseOutput `json:"exercises"`
+	Source        coach.Source            `json:"-"`
+	PromptVersion string                  `json:"-"`
 }
 
 type WorkoutExerciseOutput struct {
@@ -55,11 +93,19 @@ type OverloadInput struct {
 	LastWeight  float64
 	LastReps    int
 	Performance string
+	// PlanOutcome is how the last completed planned session went for the
+	// exercise (hit, missed, exceeded, skipped); empty without one.
+	// PlanComparison describes it.
+	PlanOutcome    string
+	PlanComparison string
+	Voice          coach.Voice
 }
 
 type OverloadOutput struct {
-	Action  string `json:"action"`
-	Message string `json:"message"`
+	Action        string       `json:"action"`
+	Message       string       `json:"message"`
+	Source        coach.Source `json:"-"`
+	PromptVersion string       `json:"-"`
 }
 
 type MotivationInput struct {
@@ -69,25 +115,33 @@ type MotivationInput struct {
 	LastWorkoutDate     string
 	LastWorkoutDuration int
 	LastWorkoutNotes    string
+	Voice               coach.Voice
 }
 
 type MotivationOutput struct {
-	Message string `json:"message"`
+	Message       string       `json:"message"`
+	Source        coach.Source `json:"-"`
+	PromptVersion string       `json:"-"`
 }
 
 type CoachingInput struct {
 	UserID            string
 	Date              string
+	LoadModel         string
+	LoadUnit          string
 	AcuteLoad7d       float64
 	ChronicLoad28d    float64
 	ACWR              float64
 	RecentWorkouts    string
 	RecentNutrition   string
 	RecentPlannerRecs string
+	Voice             coach.Voice
 }
 
 type CoachingOutput struct {
-	Suggestions []string `json:"suggestions"`
+	Suggestions   []string     `json:"suggestions"`
+	Source        coach.Source `json:"-"`
+	PromptVersion string       `json:"-"`
 }
 
 type ExplainWorkoutPlanInput struct {
@@ -95,6 +149,7 @@ type ExplainWorkoutPlanInput struct {
 	SplitDayName string
 	Fatigue      int
 	Exercises    []ExplainWorkoutExercise
+	Voice        coach.Voice
 }
 
 type ExplainWorkoutExercise struct {
@@ -105,11 +160,74 @@ type ExplainWorkoutExercise struct {
 }
 
 type ExplainWorkoutPlanOutput struct {
-	Summary       string
+	Summary       string                `json:"summary"`
 	ExerciseNotes []ExplainExerciseNote `json:"exercise_notes"`
+	Source        coach.Source          `json:"-"`
+	PromptVersion string                `json:"-"`
 }
 
 type ExplainExerciseNote struct {
-	Name string
-	Note string
+	Name string `json:"name"`
+	Note string `json:"note"`
+}
+
+// ContextItem is one piece of user data offered to the chat model. The
+// model cites items by ID.
+type ContextItem struct {
+	ID    string
+	Kind  string
+	Label string
+	Text  string
+}
+
+type ChatTurn struct {
+	Role    string
+	Content string
+}
+
+type ChatInput struct {
+	UserID string
+	// Context is ordered by importance; items at the end are dropped first
+	// when the token budget is tight.
+	Context []ContextItem
+	// History holds the earlier turns, oldest first.
+	History     []ChatTurn
+	Question    string
+	TokenBudget int
+	Voice       coach.Voice
+}
+
+type ChatOutput struct {
+	Reply         string       `json:"reply"`
+	Citations     []string     `json:"citations"`
+	Source        coach.Source `json:"-"`
+	PromptVersion string       `json:"-"`
+}
+
+type AgentInput struct {
+	UserID string
+	Date   string
+	// Context describes the user's plan (e.g. split days with their ids) so
+	// the model can refer to it in tool calls.
+	Context  []ContextItem
+	Question string
+	Tools    ToolExecutor
+	// MaxSteps bounds the model round-trips; defaultAgentMaxSteps if unset.
+	MaxSteps int
+	Voice    coach.Voice
+}
+
+// AgentStep records one tool call made during an agent run.
+type AgentStep struct {
+	Tool      string
+	Arguments json.RawMessage
+	Result    string
+	Error     string
+}
+
+type AgentOutput struct {
+	Reply         string
+	Steps         []AgentStep
+	Source        coach.Source
+	PromptVersion string
 }
@@ -1,86 +1,259 @@
 package orchestrator
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+	"S.P.A.R.T.A/backend/internal/domain/service/exercisematch"
 )
 
-func ValidateSplit(out *SplitOutput) error {
-	if out.Name == "" {
-		return fmt.Errorf("%w: invalid split name", domainerr.ErrInvalidInput)
+const (
+	minSets = 1
+	maxSets = 10
+	maxReps = 100
+)
+
+// ValidationError lists everything wrong with a model answer. The problems
+// are phrased for the model so they can be sent back in a repair prompt.
+// Once repairs are exhausted it surfaces as ErrAIUnavailable: bad model
+// output is an upstream failure, not a client error.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid AI output: " + strings.Join(e.Problems, "; ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return domainerr.ErrAIUnavailable
+}
+
+type problems []string
+
+func (p *problems) addf(format string, args ...any) {
+	*p = append(*p, fmt.Sprintf(format, args...))
+}
+
+func (p problems) err() error {
+	if len(p) == 0 {
+		return nil
 	}
+	return &ValidationError{Problems: p}
+}
 
-	if len(out.Days) == 0 {
-		return fmt.Errorf("%w: split must contain days", domainerr.ErrInvalidInput)
+// mergeProblems combines validation results so one repair round can fix
+// everything at once. Any other error is returned unchanged.
+func mergeProblems(errs ...error) error {
+	var p problems
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		var invalid *ValidationError
+		if !errors.As(err, &invalid) {
+			return err
+		}
+		p = append(p, invalid.Problems...)
+	}
+	return p.err()
+}
+
+// ParseRepRange accepts "8-12", "8–12", "8 to 12" and "10" (optionally
+// followed by "reps").
+func ParseRepRange(s string) (lo, hi int, ok bool) {
+	clean := strings.ToLower(strings.TrimSpace(s))
+	clean = strings.TrimSuffix(clean, "reps")
+	clean = strings.ReplaceAll(clean, "–", "-")
+	clean = strings.ReplaceAll(clean, "to", "-")
+	clean = strings.ReplaceAll(clean, " ", "")
+	if clean == "" {
+		return 0, 0, false
+	}
+
+	parts := strings.Split(clean, "-")
+	switch len(parts) {
+	case 1:
+		n, err := strconv.Atoi(parts[0])
+		lo, hi = n, n
+		ok = err == nil
+	case 2:
+		a, errA := strconv.Atoi(parts[0])
+		b, errB := strconv.Atoi(parts[1])
+		lo, hi = a, b
+		ok = errA == nil && errB == nil
+	}
+	if !ok || lo < 1 || hi < lo || hi > maxReps {
+		return 0, 0, false
+	}
+	return lo, hi, true
+}
+
+func checkExercise(p *problems, where, name string, sets int, repRange string) {
+	if strings.TrimSpace(name) == "" {
+		p.addf("%s: name must not be empty", where)
+		return
+	}
+	if sets < minSets || sets > maxSets {
+		p.addf("%s %q: sets must be between %d and %d, got %d", where, name, minSets, maxSets, sets)
+	}
+	if _, _, ok := ParseRepRange(repRange); !ok {
+		p.addf("%s %q: rep_range %q must look like \"8-12\" or \"10\"", where, name, repRange)
+	}
+}
+
+func ValidateSplit(out *SplitOutput) error {
+	if out == nil {
+		return fmt.Errorf("%w: nil split output", domainerr.ErrInternal)
 	}
 
-	for _, d := range out.Days {
+	var p problems
+	if strings.TrimSpace(out.Name) == "" {
+		p.addf("name must not be empty")
+	}
+	if len(out.Days) == 0 || len(out.Days) > 7 {
+		p.addf("days must contain between 1 and 7 entries, got %d", len(out.Days))
+	}
+	for i, d := range out.Days {
+		where := fmt.Sprintf("days[%d]", i)
+		if strings.TrimSpace(d.DayName) == "" {
+			p.addf("%s: day_name must not be empty", where)
+		}
 		if len(d.Exercises) == 0 {
-			return fmt.Errorf("%w: each day must contain exercises", domainerr.ErrInvalidInput)
+			p.addf("%s: exercises must not be empty", where)
+		}
+		for j, ex := range d.Exercises {
+			checkExercise(&p, fmt.Sprintf("%s.exercises[%d]", where, j), ex.Name, ex.Sets, ex.RepRange)
 		}
 	}
-
-	return nil
+	return p.err()
 }
 
 func ValidateWorkout(out *WorkoutOutput) error {
 	if out == nil {
 		return fmt.Errorf("%w: nil workout output", domainerr.ErrInternal)
 	}
+
+	var p problems
 	if len(out.Exercises) == 0 {
-		return fmt.Errorf("%w: workout must contain exercises", domainerr.ErrInvalidInput)
+		p.addf("exercises must not be empty")
 	}
-	for _, ex := range out.Exercises {
-		if ex.Name == "" || ex.Sets <= 0 || ex.RepRange == "" {
-			return fmt.Errorf("%w: invalid workout exercise", domainerr.ErrInvalidInput)
+	for i, ex := range out.Exercises {
+		where := fmt.Sprintf("exercises[%d]", i)
+		checkExercise(&p, where, ex.Name, ex.Sets, ex.RepRange)
+		if ex.Weight < 0 {
+			p.addf("%s %q: weight must not be negative, got %.2f", where, ex.Name, ex.Weight)
 		}
 	}
-	return nil
+	return p.err()
 }
 
 func ValidateOverload(out *OverloadOutput) error {
 	if out == nil {
 		return fmt.Errorf("%w: nil overload output", domainerr.ErrInternal)
 	}
-	if out.Action == "" || out.Message == "" {
-		return fmt.Errorf("%w: invalid overload output", domainerr.ErrInvalidInput)
+
+	var p problems
+	switch strings.ToLower(strings.TrimSpace(out.Action)) {
+	case "increase", "maintain", "decrease":
+	default:
+		p.addf("action must be one of increase, maintain, decrease, got %q", out.Action)
+	}
+	if strings.TrimSpace(out.Message) == "" {
+		p.addf("message must not be empty")
 	}
-	return nil
+	return p.err()
 }
 
 func ValidateMotivation(out *MotivationOutput) error {
 	if out == nil {
 		return fmt.Errorf("%w: nil motivation output", domainerr.ErrInternal)
 	}
-	if strings.TrimSpace(out.Message) == "" {
-		return fmt.Errorf("%w: empty motivation message", domainerr.ErrInvalidInput)
+
+	var p problems
+	msg := strings.TrimSpace(out.Message)
+	if msg == "" {
+		p.addf("message must not be empty")
+	}
+	if len([]rune(msg)) > 300 {
+		p.addf("message must be at most 300 characters, got %d", len([]rune(msg)))
 	}
-	return nil
+	return p.err()
 }
 
 func ValidateCoaching(out *CoachingOutput) error {
 	if out == nil {
 		return fmt.Errorf("%w: nil coaching output", domainerr.ErrInternal)
 	}
-	if len(out.Suggestions) == 0 {
-		return fmt.Errorf("%w: empty suggestions", domainerr.ErrInvalidInput)
+
+	var p problems
+	if len(out.Suggestions) == 0 || len(out.Suggestions) > 10 {
+		p.addf("suggestions must contain between 1 and 10 entries, got %d", len(out.Suggestions))
 	}
-	for _, s := range out.Suggestions {
+	for i, s := range out.Suggestions {
 		if strings.TrimSpace(s) == "" {
-			return fmt.Errorf("%w: empty suggestion", domainerr.ErrInvalidInput)
+			p.addf("suggestions[%d] must not be empty", i)
 		}
 	}
-	return nil
+	return p.err()
 }
 
-func ValidateExplainWorkoutPlan(out *ExplainWorkoutPlanOutput) error {
+// ValidateExplainWorkoutPlan also checks that every note refers to an
+// exercise from the plan being explained.
+func ValidateExplainWorkoutPlan(out *ExplainWorkoutPlanOutput, input ExplainWorkoutPlanInput) error {
 	if out == nil {
 		return fmt.Errorf("%w: nil explain output", domainerr.ErrInternal)
 	}
+
+	var p problems
 	if strings.TrimSpace(out.Summary) == "" {
-		return fmt.Errorf("%w: empty summary", domainerr.ErrInvalidInput)
+		p.addf("summary must not be empty")
+	}
+
+	planned := make(map[string]bool, len(input.Exercises))
+	for _, ex := range input.Exercises {
+		planned[exercisematch.Normalize(ex.Name)] = true
+	}
+	for i, n := range out.ExerciseNotes {
+		if len(planned) > 0 && !planned[exercisematch.Normalize(n.Name)] {
+			p.addf("exercise_notes[%d]: %q is not in the workout plan", i, n.Name)
+		}
+		if strings.TrimSpace(n.Note) == "" {
+			p.addf("exercise_notes[%d]: note must not be empty", i)
+		}
+	}
+	return p.err()
+}
+
+const maxChatReply = 4000
+
+// ValidateChat also checks that every citation names a context item the
+// model was given.
+func ValidateChat(out *ChatOutput, input ChatInput) error {
+	if out == nil {
+		return fmt.Errorf("%w: nil chat output", domainerr.ErrInternal)
+	}
+
+	var p problems
+	reply := strings.TrimSpace(out.Reply)
+	if reply == "" {
+		p.addf("reply must not be empty")
+	}
+	if len([]rune(reply)) > maxChatReply {
+		p.addf("reply must be at most %d characters, got %d", maxChatReply, len([]rune(reply)))
+	}
+
+	known := make(map[string]bool, len(input.Context))
+	for _, item := range input.Context {
+		known[item.ID] = true
+	}
+	for i, id := range out.Citations {
+		if !known[strings.TrimSpace(id)] {
+			p.addf("citations[%d]: %q is not one of the data item ids", i, id)
+		}
 	}
-	return nil
+	return p.err()
 }
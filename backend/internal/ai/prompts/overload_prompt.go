@@ -1,10 +0,0 @@
-package prompts
-
-const OverloadPromptTemplate = `You are an elite strength coach.
-
-Given the athlete's last performance, return STRICT JSON:
-
-{
-	"action": "increase|maintain|decrease",
-	"message": "..."
-}`
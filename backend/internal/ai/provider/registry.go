@@ -0,0 +1,85 @@
+// Package provider selects the LLM backend the orchestrator talks to.
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"S.P.A.R.T.A/backend/internal/ai/orchestrator"
+	"S.P.A.R.T.A/backend/internal/client"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+)
+
+const (
+	OpenAI = "openai"
+	Ollama = "ollama"
+	Rules  = "rules"
+)
+
+// Settings configures one provider. Fields a provider has no use for are
+// ignored (e.g. APIKey for Ollama).
+type Settings struct {
+	BaseURL string
+	APIKey  string
+	Model   string
+	Options client.GenerationOptions
+}
+
+// Factory builds an AIClient from settings.
+type Factory func(s Settings) orchestrator.AIClient
+
+var (
+	mu        sync.RWMutex
+	factories = map[string]Factory{}
+)
+
+func init() {
+	Register(OpenAI, func(s Settings) orchestrator.AIClient {
+		return client.NewOpenAIClientWithOptions(s.APIKey, s.Model, s.BaseURL, s.Options)
+	})
+	Register(Ollama, func(s Settings) orchestrator.AIClient {
+		return client.NewOllamaClient(s.Model, s.BaseURL, s.Options)
+	})
+}
+
+// IsRules reports whether name selects the rule engine. It has no client:
+// callers run the orchestrator in orchestrator.ModeRules instead.
+func IsRules(name string) bool {
+	return strings.EqualFold(strings.TrimSpace(name), Rules)
+}
+
+// Register adds or replaces a provider factory.
+func Register(name string, f Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+	factories[strings.ToLower(strings.TrimSpace(name))] = f
+}
+
+// New builds the named provider. Rules yields a nil client (see IsRules).
+func New(name string, s Settings) (orchestrator.AIClient, error) {
+	if IsRules(name) {
+		return nil, nil
+	}
+	mu.RLock()
+	f, ok := factories[strings.ToLower(strings.TrimSpace(name))]
+	mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown AI provider %q (available: %s)", domainerr.ErrInvalidInput, name, strings.Join(Names(), ", "))
+	}
+	return f(s), nil
+}
+
+// Names lists registered providers and Rules in alphabetical order.
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	out := make([]string, 0, len(factories)+1)
+	out = append(out, Rules)
+	for name := range factories {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,27 @@
+package provider
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+)
+
+func TestNew(t *testing.T) {
+	if got, err := New(" Rules ", Settings{}); err != nil || got != nil {
+		t.Fatalf("New(rules) = %v, %v; want no client and no error", got, err)
+	}
+	if got, err := New("OpenAI", Settings{Model: "gpt-test"}); err != nil || got == nil {
+		t.Fatalf("New(openai) = %v, %v; want a client", got, err)
+	}
+	if _, err := New("bedrock", Settings{}); !errors.Is(err, domainerr.ErrInvalidInput) {
+		t.Fatalf("New(bedrock) error = %v, want ErrInvalidInput", err)
+	}
+}
+
+func TestNames(t *testing.T) {
+	if got, want := Names(), []string{Ollama, OpenAI, Rules}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Names() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,311 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"S.P.A.R.T.A/backend/internal/ai/orchestrator"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/aicall"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/coach"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+)
+
+// overloadAnswer is a valid progressive overload completion.
+const overloadAnswer = `{"action":"Increase","message":"Add 2.5 kg next session."}`
+
+// fakeLLM is a local server speaking either the OpenAI-compatible or the
+// Ollama chat protocol. It answers every request with content.
+type fakeLLM struct {
+	t       *testing.T
+	content string
+	status  int
+
+	mu       sync.Mutex
+	auth     []string
+	requests []map[string]any
+}
+
+func (f *fakeLLM) capture(r *http.Request) map[string]any {
+	var body map[string]any
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		f.t.Errorf("request body is not JSON: %v", err)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.auth = append(f.auth, r.Header.Get("Authorization"))
+	f.requests = append(f.requests, body)
+	return body
+}
+
+func (f *fakeLLM) openAI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.URL.Path != "/v1/chat/completions" {
+		http.NotFound(w, r)
+		return
+	}
+	body := f.capture(r)
+	if f.status != 0 {
+		w.WriteHeader(f.status)
+		fmt.Fprint(w, `{"error":{"message":"model overloaded"}}`)
+		return
+	}
+
+	if stream, _ := body["stream"].(bool); stream {
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, part := range split3(f.content) {
+			chunk, _ := json.Marshal(map[string]any{
+				"model":   "fake-gpt",
+				"choices": []any{map[string]any{"delta": map[string]any{"content": part}}},
+			})
+			fmt.Fprintf(w, "data: %s\n\n", chunk)
+		}
+		fmt.Fprint(w, `data: {"model":"fake-gpt","choices":[],"usage":{"prompt_tokens":11,"completion_tokens":7,"total_tokens":18}}`+"\n\n")
+		fmt.Fprint(w, "data: [DONE]\n\n")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{
+		"model":   "fake-gpt",
+		"choices": []any{map[string]any{"message": map[string]any{"role": "assistant", "content": f.content}}},
+		"usage":   map[string]any{"prompt_tokens": 11, "completion_tokens": 7, "total_tokens": 18},
+	})
+}
+
+func (f *fakeLLM) ollama(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.URL.Path != "/api/chat" {
+		http.NotFound(w, r)
+		return
+	}
+	body := f.capture(r)
+	if f.status != 0 {
+		w.WriteHeader(f.status)
+		fmt.Fprint(w, `{"error":"model overloaded"}`)
+		return
+	}
+
+	enc := json.NewEncoder(w)
+	if stream, _ := body["stream"].(bool); stream {
+		for _, part := range split3(f.content) {
+			enc.Encode(map[string]any{"model": "fake-llama", "message": map[string]any{"role": "assistant", "content": part}})
+		}
+		enc.Encode(map[string]any{"model": "fake-llama", "done": true, "prompt_eval_count": 11, "eval_count": 7})
+		return
+	}
+	enc.Encode(map[string]any{
+		"model":             "fake-llama",
+		"message":           map[string]any{"role": "assistant", "content": f.content},
+		"done":              true,
+		"prompt_eval_count": 11,
+		"eval_count":        7,
+	})
+}
+
+// split3 cuts s into three deltas.
+func split3(s string) []string {
+	n := len(s) / 3
+	return []string{s[:n], s[n : 2*n], s[2*n:]}
+}
+
+// auditLog keeps the calls an orchestrator records.
+type auditLog struct {
+	mu    sync.Mutex
+	calls []aicall.Call
+}
+
+func (a *auditLog) CheckQuota(context.Context, string) error { return nil }
+
+func (a *auditLog) Record(_ context.Context, call aicall.Call) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.calls = append(a.calls, call)
+}
+
+type conformanceCase struct {
+	name     string
+	provider string
+	model    string
+	// wantAuth is the Authorization header the server must see.
+	wantAuth string
+	client   func(baseURL string) orchestrator.StreamingAIClient
+	handler  func(f *fakeLLM) http.HandlerFunc
+}
+
+var conformanceCases = []conformanceCase{
+	{
+		name:     "openai keyless local server",
+		provider: "openai",
+		model:    "fake-gpt",
+		client: func(baseURL string) orchestrator.StreamingAIClient {
+			return NewOpenAIClientWithOptions("", "local-model", baseURL, GenerationOptions{JSONMode: true})
+		},
+		handler: func(f *fakeLLM) http.HandlerFunc { return f.openAI },
+	},
+	{
+		name:     "openai with key and structured output",
+		provider: "openai",
+		model:    "fake-gpt",
+		wantAuth: "Bearer sk-test",
+		client: func(baseURL string) orchestrator.StreamingAIClient {
+			return NewOpenAIClientWithOptions("sk-test", "gpt-test", baseURL+"/", GenerationOptions{StructuredOutput: true})
+		},
+		handler: func(f *fakeLLM) http.HandlerFunc { return f.openAI },
+	},
+	{
+		name:     "ollama",
+		provider: "ollama",
+		model:    "fake-llama",
+		client: func(baseURL string) orchestrator.StreamingAIClient {
+			return NewOllamaClient("llama3.1", baseURL, GenerationOptions{JSONMode: true, StructuredOutput: true})
+		},
+		handler: func(f *fakeLLM) http.HandlerFunc { return f.ollama },
+	},
+}
+
+func TestProvidersAnswerOrchestratorCalls(t *testing.T) {
+	for _, tc := range conformanceCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := &fakeLLM{t: t, content: overloadAnswer}
+			srv := httptest.NewServer(tc.handler(fake))
+			defer srv.Close()
+
+			audit := &auditLog{}
+			orch := orchestrator.NewOrchestrator(tc.client(srv.URL), orchestrator.WithAuditor(audit))
+			out, err := orch.SuggestOverload(context.Background(), orchestrator.OverloadInput{
+				UserID:     "u1",
+				ExerciseID: "bench",
+				LastWeight: 80,
+				LastReps:   8,
+			})
+			if err != nil {
+				t.Fatalf("SuggestOverload: %v", err)
+			}
+			if out.Action != "increase" || out.Source != coach.SourceAI || out.PromptVersion == "" {
+				t.Fatalf("got %+v, want action increase from source ai with a prompt version", out)
+			}
+
+			if len(fake.requests) != 1 {
+				t.Fatalf("server saw %d requests, want 1", len(fake.requests))
+			}
+			if fake.auth[0] != tc.wantAuth {
+				t.Errorf("Authorization = %q, want %q", fake.auth[0], tc.wantAuth)
+			}
+			messages, _ := fake.requests[0]["messages"].([]any)
+			if len(messages) != 2 {
+				t.Fatalf("sent %d messages, want system and user", len(messages))
+			}
+			if user, _ := messages[1].(map[string]any); !strings.Contains(fmt.Sprint(user["content"]), "bench") {
+				t.Errorf("user message does not carry the prompt: %v", user["content"])
+			}
+
+			if len(audit.calls) != 1 {
+				t.Fatalf("recorded %d calls, want 1", len(audit.calls))
+			}
+			call := audit.calls[0]
+			if call.Provider != tc.provider || call.Model != tc.model || call.PromptTokens != 11 || call.CompletionTokens != 7 || call.TotalTokens != 18 {
+				t.Errorf("recorded %s/%s tokens %d+%d=%d, want %s/%s 11+7=18",
+					call.Provider, call.Model, call.PromptTokens, call.CompletionTokens, call.TotalTokens, tc.provider, tc.model)
+			}
+			if call.Outcome != aicall.OutcomeOK || call.Operation != "suggest_overload" {
+				t.Errorf("recorded %s/%s, want suggest_overload/%s", call.Operation, call.Outcome, aicall.OutcomeOK)
+			}
+		})
+	}
+}
+
+func TestProvidersStreamDeltasInOrder(t *testing.T) {
+	for _, tc := range conformanceCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := &fakeLLM{t: t, content: overloadAnswer}
+			srv := httptest.NewServer(tc.handler(fake))
+			defer srv.Close()
+
+			var deltas []string
+			full, err := tc.client(srv.URL).GenerateStream(context.Background(), "prompt", func(d string) error {
+				deltas = append(deltas, d)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("GenerateStream: %v", err)
+			}
+			if full != overloadAnswer || strings.Join(deltas, "") != overloadAnswer || len(deltas) != 3 {
+				t.Fatalf("got %q from deltas %q, want %q in 3 parts", full, deltas, overloadAnswer)
+			}
+			if fake.auth[0] != tc.wantAuth {
+				t.Errorf("Authorization = %q, want %q", fake.auth[0], tc.wantAuth)
+			}
+		})
+	}
+}
+
+func TestProvidersReportUpstreamErrorsAsUnavailable(t *testing.T) {
+	for _, tc := range conformanceCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := &fakeLLM{t: t, status: http.StatusServiceUnavailable}
+			srv := httptest.NewServer(tc.handler(fake))
+			defer srv.Close()
+
+			_, err := tc.client(srv.URL).Generate(context.Background(), "prompt")
+			if !errors.Is(err, domainerr.ErrAIUnavailable) || !strings.Contains(err.Error(), "model overloaded") {
+				t.Fatalf("Generate error = %v, want ErrAIUnavailable with the server's message", err)
+			}
+			_, err = tc.client(srv.URL).GenerateStream(context.Background(), "prompt", nil)
+			if !errors.Is(err, domainerr.ErrAIUnavailable) {
+				t.Fatalf("GenerateStream error = %v, want ErrAIUnavailable", err)
+			}
+		})
+	}
+}
+
+func TestStructuredOutputSendsTheSchema(t *testing.T) {
+	for _, tc := range conformanceCases[1:] {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := &fakeLLM{t: t, content: overloadAnswer}
+			srv := httptest.NewServer(tc.handler(fake))
+			defer srv.Close()
+
+			structured, ok := tc.client(srv.URL).(orchestrator.StructuredAIClient)
+			if !ok {
+				t.Fatal("client does not support structured output")
+			}
+			if _, err := structured.GenerateStructured(context.Background(), "prompt", orchestrator.OverloadSchema); err != nil {
+				t.Fatalf("GenerateStructured: %v", err)
+			}
+
+			var schema any
+			switch tc.provider {
+			case "openai":
+				format, _ := fake.requests[0]["response_format"].(map[string]any)
+				if format["type"] != "json_schema" {
+					t.Fatalf("response_format = %v, want json_schema", format)
+				}
+				js, _ := format["json_schema"].(map[string]any)
+				schema = js["schema"]
+			case "ollama":
+				schema = fake.requests[0]["format"]
+			}
+			want := map[string]any{}
+			if err := json.Unmarshal(orchestrator.OverloadSchema.Definition, &want); err != nil {
+				t.Fatal(err)
+			}
+			if got, _ := json.Marshal(schema); string(got) != mustMarshal(t, want) {
+				t.Errorf("schema sent = %s, want %s", got, orchestrator.OverloadSchema.Definition)
+			}
+		})
+	}
+}
+
+func mustMarshal(t *testing.T, v any) string {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
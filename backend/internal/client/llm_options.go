@@ -0,0 +1,24 @@
+package client
+
+// defaultSystemPrompt keeps completions machine-parseable; every orchestrator
+// call expects a single JSON object back.
+const defaultSystemPrompt = "Return ONLY valid JSON. No markdown, no code fences."
+
+// GenerationOptions tunes a chat completion. Zero values leave the
+// provider's own defaults in place.
+type GenerationOptions struct {
+	Temperature *float64
+	MaxTokens   int
+	JSONMode    bool
+	// StructuredOutput sends the orchestrator's JSON schema with each call.
+	// Disable it for servers that reject schema-constrained requests.
+	StructuredOutput bool
+	SystemPrompt     string
+}
+
+func (o GenerationOptions) systemPrompt() string {
+	if o.SystemPrompt != "" {
+		return o.SystemPrompt
+	}
+	return defaultSystemPrompt
+}
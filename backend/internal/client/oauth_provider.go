@@ -0,0 +1,146 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// OAuthIdentity is what a sign-in provider asserts about an account.
+// EmailVerified is only true when the provider itself checked the address.
+type OAuthIdentity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// OAuthProvider runs the authorization code flow, with PKCE, against one
+// sign-in provider.
+type OAuthProvider interface {
+	// AuthCodeURL is where the browser is sent to sign in. The provider
+	// redirects back to the configured RedirectURL with a code and state.
+	AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error)
+	// Exchange redeems the callback code and returns the verified identity.
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (*OAuthIdentity, error)
+}
+
+// OAuthConfig is the client registration at a provider.
+type OAuthConfig struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	// Scopes replace the provider's defaults when set.
+	Scopes []string
+}
+
+const (
+	oauthHTTPTimeout = 10 * time.Second
+	// maxOAuthBody bounds provider responses; they are a few KB at most.
+	maxOAuthBody = 1 << 20
+)
+
+var errOAuthResponse = errors.New("oauth: unexpected provider response")
+
+type oauthTokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	TokenType        string `json:"token_type"`
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// authCodeURL adds the standard authorization request parameters to
+// endpoint, keeping any query it already has.
+func authCodeURL(endpoint string, cfg OAuthConfig, scopes []string, extra url.Values) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("oauth: invalid authorization endpoint: %w", err)
+	}
+	q := u.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", cfg.ClientID)
+	q.Set("redirect_uri", cfg.RedirectURL)
+	q.Set("scope", strings.Join(scopes, " "))
+	for k, v := range extra {
+		q[k] = v
+	}
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// exchangeCode redeems an authorization code. basicAuth sends the client
+// credentials as HTTP Basic (client_secret_basic) rather than in the form.
+func exchangeCode(ctx context.Context, hc *http.Client, tokenURL string, cfg OAuthConfig, code, codeVerifier string, basicAuth bool) (*oauthTokenResponse, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {cfg.RedirectURL},
+		"code_verifier": {codeVerifier},
+	}
+	if !basicAuth {
+		form.Set("client_id", cfg.ClientID)
+		form.Set("client_secret", cfg.ClientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if basicAuth {
+		// RFC 6749 section 2.3.1: both parts are form-encoded first.
+		req.SetBasicAuth(url.QueryEscape(cfg.ClientID), url.QueryEscape(cfg.ClientSecret))
+	}
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var out oauthTokenResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxOAuthBody)).Decode(&out); err != nil {
+		return nil, fmt.Errorf("oauth: token endpoint returned status %d", resp.StatusCode)
+	}
+	if out.Error != "" {
+		return nil, fmt.Errorf("oauth: token endpoint: %s %s", out.Error, out.ErrorDescription)
+	}
+	if resp.StatusCode != http.StatusOK || out.AccessToken == "" {
+		return nil, fmt.Errorf("oauth: token endpoint returned status %d", resp.StatusCode)
+	}
+	return &out, nil
+}
+
+// getJSON fetches endpoint into out, with a bearer token when one is given.
+func getJSON(ctx context.Context, hc *http.Client, endpoint, bearer string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if bearer != "" {
+		req.Header.Set("Authorization", "Bearer "+bearer)
+	}
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("oauth: GET %s returned status %d", endpoint, resp.StatusCode)
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxOAuthBody)).Decode(out); err != nil {
+		return fmt.Errorf("%w: %v", errOAuthResponse, err)
+	}
+	return nil
+}
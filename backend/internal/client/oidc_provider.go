@@ -0,0 +1,238 @@
+package client
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"S.P.A.R.T.A/backend/pkg/jwtkeys"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// jwksRefreshInterval limits how often an unknown kid makes us fetch the
+// provider's keys again.
+const jwksRefreshInterval = time.Minute
+
+var defaultOIDCScopes = []string{"openid", "email", "profile"}
+
+// OIDCProvider signs in with any OpenID Connect issuer (Google, Keycloak, a
+// local fake, ...). Endpoints come from the issuer's discovery document and
+// ID tokens are checked against its published keys.
+type OIDCProvider struct {
+	issuer string
+	cfg    OAuthConfig
+	http   *http.Client
+
+	mu          sync.Mutex
+	discovery   *oidcDiscovery
+	keys        *jwtkeys.Keyring
+	keysFetched time.Time
+}
+
+type oidcDiscovery struct {
+	Issuer                   string   `json:"issuer"`
+	AuthorizationEndpoint    string   `json:"authorization_endpoint"`
+	TokenEndpoint            string   `json:"token_endpoint"`
+	UserinfoEndpoint         string   `json:"userinfo_endpoint"`
+	JWKSURI                  string   `json:"jwks_uri"`
+	TokenEndpointAuthMethods []string `json:"token_endpoint_auth_methods_supported"`
+}
+
+func NewOIDCProvider(issuer string, cfg OAuthConfig) *OIDCProvider {
+	return &OIDCProvider{
+		issuer: strings.TrimRight(issuer, "/"),
+		cfg:    cfg,
+		http:   &http.Client{Timeout: oauthHTTPTimeout},
+	}
+}
+
+func (p *OIDCProvider) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	d, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	scopes := p.cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = defaultOIDCScopes
+	}
+	if !slices.Contains(scopes, "openid") {
+		scopes = append([]string{"openid"}, scopes...)
+	}
+	return authCodeURL(d.AuthorizationEndpoint, p.cfg, scopes, url.Values{
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {codeChallenge},
+		"code_challenge_method": {"S256"},
+	})
+}
+
+func (p *OIDCProvider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*OAuthIdentity, error) {
+	d, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// client_secret_basic is the default; use the form only when the
+	// issuer says it does not take basic.
+	basicAuth := slices.Contains(d.TokenEndpointAuthMethods, "client_secret_basic") ||
+		!slices.Contains(d.TokenEndpointAuthMethods, "client_secret_post")
+	tok, err := exchangeCode(ctx, p.http, d.TokenEndpoint, p.cfg, code, codeVerifier, basicAuth)
+	if err != nil {
+		return nil, err
+	}
+	if tok.IDToken == "" {
+		return nil, errors.New("oidc: token response has no id_token")
+	}
+
+	claims, err := p.verifyIDToken(ctx, d, tok.IDToken)
+	if err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(nonce)) != 1 {
+		return nil, errors.New("oidc: id_token nonce mismatch")
+	}
+
+	identity := &OAuthIdentity{
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: bool(claims.EmailVerified),
+		Name:          claims.Name,
+	}
+	// Some issuers only put the email in the userinfo response.
+	if identity.Email == "" && d.UserinfoEndpoint != "" {
+		var info oidcUserinfo
+		if err := getJSON(ctx, p.http, d.UserinfoEndpoint, tok.AccessToken, &info); err != nil {
+			return nil, err
+		}
+		if info.Subject != claims.Subject {
+			return nil, errors.New("oidc: userinfo subject mismatch")
+		}
+		identity.Email, identity.EmailVerified = info.Email, bool(info.EmailVerified)
+		if identity.Name == "" {
+			identity.Name = info.Name
+		}
+	}
+	return identity, nil
+}
+
+type idTokenClaims struct {
+	jwt.RegisteredClaims
+	Nonce           string   `json:"nonce"`
+	AuthorizedParty string   `json:"azp"`
+	Email           string   `json:"email"`
+	EmailVerified   flexBool `json:"email_verified"`
+	Name            string   `json:"name"`
+}
+
+type oidcUserinfo struct {
+	Subject       string   `json:"sub"`
+	Email         string   `json:"email"`
+	EmailVerified flexBool `json:"email_verified"`
+	Name          string   `json:"name"`
+}
+
+// flexBool accepts true and "true"; some issuers send booleans as strings.
+type flexBool bool
+
+func (b *flexBool) UnmarshalJSON(data []byte) error {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	switch t := v.(type) {
+	case bool:
+		*b = flexBool(t)
+	case string:
+		*b = flexBool(strings.EqualFold(t, "true"))
+	default:
+		*b = false
+	}
+	return nil
+}
+
+func (p *OIDCProvider) verifyIDToken(ctx context.Context, d *oidcDiscovery, raw string) (*idTokenClaims, error) {
+	parse := func(keys *jwtkeys.Keyring) (*idTokenClaims, error) {
+		var claims idTokenClaims
+		_, err := jwt.ParseWithClaims(raw, &claims, keys.Keyfunc,
+			jwt.WithIssuer(d.Issuer),
+			jwt.WithAudience(p.cfg.ClientID),
+			jwt.WithExpirationRequired(),
+			jwt.WithLeeway(time.Minute),
+			jwt.WithValidMethods([]string{"RS256", "ES256", "ES384", "ES512", "EdDSA"}),
+		)
+		return &claims, err
+	}
+
+	keys, err := p.signingKeys(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	claims, err := parse(keys)
+	if errors.Is(err, jwtkeys.ErrUnknownKey) {
+		// The issuer may have rotated its keys since we fetched them.
+		if keys, err = p.signingKeys(ctx, true); err != nil {
+			return nil, err
+		}
+		claims, err = parse(keys)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("oidc: invalid id_token: %w", err)
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("oidc: id_token has no subject")
+	}
+	if len(claims.Audience) > 1 && claims.AuthorizedParty != p.cfg.ClientID {
+		return nil, errors.New("oidc: id_token azp mismatch")
+	}
+	return claims, nil
+}
+
+// discover fetches the discovery document once and keeps it.
+func (p *OIDCProvider) discover(ctx context.Context) (*oidcDiscovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	var d oidcDiscovery
+	if err := getJSON(ctx, p.http, p.issuer+"/.well-known/openid-configuration", "", &d); err != nil {
+		return nil, err
+	}
+	if strings.TrimRight(d.Issuer, "/") != p.issuer {
+		return nil, fmt.Errorf("oidc: discovery issuer %q does not match %q", d.Issuer, p.issuer)
+	}
+	if d.AuthorizationEndpoint == "" || d.TokenEndpoint == "" || d.JWKSURI == "" {
+		return nil, fmt.Errorf("%w: incomplete discovery document", errOAuthResponse)
+	}
+	p.discovery = &d
+	return p.discovery, nil
+}
+
+// signingKeys returns the issuer's keys, fetching them on first use and,
+// with refresh, again at most once per jwksRefreshInterval.
+func (p *OIDCProvider) signingKeys(ctx context.Context, refresh bool) (*jwtkeys.Keyring, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.keys != nil && (!refresh || time.Since(p.keysFetched) < jwksRefreshInterval) {
+		return p.keys, nil
+	}
+
+	var set jwtkeys.JWKS
+	if err := getJSON(ctx, p.http, p.discovery.JWKSURI, "", &set); err != nil {
+		return nil, err
+	}
+	keys, err := jwtkeys.FromJWKS(set)
+	if err != nil {
+		return nil, err
+	}
+	p.keys, p.keysFetched = keys, time.Now()
+	return p.keys, nil
+}
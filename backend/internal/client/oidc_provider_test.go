@@ -0,0 +1,199 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"S.P.A.R.T.A/backend/pkg/jwtkeys"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	testClientID = "sparta-web"
+	testNonce    = "nonce-1"
+)
+
+// fakeIssuer is an OpenID Connect issuer whose token endpoint returns an
+// ID token with the claims from idToken, signed by signer. keys are the
+// ones it publishes.
+type fakeIssuer struct {
+	srv     *httptest.Server
+	keys    *jwtkeys.Keyring
+	signer  *jwtkeys.Keyring
+	idToken func(issuer string) jwt.MapClaims
+	// userinfo is served when set.
+	userinfo map[string]any
+}
+
+func newFakeIssuer(t *testing.T) *fakeIssuer {
+	t.Helper()
+	keys := newIssuerKey(t)
+	f := &fakeIssuer{keys: keys, signer: keys, idToken: validClaims}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		d := map[string]any{
+			"issuer":                 f.srv.URL,
+			"authorization_endpoint": f.srv.URL + "/authorize",
+			"token_endpoint":         f.srv.URL + "/token",
+			"jwks_uri":               f.srv.URL + "/jwks",
+		}
+		if f.userinfo != nil {
+			d["userinfo_endpoint"] = f.srv.URL + "/userinfo"
+		}
+		writeJSON(w, d)
+	})
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		if id, _, ok := r.BasicAuth(); !ok || id != testClientID {
+			t.Errorf("token request without the client's basic auth")
+		}
+		if r.FormValue("code") != "the-code" || r.FormValue("code_verifier") != "the-verifier" {
+			t.Errorf("token request form = %v", r.Form)
+		}
+		signed, err := f.signer.Sign(f.idToken(f.srv.URL))
+		if err != nil {
+			t.Error(err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, map[string]any{"access_token": "access", "token_type": "Bearer", "id_token": signed})
+	})
+	mux.HandleFunc("GET /jwks", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, f.keys.JWKS())
+	})
+	mux.HandleFunc("GET /userinfo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer access" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		writeJSON(w, f.userinfo)
+	})
+	f.srv = httptest.NewServer(mux)
+	t.Cleanup(f.srv.Close)
+	return f
+}
+
+func newIssuerKey(t *testing.T) *jwtkeys.Keyring {
+	t.Helper()
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys, err := jwtkeys.New("issuer-key", priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keys
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func validClaims(issuer string) jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"iss":            issuer,
+		"aud":            testClientID,
+		"sub":            "subject-1",
+		"nonce":          testNonce,
+		"email":          "Ada@Example.com",
+		"email_verified": "true",
+		"name":           "Ada",
+		"iat":            now.Unix(),
+		"exp":            now.Add(5 * time.Minute).Unix(),
+	}
+}
+
+// with returns validClaims changed by edit.
+func with(edit func(c jwt.MapClaims)) func(string) jwt.MapClaims {
+	return func(issuer string) jwt.MapClaims {
+		c := validClaims(issuer)
+		edit(c)
+		return c
+	}
+}
+
+func exchange(f *fakeIssuer) (*OAuthIdentity, error) {
+	p := NewOIDCProvider(f.srv.URL, OAuthConfig{ClientID: testClientID, ClientSecret: "secret", RedirectURL: "http://app/callback"})
+	return p.Exchange(context.Background(), "the-code", "the-verifier", testNonce)
+}
+
+func TestOIDCExchangeReturnsTheVerifiedIdentity(t *testing.T) {
+	f := newFakeIssuer(t)
+
+	identity, err := exchange(f)
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+	want := OAuthIdentity{Subject: "subject-1", Email: "Ada@Example.com", EmailVerified: true, Name: "Ada"}
+	if *identity != want {
+		t.Errorf("identity = %+v, want %+v", *identity, want)
+	}
+}
+
+func TestOIDCExchangeRejectsBadIDTokens(t *testing.T) {
+	tests := []struct {
+		name    string
+		idToken func(string) jwt.MapClaims
+		wantErr string
+	}{
+		{"nonce mismatch", with(func(c jwt.MapClaims) { c["nonce"] = "someone-elses" }), "nonce mismatch"},
+		{"no nonce", with(func(c jwt.MapClaims) { delete(c, "nonce") }), "nonce mismatch"},
+		{"token for another client", with(func(c jwt.MapClaims) { c["aud"] = "another-client" }), "audience"},
+		{"issued by another issuer", with(func(c jwt.MapClaims) { c["iss"] = "https://evil.example" }), "issuer"},
+		{"several audiences without azp", with(func(c jwt.MapClaims) { c["aud"] = []string{testClientID, "other"} }), "azp mismatch"},
+		{"expired", with(func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() }), "expired"},
+		{"no expiry", with(func(c jwt.MapClaims) { delete(c, "exp") }), "exp"},
+		{"no subject", with(func(c jwt.MapClaims) { delete(c, "sub") }), "no subject"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newFakeIssuer(t)
+			f.idToken = tc.idToken
+
+			identity, err := exchange(f)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("Exchange = %+v, %v, want an error about %q", identity, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestOIDCExchangeRejectsAForeignSignature(t *testing.T) {
+	f := newFakeIssuer(t)
+	// Signed by a key the issuer never published, under the same kid.
+	f.signer = newIssuerKey(t)
+
+	if _, err := exchange(f); err == nil || !strings.Contains(err.Error(), "signature is invalid") {
+		t.Fatalf("Exchange error = %v, want an invalid signature", err)
+	}
+}
+
+func TestOIDCExchangeReadsTheEmailFromUserinfo(t *testing.T) {
+	f := newFakeIssuer(t)
+	f.idToken = with(func(c jwt.MapClaims) { delete(c, "email"); delete(c, "email_verified") })
+	f.userinfo = map[string]any{"sub": "subject-1", "email": "ada@example.com", "email_verified": false}
+
+	identity, err := exchange(f)
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+	if identity.Email != "ada@example.com" || identity.EmailVerified {
+		t.Errorf("identity = %+v, want the unverified userinfo email", *identity)
+	}
+
+	f.userinfo["sub"] = "subject-2"
+	if _, err := exchange(f); err == nil || !strings.Contains(err.Error(), "userinfo subject mismatch") {
+		t.Errorf("Exchange with another userinfo subject error = %v", err)
+	}
+}
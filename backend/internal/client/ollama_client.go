@@ -0,0 +1,206 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/ai/orchestrator"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+)
+
+// OllamaClient talks to Ollama's native /api/chat endpoint.
+type OllamaClient struct {
+	model  string
+	base   string
+	opts   GenerationOptions
+	http   *http.Client
+	stream *http.Client
+}
+
+func NewOllamaClient(model, baseURL string, opts GenerationOptions) *OllamaClient {
+	if strings.TrimSpace(baseURL) == "" {
+		baseURL = "http://localhost:11434"
+	}
+	return &OllamaClient{
+		model: model,
+		base:  strings.TrimRight(baseURL, "/"),
+		opts:  opts,
+		// Local models are slower to first token than hosted APIs.
+		http: &http.Client{
+			Timeout: 120 * time.Second,
+		},
+		stream: &http.Client{
+			Transport: streamTransport(120 * time.Second),
+		},
+	}
+}
+
+type ollamaChatRequest struct {
+	Model    string          `json:"model"`
+	Messages []ChatMessage   `json:"messages"`
+	Stream   bool            `json:"stream"`
+	Format   json.RawMessage `json:"format,omitempty"`
+	Options  map[string]any  `json:"options,omitempty"`
+}
+
+type ollamaChatResponse struct {
+	Model   string      `json:"model"`
+	Message ChatMessage `json:"message"`
+	Done    bool        `json:"done"`
+	Error   string      `json:"error"`
+	// Token counts are only set on the final (done) message.
+	PromptEvalCount int `json:"prompt_eval_count"`
+	EvalCount       int `json:"eval_count"`
+}
+
+func (c *OllamaClient) reportUsage(ctx context.Context, final ollamaChatResponse) {
+	model := final.Model
+	if strings.TrimSpace(model) == "" {
+		model = c.model
+	}
+	orchestrator.ReportUsage(ctx, orchestrator.Usage{
+		Provider:         "ollama",
+		Model:            model,
+		PromptTokens:     final.PromptEvalCount,
+		CompletionTokens: final.EvalCount,
+	})
+}
+
+func (c *OllamaClient) chatRequest(prompt string, stream bool) ollamaChatRequest {
+	req := ollamaChatRequest{
+		Model: c.model,
+		Messages: []ChatMessage{
+			{Role: "system", Content: c.opts.systemPrompt()},
+			{Role: "user", Content: prompt},
+		},
+		Stream: stream,
+	}
+	if c.opts.JSONMode {
+		req.Format = json.RawMessage(`"json"`)
+	}
+
+	options := map[string]any{}
+	if c.opts.Temperature != nil {
+		options["temperature"] = *c.opts.Temperature
+	}
+	if c.opts.MaxTokens > 0 {
+		options["num_predict"] = c.opts.MaxTokens
+	}
+	if len(options) > 0 {
+		req.Options = options
+	}
+	return req
+}
+
+func (c *OllamaClient) do(ctx context.Context, hc *http.Client, chat ollamaChatRequest) (*http.Response, error) {
+	payload, err := json.Marshal(chat)
+	if err != nil {
+		return nil, domainerr.ErrInternal
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.base+"/api/chat", bytes.NewReader(payload))
+	if err != nil {
+		return nil, domainerr.ErrInternal
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed", domainerr.ErrAIUnavailable)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+		msg := "upstream error"
+		var decoded ollamaChatResponse
+		if err := json.Unmarshal(body, &decoded); err == nil && strings.TrimSpace(decoded.Error) != "" {
+			msg = strings.TrimSpace(decoded.Error)
+		}
+		return nil, fmt.Errorf("%w: Ollama error (%d): %s", domainerr.ErrAIUnavailable, resp.StatusCode, msg)
+	}
+	return resp, nil
+}
+
+func (c *OllamaClient) Generate(ctx context.Context, prompt string) (string, error) {
+	return c.complete(ctx, c.chatRequest(prompt, false))
+}
+
+// GenerateStructured passes the schema as Ollama's format parameter.
+func (c *OllamaClient) GenerateStructured(ctx context.Context, prompt string, schema orchestrator.Schema) (string, error) {
+	req := c.chatRequest(prompt, false)
+	if c.opts.StructuredOutput {
+		req.Format = schema.Definition
+	}
+	return c.complete(ctx, req)
+}
+
+func (c *OllamaClient) complete(ctx context.Context, chat ollamaChatRequest) (string, error) {
+	resp, err := c.do(ctx, c.http, chat)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var decoded ollamaChatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		return "", fmt.Errorf("%w: invalid response", domainerr.ErrAIUnavailable)
+	}
+	c.reportUsage(ctx, decoded)
+	if strings.TrimSpace(decoded.Message.Content) == "" {
+		return "", fmt.Errorf("%w: empty response", domainerr.ErrAIUnavailable)
+	}
+	return decoded.Message.Content, nil
+}
+
+// GenerateStream reads Ollama's newline-delimited JSON stream.
+func (c *OllamaClient) GenerateStream(ctx context.Context, prompt string, onDelta func(delta string) error) (string, error) {
+	resp, err := c.do(ctx, c.stream, c.chatRequest(prompt, true))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var full strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var chunk ollamaChatResponse
+		if err := json.Unmarshal(line, &chunk); err != nil {
+			return "", fmt.Errorf("%w: invalid stream chunk", domainerr.ErrAIUnavailable)
+		}
+		if chunk.Error != "" {
+			return "", fmt.Errorf("%w: Ollama error: %s", domainerr.ErrAIUnavailable, chunk.Error)
+		}
+		if delta := chunk.Message.Content; delta != "" {
+			full.WriteString(delta)
+			if onDelta != nil {
+				if err := onDelta(delta); err != nil {
+					return "", err
+				}
+			}
+		}
+		if chunk.Done {
+			c.reportUsage(ctx, chunk)
+			return full.String(), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		return "", fmt.Errorf("%w: stream interrupted", domainerr.ErrAIUnavailable)
+	}
+	return full.String(), nil
+}
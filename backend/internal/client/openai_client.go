@@ -10,106 +10,241 @@ import (
 	"strings"
 	"time"
 
+	"S.P.A.R.T.A/backend/internal/ai/orchestrator"
 	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
 )
 
+// DefaultOpenAIBaseURL is used when no base URL is configured.
+const DefaultOpenAIBaseURL = "https://api.openai.com"
+
 type OpenAIClient struct {
 	apiKey string
 	model  string
 	base   string
+	opts   GenerationOptions
 	http   *http.Client
+	// stream has no overall timeout: a streamed completion may legitimately
+	// run longer than a blocking one. Only the wait for headers is bounded.
+	stream *http.Client
 }
 
 func NewOpenAIClient(key, model, baseURL string) *OpenAIClient {
+	return NewOpenAIClientWithOptions(key, model, baseURL, GenerationOptions{})
+}
+
+// NewOpenAIClientWithOptions targets any OpenAI-compatible
+// /v1/chat/completions endpoint (OpenAI, vLLM, LM Studio, llama.cpp server).
+// An empty key sends no Authorization header, as local servers expect.
+func NewOpenAIClientWithOptions(key, model, baseURL string, opts GenerationOptions) *OpenAIClient {
 	if strings.TrimSpace(baseURL) == "" {
-		baseURL = "https://api.openai.com"
+		baseURL = DefaultOpenAIBaseURL
 	}
 	return &OpenAIClient{
 		apiKey: key,
 		model:  model,
 		base:   strings.TrimRight(baseURL, "/"),
+		opts:   opts,
 		http: &http.Client{
 			Timeout: 25 * time.Second,
 		},
+		stream: &http.Client{
+			Transport: streamTransport(25 * time.Second),
+		},
 	}
 }
 
 type ChatRequest struct {
-	Model    string        `json:"model"`
-	Messages []ChatMessage `json:"messages"`
+	Model          string          `json:"model"`
+	Messages       []ChatMessage   `json:"messages"`
+	Temperature    *float64        `json:"temperature,omitempty"`
+	MaxTokens      int             `json:"max_tokens,omitempty"`
+	ResponseFormat *ResponseFormat `json:"response_format,omitempty"`
+	Tools          []ChatTool      `json:"tools,omitempty"`
+}
+
+type ResponseFormat struct {
+	Type       string            `json:"type"`
+	JSONSchema *JSONSchemaFormat `json:"json_schema,omitempty"`
+}
+
+type JSONSchemaFormat struct {
+	Name   string          `json:"name"`
+	Schema json.RawMessage `json:"schema"`
+	Strict bool            `json:"strict"`
 }
 
 type ChatMessage struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
+	Role       string         `json:"role"`
+	Content    string         `json:"content"`
+	ToolCalls  []ChatToolCall `json:"tool_calls,omitempty"`
+	ToolCallID string         `json:"tool_call_id,omitempty"`
+}
+
+type ChatTool struct {
+	Type     string       `json:"type"`
+	Function ChatFunction `json:"function"`
+}
+
+type ChatFunction struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Parameters  json.RawMessage `json:"parameters,omitempty"`
+}
+
+type ChatToolCall struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Function struct {
+		Name string `json:"name"`
+		// Arguments is a JSON object encoded as a string.
+		Arguments string `json:"arguments"`
+	} `json:"function"`
 }
 
 type ChatResponse struct {
+	Model   string `json:"model"`
 	Choices []struct {
 		Message ChatMessage `json:"message"`
 	} `json:"choices"`
+	Usage *ChatUsage `json:"usage,omitempty"`
+}
+
+type ChatUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// reportUsage forwards token counts to the orchestrator's audit log. Servers
+// that omit usage are still recorded with provider and model.
+func (c *OpenAIClient) reportUsage(ctx context.Context, model string, usage *ChatUsage) {
+	if strings.TrimSpace(model) == "" {
+		model = c.model
+	}
+	u := orchestrator.Usage{Provider: "openai", Model: model}
+	if usage != nil {
+		u.PromptTokens = usage.PromptTokens
+		u.CompletionTokens = usage.CompletionTokens
+		u.TotalTokens = usage.TotalTokens
+	}
+	orchestrator.ReportUsage(ctx, u)
 }
 
 func (c *OpenAIClient) Generate(ctx context.Context, prompt string) (string, error) {
-	if strings.TrimSpace(c.apiKey) == "" {
-		return "", domainerr.ErrAIUnavailable
+	return c.complete(ctx, c.chatRequest(prompt))
+}
+
+// GenerateStructured constrains the answer with response_format json_schema.
+func (c *OpenAIClient) GenerateStructured(ctx context.Context, prompt string, schema orchestrator.Schema) (string, error) {
+	req := c.chatRequest(prompt)
+	if c.opts.StructuredOutput {
+		req.ResponseFormat = &ResponseFormat{
+			Type: "json_schema",
+			JSONSchema: &JSONSchemaFormat{
+				Name:   schema.Name,
+				Schema: schema.Definition,
+				Strict: true,
+			},
+		}
 	}
+	return c.complete(ctx, req)
+}
 
-	reqBody := ChatRequest{
-		Model: c.model,
-		Messages: []ChatMessage{
-			{Role: "system", Content: "Return ONLY valid JSON. No markdown, no code fences."},
-			{Role: "user", Content: prompt},
-		},
+func (c *OpenAIClient) complete(ctx context.Context, chat ChatRequest) (string, error) {
+	decoded, err := c.send(ctx, chat)
+	if err != nil {
+		return "", err
 	}
+	return decoded.Choices[0].Message.Content, nil
+}
+
+// setHeaders marks req as JSON and authorizes it when a key is set.
+func (c *OpenAIClient) setHeaders(req *http.Request) {
+	if key := strings.TrimSpace(c.apiKey); key != "" {
+		req.Header.Set("Authorization", "Bearer "+key)
+	}
+	req.Header.Set("Content-Type", "application/json")
+}
 
-	payload, err := json.Marshal(reqBody)
+// send posts chat to /v1/chat/completions and returns a response with at
+// least one choice.
+func (c *OpenAIClient) send(ctx context.Context, chat ChatRequest) (*ChatResponse, error) {
+	payload, err := json.Marshal(chat)
 	if err != nil {
-		return "", domainerr.ErrInternal
+		return nil, domainerr.ErrInternal
 	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.base+"/v1/chat/completions", bytes.NewReader(payload))
 	if err != nil {
-		return "", domainerr.ErrInternal
+		return nil, domainerr.ErrInternal
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
-	req.Header.Set("Content-Type", "application/json")
+	c.setHeaders(req)
 
 	resp, err := c.http.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("%w: request failed", domainerr.ErrAIUnavailable)
+		return nil, fmt.Errorf("%w: request failed", domainerr.ErrAIUnavailable)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("%w: failed reading response", domainerr.ErrAIUnavailable)
+		return nil, fmt.Errorf("%w: failed reading response", domainerr.ErrAIUnavailable)
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		// Best-effort parse OpenAI error response.
-		msg := "upstream error"
-		var decodedErr struct {
-			Error struct {
-				Message string `json:"message"`
-			} `json:"error"`
-		}
-		if err := json.Unmarshal(body, &decodedErr); err == nil {
-			if strings.TrimSpace(decodedErr.Error.Message) != "" {
-				msg = strings.TrimSpace(decodedErr.Error.Message)
-			}
-		}
-		return "", fmt.Errorf("%w: OpenAI error (%d): %s", domainerr.ErrAIUnavailable, resp.StatusCode, msg)
+		return nil, upstreamError(resp.StatusCode, body)
 	}
 
 	var decoded ChatResponse
 	if err := json.Unmarshal(body, &decoded); err != nil {
-		return "", fmt.Errorf("%w: invalid response", domainerr.ErrAIUnavailable)
+		return nil, fmt.Errorf("%w: invalid response", domainerr.ErrAIUnavailable)
 	}
+	c.reportUsage(ctx, decoded.Model, decoded.Usage)
 	if len(decoded.Choices) == 0 {
-		return "", fmt.Errorf("%w: empty response", domainerr.ErrAIUnavailable)
+		return nil, fmt.Errorf("%w: empty response", domainerr.ErrAIUnavailable)
 	}
 
-	return decoded.Choices[0].Message.Content, nil
+	return &decoded, nil
+}
+
+func (c *OpenAIClient) chatRequest(prompt string) ChatRequest {
+	req := ChatRequest{
+		Model: c.model,
+		Messages: []ChatMessage{
+			{Role: "system", Content: c.opts.systemPrompt()},
+			{Role: "user", Content: prompt},
+		},
+		Temperature: c.opts.Temperature,
+		MaxTokens:   c.opts.MaxTokens,
+	}
+	if c.opts.JSONMode {
+		req.ResponseFormat = &ResponseFormat{Type: "json_object"}
+	}
+	return req
+}
+
+// streamTransport is http.DefaultTransport (proxy, dial and TLS handshake
+// timeouts, HTTP/2, connection reuse) with a bound on the wait for response
+// headers, for clients without an overall timeout.
+func streamTransport(headerTimeout time.Duration) *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.ResponseHeaderTimeout = headerTimeout
+	return t
+}
+
+func upstreamError(status int, body []byte) error {
+	// Best-effort parse OpenAI error response.
+	msg := "upstream error"
+	var decodedErr struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &decodedErr); err == nil {
+		if strings.TrimSpace(decodedErr.Error.Message) != "" {
+			msg = strings.TrimSpace(decodedErr.Error.Message)
+		}
+	}
+	return fmt.Errorf("%w: OpenAI error (%d): %s", domainerr.ErrAIUnavailable, status, msg)
 }
@@ -0,0 +1,120 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+)
+
+type chatStreamRequest struct {
+	ChatRequest
+	Stream        bool           `json:"stream"`
+	StreamOptions *streamOptions `json:"stream_options,omitempty"`
+}
+
+// streamOptions asks for a final chunk carrying token usage.
+type streamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
+}
+
+type chatStreamChunk struct {
+	Model   string `json:"model"`
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+	} `json:"choices"`
+	Usage *ChatUsage `json:"usage,omitempty"`
+}
+
+// GenerateStream requests a streamed chat completion and forwards each
+// content delta to onDelta. It returns the concatenated content.
+func (c *OpenAIClient) GenerateStream(ctx context.Context, prompt string, onDelta func(delta string) error) (string, error) {
+	payload, err := json.Marshal(chatStreamRequest{
+		ChatRequest:   c.chatRequest(prompt),
+		Stream:        true,
+		StreamOptions: &streamOptions{IncludeUsage: true},
+	})
+	if err != nil {
+		return "", domainerr.ErrInternal
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.base+"/v1/chat/completions", bytes.NewReader(payload))
+	if err != nil {
+		return "", domainerr.ErrInternal
+	}
+	c.setHeaders(req)
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := c.stream.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%w: request failed", domainerr.ErrAIUnavailable)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+		return "", upstreamError(resp.StatusCode, body)
+	}
+
+	var (
+		full  strings.Builder
+		model string
+		usage *ChatUsage
+	)
+	// Usage arrives in the last chunk, so it is reported however the stream
+	// ends.
+	defer func() { c.reportUsage(ctx, model, usage) }()
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		data, ok := strings.CutPrefix(line, "data:")
+		if !ok {
+			continue
+		}
+		data = strings.TrimSpace(data)
+		if data == "[DONE]" {
+			return full.String(), nil
+		}
+
+		var chunk chatStreamChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return "", fmt.Errorf("%w: invalid stream chunk", domainerr.ErrAIUnavailable)
+		}
+		if chunk.Model != "" {
+			model = chunk.Model
+		}
+		if chunk.Usage != nil {
+			usage = chunk.Usage
+		}
+		if len(chunk.Choices) == 0 || chunk.Choices[0].Delta.Content == "" {
+			continue
+		}
+
+		delta := chunk.Choices[0].Delta.Content
+		full.WriteString(delta)
+		if onDelta != nil {
+			if err := onDelta(delta); err != nil {
+				return "", err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		return "", fmt.Errorf("%w: stream interrupted", domainerr.ErrAIUnavailable)
+	}
+
+	// Some compatible servers close the stream without a [DONE] marker.
+	return full.String(), nil
+}
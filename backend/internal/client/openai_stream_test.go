@@ -0,0 +1,213 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/ai/orchestrator"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+)
+
+// sseServer replies to chat completions with body as an event stream.
+func sseServer(t *testing.T, body string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Accept"); got != "text/event-stream" {
+			t.Errorf("Accept = %q, want text/event-stream", got)
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func delta(content string) string {
+	return fmt.Sprintf(`data: {"model":"gpt-test","choices":[{"delta":{"content":%q}}]}`+"\n\n", content)
+}
+
+func TestGenerateStreamParsesServerSentEvents(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{
+			name: "deltas then done",
+			body: delta("Hel") + delta("lo") + "data: [DONE]\n\n",
+			want: []string{"Hel", "lo"},
+		},
+		{
+			name: "comments, event names and role-only chunks are skipped",
+			body: ": keep-alive\n\n" +
+				"event: message\n" + `data: {"choices":[{"delta":{"role":"assistant"}}]}` + "\n\n" +
+				delta("Hi") +
+				`data:{"choices":[{"delta":{"content":"!"}}]}` + "\n\n" +
+				"data: [DONE]\n\n",
+			want: []string{"Hi", "!"},
+		},
+		{
+			name: "stream closed without done",
+			body: delta("a") + delta("b"),
+			want: []string{"a", "b"},
+		},
+		{
+			name: "nothing after done is read",
+			body: delta("x") + "data: [DONE]\n\n" + delta("y"),
+			want: []string{"x"},
+		},
+		{
+			name: "CRLF line endings",
+			body: strings.ReplaceAll(delta("one")+delta("two")+"data: [DONE]\n\n", "\n", "\r\n"),
+			want: []string{"one", "two"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := sseServer(t, tc.body)
+			c := NewOpenAIClient("", "gpt-test", srv.URL)
+
+			var got []string
+			full, err := c.GenerateStream(context.Background(), "prompt", func(d string) error {
+				got = append(got, d)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("GenerateStream: %v", err)
+			}
+			if strings.Join(got, "|") != strings.Join(tc.want, "|") || full != strings.Join(tc.want, "") {
+				t.Fatalf("deltas %q full %q, want %q", got, full, tc.want)
+			}
+		})
+	}
+}
+
+func TestGenerateStreamErrors(t *testing.T) {
+	t.Run("malformed chunk", func(t *testing.T) {
+		srv := sseServer(t, delta("ok")+"data: {not json}\n\n")
+		_, err := NewOpenAIClient("", "m", srv.URL).GenerateStream(context.Background(), "p", nil)
+		if !errors.Is(err, domainerr.ErrAIUnavailable) {
+			t.Fatalf("error = %v, want ErrAIUnavailable", err)
+		}
+	})
+
+	t.Run("callback error aborts the stream", func(t *testing.T) {
+		srv := sseServer(t, delta("a")+delta("b")+delta("c"))
+		stop := errors.New("client went away")
+		calls := 0
+		_, err := NewOpenAIClient("", "m", srv.URL).GenerateStream(context.Background(), "p", func(string) error {
+			calls++
+			return stop
+		})
+		if !errors.Is(err, stop) || calls != 1 {
+			t.Fatalf("error = %v after %d calls, want the callback's error after 1", err, calls)
+		}
+	})
+
+	t.Run("cancelled mid-stream", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, delta("first"))
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		}))
+		defer srv.Close()
+
+		_, err := NewOpenAIClient("", "m", srv.URL).GenerateStream(ctx, "p", func(string) error {
+			cancel()
+			return nil
+		})
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("error = %v, want context.Canceled", err)
+		}
+	})
+
+	t.Run("error status", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(w, `{"error":{"message":"Rate limit reached"}}`)
+		}))
+		defer srv.Close()
+
+		_, err := NewOpenAIClient("", "m", srv.URL).GenerateStream(context.Background(), "p", nil)
+		if !errors.Is(err, domainerr.ErrAIUnavailable) || !strings.Contains(err.Error(), "(429): Rate limit reached") {
+			t.Fatalf("error = %v, want ErrAIUnavailable with the upstream message", err)
+		}
+	})
+
+	t.Run("slow headers", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-time.After(2 * time.Second):
+			case <-r.Context().Done():
+			}
+		}))
+		defer srv.Close()
+
+		c := NewOpenAIClient("", "m", srv.URL)
+		c.stream.Transport.(*http.Transport).ResponseHeaderTimeout = 50 * time.Millisecond
+		_, err := c.GenerateStream(context.Background(), "p", nil)
+		if !errors.Is(err, domainerr.ErrAIUnavailable) {
+			t.Fatalf("error = %v, want ErrAIUnavailable", err)
+		}
+	})
+}
+
+func TestStreamTransportKeepsDefaultSettings(t *testing.T) {
+	c := NewOpenAIClient("", "m", "")
+	tr, ok := c.stream.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("stream transport is %T", c.stream.Transport)
+	}
+	def := http.DefaultTransport.(*http.Transport)
+	if tr == def {
+		t.Fatal("stream transport is http.DefaultTransport itself, not a clone")
+	}
+	if tr.Proxy == nil || tr.DialContext == nil || tr.TLSHandshakeTimeout != def.TLSHandshakeTimeout ||
+		tr.IdleConnTimeout != def.IdleConnTimeout || tr.ForceAttemptHTTP2 != def.ForceAttemptHTTP2 {
+		t.Errorf("stream transport lost the defaults: %+v", tr)
+	}
+	if tr.ResponseHeaderTimeout != 25*time.Second {
+		t.Errorf("ResponseHeaderTimeout = %v, want 25s", tr.ResponseHeaderTimeout)
+	}
+	if c.stream.Timeout != 0 {
+		t.Errorf("stream client has an overall timeout of %v", c.stream.Timeout)
+	}
+}
+
+func TestStreamedCallsReportUsage(t *testing.T) {
+	body := delta(`{"suggestions":["Deload `) + delta(`this week"]}`) +
+		`data: {"model":"gpt-test-0301","choices":[],"usage":{"prompt_tokens":40,"completion_tokens":9,"total_tokens":49}}` + "\n\n" +
+		"data: [DONE]\n\n"
+	srv := sseServer(t, body)
+
+	audit := &auditLog{}
+	orch := orchestrator.NewOrchestrator(NewOpenAIClient("", "gpt-test", srv.URL), orchestrator.WithAuditor(audit))
+	var streamed strings.Builder
+	out, err := orch.StreamCoachingSuggestions(context.Background(), orchestrator.CoachingInput{UserID: "u1"}, func(d string) error {
+		streamed.WriteString(d)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamCoachingSuggestions: %v", err)
+	}
+	if len(out.Suggestions) != 1 || out.Suggestions[0] != "Deload this week" {
+		t.Fatalf("suggestions = %q", out.Suggestions)
+	}
+	if streamed.Len() == 0 {
+		t.Error("nothing was streamed to the caller")
+	}
+	if len(audit.calls) != 1 {
+		t.Fatalf("recorded %d calls, want 1", len(audit.calls))
+	}
+	if c := audit.calls[0]; c.Model != "gpt-test-0301" || c.PromptTokens != 40 || c.CompletionTokens != 9 || c.TotalTokens != 49 {
+		t.Errorf("recorded %s %d+%d=%d, want gpt-test-0301 40+9=49", c.Model, c.PromptTokens, c.CompletionTokens, c.TotalTokens)
+	}
+}
@@ -0,0 +1,62 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"S.P.A.R.T.A/backend/internal/ai/orchestrator"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+)
+
+// GenerateWithTools runs one function-calling completion. The orchestrator's
+// system message replaces the client's default one.
+func (c *OpenAIClient) GenerateWithTools(ctx context.Context, messages []orchestrator.AgentMessage, tools []orchestrator.ToolSpec) (*orchestrator.AgentReply, error) {
+	req := ChatRequest{
+		Model:       c.model,
+		Temperature: c.opts.Temperature,
+		MaxTokens:   c.opts.MaxTokens,
+	}
+	for _, t := range tools {
+		req.Tools = append(req.Tools, ChatTool{
+			Type:     "function",
+			Function: ChatFunction{Name: t.Name, Description: t.Description, Parameters: t.Parameters},
+		})
+	}
+	for _, m := range messages {
+		msg := ChatMessage{Role: m.Role, Content: m.Content, ToolCallID: m.ToolCallID}
+		for _, call := range m.ToolCalls {
+			var tc ChatToolCall
+			tc.ID = call.ID
+			tc.Type = "function"
+			tc.Function.Name = call.Name
+			tc.Function.Arguments = string(call.Arguments)
+			msg.ToolCalls = append(msg.ToolCalls, tc)
+		}
+		req.Messages = append(req.Messages, msg)
+	}
+
+	decoded, err := c.send(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := decoded.Choices[0].Message
+	reply := &orchestrator.AgentReply{Content: msg.Content}
+	for _, tc := range msg.ToolCalls {
+		args := strings.TrimSpace(tc.Function.Arguments)
+		if args == "" {
+			args = "{}"
+		}
+		if !json.Valid([]byte(args)) {
+			return nil, fmt.Errorf("%w: tool call %q has invalid arguments", domainerr.ErrAIUnavailable, tc.Function.Name)
+		}
+		reply.ToolCalls = append(reply.ToolCalls, orchestrator.ToolCall{
+			ID:        tc.ID,
+			Name:      tc.Function.Name,
+			Arguments: json.RawMessage(args),
+		})
+	}
+	return reply, nil
+}
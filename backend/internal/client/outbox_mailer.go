@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// OutboxMailer keeps mail local for development and tests: every message is
+// logged and, when dir is set, written there as an .eml file instead of
+// being sent.
+type OutboxMailer struct {
+	dir  string
+	from string
+}
+
+func NewOutboxMailer(dir, from string) *OutboxMailer {
+	return &OutboxMailer{dir: dir, from: from}
+}
+
+func (m *OutboxMailer) Send(ctx context.Context, msg MailMessage) error {
+	now := time.Now().UTC()
+	data, err := formatMail(m.from, msg, now)
+	if err != nil {
+		return err
+	}
+
+	if m.dir == "" {
+		slog.InfoContext(ctx, "mail outbox", "to", msg.To, "subject", msg.Subject, "body", msg.Body)
+		return nil
+	}
+	if err := os.MkdirAll(m.dir, 0o700); err != nil {
+		return err
+	}
+	name := now.Format("20060102T150405.000000000") + "-" + outboxFileSafe(msg.To) + ".eml"
+	path := filepath.Join(m.dir, name)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "mail outbox", "to", msg.To, "subject", msg.Subject, "file", path)
+	return nil
+}
+
+func outboxFileSafe(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '@', r == '.', r == '-', r == '_':
+			return r
+		}
+		return '_'
+	}, s)
+}
@@ -0,0 +1,105 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// implicitTLSPort is the SMTPS port; other ports upgrade with STARTTLS when
+// the server offers it.
+const implicitTLSPort = 465
+
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	// From is the sender, e.g. "S.P.A.R.T.A <no-reply@example.com>".
+	From string
+}
+
+type SMTPMailer struct {
+	cfg     SMTPConfig
+	from    string
+	timeout time.Duration
+}
+
+func NewSMTPMailer(cfg SMTPConfig) (*SMTPMailer, error) {
+	from, err := mail.ParseAddress(cfg.From)
+	if err != nil {
+		return nil, err
+	}
+	return &SMTPMailer{cfg: cfg, from: from.Address, timeout: 30 * time.Second}, nil
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, msg MailMessage) error {
+	now := time.Now()
+	data, err := formatMail(m.cfg.From, msg, now)
+	if err != nil {
+		return err
+	}
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return err
+	}
+
+	addr := net.JoinHostPort(m.cfg.Host, strconv.Itoa(m.cfg.Port))
+	dialer := &net.Dialer{Timeout: m.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = now.Add(m.timeout)
+	}
+	_ = conn.SetDeadline(deadline)
+
+	tlsCfg := &tls.Config{ServerName: m.cfg.Host}
+	if m.cfg.Port == implicitTLSPort {
+		conn = tls.Client(conn, tlsCfg)
+	}
+	c, err := smtp.NewClient(conn, m.cfg.Host)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if m.cfg.Port != implicitTLSPort {
+		if ok, _ := c.Extension("STARTTLS"); ok {
+			if err := c.StartTLS(tlsCfg); err != nil {
+				return err
+			}
+		}
+	}
+	if m.cfg.Username != "" {
+		// PlainAuth refuses to send credentials over plaintext except to
+		// localhost.
+		if err := c.Auth(smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(m.from); err != nil {
+		return err
+	}
+	if err := c.Rcpt(to.Address); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
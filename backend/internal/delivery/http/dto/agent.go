@@ -0,0 +1,88 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/agent"
+)
+
+type RunAgentDTO struct {
+	Message string `json:"message" validate:"required,max=2000"`
+}
+
+type AgentStepDTO struct {
+	Tool      string          `json:"tool"`
+	Arguments json.RawMessage `json:"arguments"`
+	Result    json.RawMessage `json:"result,omitempty"`
+	Error     string          `json:"error,omitempty"`
+}
+
+type AgentChangeDTO struct {
+	Path   string `json:"path"`
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
+}
+
+type AgentActionResponseDTO struct {
+	ID         string           `json:"id"`
+	Tool       string           `json:"tool"`
+	Summary    string           `json:"summary"`
+	Changes    []AgentChangeDTO `json:"changes"`
+	Status     string           `json:"status"`
+	CreatedAt  time.Time        `json:"created_at"`
+	ExpiresAt  time.Time        `json:"expires_at"`
+	ResolvedAt *time.Time       `json:"resolved_at,omitempty"`
+}
+
+type AgentResultResponseDTO struct {
+	Reply   string                   `json:"reply"`
+	Source  string                   `json:"source"`
+	Steps   []AgentStepDTO           `json:"steps"`
+	Actions []AgentActionResponseDTO `json:"pending_actions"`
+}
+
+func FromDomainAgentAction(a agent.Action) AgentActionResponseDTO {
+	changes := make([]AgentChangeDTO, 0, len(a.Changes))
+	for _, c := range a.Changes {
+		changes = append(changes, AgentChangeDTO{Path: c.Path, Before: c.Before, After: c.After})
+	}
+	return AgentActionResponseDTO{
+		ID:         a.ID,
+		Tool:       a.Tool,
+		Summary:    a.Summary,
+		Changes:    changes,
+		Status:     string(a.Status),
+		CreatedAt:  a.CreatedAt,
+		ExpiresAt:  a.ExpiresAt,
+		ResolvedAt: a.ResolvedAt,
+	}
+}
+
+func FromDomainAgentResult(r agent.Result) AgentResultResponseDTO {
+	out := AgentResultResponseDTO{
+		Reply:   r.Reply,
+		Source:  string(r.Source),
+		Steps:   make([]AgentStepDTO, 0, len(r.Steps)),
+		Actions: make([]AgentActionResponseDTO, 0, len(r.Actions)),
+	}
+	for _, st := range r.Steps {
+		step := AgentStepDTO{Tool: st.Tool, Arguments: st.Arguments, Error: st.Error}
+		if !json.Valid(step.Arguments) {
+			step.Arguments = json.RawMessage(`{}`)
+		}
+		// Tool results are JSON; anything else is passed on as a string.
+		if st.Result != "" {
+			if json.Valid([]byte(st.Result)) {
+				step.Result = json.RawMessage(st.Result)
+			} else {
+				step.Result, _ = json.Marshal(st.Result)
+			}
+		}
+		out.Steps = append(out.Steps, step)
+	}
+	for _, a := range r.Actions {
+		out.Actions = append(out.Actions, FromDomainAgentAction(a))
+	}
+	return out
+}
@@ -0,0 +1,53 @@
+package dto
+
+import "time"
+
+// ListAICallsQueryDTO filters the AI call log. from and to are inclusive
+// calendar days (UTC).
+type ListAICallsQueryDTO struct {
+	UserID    string `form:"user_id" validate:"omitempty,uuid"`
+	Operation string `form:"operation"`
+	Outcome   string `form:"outcome" validate:"omitempty,oneof=ok invalid_output error"`
+	From      string `form:"from"`
+	To        string `form:"to"`
+	Cursor    string `form:"cursor"`
+	Limit     int    `form:"limit" validate:"omitempty,gte=1,lte=200"`
+}
+
+type AICallStatsQueryDTO struct {
+	GroupBy   string `form:"group_by" validate:"omitempty,oneof=day operation user model"`
+	UserID    string `form:"user_id" validate:"omitempty,uuid"`
+	Operation string `form:"operation"`
+	From      string `form:"from"`
+	To        string `form:"to"`
+}
+
+type AICallResponseDTO struct {
+	ID               string    `json:"id"`
+	UserID           *string   `json:"user_id,omitempty"`
+	Operation        string    `json:"operation"`
+	Attempt          int       `json:"attempt"`
+	Provider         string    `json:"provider,omitempty"`
+	Model            string    `json:"model,omitempty"`
+	PromptHash       string    `json:"prompt_hash"`
+	PromptVersion    string    `json:"prompt_version,omitempty"`
+	Response         string    `json:"response,omitempty"`
+	Outcome          string    `json:"outcome"`
+	Error            string    `json:"error,omitempty"`
+	LatencyMs        int       `json:"latency_ms"`
+	PromptTokens     int       `json:"prompt_tokens"`
+	CompletionTokens int       `json:"completion_tokens"`
+	TotalTokens      int       `json:"total_tokens"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+type AICallStatResponseDTO struct {
+	Key              string  `json:"key"`
+	Calls            int     `json:"calls"`
+	InvalidOutputs   int     `json:"invalid_outputs"`
+	Errors           int     `json:"errors"`
+	PromptTokens     int64   `json:"prompt_tokens"`
+	CompletionTokens int64   `json:"completion_tokens"`
+	TotalTokens      int64   `json:"total_tokens"`
+	AvgLatencyMs     float64 `json:"avg_latency_ms"`
+}
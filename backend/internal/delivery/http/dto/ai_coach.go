@@ -1,8 +1,17 @@
 package dto
 
+// GenerateSplitRequestDTO's constraint fields override the user's stored
+// preferences for this request only.
 type GenerateSplitRequestDTO struct {
 	DaysPerWeek int    `json:"days_per_week" validate:"required,gte=1,lte=7"`
 	FocusMuscle string `json:"focus_muscle" validate:"required"`
+
+	ExperienceLevel   *string   `json:"experience_level" validate:"omitempty,oneof=beginner intermediate advanced"`
+	Equipment         *[]string `json:"equipment" validate:"omitempty,max=20"`
+	MaxSessionMinutes *int      `json:"max_session_minutes" validate:"omitempty,gte=0,lte=240"`
+	Injuries          *string   `json:"injuries" validate:"omitempty,max=500"`
+	ExcludedMovements *[]string `json:"excluded_movements" validate:"omitempty,max=20"`
+	PreferredDays     *[]string `json:"preferred_days" validate:"omitempty,max=7"`
 }
 
 type SuggestOverloadRequestDTO struct {
@@ -30,6 +39,7 @@ type ExplainWorkoutPlanRequestDTO struct {
 type CoachingSuggestionsResponseDTO struct {
 	Date        string   `json:"date"`
 	Suggestions []string `json:"suggestions"`
+	Source      string   `json:"source"`
 }
 
 type WorkoutExplanationExerciseNoteDTO struct {
@@ -40,4 +50,5 @@ type WorkoutExplanationExerciseNoteDTO struct {
 type WorkoutExplanationResponseDTO struct {
 	Summary       string                              `json:"summary"`
 	ExerciseNotes []WorkoutExplanationExerciseNoteDTO `json:"exercise_notes"`
+	Source        string                              `json:"source"`
 }
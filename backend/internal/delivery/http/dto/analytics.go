@@ -0,0 +1,13 @@
+package dto
+
+type ExerciseProgressionQueryDTO struct {
+	From    string `form:"from"`
+	To      string `form:"to"`
+	Bucket  string `form:"bucket" validate:"omitempty,oneof=day week month"`
+	Formula string `form:"formula" validate:"omitempty,oneof=epley brzycki"`
+}
+
+type MuscleVolumeQueryDTO struct {
+	From string `form:"from"`
+	To   string `form:"to"`
+}
@@ -0,0 +1,130 @@
+package dto
+
+import "S.P.A.R.T.A/backend/internal/domain/service/training"
+
+type ProgressPointResponseDTO struct {
+	PeriodStart  string  `json:"period_start"`
+	E1RM         float64 `json:"e1rm"`
+	TopSetWeight float64 `json:"top_set_weight"`
+	TopSetReps   int     `json:"top_set_reps"`
+	Volume       float64 `json:"volume"`
+	Sets         int     `json:"sets"`
+	Sessions     int     `json:"sessions"`
+}
+
+type WeeklySetCountResponseDTO struct {
+	WeekStart string `json:"week_start"`
+	Sets      int    `json:"sets"`
+}
+
+type ExerciseProgressionResponseDTO struct {
+	ExerciseID string                      `json:"exercise_id"`
+	Bucket     string                      `json:"bucket"`
+	Formula    string                      `json:"formula"`
+	Points     []ProgressPointResponseDTO  `json:"points"`
+	WeeklySets []WeeklySetCountResponseDTO `json:"weekly_sets"`
+}
+
+func FromExerciseProgression(p training.ExerciseProgression) ExerciseProgressionResponseDTO {
+	out := ExerciseProgressionResponseDTO{
+		ExerciseID: p.ExerciseID,
+		Bucket:     string(p.Bucket),
+		Formula:    string(p.Formula),
+		Points:     make([]ProgressPointResponseDTO, 0, len(p.Points)),
+		WeeklySets: make([]WeeklySetCountResponseDTO, 0, len(p.WeeklySets)),
+	}
+
+	for _, pt := range p.Points {
+		out.Points = append(out.Points, ProgressPointResponseDTO{
+			PeriodStart:  pt.PeriodStart.Format("2006-01-02"),
+			E1RM:         pt.E1RM,
+			TopSetWeight: pt.TopSetWeight,
+			TopSetReps:   pt.TopSetReps,
+			Volume:       pt.Volume,
+			Sets:         pt.Sets,
+			Sessions:     pt.Sessions,
+		})
+	}
+	for _, w := range p.WeeklySets {
+		out.WeeklySets = append(out.WeeklySets, WeeklySetCountResponseDTO{
+			WeekStart: w.WeekStart.Format("2006-01-02"),
+			Sets:      w.Sets,
+		})
+	}
+	return out
+}
+
+type MuscleLandmarksResponseDTO struct {
+	MEV float64 `json:"mev"`
+	MAV float64 `json:"mav"`
+	MRV float64 `json:"mrv"`
+}
+
+type MuscleVolumeResponseDTO struct {
+	Muscle    string                     `json:"muscle"`
+	Region    string                     `json:"region"`
+	HardSets  float64                    `json:"hard_sets"`
+	Landmarks MuscleLandmarksResponseDTO `json:"landmarks"`
+	Status    string                     `json:"status"`
+}
+
+type BalanceCheckResponseDTO struct {
+	Name       string  `json:"name"`
+	LeftSets   float64 `json:"left_sets"`
+	RightSets  float64 `json:"right_sets"`
+	Ratio      float64 `json:"ratio"`
+	Imbalanced bool    `json:"imbalanced"`
+}
+
+type MuscleWeekResponseDTO struct {
+	WeekStart        string                    `json:"week_start"`
+	Muscles          []MuscleVolumeResponseDTO `json:"muscles"`
+	UnclassifiedSets float64                   `json:"unclassified_sets"`
+	Balance          []BalanceCheckResponseDTO `json:"balance"`
+}
+
+type MuscleVolumeReportResponseDTO struct {
+	Weeks                   []MuscleWeekResponseDTO `json:"weeks"`
+	UnclassifiedExerciseIDs []string                `json:"unclassified_exercise_ids"`
+}
+
+func FromMuscleVolumeReport(r training.MuscleVolumeReport) MuscleVolumeReportResponseDTO {
+	out := MuscleVolumeReportResponseDTO{
+		Weeks:                   make([]MuscleWeekResponseDTO, 0, len(r.Weeks)),
+		UnclassifiedExerciseIDs: make([]string, 0, len(r.UnclassifiedExerciseIDs)),
+	}
+	out.UnclassifiedExerciseIDs = append(out.UnclassifiedExerciseIDs, r.UnclassifiedExerciseIDs...)
+
+	for _, w := range r.Weeks {
+		week := MuscleWeekResponseDTO{
+			WeekStart:        w.WeekStart.Format("2006-01-02"),
+			Muscles:          make([]MuscleVolumeResponseDTO, 0, len(w.Muscles)),
+			UnclassifiedSets: w.UnclassifiedSets,
+			Balance:          make([]BalanceCheckResponseDTO, 0, len(w.Balance)),
+		}
+		for _, m := range w.Muscles {
+			week.Muscles = append(week.Muscles, MuscleVolumeResponseDTO{
+				Muscle:   string(m.Group),
+				Region:   string(m.Region),
+				HardSets: m.HardSets,
+				Landmarks: MuscleLandmarksResponseDTO{
+					MEV: m.Landmarks.MEV,
+					MAV: m.Landmarks.MAV,
+					MRV: m.Landmarks.MRV,
+				},
+				Status: string(m.Status),
+			})
+		}
+		for _, b := range w.Balance {
+			week.Balance = append(week.Balance, BalanceCheckResponseDTO{
+				Name:       b.Name,
+				LeftSets:   b.LeftSets,
+				RightSets:  b.RightSets,
+				Ratio:      b.Ratio,
+				Imbalanced: b.Imbalanced,
+			})
+		}
+		out.Weeks = append(out.Weeks, week)
+	}
+	return out
+}
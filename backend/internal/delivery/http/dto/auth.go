@@ -1,5 +1,11 @@
 package dto
 
+import (
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/auth"
+)
+
 type RegisterRequestDTO struct {
 	Name        string `json:"name" validate:"required"`
 	Email       string `json:"email" validate:"required,email"`
@@ -12,6 +18,68 @@ type LoginRequestDTO struct {
 	Password string `json:"password" validate:"required"`
 }
 
+type RefreshRequestDTO struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type LogoutRequestDTO struct {
+	// All signs out every device instead of only the current one.
+	All bool `json:"all"`
+}
+
+type ForgotPasswordRequestDTO struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type ResetPasswordRequestDTO struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required,min=8"`
+}
+
+type VerifyEmailRequestDTO struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type OAuthCallbackRequestDTO struct {
+	Code  string `json:"code" validate:"required"`
+	State string `json:"state" validate:"required"`
+}
+
+type OAuthStartResponseDTO struct {
+	// URL is the provider's sign-in page; the browser goes there next.
+	URL string `json:"url"`
+	// State comes back with the callback; the web app keeps it to check
+	// the callback belongs to a sign-in it started.
+	State string `json:"state"`
+}
+
+type AuthSessionResponseDTO struct {
+	ID         string    `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	Current    bool      `json:"current"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// FromDomainAuthSessions flags the session the request was made with.
+func FromDomainAuthSessions(items []auth.Session, currentID string) []AuthSessionResponseDTO {
+	out := make([]AuthSessionResponseDTO, 0, len(items))
+	for _, s := range items {
+		out = append(out, AuthSessionResponseDTO{
+			ID:         s.ID,
+			UserAgent:  s.UserAgent,
+			IP:         s.IP,
+			Current:    s.ID == currentID,
+			CreatedAt:  s.CreatedAt,
+			LastUsedAt: s.LastUsedAt,
+			ExpiresAt:  s.ExpiresAt,
+		})
+	}
+	return out
+}
+
 type CreateAdminInviteRequestDTO struct {
 	ExpiresInHours int `json:"expires_in_hours" validate:"omitempty,min=1,max=336"`
 }
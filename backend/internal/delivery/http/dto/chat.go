@@ -0,0 +1,81 @@
+package dto
+
+import (
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/chat"
+)
+
+type CreateChatThreadDTO struct {
+	Title string `json:"title" validate:"omitempty,max=120"`
+}
+
+type SendChatMessageDTO struct {
+	Content string `json:"content" validate:"required,max=2000"`
+}
+
+type ChatThreadResponseDTO struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	Title     string    `json:"title"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ChatCitationDTO struct {
+	Kind  string `json:"kind"`
+	Ref   string `json:"ref,omitempty"`
+	Label string `json:"label"`
+}
+
+type ChatMessageResponseDTO struct {
+	ID        string            `json:"id"`
+	ThreadID  string            `json:"thread_id"`
+	Role      string            `json:"role"`
+	Content   string            `json:"content"`
+	Citations []ChatCitationDTO `json:"citations"`
+	Source    string            `json:"source,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+func FromDomainChatThread(t chat.Thread) ChatThreadResponseDTO {
+	return ChatThreadResponseDTO{
+		ID:        t.ID,
+		UserID:    t.UserID,
+		Title:     t.Title,
+		CreatedAt: t.CreatedAt,
+		UpdatedAt: t.UpdatedAt,
+	}
+}
+
+func FromDomainChatThreads(items []chat.Thread) []ChatThreadResponseDTO {
+	out := make([]ChatThreadResponseDTO, 0, len(items))
+	for _, item := range items {
+		out = append(out, FromDomainChatThread(item))
+	}
+	return out
+}
+
+func FromDomainChatMessage(m chat.Message) ChatMessageResponseDTO {
+	citations := make([]ChatCitationDTO, 0, len(m.Citations))
+	for _, c := range m.Citations {
+		citations = append(citations, ChatCitationDTO{Kind: c.Kind, Ref: c.Ref, Label: c.Label})
+	}
+	return ChatMessageResponseDTO{
+		ID:        m.ID,
+		ThreadID:  m.ThreadID,
+		Role:      string(m.Role),
+		Content:   m.Content,
+		Citations: citations,
+		Source:    string(m.Source),
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+func FromDomainChatMessages(items []chat.Message) []ChatMessageResponseDTO {
+	out := make([]ChatMessageResponseDTO, 0, len(items))
+	for _, item := range items {
+		out = append(out, FromDomainChatMessage(item))
+	}
+	return out
+}
@@ -43,3 +43,106 @@ func ToDomainWorkoutSession(d CreateWorkoutSessionDTO) workout.WorkoutSession {
 
 	return ws
 }
+
+func ToDomainWorkoutSessionForUpdate(sessionID string, d UpdateWorkoutSessionDTO) (workout.WorkoutSession, error) {
+	sessionDate, err := time.Parse("2006-01-02", d.SessionDate)
+	if err != nil {
+		return workout.WorkoutSession{}, err
+	}
+
+	ws := workout.WorkoutSession{
+		ID:          sessionID,
+		SplitDayID:  d.SplitDayID,
+		SessionDate: sessionDate,
+		DurationMin: d.DurationMin,
+		Notes:       d.Notes,
+	}
+
+	for _, exDTO := range d.Exercises {
+		we := workout.WorkoutExercise{
+			ID:         uuid.NewString(),
+			ExerciseID: exDTO.ExerciseID,
+		}
+		for _, setDTO := range exDTO.Sets {
+			we.Sets = append(we.Sets, ToDomainWorkoutSet(uuid.NewString(), setDTO))
+		}
+		ws.Exercises = append(ws.Exercises, we)
+	}
+
+	return ws, nil
+}
+
+func ToDomainWorkoutSessionPatch(d PatchWorkoutSessionDTO) (workout.WorkoutSessionPatch, error) {
+	patch := workout.WorkoutSessionPatch{
+		SplitDayID:        d.SplitDayID,
+		DurationMin:       d.DurationMin,
+		Notes:             d.Notes,
+		RemoveExerciseIDs: d.RemoveExerciseIDs,
+	}
+
+	if d.SessionDate != nil {
+		sessionDate, err := time.Parse("2006-01-02", *d.SessionDate)
+		if err != nil {
+			return workout.WorkoutSessionPatch{}, err
+		}
+		patch.SessionDate = &sessionDate
+	}
+
+	for _, exDTO := range d.Exercises {
+		exPatch := workout.WorkoutExercisePatch{
+			ID:           exDTO.ID,
+			ExerciseID:   exDTO.ExerciseID,
+			RemoveSetIDs: exDTO.RemoveSetIDs,
+		}
+		for _, setDTO := range exDTO.Sets {
+			exPatch.Sets = append(exPatch.Sets, workout.WorkoutSet{
+				ID:       setDTO.ID,
+				SetOrder: setDTO.SetOrder,
+				Reps:     setDTO.Reps,
+				Weight:   setDTO.Weight,
+				RPE:      setDTO.RPE,
+				SetType:  setDTO.SetType,
+			})
+		}
+		patch.Exercises = append(patch.Exercises, exPatch)
+	}
+
+	return patch, nil
+}
+
+func ToDomainWorkoutSet(setID string, d WorkoutSetDTO) workout.WorkoutSet {
+	return workout.WorkoutSet{
+		ID:        setID,
+		SetOrder:  d.SetOrder,
+		Reps:      d.Reps,
+		Weight:    d.Weight,
+		RPE:       d.RPE,
+		SetType:   d.SetType,
+		CreatedAt: time.Now(),
+	}
+}
+
+func ToDomainSessionFilter(q ListWorkoutSessionsQueryDTO) (workout.SessionFilter, error) {
+	filter := workout.SessionFilter{
+		SplitDayID: q.SplitDayID,
+		ExerciseID: q.ExerciseID,
+		Status:     q.Status,
+		Cursor:     q.Cursor,
+		Limit:      q.Limit,
+	}
+	if q.From != "" {
+		from, err := time.Parse("2006-01-02", q.From)
+		if err != nil {
+			return workout.SessionFilter{}, err
+		}
+		filter.From = &from
+	}
+	if q.To != "" {
+		to, err := time.Parse("2006-01-02", q.To)
+		if err != nil {
+			return workout.SessionFilter{}, err
+		}
+		filter.To = &to
+	}
+	return filter, nil
+}
@@ -0,0 +1,99 @@
+package dto
+
+import (
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/aicall"
+)
+
+func ToDomainAICallFilter(q ListAICallsQueryDTO) (aicall.Filter, error) {
+	filter := aicall.Filter{
+		UserID:    q.UserID,
+		Operation: q.Operation,
+		Outcome:   aicall.Outcome(q.Outcome),
+		Cursor:    q.Cursor,
+		Limit:     q.Limit,
+	}
+	from, to, err := parseDayRange(q.From, q.To)
+	if err != nil {
+		return aicall.Filter{}, err
+	}
+	filter.From, filter.To = from, to
+	return filter, nil
+}
+
+func ToDomainAICallStatsFilter(q AICallStatsQueryDTO) (aicall.Filter, error) {
+	filter := aicall.Filter{
+		UserID:    q.UserID,
+		Operation: q.Operation,
+	}
+	from, to, err := parseDayRange(q.From, q.To)
+	if err != nil {
+		return aicall.Filter{}, err
+	}
+	filter.From, filter.To = from, to
+	return filter, nil
+}
+
+// parseDayRange turns inclusive YYYY-MM-DD bounds into [from, to) instants.
+func parseDayRange(fromStr, toStr string) (*time.Time, *time.Time, error) {
+	var from, to *time.Time
+	if fromStr != "" {
+		t, err := time.Parse("2006-01-02", fromStr)
+		if err != nil {
+			return nil, nil, err
+		}
+		from = &t
+	}
+	if toStr != "" {
+		t, err := time.Parse("2006-01-02", toStr)
+		if err != nil {
+			return nil, nil, err
+		}
+		t = t.AddDate(0, 0, 1)
+		to = &t
+	}
+	return from, to, nil
+}
+
+func FromDomainAICalls(items []aicall.Call) []AICallResponseDTO {
+	out := make([]AICallResponseDTO, 0, len(items))
+	for _, c := range items {
+		out = append(out, AICallResponseDTO{
+			ID:               c.ID,
+			UserID:           c.UserID,
+			Operation:        c.Operation,
+			Attempt:          c.Attempt,
+			Provider:         c.Provider,
+			Model:            c.Model,
+			PromptHash:       c.PromptHash,
+			PromptVersion:    c.PromptVersion,
+			Response:         c.Response,
+			Outcome:          string(c.Outcome),
+			Error:            c.Error,
+			LatencyMs:        c.LatencyMs,
+			PromptTokens:     c.PromptTokens,
+			CompletionTokens: c.CompletionTokens,
+			TotalTokens:      c.TotalTokens,
+			CreatedAt:        c.CreatedAt,
+		})
+	}
+	return out
+}
+
+func FromDomainAICallStats(items []aicall.Stat) []AICallStatResponseDTO {
+	out := make([]AICallStatResponseDTO, 0, len(items))
+	for _, s := range items {
+		out = append(out, AICallStatResponseDTO{
+			Key:              s.Key,
+			Calls:            s.Calls,
+			InvalidOutputs:   s.InvalidOutputs,
+			Errors:           s.Errors,
+			PromptTokens:     s.PromptTokens,
+			CompletionTokens: s.CompletionTokens,
+			TotalTokens:      s.TotalTokens,
+			AvgLatencyMs:     s.AvgLatencyMs,
+		})
+	}
+	return out
+}
@@ -3,4 +3,5 @@ package dto
 type DailyMotivationResponseDTO struct {
 	Date    string `json:"date"`
 	Message string `json:"message"`
+	Source  string `json:"source"`
 }
@@ -12,6 +12,8 @@ type PlannerRecommendationResponseDTO struct {
 	WorkoutSessionID   *string   `json:"workout_session_id,omitempty"`
 	Recommendation     string    `json:"recommendation"`
 	RecommendationType string    `json:"recommendation_type"`
+	Source             string    `json:"source,omitempty"`
+	PromptVariant      string    `json:"prompt_variant,omitempty"`
 	CreatedAt          time.Time `json:"created_at"`
 }
 
@@ -22,6 +24,8 @@ func FromDomainPlannerRecommendation(p planner.PlannerRecommendation) PlannerRec
 		WorkoutSessionID:   p.WorkoutSessionID,
 		Recommendation:     p.Recommendation,
 		RecommendationType: p.RecommendationType,
+		Source:             string(p.Source),
+		PromptVariant:      p.PromptVariant,
 		CreatedAt:          p.CreatedAt,
 	}
 }
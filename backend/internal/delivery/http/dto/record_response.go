@@ -0,0 +1,45 @@
+package dto
+
+import (
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/record"
+)
+
+type PersonalRecordResponseDTO struct {
+	ID               string    `json:"id"`
+	ExerciseID       string    `json:"exercise_id"`
+	Type             string    `json:"type"`
+	Value            float64   `json:"value"`
+	Weight           float64   `json:"weight,omitempty"`
+	Reps             int       `json:"reps,omitempty"`
+	Formula          string    `json:"formula,omitempty"`
+	WorkoutSessionID *string   `json:"workout_session_id,omitempty"`
+	WorkoutSetID     *string   `json:"workout_set_id,omitempty"`
+	AchievedAt       string    `json:"achieved_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+func FromDomainPersonalRecord(r record.PersonalRecord) PersonalRecordResponseDTO {
+	return PersonalRecordResponseDTO{
+		ID:               r.ID,
+		ExerciseID:       r.ExerciseID,
+		Type:             string(r.Type),
+		Value:            r.Value,
+		Weight:           r.Weight,
+		Reps:             r.Reps,
+		Formula:          r.Formula,
+		WorkoutSessionID: r.WorkoutSessionID,
+		WorkoutSetID:     r.WorkoutSetID,
+		AchievedAt:       r.AchievedAt.Format("2006-01-02"),
+		UpdatedAt:        r.UpdatedAt,
+	}
+}
+
+func FromDomainPersonalRecords(items []record.PersonalRecord) []PersonalRecordResponseDTO {
+	out := make([]PersonalRecordResponseDTO, 0, len(items))
+	for _, item := range items {
+		out = append(out, FromDomainPersonalRecord(item))
+	}
+	return out
+}
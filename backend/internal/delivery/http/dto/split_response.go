@@ -1,6 +1,7 @@
 package dto
 
 import (
+	"math"
 	"time"
 
 	"S.P.A.R.T.A/backend/internal/domain/aggregate/split"
@@ -32,6 +33,22 @@ type SplitTemplateResponseDTO struct {
 	IsActive    bool                  `json:"is_active"`
 	Days        []SplitDayResponseDTO `json:"days"`
 	CreatedAt   time.Time             `json:"created_at"`
+	Source      string                `json:"source,omitempty"`
+	// UnresolvedExercises is only set when generating a split.
+	UnresolvedExercises []UnresolvedExerciseResponseDTO `json:"unresolved_exercises,omitempty"`
+}
+
+type ExerciseSuggestionResponseDTO struct {
+	ExerciseID string  `json:"exercise_id"`
+	Name       string  `json:"name"`
+	Score      float64 `json:"score"`
+}
+
+type UnresolvedExerciseResponseDTO struct {
+	DayOrder    int                             `json:"day_order"`
+	DayName     string                          `json:"day_name"`
+	Name        string                          `json:"name"`
+	Suggestions []ExerciseSuggestionResponseDTO `json:"suggestions"`
 }
 
 func FromDomainSplitTemplate(t split.SplitTemplate) SplitTemplateResponseDTO {
@@ -75,3 +92,27 @@ func FromDomainSplitTemplates(items []split.SplitTemplate) []SplitTemplateRespon
 	}
 	return out
 }
+
+func FromDomainUnresolvedExercises(items []split.UnresolvedExercise) []UnresolvedExerciseResponseDTO {
+	if len(items) == 0 {
+		return nil
+	}
+	out := make([]UnresolvedExerciseResponseDTO, 0, len(items))
+	for _, item := range items {
+		suggestions := make([]ExerciseSuggestionResponseDTO, 0, len(item.Suggestions))
+		for _, s := range item.Suggestions {
+			suggestions = append(suggestions, ExerciseSuggestionResponseDTO{
+				ExerciseID: s.ExerciseID,
+				Name:       s.Name,
+				Score:      math.Round(s.Score*100) / 100,
+			})
+		}
+		out = append(out, UnresolvedExerciseResponseDTO{
+			DayOrder:    item.DayOrder,
+			DayName:     item.DayName,
+			Name:        item.Name,
+			Suggestions: suggestions,
+		})
+	}
+	return out
+}
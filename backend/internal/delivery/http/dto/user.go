@@ -0,0 +1,17 @@
+package dto
+
+type UpdatePreferencesDTO struct {
+	LoadModel     *string  `json:"load_model" validate:"omitempty,oneof=tonnage session_rpe rpe_tonnage ewma"`
+	BodyWeightKg  *float64 `json:"body_weight_kg" validate:"omitempty,gte=0,lte=400"`
+	CoachPersona  *string  `json:"coach_persona" validate:"omitempty,oneof=brutal supportive clinical custom"`
+	CustomPersona *string  `json:"custom_persona" validate:"omitempty,max=500"`
+	Language      *string  `json:"language" validate:"omitempty,max=35,bcp47_language_tag"`
+	Profanity     *bool    `json:"profanity"`
+
+	ExperienceLevel   *string   `json:"experience_level" validate:"omitempty,oneof=beginner intermediate advanced"`
+	Equipment         *[]string `json:"equipment" validate:"omitempty,max=20"`
+	MaxSessionMinutes *int      `json:"max_session_minutes" validate:"omitempty,gte=0,lte=240"`
+	Injuries          *string   `json:"injuries" validate:"omitempty,max=500"`
+	ExcludedMovements *[]string `json:"excluded_movements" validate:"omitempty,max=20"`
+	PreferredDays     *[]string `json:"preferred_days" validate:"omitempty,max=7"`
+}
@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/user"
+)
+
+type PreferencesResponseDTO struct {
+	LoadModel     string   `json:"load_model"`
+	BodyWeightKg  *float64 `json:"body_weight_kg,omitempty"`
+	CoachPersona  string   `json:"coach_persona"`
+	CustomPersona string   `json:"custom_persona,omitempty"`
+	Language      string   `json:"language"`
+	Profanity     bool     `json:"profanity"`
+
+	ExperienceLevel   string   `json:"experience_level"`
+	Equipment         []string `json:"equipment"`
+	MaxSessionMinutes int      `json:"max_session_minutes,omitempty"`
+	Injuries          string   `json:"injuries,omitempty"`
+	ExcludedMovements []string `json:"excluded_movements"`
+	PreferredDays     []string `json:"preferred_days"`
+
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func FromDomainPreferences(p user.Preferences) PreferencesResponseDTO {
+	return PreferencesResponseDTO{
+		LoadModel:     p.LoadModel,
+		BodyWeightKg:  p.BodyWeightKg,
+		CoachPersona:  p.CoachPersona,
+		CustomPersona: p.CustomPersona,
+		Language:      p.Language,
+		Profanity:     p.Profanity,
+
+		ExperienceLevel:   p.ExperienceLevel,
+		Equipment:         emptyIfNil(p.Equipment),
+		MaxSessionMinutes: p.MaxSessionMinutes,
+		Injuries:          p.Injuries,
+		ExcludedMovements: emptyIfNil(p.ExcludedMovements),
+		PreferredDays:     emptyIfNil(p.PreferredDays),
+
+		UpdatedAt: p.UpdatedAt,
+	}
+}
+
+func emptyIfNil(items []string) []string {
+	if items == nil {
+		return []string{}
+	}
+	return items
+}
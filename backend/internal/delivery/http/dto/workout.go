@@ -21,3 +21,46 @@ type CreateWorkoutSessionDTO struct {
 	Notes       string               `json:"notes"`
 	Exercises   []WorkoutExerciseDTO `json:"exercises" validate:"required,dive"`
 }
+
+type UpdateWorkoutSessionDTO struct {
+	SplitDayID  *string              `json:"split_day_id"`
+	SessionDate string               `json:"session_date" validate:"required"`
+	DurationMin int                  `json:"duration_minutes" validate:"required"`
+	Notes       string               `json:"notes"`
+	Exercises   []WorkoutExerciseDTO `json:"exercises" validate:"required,dive"`
+}
+
+type PatchWorkoutSetDTO struct {
+	ID       string  `json:"id" validate:"omitempty,uuid4"`
+	SetOrder int     `json:"set_order" validate:"required"`
+	Reps     int     `json:"reps" validate:"required"`
+	Weight   float64 `json:"weight" validate:"gte=0"`
+	RPE      float64 `json:"rpe" validate:"gte=0,lte=10"`
+	SetType  string  `json:"set_type" validate:"required"`
+}
+
+type PatchWorkoutExerciseDTO struct {
+	ID           string               `json:"id" validate:"omitempty,uuid4"`
+	ExerciseID   string               `json:"exercise_id" validate:"omitempty,uuid4"`
+	Sets         []PatchWorkoutSetDTO `json:"sets" validate:"dive"`
+	RemoveSetIDs []string             `json:"remove_set_ids" validate:"dive,uuid4"`
+}
+
+type PatchWorkoutSessionDTO struct {
+	SplitDayID        *string                   `json:"split_day_id"`
+	SessionDate       *string                   `json:"session_date"`
+	DurationMin       *int                      `json:"duration_minutes" validate:"omitempty,gte=0"`
+	Notes             *string                   `json:"notes"`
+	Exercises         []PatchWorkoutExerciseDTO `json:"exercises" validate:"dive"`
+	RemoveExerciseIDs []string                  `json:"remove_exercise_ids" validate:"dive,uuid4"`
+}
+
+type ListWorkoutSessionsQueryDTO struct {
+	From       string `form:"from"`
+	To         string `form:"to"`
+	SplitDayID string `form:"split_day_id" validate:"omitempty,uuid"`
+	ExerciseID string `form:"exercise_id" validate:"omitempty,uuid"`
+	Status     string `form:"status" validate:"omitempty,oneof=draft completed"`
+	Cursor     string `form:"cursor"`
+	Limit      int    `form:"limit" validate:"omitempty,gte=1,lte=100"`
+}
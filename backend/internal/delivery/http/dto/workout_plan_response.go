@@ -3,34 +3,100 @@ package dto
 import "S.P.A.R.T.A/backend/internal/domain/aggregate/workout"
 
 type WorkoutPlanExerciseResponseDTO struct {
-	Name     string  `json:"name"`
-	Sets     int     `json:"sets"`
-	RepRange string  `json:"rep_range"`
-	Weight   float64 `json:"weight"`
+	ExerciseID string  `json:"exercise_id,omitempty"`
+	Name       string  `json:"name"`
+	Sets       int     `json:"sets"`
+	RepRange   string  `json:"rep_range"`
+	RepsMin    int     `json:"reps_min"`
+	RepsMax    int     `json:"reps_max"`
+	Weight     float64 `json:"weight"`
 }
 
 type WorkoutPlanResponseDTO struct {
-	UserID     string                           `json:"user_id"`
-	SplitDayID string                           `json:"split_day_id"`
-	Date       string                           `json:"date"`
-	Exercises  []WorkoutPlanExerciseResponseDTO `json:"exercises"`
+	ID            string                           `json:"id"`
+	UserID        string                           `json:"user_id"`
+	SplitDayID    string                           `json:"split_day_id"`
+	SplitDayName  string                           `json:"split_day_name,omitempty"`
+	Fatigue       int                              `json:"fatigue"`
+	Date          string                           `json:"date"`
+	Exercises     []WorkoutPlanExerciseResponseDTO `json:"exercises"`
+	Source        string                           `json:"source"`
+	PromptVersion string                           `json:"prompt_version,omitempty"`
+	SessionID     *string                          `json:"session_id,omitempty"`
 }
 
 func FromDomainWorkoutPlan(p workout.WorkoutPlan) WorkoutPlanResponseDTO {
 	out := WorkoutPlanResponseDTO{
-		UserID:     p.UserID,
-		SplitDayID: p.SplitDayID,
-		Date:       p.Date.UTC().Format("2006-01-02"),
+		ID:            p.ID,
+		UserID:        p.UserID,
+		SplitDayID:    p.SplitDayID,
+		SplitDayName:  p.SplitDayName,
+		Fatigue:       p.Fatigue,
+		Date:          p.Date.UTC().Format("2006-01-02"),
+		Source:        string(p.Source),
+		PromptVersion: p.PromptVersion,
+		SessionID:     p.SessionID,
 	}
 
 	for _, ex := range p.Exercises {
 		out.Exercises = append(out.Exercises, WorkoutPlanExerciseResponseDTO{
-			Name:     ex.Name,
-			Sets:     ex.Sets,
-			RepRange: ex.RepRange,
-			Weight:   ex.Weight,
+			ExerciseID: ex.ExerciseID,
+			Name:       ex.Name,
+			Sets:       ex.Sets,
+			RepRange:   ex.RepRange,
+			RepsMin:    ex.RepsMin,
+			RepsMax:    ex.RepsMax,
+			Weight:     ex.Weight,
 		})
 	}
 
 	return out
 }
+
+func FromDomainWorkoutPlans(items []workout.WorkoutPlan) []WorkoutPlanResponseDTO {
+	out := make([]WorkoutPlanResponseDTO, 0, len(items))
+	for _, item := range items {
+		out = append(out, FromDomainWorkoutPlan(item))
+	}
+	return out
+}
+
+type ListWorkoutPlansQueryDTO struct {
+	Limit int `form:"limit" validate:"omitempty,gte=1,lte=100"`
+}
+
+type ExerciseComparisonResponseDTO struct {
+	ExerciseID     string  `json:"exercise_id"`
+	Name           string  `json:"name"`
+	Outcome        string  `json:"outcome"`
+	PlannedSets    int     `json:"planned_sets"`
+	ActualSets     int     `json:"actual_sets"`
+	SetsDelta      int     `json:"sets_delta"`
+	PlannedRepsMin int     `json:"planned_reps_min"`
+	PlannedRepsMax int     `json:"planned_reps_max"`
+	ActualReps     float64 `json:"actual_reps"`
+	RepsDelta      float64 `json:"reps_delta"`
+	PlannedWeight  float64 `json:"planned_weight"`
+	ActualWeight   float64 `json:"actual_weight"`
+	WeightDelta    float64 `json:"weight_delta"`
+}
+
+type PlanComparisonResponseDTO struct {
+	PlanID        string                          `json:"plan_id"`
+	SessionID     string                          `json:"session_id"`
+	SessionStatus string                          `json:"session_status"`
+	Exercises     []ExerciseComparisonResponseDTO `json:"exercises"`
+}
+
+func FromDomainPlanComparison(c workout.PlanComparison) PlanComparisonResponseDTO {
+	out := PlanComparisonResponseDTO{
+		PlanID:        c.PlanID,
+		SessionID:     c.SessionID,
+		SessionStatus: c.SessionStatus,
+		Exercises:     make([]ExerciseComparisonResponseDTO, 0, len(c.Exercises)),
+	}
+	for _, e := range c.Exercises {
+		out.Exercises = append(out.Exercises, ExerciseComparisonResponseDTO(e))
+	}
+	return out
+}
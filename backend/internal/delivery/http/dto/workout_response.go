@@ -3,6 +3,7 @@ package dto
 import (
 	"time"
 
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/record"
 	"S.P.A.R.T.A/backend/internal/domain/aggregate/workout"
 )
 
@@ -13,6 +14,8 @@ type WorkoutSetResponseDTO struct {
 	Weight    float64   `json:"weight"`
 	RPE       float64   `json:"rpe"`
 	SetType   string    `json:"set_type"`
+	IsPR      bool      `json:"is_pr"`
+	PRTypes   []string  `json:"pr_types,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
@@ -26,10 +29,13 @@ type WorkoutSessionResponseDTO struct {
 	ID              string                       `json:"id"`
 	UserID          string                       `json:"user_id"`
 	SplitDayID      *string                      `json:"split_day_id,omitempty"`
+	PlanID          *string                      `json:"plan_id,omitempty"`
+	Status          string                       `json:"status"`
 	SessionDate     string                       `json:"session_date"`
 	DurationMinutes int                          `json:"duration_minutes"`
 	Notes           string                       `json:"notes"`
 	Exercises       []WorkoutExerciseResponseDTO `json:"exercises"`
+	PersonalRecords []PersonalRecordResponseDTO  `json:"personal_records,omitempty"`
 	CreatedAt       time.Time                    `json:"created_at"`
 }
 
@@ -38,6 +44,8 @@ func FromDomainWorkoutSession(s workout.WorkoutSession) WorkoutSessionResponseDT
 		ID:              s.ID,
 		UserID:          s.UserID,
 		SplitDayID:      s.SplitDayID,
+		PlanID:          s.PlanID,
+		Status:          s.Status,
 		SessionDate:     s.SessionDate.Format("2006-01-02"),
 		DurationMinutes: s.DurationMin,
 		Notes:           s.Notes,
@@ -66,6 +74,34 @@ func FromDomainWorkoutSession(s workout.WorkoutSession) WorkoutSessionResponseDT
 	return out
 }
 
+// FromDomainWorkoutSessionWithRecords flags the sets that produced a record
+// and lists the records the session set.
+func FromDomainWorkoutSessionWithRecords(s workout.WorkoutSession, records []record.PersonalRecord) WorkoutSessionResponseDTO {
+	out := FromDomainWorkoutSession(s)
+	if len(records) == 0 {
+		return out
+	}
+
+	bySet := make(map[string][]string)
+	for _, r := range records {
+		if r.WorkoutSetID != nil {
+			bySet[*r.WorkoutSetID] = append(bySet[*r.WorkoutSetID], string(r.Type))
+		}
+	}
+
+	for i := range out.Exercises {
+		for j := range out.Exercises[i].Sets {
+			set := &out.Exercises[i].Sets[j]
+			if types, ok := bySet[set.ID]; ok {
+				set.IsPR = true
+				set.PRTypes = types
+			}
+		}
+	}
+	out.PersonalRecords = FromDomainPersonalRecords(records)
+	return out
+}
+
 func FromDomainWorkoutSessions(items []workout.WorkoutSession) []WorkoutSessionResponseDTO {
 	out := make([]WorkoutSessionResponseDTO, 0, len(items))
 	for _, item := range items {
@@ -73,3 +109,8 @@ func FromDomainWorkoutSessions(items []workout.WorkoutSession) []WorkoutSessionR
 	}
 	return out
 }
+
+type PageMetaDTO struct {
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+}
@@ -5,17 +5,19 @@ import (
 
 	"S.P.A.R.T.A/backend/internal/delivery/http/dto"
 	"S.P.A.R.T.A/backend/internal/delivery/http/response"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/aicall"
 	domainuc "S.P.A.R.T.A/backend/internal/domain/usecase"
 	"S.P.A.R.T.A/backend/pkg/validator"
 	"github.com/gin-gonic/gin"
 )
 
 type AdminHandler struct {
-	uc domainuc.AdminUsecase
+	uc      domainuc.AdminUsecase
+	aiAudit domainuc.AIAuditUsecase
 }
 
-func NewAdminHandler(uc domainuc.AdminUsecase) *AdminHandler {
-	return &AdminHandler{uc: uc}
+func NewAdminHandler(uc domainuc.AdminUsecase, aiAudit domainuc.AIAuditUsecase) *AdminHandler {
+	return &AdminHandler{uc: uc, aiAudit: aiAudit}
 }
 
 func (h *AdminHandler) CreateInvite(c *gin.Context) {
@@ -43,3 +45,63 @@ func (h *AdminHandler) CreateInvite(c *gin.Context) {
 
 	response.Created(c, res)
 }
+
+func (h *AdminHandler) ListAICalls(c *gin.Context) {
+	var q dto.ListAICallsQueryDTO
+	if err := c.ShouldBindQuery(&q); err != nil {
+		response.BadRequest(c, "invalid query")
+		return
+	}
+	if err := validator.ValidateStruct(&q); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	filter, err := dto.ToDomainAICallFilter(q)
+	if err != nil {
+		response.BadRequest(c, "invalid date format (expected YYYY-MM-DD)")
+		return
+	}
+
+	page, err := h.aiAudit.ListCalls(c.Request.Context(), filter)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.SuccessWithMeta(c, dto.FromDomainAICalls(page.Items), dto.PageMetaDTO{
+		NextCursor: page.NextCursor,
+		HasMore:    page.NextCursor != "",
+	})
+}
+
+func (h *AdminHandler) GetAICallStats(c *gin.Context) {
+	var q dto.AICallStatsQueryDTO
+	if err := c.ShouldBindQuery(&q); err != nil {
+		response.BadRequest(c, "invalid query")
+		return
+	}
+	if err := validator.ValidateStruct(&q); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	groupBy, ok := aicall.ParseGroupBy(q.GroupBy)
+	if !ok {
+		response.BadRequest(c, "group_by must be one of day, operation, user, model")
+		return
+	}
+	filter, err := dto.ToDomainAICallStatsFilter(q)
+	if err != nil {
+		response.BadRequest(c, "invalid date format (expected YYYY-MM-DD)")
+		return
+	}
+
+	stats, err := h.aiAudit.GetCallStats(c.Request.Context(), filter, groupBy)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, dto.FromDomainAICallStats(stats))
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"context"
+
+	"S.P.A.R.T.A/backend/internal/delivery/http/dto"
+	"S.P.A.R.T.A/backend/internal/delivery/http/response"
+	domainuc "S.P.A.R.T.A/backend/internal/domain/usecase"
+	"S.P.A.R.T.A/backend/pkg/validator"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AgentHandler struct {
+	uc domainuc.AgentUsecase
+}
+
+func NewAgentHandler(uc domainuc.AgentUsecase) *AgentHandler {
+	return &AgentHandler{uc: uc}
+}
+
+func (h *AgentHandler) Run(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		response.BadRequest(c, "invalid user id")
+		return
+	}
+
+	var req dto.RunAgentDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid body")
+		return
+	}
+	if err := validator.ValidateStruct(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	res, err := h.uc.Run(c.Request.Context(), userID, req.Message)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, dto.FromDomainAgentResult(*res))
+}
+
+func (h *AgentHandler) GetAction(c *gin.Context) {
+	action, err := h.uc.GetAction(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, dto.FromDomainAgentAction(*action))
+}
+
+func (h *AgentHandler) ConfirmAction(c *gin.Context) {
+	action, err := h.uc.ConfirmAction(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, dto.FromDomainAgentAction(*action))
+}
+
+func (h *AgentHandler) RejectAction(c *gin.Context) {
+	action, err := h.uc.RejectAction(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, dto.FromDomainAgentAction(*action))
+}
+
+// ActionOwner resolves the owner of an agent action for the ownership middleware.
+func (h *AgentHandler) ActionOwner(ctx context.Context, id string) (string, error) {
+	return h.uc.GetActionOwner(ctx, id)
+}
@@ -5,6 +5,7 @@ import (
 
 	"S.P.A.R.T.A/backend/internal/delivery/http/dto"
 	"S.P.A.R.T.A/backend/internal/delivery/http/response"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/split"
 	"S.P.A.R.T.A/backend/internal/domain/aggregate/workout"
 	domainuc "S.P.A.R.T.A/backend/internal/domain/usecase"
 	"S.P.A.R.T.A/backend/pkg/validator"
@@ -42,18 +43,29 @@ func (h *AICoachHandler) GenerateSplit(c *gin.Context) {
 		return
 	}
 
-	result, err := h.usecase.GenerateSplitTemplate(
+	result, unresolved, err := h.usecase.GenerateSplitTemplate(
 		c.Request.Context(),
 		userID,
 		req.DaysPerWeek,
 		req.FocusMuscle,
+		split.ConstraintsOverride{
+			ExperienceLevel:   req.ExperienceLevel,
+			Equipment:         req.Equipment,
+			MaxSessionMinutes: req.MaxSessionMinutes,
+			Injuries:          req.Injuries,
+			ExcludedMovements: req.ExcludedMovements,
+			PreferredDays:     req.PreferredDays,
+		},
 	)
 	if err != nil {
 		response.Error(c, err)
 		return
 	}
 
-	response.Success(c, dto.FromDomainSplitTemplate(*result))
+	out := dto.FromDomainSplitTemplate(*result)
+	out.Source = result.CreatedBy
+	out.UnresolvedExercises = dto.FromDomainUnresolvedExercises(unresolved)
+	response.Success(c, out)
 }
 
 func (h *AICoachHandler) SuggestOverload(c *gin.Context) {
@@ -104,7 +116,7 @@ func (h *AICoachHandler) GetDailyMotivation(c *gin.Context) {
 		return
 	}
 
-	msg, err := h.usecase.GetDailyMotivation(c.Request.Context(), userID)
+	motivation, err := h.usecase.GetDailyMotivation(c.Request.Context(), userID)
 	if err != nil {
 		response.Error(c, err)
 		return
@@ -112,7 +124,8 @@ func (h *AICoachHandler) GetDailyMotivation(c *gin.Context) {
 
 	response.Success(c, dto.DailyMotivationResponseDTO{
 		Date:    time.Now().UTC().Format("2006-01-02"),
-		Message: msg,
+		Message: motivation.Message,
+		Source:  string(motivation.Source),
 	})
 }
 
@@ -176,7 +189,24 @@ func (h *AICoachHandler) GetCoachingSuggestions(c *gin.Context) {
 		return
 	}
 
-	suggestions, err := h.usecase.GetCoachingSuggestions(c.Request.Context(), userID)
+	if response.WantsStream(c) {
+		stream := response.NewEventStream(c)
+		coaching, err := h.usecase.StreamCoachingSuggestions(c.Request.Context(), userID, func(delta string) error {
+			return stream.Send("delta", gin.H{"content": delta})
+		})
+		if err != nil {
+			stream.Fail(err)
+			return
+		}
+		stream.Result(dto.CoachingSuggestionsResponseDTO{
+			Date:        time.Now().UTC().Format("2006-01-02"),
+			Suggestions: coaching.Suggestions,
+			Source:      string(coaching.Source),
+		})
+		return
+	}
+
+	coaching, err := h.usecase.GetCoachingSuggestions(c.Request.Context(), userID)
 	if err != nil {
 		response.Error(c, err)
 		return
@@ -184,7 +214,8 @@ func (h *AICoachHandler) GetCoachingSuggestions(c *gin.Context) {
 
 	response.Success(c, dto.CoachingSuggestionsResponseDTO{
 		Date:        time.Now().UTC().Format("2006-01-02"),
-		Suggestions: suggestions,
+		Suggestions: coaching.Suggestions,
+		Source:      string(coaching.Source),
 	})
 }
 
@@ -219,16 +250,32 @@ func (h *AICoachHandler) ExplainWorkoutPlan(c *gin.Context) {
 		})
 	}
 
+	if response.WantsStream(c) {
+		stream := response.NewEventStream(c)
+		expl, err := h.usecase.StreamExplainWorkoutPlan(c.Request.Context(), userID, plan, req.SplitDayName, req.Fatigue, func(delta string) error {
+			return stream.Send("delta", gin.H{"content": delta})
+		})
+		if err != nil {
+			stream.Fail(err)
+			return
+		}
+		stream.Result(toWorkoutExplanationDTO(*expl))
+		return
+	}
+
 	expl, err := h.usecase.ExplainWorkoutPlan(c.Request.Context(), userID, plan, req.SplitDayName, req.Fatigue)
 	if err != nil {
 		response.Error(c, err)
 		return
 	}
 
-	out := dto.WorkoutExplanationResponseDTO{Summary: expl.Summary}
+	response.Success(c, toWorkoutExplanationDTO(*expl))
+}
+
+func toWorkoutExplanationDTO(expl workout.WorkoutExplanation) dto.WorkoutExplanationResponseDTO {
+	out := dto.WorkoutExplanationResponseDTO{Summary: expl.Summary, Source: string(expl.Source)}
 	for _, n := range expl.ExerciseNotes {
 		out.ExerciseNotes = append(out.ExerciseNotes, dto.WorkoutExplanationExerciseNoteDTO{Name: n.Name, Note: n.Note})
 	}
-
-	response.Success(c, out)
+	return out
 }
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/delivery/http/dto"
+	"S.P.A.R.T.A/backend/internal/delivery/http/response"
+	"S.P.A.R.T.A/backend/internal/domain/service/training"
+	domainuc "S.P.A.R.T.A/backend/internal/domain/usecase"
+	"S.P.A.R.T.A/backend/pkg/validator"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AnalyticsHandler struct {
+	uc domainuc.AnalyticsUsecase
+}
+
+func NewAnalyticsHandler(uc domainuc.AnalyticsUsecase) *AnalyticsHandler {
+	return &AnalyticsHandler{uc: uc}
+}
+
+func (h *AnalyticsHandler) GetExerciseProgression(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		response.BadRequest(c, "invalid user id")
+		return
+	}
+
+	exerciseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "invalid exercise id")
+		return
+	}
+
+	var q dto.ExerciseProgressionQueryDTO
+	if err := c.ShouldBindQuery(&q); err != nil {
+		response.BadRequest(c, "invalid query")
+		return
+	}
+	if err := validator.ValidateStruct(&q); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	var from, to time.Time
+	if q.From != "" {
+		if from, err = time.Parse("2006-01-02", q.From); err != nil {
+			response.BadRequest(c, "invalid date format (expected YYYY-MM-DD)")
+			return
+		}
+	}
+	if q.To != "" {
+		if to, err = time.Parse("2006-01-02", q.To); err != nil {
+			response.BadRequest(c, "invalid date format (expected YYYY-MM-DD)")
+			return
+		}
+	}
+
+	bucket, _ := training.ParseBucket(q.Bucket)
+
+	res, err := h.uc.GetExerciseProgression(
+		c.Request.Context(),
+		userID.String(),
+		exerciseID.String(),
+		from,
+		to,
+		bucket,
+		training.OneRepMaxFormula(q.Formula),
+	)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, dto.FromExerciseProgression(*res))
+}
+
+func (h *AnalyticsHandler) GetMuscleVolume(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		response.BadRequest(c, "invalid user id")
+		return
+	}
+
+	var q dto.MuscleVolumeQueryDTO
+	if err := c.ShouldBindQuery(&q); err != nil {
+		response.BadRequest(c, "invalid query")
+		return
+	}
+
+	var from, to time.Time
+	if q.From != "" {
+		if from, err = time.Parse("2006-01-02", q.From); err != nil {
+			response.BadRequest(c, "invalid date format (expected YYYY-MM-DD)")
+			return
+		}
+	}
+	if q.To != "" {
+		if to, err = time.Parse("2006-01-02", q.To); err != nil {
+			response.BadRequest(c, "invalid date format (expected YYYY-MM-DD)")
+			return
+		}
+	}
+
+	res, err := h.uc.GetMuscleVolumeReport(c.Request.Context(), userID.String(), from, to)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, dto.FromMuscleVolumeReport(*res))
+}
@@ -1,8 +1,12 @@
 package handler
 
 import (
+	"context"
+
 	"S.P.A.R.T.A/backend/internal/delivery/http/dto"
+	"S.P.A.R.T.A/backend/internal/delivery/http/middleware"
 	"S.P.A.R.T.A/backend/internal/delivery/http/response"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/auth"
 	domainuc "S.P.A.R.T.A/backend/internal/domain/usecase"
 	"S.P.A.R.T.A/backend/pkg/validator"
 	"github.com/gin-gonic/gin"
@@ -27,7 +31,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
-	res, err := h.uc.Register(c.Request.Context(), req.Name, req.Email, req.Password, req.InviteToken)
+	res, err := h.uc.Register(c.Request.Context(), req.Name, req.Email, req.Password, req.InviteToken, clientOf(c))
 	if err != nil {
 		response.Error(c, err)
 		return
@@ -47,7 +51,175 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	res, err := h.uc.Login(c.Request.Context(), req.Email, req.Password)
+	res, err := h.uc.Login(c.Request.Context(), req.Email, req.Password, clientOf(c))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, res)
+}
+
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req dto.RefreshRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid body")
+		return
+	}
+	if err := validator.ValidateStruct(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	res, err := h.uc.Refresh(c.Request.Context(), req.RefreshToken)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, res)
+}
+
+// Logout signs out the session the access token belongs to, or every
+// session with {"all": true}. The body is optional.
+func (h *AuthHandler) Logout(c *gin.Context) {
+	var req dto.LogoutRequestDTO
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.BadRequest(c, "invalid body")
+			return
+		}
+	}
+
+	var err error
+	if req.All {
+		err = h.uc.RevokeAllSessions(c.Request.Context(), middleware.GetUserID(c))
+	} else {
+		err = h.uc.RevokeSession(c.Request.Context(), middleware.GetSessionID(c))
+	}
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"logged_out": true})
+}
+
+func (h *AuthHandler) ListSessions(c *gin.Context) {
+	sessions, err := h.uc.ListSessions(c.Request.Context(), middleware.GetUserID(c))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, dto.FromDomainAuthSessions(sessions, middleware.GetSessionID(c)))
+}
+
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	if err := h.uc.RevokeSession(c.Request.Context(), c.Param("id")); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"revoked": true})
+}
+
+// ForgotPassword always answers the same way, whether or not the email
+// belongs to an account.
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	var req dto.ForgotPasswordRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid body")
+		return
+	}
+	if err := validator.ValidateStruct(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.uc.RequestPasswordReset(c.Request.Context(), req.Email); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"requested": true})
+}
+
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req dto.ResetPasswordRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid body")
+		return
+	}
+	if err := validator.ValidateStruct(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.uc.ResetPassword(c.Request.Context(), req.Token, req.Password); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"reset": true})
+}
+
+func (h *AuthHandler) VerifyEmail(c *gin.Context) {
+	var req dto.VerifyEmailRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid body")
+		return
+	}
+	if err := validator.ValidateStruct(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	if err := h.uc.VerifyEmail(c.Request.Context(), req.Token); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"verified": true})
+}
+
+func (h *AuthHandler) ResendVerification(c *gin.Context) {
+	if err := h.uc.SendVerificationEmail(c.Request.Context(), middleware.GetUserID(c)); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"sent": true})
+}
+
+func (h *AuthHandler) OAuthProviders(c *gin.Context) {
+	response.Success(c, gin.H{"providers": h.uc.OAuthProviders()})
+}
+
+func (h *AuthHandler) StartOAuth(c *gin.Context) {
+	authURL, state, err := h.uc.StartOAuth(c.Request.Context(), c.Param("provider"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, dto.OAuthStartResponseDTO{URL: authURL, State: state})
+}
+
+// CompleteOAuth takes the code and state the provider redirected the
+// browser back with, and answers like Login.
+func (h *AuthHandler) CompleteOAuth(c *gin.Context) {
+	var req dto.OAuthCallbackRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid body")
+		return
+	}
+	if err := validator.ValidateStruct(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	res, err := h.uc.CompleteOAuth(c.Request.Context(), c.Param("provider"), req.Code, req.State, clientOf(c))
 	if err != nil {
 		response.Error(c, err)
 		return
@@ -55,3 +227,13 @@ func (h *AuthHandler) Login(c *gin.Context) {
 
 	response.Success(c, res)
 }
+
+// SessionOwner resolves the owner of a sign-in session for the ownership
+// middleware.
+func (h *AuthHandler) SessionOwner(ctx context.Context, id string) (string, error) {
+	return h.uc.GetSessionOwner(ctx, id)
+}
+
+func clientOf(c *gin.Context) auth.Client {
+	return auth.Client{UserAgent: c.Request.UserAgent(), IP: c.ClientIP()}
+}
@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"context"
+	"io"
+
+	"S.P.A.R.T.A/backend/internal/delivery/http/dto"
+	"S.P.A.R.T.A/backend/internal/delivery/http/middleware"
+	"S.P.A.R.T.A/backend/internal/delivery/http/response"
+	domainuc "S.P.A.R.T.A/backend/internal/domain/usecase"
+	"S.P.A.R.T.A/backend/pkg/validator"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ChatHandler struct {
+	uc domainuc.ChatUsecase
+}
+
+func NewChatHandler(uc domainuc.ChatUsecase) *ChatHandler {
+	return &ChatHandler{uc: uc}
+}
+
+func (h *ChatHandler) CreateThread(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		response.BadRequest(c, "invalid user id")
+		return
+	}
+
+	// The body is optional: an empty request creates an untitled thread.
+	var req dto.CreateChatThreadDTO
+	if err := c.ShouldBindJSON(&req); err != nil && err != io.EOF {
+		response.BadRequest(c, "invalid body")
+		return
+	}
+	if err := validator.ValidateStruct(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	thread, err := h.uc.CreateThread(c.Request.Context(), userID, req.Title)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Created(c, dto.FromDomainChatThread(*thread))
+}
+
+func (h *ChatHandler) ListThreads(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		response.BadRequest(c, "invalid user id")
+		return
+	}
+
+	threads, err := h.uc.ListThreads(c.Request.Context(), userID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, dto.FromDomainChatThreads(threads))
+}
+
+func (h *ChatHandler) GetThread(c *gin.Context) {
+	thread, err := h.uc.GetThread(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, dto.FromDomainChatThread(*thread))
+}
+
+func (h *ChatHandler) DeleteThread(c *gin.Context) {
+	if err := h.uc.DeleteThread(c.Request.Context(), c.Param("id")); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"deleted": true})
+}
+
+func (h *ChatHandler) ListMessages(c *gin.Context) {
+	messages, err := h.uc.ListMessages(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, dto.FromDomainChatMessages(messages))
+}
+
+func (h *ChatHandler) SendMessage(c *gin.Context) {
+	// Answer with the thread owner's data so that admin access does not
+	// re-scope the context.
+	userID, err := uuid.Parse(middleware.GetResourceOwnerID(c))
+	if err != nil {
+		response.BadRequest(c, "invalid user id")
+		return
+	}
+
+	var req dto.SendChatMessageDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid body")
+		return
+	}
+	if err := validator.ValidateStruct(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	reply, err := h.uc.SendMessage(c.Request.Context(), userID, c.Param("id"), req.Content)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Created(c, dto.FromDomainChatMessage(*reply))
+}
+
+// ThreadOwner resolves the owner of a chat thread for the ownership middleware.
+func (h *ChatHandler) ThreadOwner(ctx context.Context, id string) (string, error) {
+	return h.uc.GetThreadOwner(ctx, id)
+}
@@ -4,7 +4,7 @@ import (
 	"S.P.A.R.T.A/backend/internal/delivery/http/dto"
 	"S.P.A.R.T.A/backend/internal/delivery/http/middleware"
 	"S.P.A.R.T.A/backend/internal/delivery/http/response"
-	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+	"S.P.A.R.T.A/backend/internal/domain/service/authz"
 	domainuc "S.P.A.R.T.A/backend/internal/domain/usecase"
 	"S.P.A.R.T.A/backend/pkg/validator"
 	"github.com/gin-gonic/gin"
@@ -31,9 +31,8 @@ func (h *NutritionHandler) UpsertDailyNutrition(c *gin.Context) {
 		return
 	}
 
-	authedUserID := middleware.GetUserID(c)
-	if authedUserID != "" && req.UserID != authedUserID {
-		response.Error(c, domainerr.ErrForbidden)
+	if err := authz.CanAccess(middleware.GetPrincipal(c), req.UserID); err != nil {
+		response.Error(c, err)
 		return
 	}
 
@@ -52,12 +51,7 @@ func (h *NutritionHandler) UpsertDailyNutrition(c *gin.Context) {
 }
 
 func (h *NutritionHandler) GetDailyNutrition(c *gin.Context) {
-	userID := c.Param("user_id")
-	authedUserID := middleware.GetUserID(c)
-	if authedUserID != "" && userID != authedUserID {
-		response.Error(c, domainerr.ErrForbidden)
-		return
-	}
+	userID := middleware.GetResourceOwnerID(c)
 	date := c.Query("date")
 
 	res, err := h.uc.GetByDate(c.Request.Context(), userID, date)
@@ -4,7 +4,6 @@ import (
 	"S.P.A.R.T.A/backend/internal/delivery/http/dto"
 	"S.P.A.R.T.A/backend/internal/delivery/http/middleware"
 	"S.P.A.R.T.A/backend/internal/delivery/http/response"
-	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
 	domainuc "S.P.A.R.T.A/backend/internal/domain/usecase"
 	"github.com/gin-gonic/gin"
 )
@@ -18,12 +17,7 @@ func NewPlannerHandler(uc domainuc.PlannerUsecase) *PlannerHandler {
 }
 
 func (h *PlannerHandler) GenerateRecommendation(c *gin.Context) {
-	userID := c.Param("user_id")
-	authedUserID := middleware.GetUserID(c)
-	if authedUserID != "" && userID != authedUserID {
-		response.Error(c, domainerr.ErrForbidden)
-		return
-	}
+	userID := middleware.GetResourceOwnerID(c)
 
 	res, err := h.uc.GenerateRecommendation(c.Request.Context(), userID)
 	if err != nil {
@@ -35,12 +29,7 @@ func (h *PlannerHandler) GenerateRecommendation(c *gin.Context) {
 }
 
 func (h *PlannerHandler) GetUserRecommendations(c *gin.Context) {
-	userID := c.Param("user_id")
-	authedUserID := middleware.GetUserID(c)
-	if authedUserID != "" && userID != authedUserID {
-		response.Error(c, domainerr.ErrForbidden)
-		return
-	}
+	userID := middleware.GetResourceOwnerID(c)
 
 	res, err := h.uc.GetUserRecommendations(c.Request.Context(), userID)
 	if err != nil {
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"S.P.A.R.T.A/backend/internal/delivery/http/dto"
+	"S.P.A.R.T.A/backend/internal/delivery/http/response"
+	domainuc "S.P.A.R.T.A/backend/internal/domain/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type RecordHandler struct {
+	uc domainuc.PersonalRecordUsecase
+}
+
+func NewRecordHandler(uc domainuc.PersonalRecordUsecase) *RecordHandler {
+	return &RecordHandler{uc: uc}
+}
+
+func (h *RecordHandler) GetMyRecords(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		response.BadRequest(c, "invalid user id")
+		return
+	}
+
+	res, err := h.uc.GetUserRecords(c.Request.Context(), userID.String())
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, dto.FromDomainPersonalRecords(res))
+}
+
+func (h *RecordHandler) GetExerciseRecords(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		response.BadRequest(c, "invalid user id")
+		return
+	}
+
+	exerciseID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.BadRequest(c, "invalid exercise id")
+		return
+	}
+
+	res, err := h.uc.GetExerciseRecords(c.Request.Context(), userID.String(), exerciseID.String())
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, dto.FromDomainPersonalRecords(res))
+}
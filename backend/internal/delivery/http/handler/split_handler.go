@@ -1,14 +1,15 @@
 package handler
 
 import (
+	"context"
+
 	"S.P.A.R.T.A/backend/internal/delivery/http/dto"
 	"S.P.A.R.T.A/backend/internal/delivery/http/middleware"
 	"S.P.A.R.T.A/backend/internal/delivery/http/response"
-	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+	"S.P.A.R.T.A/backend/internal/domain/service/authz"
 	domainuc "S.P.A.R.T.A/backend/internal/domain/usecase"
 	"S.P.A.R.T.A/backend/pkg/validator"
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 )
 
 type SplitHandler struct {
@@ -31,9 +32,8 @@ func (h *SplitHandler) CreateTemplate(c *gin.Context) {
 		return
 	}
 
-	authedUserID := middleware.GetUserID(c)
-	if authedUserID != "" && req.UserID != authedUserID {
-		response.Error(c, domainerr.ErrForbidden)
+	if err := authz.CanAccess(middleware.GetPrincipal(c), req.UserID); err != nil {
+		response.Error(c, err)
 		return
 	}
 
@@ -48,12 +48,7 @@ func (h *SplitHandler) CreateTemplate(c *gin.Context) {
 }
 
 func (h *SplitHandler) GetUserTemplates(c *gin.Context) {
-	userID := c.Param("user_id")
-	authedUserID := middleware.GetUserID(c)
-	if authedUserID != "" && userID != authedUserID {
-		response.Error(c, domainerr.ErrForbidden)
-		return
-	}
+	userID := middleware.GetResourceOwnerID(c)
 
 	res, err := h.uc.GetUserTemplates(c.Request.Context(), userID)
 	if err != nil {
@@ -73,27 +68,13 @@ func (h *SplitHandler) GetTemplate(c *gin.Context) {
 		return
 	}
 
-	authedUserID := middleware.GetUserID(c)
-	if authedUserID != "" && tpl.UserID != authedUserID {
-		response.Error(c, domainerr.ErrForbidden)
-		return
-	}
-
 	response.Success(c, dto.FromDomainSplitTemplate(*tpl))
 }
 
 func (h *SplitHandler) UpdateTemplate(c *gin.Context) {
 	templateID := c.Param("id")
-	if _, err := uuid.Parse(templateID); err != nil {
-		response.BadRequest(c, "invalid template id")
-		return
-	}
-
-	userID := middleware.GetUserID(c)
-	if userID == "" {
-		response.Error(c, domainerr.ErrUnauthorized)
-		return
-	}
+	// Act on the template's owner so that admin access does not re-scope it.
+	userID := middleware.GetResourceOwnerID(c)
 
 	var req dto.UpdateSplitTemplateDTO
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -116,16 +97,8 @@ func (h *SplitHandler) UpdateTemplate(c *gin.Context) {
 
 func (h *SplitHandler) ActivateTemplate(c *gin.Context) {
 	templateID := c.Param("id")
-	if _, err := uuid.Parse(templateID); err != nil {
-		response.BadRequest(c, "invalid template id")
-		return
-	}
-
-	userID := middleware.GetUserID(c)
-	if userID == "" {
-		response.Error(c, domainerr.ErrUnauthorized)
-		return
-	}
+	// Act on the template's owner so that admin access does not re-scope it.
+	userID := middleware.GetResourceOwnerID(c)
 
 	if err := h.uc.ActivateTemplate(c.Request.Context(), userID, templateID); err != nil {
 		response.Error(c, err)
@@ -137,16 +110,8 @@ func (h *SplitHandler) ActivateTemplate(c *gin.Context) {
 
 func (h *SplitHandler) DeactivateTemplate(c *gin.Context) {
 	templateID := c.Param("id")
-	if _, err := uuid.Parse(templateID); err != nil {
-		response.BadRequest(c, "invalid template id")
-		return
-	}
-
-	userID := middleware.GetUserID(c)
-	if userID == "" {
-		response.Error(c, domainerr.ErrUnauthorized)
-		return
-	}
+	// Act on the template's owner so that admin access does not re-scope it.
+	userID := middleware.GetResourceOwnerID(c)
 
 	if err := h.uc.DeactivateTemplate(c.Request.Context(), userID, templateID); err != nil {
 		response.Error(c, err)
@@ -155,3 +120,8 @@ func (h *SplitHandler) DeactivateTemplate(c *gin.Context) {
 
 	response.Success(c, gin.H{"deactivated": true})
 }
+
+// TemplateOwner resolves the owner of a split template for the ownership middleware.
+func (h *SplitHandler) TemplateOwner(ctx context.Context, id string) (string, error) {
+	return h.uc.GetTemplateOwner(ctx, id)
+}
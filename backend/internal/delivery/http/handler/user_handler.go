@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"S.P.A.R.T.A/backend/internal/delivery/http/dto"
+	"S.P.A.R.T.A/backend/internal/delivery/http/response"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/user"
+	domainuc "S.P.A.R.T.A/backend/internal/domain/usecase"
+	"S.P.A.R.T.A/backend/pkg/validator"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type UserHandler struct {
+	uc domainuc.UserUsecase
+}
+
+func NewUserHandler(uc domainuc.UserUsecase) *UserHandler {
+	return &UserHandler{uc: uc}
+}
+
+func (h *UserHandler) GetPreferences(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		response.BadRequest(c, "invalid user id")
+		return
+	}
+
+	res, err := h.uc.GetPreferences(c.Request.Context(), userID.String())
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, dto.FromDomainPreferences(*res))
+}
+
+func (h *UserHandler) UpdatePreferences(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		response.BadRequest(c, "invalid user id")
+		return
+	}
+
+	var req dto.UpdatePreferencesDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid body")
+		return
+	}
+	if err := validator.ValidateStruct(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	res, err := h.uc.UpdatePreferences(c.Request.Context(), userID.String(), user.PreferencesPatch{
+		LoadModel:     req.LoadModel,
+		BodyWeightKg:  req.BodyWeightKg,
+		CoachPersona:  req.CoachPersona,
+		CustomPersona: req.CustomPersona,
+		Language:      req.Language,
+		Profanity:     req.Profanity,
+
+		ExperienceLevel:   req.ExperienceLevel,
+		Equipment:         req.Equipment,
+		MaxSessionMinutes: req.MaxSessionMinutes,
+		Injuries:          req.Injuries,
+		ExcludedMovements: req.ExcludedMovements,
+		PreferredDays:     req.PreferredDays,
+	})
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, dto.FromDomainPreferences(*res))
+}
@@ -1,14 +1,17 @@
 package handler
 
 import (
+	"context"
+
 	"S.P.A.R.T.A/backend/internal/delivery/http/dto"
 	"S.P.A.R.T.A/backend/internal/delivery/http/middleware"
 	"S.P.A.R.T.A/backend/internal/delivery/http/response"
-	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+	"S.P.A.R.T.A/backend/internal/domain/service/authz"
 	domainuc "S.P.A.R.T.A/backend/internal/domain/usecase"
 	"S.P.A.R.T.A/backend/pkg/validator"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 type WorkoutHandler struct {
@@ -32,20 +35,20 @@ func (h *WorkoutHandler) CreateWorkoutSession(c *gin.Context) {
 		return
 	}
 
-	authedUserID := middleware.GetUserID(c)
-	if authedUserID != "" && req.UserID != authedUserID {
-		response.Error(c, domainerr.ErrForbidden)
+	if err := authz.CanAccess(middleware.GetPrincipal(c), req.UserID); err != nil {
+		response.Error(c, err)
 		return
 	}
 
 	domainSession := dto.ToDomainWorkoutSession(req)
 
-	if err := h.workoutUC.CreateWorkoutSession(c.Request.Context(), &domainSession); err != nil {
+	records, err := h.workoutUC.CreateWorkoutSession(c.Request.Context(), &domainSession)
+	if err != nil {
 		response.Error(c, err)
 		return
 	}
 
-	response.Created(c, dto.FromDomainWorkoutSession(domainSession))
+	response.Created(c, dto.FromDomainWorkoutSessionWithRecords(domainSession, records))
 }
 
 func (h *WorkoutHandler) GetWorkoutSession(c *gin.Context) {
@@ -61,18 +64,188 @@ func (h *WorkoutHandler) GetWorkoutSession(c *gin.Context) {
 }
 
 func (h *WorkoutHandler) GetUserWorkoutSessions(c *gin.Context) {
-	userID := c.Param("user_id")
-	authedUserID := middleware.GetUserID(c)
-	if authedUserID != "" && userID != authedUserID {
-		response.Error(c, domainerr.ErrForbidden)
+	userID := middleware.GetResourceOwnerID(c)
+
+	var q dto.ListWorkoutSessionsQueryDTO
+	if err := c.ShouldBindQuery(&q); err != nil {
+		response.BadRequest(c, "invalid query")
+		return
+	}
+	if err := validator.ValidateStruct(&q); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	filter, err := dto.ToDomainSessionFilter(q)
+	if err != nil {
+		response.BadRequest(c, "invalid date format (expected YYYY-MM-DD)")
+		return
+	}
+
+	page, err := h.workoutUC.GetUserWorkoutSessions(c.Request.Context(), userID, filter)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.SuccessWithMeta(c, dto.FromDomainWorkoutSessions(page.Items), dto.PageMetaDTO{
+		NextCursor: page.NextCursor,
+		HasMore:    page.NextCursor != "",
+	})
+}
+
+func (h *WorkoutHandler) ReplaceWorkoutSession(c *gin.Context) {
+	id := c.Param("id")
+
+	var req dto.UpdateWorkoutSessionDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid body")
+		return
+	}
+	if err := validator.ValidateStruct(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	domainSession, err := dto.ToDomainWorkoutSessionForUpdate(id, req)
+	if err != nil {
+		response.BadRequest(c, "invalid date format (expected YYYY-MM-DD)")
+		return
+	}
+
+	result, err := h.workoutUC.ReplaceWorkoutSession(c.Request.Context(), &domainSession)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, dto.FromDomainWorkoutSession(*result))
+}
+
+func (h *WorkoutHandler) PatchWorkoutSession(c *gin.Context) {
+	id := c.Param("id")
+
+	var req dto.PatchWorkoutSessionDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid body")
 		return
 	}
+	if err := validator.ValidateStruct(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	patch, err := dto.ToDomainWorkoutSessionPatch(req)
+	if err != nil {
+		response.BadRequest(c, "invalid date format (expected YYYY-MM-DD)")
+		return
+	}
+
+	result, err := h.workoutUC.PatchWorkoutSession(c.Request.Context(), id, patch)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, dto.FromDomainWorkoutSession(*result))
+}
 
-	result, err := h.workoutUC.GetUserWorkoutSessions(c.Request.Context(), userID)
+// FinishWorkoutSession completes a draft session started from a plan.
+func (h *WorkoutHandler) FinishWorkoutSession(c *gin.Context) {
+	session, records, err := h.workoutUC.FinishWorkoutSession(c.Request.Context(), c.Param("id"))
 	if err != nil {
 		response.Error(c, err)
 		return
 	}
 
-	response.Success(c, dto.FromDomainWorkoutSessions(result))
+	response.Success(c, dto.FromDomainWorkoutSessionWithRecords(*session, records))
+}
+
+func (h *WorkoutHandler) DeleteWorkoutSession(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := h.workoutUC.DeleteWorkoutSession(c.Request.Context(), id); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, gin.H{"deleted": true})
+}
+
+func (h *WorkoutHandler) AddWorkoutSet(c *gin.Context) {
+	id := c.Param("id")
+	workoutExerciseID := c.Param("workout_exercise_id")
+	if _, err := uuid.Parse(workoutExerciseID); err != nil {
+		response.BadRequest(c, "invalid workout exercise id")
+		return
+	}
+
+	var req dto.WorkoutSetDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid body")
+		return
+	}
+	if err := validator.ValidateStruct(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	set := dto.ToDomainWorkoutSet(uuid.NewString(), req)
+	result, err := h.workoutUC.AddWorkoutSet(c.Request.Context(), id, workoutExerciseID, &set)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Created(c, dto.FromDomainWorkoutSession(*result))
+}
+
+func (h *WorkoutHandler) UpdateWorkoutSet(c *gin.Context) {
+	id := c.Param("id")
+	setID := c.Param("set_id")
+	if _, err := uuid.Parse(setID); err != nil {
+		response.BadRequest(c, "invalid set id")
+		return
+	}
+
+	var req dto.WorkoutSetDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid body")
+		return
+	}
+	if err := validator.ValidateStruct(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	set := dto.ToDomainWorkoutSet(setID, req)
+	result, err := h.workoutUC.UpdateWorkoutSet(c.Request.Context(), id, &set)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, dto.FromDomainWorkoutSession(*result))
+}
+
+func (h *WorkoutHandler) DeleteWorkoutSet(c *gin.Context) {
+	id := c.Param("id")
+	setID := c.Param("set_id")
+	if _, err := uuid.Parse(setID); err != nil {
+		response.BadRequest(c, "invalid set id")
+		return
+	}
+
+	result, err := h.workoutUC.DeleteWorkoutSet(c.Request.Context(), id, setID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, dto.FromDomainWorkoutSession(*result))
+}
+
+// SessionOwner resolves the owner of a workout session for the ownership middleware.
+func (h *WorkoutHandler) SessionOwner(ctx context.Context, id string) (string, error) {
+	return h.workoutUC.GetWorkoutSessionOwner(ctx, id)
 }
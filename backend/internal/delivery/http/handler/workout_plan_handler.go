@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"context"
+
+	"S.P.A.R.T.A/backend/internal/delivery/http/dto"
+	"S.P.A.R.T.A/backend/internal/delivery/http/response"
+	domainuc "S.P.A.R.T.A/backend/internal/domain/usecase"
+	"S.P.A.R.T.A/backend/pkg/validator"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type WorkoutPlanHandler struct {
+	uc domainuc.WorkoutPlanUsecase
+}
+
+func NewWorkoutPlanHandler(uc domainuc.WorkoutPlanUsecase) *WorkoutPlanHandler {
+	return &WorkoutPlanHandler{uc: uc}
+}
+
+func (h *WorkoutPlanHandler) ListPlans(c *gin.Context) {
+	var q dto.ListWorkoutPlansQueryDTO
+	if err := c.ShouldBindQuery(&q); err != nil {
+		response.BadRequest(c, "invalid query")
+		return
+	}
+	if err := validator.ValidateStruct(&q); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	plans, err := h.uc.ListPlans(c.Request.Context(), c.GetString("user_id"), q.Limit)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, dto.FromDomainWorkoutPlans(plans))
+}
+
+func (h *WorkoutPlanHandler) GetPlan(c *gin.Context) {
+	plan, err := h.uc.GetPlan(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, dto.FromDomainWorkoutPlan(*plan))
+}
+
+func (h *WorkoutPlanHandler) ExplainPlan(c *gin.Context) {
+	userID, err := uuid.Parse(c.GetString("user_id"))
+	if err != nil {
+		response.BadRequest(c, "invalid user id")
+		return
+	}
+	id := c.Param("id")
+
+	if response.WantsStream(c) {
+		stream := response.NewEventStream(c)
+		expl, err := h.uc.StreamExplainPlan(c.Request.Context(), userID, id, func(delta string) error {
+			return stream.Send("delta", gin.H{"content": delta})
+		})
+		if err != nil {
+			stream.Fail(err)
+			return
+		}
+		stream.Result(toWorkoutExplanationDTO(*expl))
+		return
+	}
+
+	expl, err := h.uc.ExplainPlan(c.Request.Context(), userID, id)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, toWorkoutExplanationDTO(*expl))
+}
+
+func (h *WorkoutPlanHandler) StartPlan(c *gin.Context) {
+	session, err := h.uc.StartPlan(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Created(c, dto.FromDomainWorkoutSession(*session))
+}
+
+func (h *WorkoutPlanHandler) ComparePlan(c *gin.Context) {
+	comparison, err := h.uc.ComparePlan(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, dto.FromDomainPlanComparison(*comparison))
+}
+
+// PlanOwner resolves the owner of a workout plan for the ownership middleware.
+func (h *WorkoutPlanHandler) PlanOwner(ctx context.Context, id string) (string, error) {
+	return h.uc.GetPlanOwner(ctx, id)
+}
@@ -1,19 +1,28 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
 	"strings"
 
+	"S.P.A.R.T.A/backend/pkg/jwtkeys"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// TokenStateResolver reports whether a session has been revoked and the
+// user's current token version.
+type TokenStateResolver func(ctx context.Context, userID, sessionID string) (revoked bool, version int64, err error)
+
 type AuthMiddleware struct {
-	jwtSecret string
+	keys       *jwtkeys.Keyring
+	tokenState TokenStateResolver
 }
 
-func NewAuthMiddleware(secret string) *AuthMiddleware {
-	return &AuthMiddleware{jwtSecret: secret}
+// NewAuthMiddleware checks every token against tokenState; a nil resolver
+// only verifies signatures and expiry.
+func NewAuthMiddleware(keys *jwtkeys.Keyring, tokenState TokenStateResolver) *AuthMiddleware {
+	return &AuthMiddleware{keys: keys, tokenState: tokenState}
 }
 
 func (a *AuthMiddleware) RequireAuth() gin.HandlerFunc {
@@ -58,12 +67,7 @@ func (a *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 			return
 		}
 
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, jwt.ErrTokenSignatureInvalid
-			}
-			return []byte(a.jwtSecret), nil
-		})
+		token, err := jwt.Parse(tokenString, a.keys.Keyfunc)
 
 		if err != nil || !token.Valid {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
@@ -100,8 +104,39 @@ func (a *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 			return
 		}
 
+		// Tokens are bound to a sign-in session so they can be revoked.
+		sessionID, _ := claims["sid"].(string)
+		if strings.TrimSpace(sessionID) == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"status":  "error",
+				"message": "invalid token",
+			})
+			return
+		}
+
+		if a.tokenState != nil {
+			revoked, version, err := a.tokenState(c.Request.Context(), userID, sessionID)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+					"status":  "error",
+					"message": "authentication unavailable",
+				})
+				return
+			}
+			// JSON numbers decode as float64.
+			tokenVersion, _ := claims["ver"].(float64)
+			if revoked || int64(tokenVersion) < version {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+					"status":  "error",
+					"message": "token revoked",
+				})
+				return
+			}
+		}
+
 		// inject into context
 		c.Set("user_id", userID)
+		c.Set("session_id", sessionID)
 
 		role := "user"
 		if roleVal, ok := claims["role"]; ok {
@@ -114,6 +149,14 @@ func (a *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 		}
 		c.Set("role", role)
 
+		// Tokens minted before verification existed carry no claim; their
+		// accounts were all marked verified when it was introduced.
+		emailVerified := true
+		if v, ok := claims["email_verified"].(bool); ok {
+			emailVerified = v
+		}
+		c.Set("email_verified", emailVerified)
+
 		c.Next()
 	}
 }
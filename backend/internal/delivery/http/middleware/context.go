@@ -1,6 +1,11 @@
 package middleware
 
-import "github.com/gin-gonic/gin"
+import (
+	"S.P.A.R.T.A/backend/internal/domain/service/authz"
+	"github.com/gin-gonic/gin"
+)
+
+const resourceOwnerIDKey = "resource_owner_id"
 
 func GetUserID(c *gin.Context) string {
 	val, exists := c.Get("user_id")
@@ -13,3 +18,21 @@ func GetUserID(c *gin.Context) string {
 	}
 	return s
 }
+
+// GetSessionID returns the sign-in session the access token belongs to.
+func GetSessionID(c *gin.Context) string {
+	return c.GetString("session_id")
+}
+
+func GetPrincipal(c *gin.Context) authz.Principal {
+	return authz.Principal{
+		UserID: GetUserID(c),
+		Role:   c.GetString("role"),
+	}
+}
+
+// GetResourceOwnerID returns the owner resolved by RequireOwner. Handlers use
+// it instead of the caller's id so that admin access acts on the real owner.
+func GetResourceOwnerID(c *gin.Context) string {
+	return c.GetString(resourceOwnerIDKey)
+}
@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"S.P.A.R.T.A/backend/internal/delivery/http/response"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+	"S.P.A.R.T.A/backend/internal/domain/service/authz"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// OwnerResolver returns the id of the user owning the resource with the given id.
+type OwnerResolver func(ctx context.Context, resourceID string) (string, error)
+
+type OwnershipMiddleware struct{}
+
+func NewOwnershipMiddleware() *OwnershipMiddleware {
+	return &OwnershipMiddleware{}
+}
+
+// RequireSelf guards routes addressed by a user id path parameter
+// (e.g. /workouts/user/:user_id).
+func (m *OwnershipMiddleware) RequireSelf(param string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ownerID := c.Param(param)
+		if err := authz.CanAccess(GetPrincipal(c), ownerID); err != nil {
+			abortWithError(c, err)
+			return
+		}
+		c.Set(resourceOwnerIDKey, ownerID)
+		c.Next()
+	}
+}
+
+// RequireOwner resolves the owner of the resource addressed by a path parameter
+// and guards it (e.g. /workouts/:id).
+func (m *OwnershipMiddleware) RequireOwner(param string, resolve OwnerResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param(param)
+		if _, err := uuid.Parse(id); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"status":  "error",
+				"message": "invalid " + param,
+			})
+			return
+		}
+
+		ownerID, err := resolve(c.Request.Context(), id)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		if err := authz.CanAccess(GetPrincipal(c), ownerID); err != nil {
+			abortWithError(c, err)
+			return
+		}
+		c.Set(resourceOwnerIDKey, ownerID)
+		c.Next()
+	}
+}
+
+func abortWithError(c *gin.Context, err error) {
+	status := response.MapErrorToStatus(err)
+	message := err.Error()
+	if status == http.StatusInternalServerError {
+		message = domainerr.ErrInternal.Error()
+	}
+	c.AbortWithStatusJSON(status, gin.H{
+		"status":  "error",
+		"message": message,
+	})
+}
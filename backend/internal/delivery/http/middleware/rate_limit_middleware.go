@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/ratelimit"
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimiter counts a request for key against policy.
+type RateLimiter func(ctx context.Context, key string, policy ratelimit.Policy) (ratelimit.Decision, error)
+
+// RateLimitKey selects who a limit applies to.
+type RateLimitKey int
+
+const (
+	// KeyByIP limits each client address.
+	KeyByIP RateLimitKey = iota
+	// KeyByUser limits each signed-in user and falls back to the client
+	// address before authentication.
+	KeyByUser
+)
+
+type RateLimitMiddleware struct {
+	allow RateLimiter
+}
+
+// NewRateLimitMiddleware with a nil limiter lets every request through.
+func NewRateLimitMiddleware(allow RateLimiter) *RateLimitMiddleware {
+	return &RateLimitMiddleware{allow: allow}
+}
+
+// Limit enforces policy and reports it in RateLimit-* headers; refused
+// requests get 429 with Retry-After. When the limiter fails the request is
+// let through, so an outage of its store does not take the API down.
+func (m *RateLimitMiddleware) Limit(policy ratelimit.Policy, by RateLimitKey) gin.HandlerFunc {
+	if m.allow == nil || !policy.Enabled() {
+		return func(c *gin.Context) { c.Next() }
+	}
+	return func(c *gin.Context) {
+		key := "ip:" + c.ClientIP()
+		if by == KeyByUser {
+			if userID := c.GetString("user_id"); userID != "" {
+				key = "user:" + userID
+			}
+		}
+
+		d, err := m.allow(c.Request.Context(), key, policy)
+		if err != nil {
+			slog.WarnContext(c.Request.Context(), "rate limiter unavailable", "policy", policy.Name, "error", err)
+			c.Next()
+			return
+		}
+
+		setRateLimitHeaders(c, policy, d)
+		if !d.Allowed {
+			c.Header("Retry-After", strconv.Itoa(ceilSeconds(d.RetryAfter)))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"status":  "error",
+				"message": "too many requests",
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// setRateLimitHeaders keeps the most restrictive policy when several apply
+// to one route.
+func setRateLimitHeaders(c *gin.Context, policy ratelimit.Policy, d ratelimit.Decision) {
+	h := c.Writer.Header()
+	if prev, err := strconv.Atoi(h.Get("RateLimit-Remaining")); err == nil && prev <= d.Remaining {
+		return
+	}
+	h.Set("RateLimit-Limit", strconv.Itoa(d.Limit))
+	h.Set("RateLimit-Remaining", strconv.Itoa(d.Remaining))
+	h.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(d.Reset)))
+	h.Set("RateLimit-Policy", strconv.Itoa(policy.Limit)+";w="+strconv.Itoa(ceilSeconds(policy.Window)))
+}
+
+func ceilSeconds(d time.Duration) int {
+	if d <= 0 {
+		return 0
+	}
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/ratelimit"
+	"github.com/gin-gonic/gin"
+)
+
+// decisions answers each policy by name.
+func decisions(byPolicy map[string]ratelimit.Decision) RateLimiter {
+	return func(_ context.Context, _ string, policy ratelimit.Policy) (ratelimit.Decision, error) {
+		d, ok := byPolicy[policy.Name]
+		if !ok {
+			return ratelimit.Decision{}, errors.New("limiter down")
+		}
+		return d, nil
+	}
+}
+
+func serve(handlers ...gin.HandlerFunc) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/", append(handlers, func(c *gin.Context) { c.Status(http.StatusNoContent) })...)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	return w
+}
+
+func TestRateLimitHeaders(t *testing.T) {
+	policy := ratelimit.Policy{Name: "api", Limit: 60, Window: time.Minute}
+
+	tests := []struct {
+		name        string
+		decision    ratelimit.Decision
+		wantCode    int
+		wantHeaders map[string]string
+	}{
+		{
+			name:     "allowed",
+			decision: ratelimit.Decision{Allowed: true, Limit: 60, Remaining: 59, Reset: 59500 * time.Millisecond},
+			wantCode: http.StatusNoContent,
+			wantHeaders: map[string]string{
+				"RateLimit-Limit":     "60",
+				"RateLimit-Remaining": "59",
+				"RateLimit-Reset":     "60",
+				"RateLimit-Policy":    "60;w=60",
+				"Retry-After":         "",
+			},
+		},
+		{
+			name:     "refused",
+			decision: ratelimit.Decision{Limit: 60, Remaining: 0, Reset: 30 * time.Second, RetryAfter: 1100 * time.Millisecond},
+			wantCode: http.StatusTooManyRequests,
+			wantHeaders: map[string]string{
+				"RateLimit-Remaining": "0",
+				"RateLimit-Reset":     "30",
+				"Retry-After":         "2",
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := NewRateLimitMiddleware(decisions(map[string]ratelimit.Decision{"api": tc.decision}))
+			w := serve(m.Limit(policy, KeyByIP))
+			if w.Code != tc.wantCode {
+				t.Fatalf("status = %d, want %d", w.Code, tc.wantCode)
+			}
+			for name, want := range tc.wantHeaders {
+				if got := w.Header().Get(name); got != want {
+					t.Errorf("%s = %q, want %q", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestStackedRateLimitsKeepTheMostRestrictive(t *testing.T) {
+	burst := ratelimit.Policy{Name: "burst", Limit: 10, Window: time.Second}
+	hourly := ratelimit.Policy{Name: "hourly", Limit: 100, Window: time.Hour}
+	m := NewRateLimitMiddleware(decisions(map[string]ratelimit.Decision{
+		"burst":  {Allowed: true, Limit: 10, Remaining: 9, Reset: time.Second},
+		"hourly": {Allowed: true, Limit: 100, Remaining: 3, Reset: 40 * time.Minute},
+	}))
+
+	// Whichever order they run in, the hourly limit has less room left.
+	for _, order := range [][]ratelimit.Policy{{burst, hourly}, {hourly, burst}} {
+		w := serve(m.Limit(order[0], KeyByIP), m.Limit(order[1], KeyByIP))
+		if got := w.Header().Get("RateLimit-Remaining"); got != "3" {
+			t.Errorf("%s then %s: RateLimit-Remaining = %q, want 3", order[0].Name, order[1].Name, got)
+		}
+		if got := w.Header().Get("RateLimit-Policy"); got != "100;w=3600" {
+			t.Errorf("%s then %s: RateLimit-Policy = %q, want 100;w=3600", order[0].Name, order[1].Name, got)
+		}
+	}
+}
+
+func TestRateLimitFailsOpen(t *testing.T) {
+	m := NewRateLimitMiddleware(decisions(nil))
+	w := serve(m.Limit(ratelimit.Policy{Name: "api", Limit: 1, Window: time.Minute}, KeyByIP))
+	if w.Code != http.StatusNoContent || w.Header().Get("RateLimit-Limit") != "" {
+		t.Errorf("status = %d, headers = %v, want the request through without headers", w.Code, w.Header())
+	}
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type VerifiedEmailMiddleware struct{}
+
+func NewVerifiedEmailMiddleware() *VerifiedEmailMiddleware {
+	return &VerifiedEmailMiddleware{}
+}
+
+// RequireVerifiedEmail must run after RequireAuth. The flag comes from the
+// access token, so a user who just verified needs a refreshed token.
+func (m *VerifiedEmailMiddleware) RequireVerifiedEmail() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !c.GetBool("email_verified") {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"status":  "error",
+				"message": "email not verified",
+			})
+			return
+		}
+		c.Next()
+	}
+}
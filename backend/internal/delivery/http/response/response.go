@@ -1,7 +1,10 @@
 package response
 
 import (
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 
@@ -12,6 +15,7 @@ type APIResponse struct {
 	Status  string      `json:"status"`
 	Message string      `json:"message,omitempty"`
 	Data    interface{} `json:"data,omitempty"`
+	Meta    interface{} `json:"meta,omitempty"`
 }
 
 func Success(c *gin.Context, data interface{}) {
@@ -21,6 +25,14 @@ func Success(c *gin.Context, data interface{}) {
 	})
 }
 
+func SuccessWithMeta(c *gin.Context, data interface{}, meta interface{}) {
+	c.JSON(200, APIResponse{
+		Status: "success",
+		Data:   data,
+		Meta:   meta,
+	})
+}
+
 func Created(c *gin.Context, data interface{}) {
 	c.JSON(201, APIResponse{
 		Status: "success",
@@ -46,6 +58,11 @@ func Error(c *gin.Context, err error) {
 		message = domainerr.ErrInternal.Error()
 	}
 
+	var retry *domainerr.RetryAfterError
+	if errors.As(err, &retry) {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retry.RetryAfter.Seconds()))))
+	}
+
 	c.JSON(status, APIResponse{
 		Status:  "error",
 		Message: message,
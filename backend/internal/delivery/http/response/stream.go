@@ -0,0 +1,71 @@
+package response
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+)
+
+// WantsStream reports whether the client asked for Server-Sent Events,
+// either with ?stream=true or an Accept: text/event-stream header.
+func WantsStream(c *gin.Context) bool {
+	if strings.EqualFold(c.Query("stream"), "true") || c.Query("stream") == "1" {
+		return true
+	}
+	return strings.Contains(c.GetHeader("Accept"), "text/event-stream")
+}
+
+// EventStream writes SSE events. Headers are sent lazily on the first
+// event, so a request that fails before producing output can still get a
+// regular JSON error with the right status code.
+type EventStream struct {
+	c       *gin.Context
+	started bool
+}
+
+func NewEventStream(c *gin.Context) *EventStream {
+	return &EventStream{c: c}
+}
+
+func (s *EventStream) Started() bool {
+	return s.started
+}
+
+func (s *EventStream) Send(event string, data interface{}) error {
+	if !s.started {
+		h := s.c.Writer.Header()
+		h.Set("Content-Type", "text/event-stream")
+		h.Set("Cache-Control", "no-cache")
+		h.Set("Connection", "keep-alive")
+		h.Set("X-Accel-Buffering", "no")
+		s.c.Status(http.StatusOK)
+		s.started = true
+	}
+
+	s.c.SSEvent(event, data)
+	s.c.Writer.Flush()
+	return s.c.Request.Context().Err()
+}
+
+// Result sends the final payload in the same envelope as Success.
+func (s *EventStream) Result(data interface{}) {
+	_ = s.Send("result", APIResponse{Status: "success", Data: data})
+}
+
+// Fail reports err as an "error" event, or as a JSON error response when
+// nothing has been streamed yet.
+func (s *EventStream) Fail(err error) {
+	if !s.started {
+		Error(s.c, err)
+		return
+	}
+
+	message := err.Error()
+	if MapErrorToStatus(err) == http.StatusInternalServerError {
+		message = domainerr.ErrInternal.Error()
+	}
+	_ = s.Send("error", APIResponse{Status: "error", Message: message})
+}
@@ -0,0 +1,252 @@
+package response
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"S.P.A.R.T.A/backend/internal/ai/orchestrator"
+	"S.P.A.R.T.A/backend/internal/client"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+type sseEvent struct {
+	name string
+	data string
+}
+
+// readEvents parses an SSE body into its events.
+func readEvents(t *testing.T, body string) []sseEvent {
+	t.Helper()
+	var (
+		out []sseEvent
+		cur sseEvent
+	)
+	sc := bufio.NewScanner(strings.NewReader(body))
+	for sc.Scan() {
+		line := sc.Text()
+		switch {
+		case line == "":
+			if cur.name != "" || cur.data != "" {
+				out = append(out, cur)
+			}
+			cur = sseEvent{}
+		case strings.HasPrefix(line, "event:"):
+			cur.name = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			cur.data += strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		}
+	}
+	if cur.name != "" || cur.data != "" {
+		out = append(out, cur)
+	}
+	return out
+}
+
+func decodeEnvelope(t *testing.T, data string) APIResponse {
+	t.Helper()
+	var resp APIResponse
+	if err := json.Unmarshal([]byte(data), &resp); err != nil {
+		t.Fatalf("event data %q is not an API response: %v", data, err)
+	}
+	return resp
+}
+
+func TestWantsStream(t *testing.T) {
+	tests := []struct {
+		url    string
+		accept string
+		want   bool
+	}{
+		{url: "/", want: false},
+		{url: "/?stream=true", want: true},
+		{url: "/?stream=TRUE", want: true},
+		{url: "/?stream=1", want: true},
+		{url: "/?stream=false", want: false},
+		{url: "/?stream=yes", want: false},
+		{url: "/", accept: "text/event-stream", want: true},
+		{url: "/", accept: "application/json, text/event-stream", want: true},
+		{url: "/", accept: "application/json", want: false},
+	}
+	for _, tc := range tests {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, tc.url, nil)
+		if tc.accept != "" {
+			c.Request.Header.Set("Accept", tc.accept)
+		}
+		if got := WantsStream(c); got != tc.want {
+			t.Errorf("WantsStream(%s, Accept %q) = %v, want %v", tc.url, tc.accept, got, tc.want)
+		}
+	}
+}
+
+func newStreamContext() (*gin.Context, *httptest.ResponseRecorder) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/?stream=true", nil)
+	return c, w
+}
+
+func TestEventStreamSendsEventsThenResult(t *testing.T) {
+	c, w := newStreamContext()
+	s := NewEventStream(c)
+
+	for _, d := range []string{"Hel", "lo"} {
+		if err := s.Send("delta", gin.H{"content": d}); err != nil {
+			t.Fatalf("Send: %v", err)
+		}
+	}
+	s.Result(gin.H{"text": "Hello"})
+
+	if w.Code != http.StatusOK {
+		t.Errorf("status = %d, want 200", w.Code)
+	}
+	for h, want := range map[string]string{
+		"Content-Type":      "text/event-stream",
+		"Cache-Control":     "no-cache",
+		"X-Accel-Buffering": "no",
+	} {
+		if got := w.Header().Get(h); !strings.HasPrefix(got, want) {
+			t.Errorf("%s = %q, want %q", h, got, want)
+		}
+	}
+	if !w.Flushed {
+		t.Error("events were not flushed")
+	}
+
+	events := readEvents(t, w.Body.String())
+	if len(events) != 3 {
+		t.Fatalf("got %d events %+v, want 3", len(events), events)
+	}
+	if events[0].name != "delta" || events[0].data != `{"content":"Hel"}` || events[1].data != `{"content":"lo"}` {
+		t.Errorf("delta events = %+v", events[:2])
+	}
+	result := decodeEnvelope(t, events[2].data)
+	if events[2].name != "result" || result.Status != "success" || fmt.Sprint(result.Data) != "map[text:Hello]" {
+		t.Errorf("result event = %+v", events[2])
+	}
+}
+
+func TestEventStreamFail(t *testing.T) {
+	t.Run("before any event answers with a JSON error", func(t *testing.T) {
+		c, w := newStreamContext()
+		s := NewEventStream(c)
+		s.Fail(domainerr.ErrAIUnavailable)
+
+		if w.Code != http.StatusServiceUnavailable {
+			t.Errorf("status = %d, want 503", w.Code)
+		}
+		if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+			t.Errorf("Content-Type = %q, want JSON", ct)
+		}
+		if resp := decodeEnvelope(t, w.Body.String()); resp.Status != "error" || resp.Message != domainerr.ErrAIUnavailable.Error() {
+			t.Errorf("body = %+v", resp)
+		}
+	})
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "mapped errors keep their message", err: domainerr.ErrAIUnavailable, want: domainerr.ErrAIUnavailable.Error()},
+		{name: "internal errors are sanitized", err: errors.New("pq: connection refused"), want: domainerr.ErrInternal.Error()},
+	}
+	for _, tc := range tests {
+		t.Run("after a delta, "+tc.name, func(t *testing.T) {
+			c, w := newStreamContext()
+			s := NewEventStream(c)
+			if err := s.Send("delta", gin.H{"content": "partial"}); err != nil {
+				t.Fatal(err)
+			}
+			s.Fail(tc.err)
+
+			if w.Code != http.StatusOK {
+				t.Errorf("status = %d, want the 200 already sent", w.Code)
+			}
+			events := readEvents(t, w.Body.String())
+			if len(events) != 2 || events[1].name != "error" {
+				t.Fatalf("events = %+v, want a delta then an error", events)
+			}
+			if resp := decodeEnvelope(t, events[1].data); resp.Status != "error" || resp.Message != tc.want {
+				t.Errorf("error event = %+v, want message %q", resp, tc.want)
+			}
+		})
+	}
+}
+
+func TestEventStreamReportsClientDisconnect(t *testing.T) {
+	c, _ := newStreamContext()
+	ctx, cancel := context.WithCancel(context.Background())
+	c.Request = c.Request.WithContext(ctx)
+	s := NewEventStream(c)
+
+	cancel()
+	if err := s.Send("delta", gin.H{"content": "x"}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Send after disconnect = %v, want context.Canceled", err)
+	}
+}
+
+// TestStreamingFromOpenAIToClient pipes a fake OpenAI event stream through
+// the client and orchestrator into an EventStream, as the coaching handler
+// does.
+func TestStreamingFromOpenAIToClient(t *testing.T) {
+	answer := `{"suggestions":["Sleep more","Deload next week"]}`
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, part := range []string{answer[:12], answer[12:30], answer[30:]} {
+			chunk, _ := json.Marshal(map[string]any{
+				"choices": []any{map[string]any{"delta": map[string]any{"content": part}}},
+			})
+			fmt.Fprintf(w, "data: %s\n\n", chunk)
+			w.(http.Flusher).Flush()
+		}
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer upstream.Close()
+
+	orch := orchestrator.NewOrchestrator(client.NewOpenAIClient("", "gpt-test", upstream.URL))
+	c, w := newStreamContext()
+	s := NewEventStream(c)
+
+	out, err := orch.StreamCoachingSuggestions(c.Request.Context(), orchestrator.CoachingInput{UserID: "u1"}, func(d string) error {
+		return s.Send("delta", gin.H{"content": d})
+	})
+	if err != nil {
+		s.Fail(err)
+		t.Fatalf("StreamCoachingSuggestions: %v", err)
+	}
+	s.Result(gin.H{"suggestions": out.Suggestions})
+
+	events := readEvents(t, w.Body.String())
+	var streamed strings.Builder
+	for _, e := range events[:len(events)-1] {
+		if e.name != "delta" {
+			t.Fatalf("unexpected %q event before the result", e.name)
+		}
+		var d struct{ Content string }
+		if err := json.Unmarshal([]byte(e.data), &d); err != nil {
+			t.Fatal(err)
+		}
+		streamed.WriteString(d.Content)
+	}
+	if streamed.String() != answer {
+		t.Errorf("streamed %q, want %q", streamed.String(), answer)
+	}
+	last := events[len(events)-1]
+	if last.name != "result" || !strings.Contains(last.data, "Deload next week") {
+		t.Errorf("last event = %+v, want the parsed result", last)
+	}
+}
@@ -5,10 +5,19 @@ import (
 
 	"S.P.A.R.T.A/backend/internal/delivery/http/handler"
 	"S.P.A.R.T.A/backend/internal/delivery/http/middleware"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/ratelimit"
+	"S.P.A.R.T.A/backend/pkg/jwtkeys"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 )
 
+// RateLimits are the per route group policies; see configs.RateLimitConfig.
+type RateLimits struct {
+	Auth ratelimit.Policy
+	AI   ratelimit.Policy
+	API  ratelimit.Policy
+}
+
 func SetupRouter(
 	workoutHandler *handler.WorkoutHandler,
 	splitHandler *handler.SplitHandler,
@@ -18,7 +27,16 @@ func SetupRouter(
 	AICoachHandler *handler.AICoachHandler,
 	authHandler *handler.AuthHandler,
 	adminHandler *handler.AdminHandler,
-	jwtSecret string,
+	recordHandler *handler.RecordHandler,
+	analyticsHandler *handler.AnalyticsHandler,
+	userHandler *handler.UserHandler,
+	chatHandler *handler.ChatHandler,
+	agentHandler *handler.AgentHandler,
+	workoutPlanHandler *handler.WorkoutPlanHandler,
+	keys *jwtkeys.Keyring,
+	tokenState middleware.TokenStateResolver,
+	rateLimiter middleware.RateLimiter,
+	limits RateLimits,
 ) *gin.Engine {
 
 	r := gin.New()
@@ -30,10 +48,15 @@ func SetupRouter(
 			"http://localhost:3000",
 			"http://127.0.0.1:3000",
 		},
-		AllowMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
 		AllowHeaders: []string{"Authorization", "Content-Type", "X-Request-Id"},
 		ExposeHeaders: []string{
 			"X-Request-Id",
+			"RateLimit-Limit",
+			"RateLimit-Remaining",
+			"RateLimit-Reset",
+			"RateLimit-Policy",
+			"Retry-After",
 		},
 		MaxAge: 12 * time.Hour,
 	}
@@ -44,8 +67,17 @@ func SetupRouter(
 	r.Use(middleware.LoggerMiddleware())
 	r.Use(middleware.MetricsMiddleware())
 
-	authMW := middleware.NewAuthMiddleware(jwtSecret)
+	authMW := middleware.NewAuthMiddleware(keys, tokenState)
 	adminMW := middleware.NewAdminMiddleware()
+	verifiedMW := middleware.NewVerifiedEmailMiddleware()
+	rateLimitMW := middleware.NewRateLimitMiddleware(rateLimiter)
+	ownershipMW := middleware.NewOwnershipMiddleware()
+
+	// Public verification keys for services that accept our access tokens.
+	r.GET("/.well-known/jwks.json", func(c *gin.Context) {
+		c.Header("Cache-Control", "public, max-age=300")
+		c.JSON(200, keys.JWKS())
+	})
 
 	api := r.Group("/api/v1")
 
@@ -60,52 +92,88 @@ func SetupRouter(
 
 	// auth (public)
 	auth := api.Group("/auth")
+	auth.Use(rateLimitMW.Limit(limits.Auth, middleware.KeyByIP))
 	{
 		auth.POST("/register", authHandler.Register)
 		auth.POST("/login", authHandler.Login)
+		auth.POST("/refresh", authHandler.Refresh)
+		auth.POST("/password/forgot", authHandler.ForgotPassword)
+		auth.POST("/password/reset", authHandler.ResetPassword)
+		auth.POST("/email/verify", authHandler.VerifyEmail)
+		auth.GET("/oauth/providers", authHandler.OAuthProviders)
+		auth.POST("/oauth/:provider/start", authHandler.StartOAuth)
+		auth.POST("/oauth/:provider/callback", authHandler.CompleteOAuth)
 	}
 
 	secured := api.Group("/")
 	secured.Use(authMW.RequireAuth())
+	secured.Use(rateLimitMW.Limit(limits.API, middleware.KeyByUser))
 
 	// admin (secured + admin-only)
 	admin := secured.Group("/admin")
 	admin.Use(adminMW.RequireAdmin())
 	{
 		admin.POST("/invites", adminHandler.CreateInvite)
+		admin.GET("/ai/calls", adminHandler.ListAICalls)
+		admin.GET("/ai/calls/stats", adminHandler.GetAICallStats)
+	}
+
+	// Ownership guards: every user-owned resource goes through one of these.
+	selfOnly := ownershipMW.RequireSelf("user_id")
+	sessionOwner := ownershipMW.RequireOwner("id", workoutHandler.SessionOwner)
+	templateOwner := ownershipMW.RequireOwner("id", splitHandler.TemplateOwner)
+	threadOwner := ownershipMW.RequireOwner("id", chatHandler.ThreadOwner)
+	actionOwner := ownershipMW.RequireOwner("id", agentHandler.ActionOwner)
+	planOwner := ownershipMW.RequireOwner("id", workoutPlanHandler.PlanOwner)
+	authSessionOwner := ownershipMW.RequireOwner("id", authHandler.SessionOwner)
+
+	// auth (secured)
+	authSecured := secured.Group("/auth")
+	{
+		authSecured.POST("/logout", authHandler.Logout)
+		authSecured.GET("/sessions", authHandler.ListSessions)
+		authSecured.DELETE("/sessions/:id", authSessionOwner, authHandler.RevokeSession)
+		authSecured.POST("/email/verification", authHandler.ResendVerification)
 	}
 
 	// workouts
 	workouts := secured.Group("/workouts")
 	{
 		workouts.POST("", workoutHandler.CreateWorkoutSession)
-		workouts.GET("/:id", workoutHandler.GetWorkoutSession)
-		workouts.GET("/user/:user_id", workoutHandler.GetUserWorkoutSessions)
+		workouts.GET("/:id", sessionOwner, workoutHandler.GetWorkoutSession)
+		workouts.PUT("/:id", sessionOwner, workoutHandler.ReplaceWorkoutSession)
+		workouts.PATCH("/:id", sessionOwner, workoutHandler.PatchWorkoutSession)
+		workouts.DELETE("/:id", sessionOwner, workoutHandler.DeleteWorkoutSession)
+		workouts.POST("/:id/finish", sessionOwner, workoutHandler.FinishWorkoutSession)
+		workouts.POST("/:id/exercises/:workout_exercise_id/sets", sessionOwner, workoutHandler.AddWorkoutSet)
+		workouts.PUT("/:id/sets/:set_id", sessionOwner, workoutHandler.UpdateWorkoutSet)
+		workouts.DELETE("/:id/sets/:set_id", sessionOwner, workoutHandler.DeleteWorkoutSet)
+		workouts.GET("/user/:user_id", selfOnly, workoutHandler.GetUserWorkoutSessions)
 	}
 
 	// splits
 	splits := secured.Group("/splits")
 	{
 		splits.POST("", splitHandler.CreateTemplate)
-		splits.GET("/:id", splitHandler.GetTemplate)
-		splits.PUT("/:id", splitHandler.UpdateTemplate)
-		splits.POST("/:id/activate", splitHandler.ActivateTemplate)
-		splits.POST("/:id/deactivate", splitHandler.DeactivateTemplate)
-		splits.GET("/user/:user_id", splitHandler.GetUserTemplates)
+		splits.GET("/:id", templateOwner, splitHandler.GetTemplate)
+		splits.PUT("/:id", templateOwner, splitHandler.UpdateTemplate)
+		splits.POST("/:id/activate", templateOwner, splitHandler.ActivateTemplate)
+		splits.POST("/:id/deactivate", templateOwner, splitHandler.DeactivateTemplate)
+		splits.GET("/user/:user_id", selfOnly, splitHandler.GetUserTemplates)
 	}
 
 	// nutrition
 	nutrition := secured.Group("/nutrition")
 	{
 		nutrition.POST("", nutritionHandler.UpsertDailyNutrition)
-		nutrition.GET("/user/:user_id", nutritionHandler.GetDailyNutrition)
+		nutrition.GET("/user/:user_id", selfOnly, nutritionHandler.GetDailyNutrition)
 	}
 
 	// planner
 	planner := secured.Group("/planner")
 	{
-		planner.POST("/generate/:user_id", plannerHandler.GenerateRecommendation)
-		planner.GET("/user/:user_id", plannerHandler.GetUserRecommendations)
+		planner.POST("/generate/:user_id", selfOnly, verifiedMW.RequireVerifiedEmail(), rateLimitMW.Limit(limits.AI, middleware.KeyByUser), plannerHandler.GenerateRecommendation)
+		planner.GET("/user/:user_id", selfOnly, plannerHandler.GetUserRecommendations)
 	}
 
 	// exercises
@@ -115,10 +183,28 @@ func SetupRouter(
 		exercises.GET("", exerciseHandler.ListExercises)
 		exercises.GET("/:id", exerciseHandler.GetExercise)
 		exercises.POST("/:id/media", exerciseHandler.AddExerciseMedia)
+		exercises.GET("/:id/records", recordHandler.GetExerciseRecords)
 	}
 
-	// ai
+	// users (scoped to the caller)
+	users := secured.Group("/users")
+	{
+		users.GET("/me/records", recordHandler.GetMyRecords)
+		users.GET("/me/preferences", userHandler.GetPreferences)
+		users.PATCH("/me/preferences", userHandler.UpdatePreferences)
+	}
+
+	// analytics (scoped to the caller)
+	analytics := secured.Group("/analytics")
+	{
+		analytics.GET("/exercises/:id/progression", analyticsHandler.GetExerciseProgression)
+		analytics.GET("/muscles/weekly", analyticsHandler.GetMuscleVolume)
+	}
+
+	// ai (verified email only)
 	ai := secured.Group("/ai")
+	ai.Use(verifiedMW.RequireVerifiedEmail())
+	ai.Use(rateLimitMW.Limit(limits.AI, middleware.KeyByUser))
 	{
 		ai.POST("/generate-split", AICoachHandler.GenerateSplit)
 		ai.POST("/overload", AICoachHandler.SuggestOverload)
@@ -127,6 +213,24 @@ func SetupRouter(
 		ai.POST("/motivation/reset", AICoachHandler.ResetDailyMotivation)
 		ai.GET("/coaching", AICoachHandler.GetCoachingSuggestions)
 		ai.POST("/explain-workout", AICoachHandler.ExplainWorkoutPlan)
+
+		ai.GET("/workout-plans", workoutPlanHandler.ListPlans)
+		ai.GET("/workout-plans/:id", planOwner, workoutPlanHandler.GetPlan)
+		ai.POST("/workout-plans/:id/explain", planOwner, workoutPlanHandler.ExplainPlan)
+		ai.POST("/workout-plans/:id/start", planOwner, workoutPlanHandler.StartPlan)
+		ai.GET("/workout-plans/:id/comparison", planOwner, workoutPlanHandler.ComparePlan)
+
+		ai.POST("/chat/threads", chatHandler.CreateThread)
+		ai.GET("/chat/threads", chatHandler.ListThreads)
+		ai.GET("/chat/threads/:id", threadOwner, chatHandler.GetThread)
+		ai.DELETE("/chat/threads/:id", threadOwner, chatHandler.DeleteThread)
+		ai.GET("/chat/threads/:id/messages", threadOwner, chatHandler.ListMessages)
+		ai.POST("/chat/threads/:id/messages", threadOwner, chatHandler.SendMessage)
+
+		ai.POST("/agent", agentHandler.Run)
+		ai.GET("/agent/actions/:id", actionOwner, agentHandler.GetAction)
+		ai.POST("/agent/actions/:id/confirm", actionOwner, agentHandler.ConfirmAction)
+		ai.POST("/agent/actions/:id/reject", actionOwner, agentHandler.RejectAction)
 	}
 
 	return r
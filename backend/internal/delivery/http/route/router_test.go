@@ -0,0 +1,437 @@
+package route
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"S.P.A.R.T.A/backend/internal/delivery/http/handler"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/agent"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/chat"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/nutrition"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/planner"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/record"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/split"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/workout"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+	domainuc "S.P.A.R.T.A/backend/internal/domain/usecase"
+	"S.P.A.R.T.A/backend/pkg/jwtkeys"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// errReached is what every faked usecase method returns, so a request that
+// got past the ownership guard answers 409.
+var errReached = domainerr.ErrConflict
+
+// usecaseCalls counts the calls that reached a usecase past its guard.
+type usecaseCalls struct {
+	mu sync.Mutex
+	n  int
+}
+
+func (c *usecaseCalls) hit() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.n++
+	return errReached
+}
+
+func (c *usecaseCalls) count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.n
+}
+
+// owners maps resource ids to the user owning them; unknown ids are not
+// found.
+type owners map[string]string
+
+func (o owners) owner(id string) (string, error) {
+	if owner, ok := o[id]; ok {
+		return owner, nil
+	}
+	return "", domainerr.ErrNotFound
+}
+
+type fakeWorkoutUsecase struct {
+	domainuc.WorkoutUsecase
+	owners owners
+	calls  *usecaseCalls
+}
+
+func (f fakeWorkoutUsecase) GetWorkoutSessionOwner(_ context.Context, id string) (string, error) {
+	return f.owners.owner(id)
+}
+func (f fakeWorkoutUsecase) GetWorkoutSession(context.Context, string) (*workout.WorkoutSession, error) {
+	return nil, f.calls.hit()
+}
+func (f fakeWorkoutUsecase) GetUserWorkoutSessions(context.Context, string, workout.SessionFilter) (*workout.SessionPage, error) {
+	return nil, f.calls.hit()
+}
+func (f fakeWorkoutUsecase) ReplaceWorkoutSession(context.Context, *workout.WorkoutSession) (*workout.WorkoutSession, error) {
+	return nil, f.calls.hit()
+}
+func (f fakeWorkoutUsecase) PatchWorkoutSession(context.Context, string, workout.WorkoutSessionPatch) (*workout.WorkoutSession, error) {
+	return nil, f.calls.hit()
+}
+func (f fakeWorkoutUsecase) FinishWorkoutSession(context.Context, string) (*workout.WorkoutSession, []record.PersonalRecord, error) {
+	return nil, nil, f.calls.hit()
+}
+func (f fakeWorkoutUsecase) DeleteWorkoutSession(context.Context, string) error {
+	return f.calls.hit()
+}
+func (f fakeWorkoutUsecase) AddWorkoutSet(context.Context, string, string, *workout.WorkoutSet) (*workout.WorkoutSession, error) {
+	return nil, f.calls.hit()
+}
+func (f fakeWorkoutUsecase) UpdateWorkoutSet(context.Context, string, *workout.WorkoutSet) (*workout.WorkoutSession, error) {
+	return nil, f.calls.hit()
+}
+func (f fakeWorkoutUsecase) DeleteWorkoutSet(context.Context, string, string) (*workout.WorkoutSession, error) {
+	return nil, f.calls.hit()
+}
+
+type fakeSplitUsecase struct {
+	domainuc.SplitUsecase
+	owners owners
+	calls  *usecaseCalls
+}
+
+func (f fakeSplitUsecase) GetTemplateOwner(_ context.Context, id string) (string, error) {
+	return f.owners.owner(id)
+}
+func (f fakeSplitUsecase) GetTemplate(context.Context, string) (*split.SplitTemplate, error) {
+	return nil, f.calls.hit()
+}
+func (f fakeSplitUsecase) UpdateTemplate(context.Context, *split.SplitTemplate) error {
+	return f.calls.hit()
+}
+func (f fakeSplitUsecase) ActivateTemplate(context.Context, string, string) error {
+	return f.calls.hit()
+}
+func (f fakeSplitUsecase) DeactivateTemplate(context.Context, string, string) error {
+	return f.calls.hit()
+}
+func (f fakeSplitUsecase) GetUserTemplates(context.Context, string) ([]split.SplitTemplate, error) {
+	return nil, f.calls.hit()
+}
+
+type fakeNutritionUsecase struct {
+	domainuc.NutritionUsecase
+	calls *usecaseCalls
+}
+
+func (f fakeNutritionUsecase) GetByDate(context.Context, string, string) (*nutrition.DailyNutrition, error) {
+	return nil, f.calls.hit()
+}
+
+type fakePlannerUsecase struct {
+	domainuc.PlannerUsecase
+	calls *usecaseCalls
+}
+
+func (f fakePlannerUsecase) GenerateRecommendation(context.Context, string) (*planner.PlannerRecommendation, error) {
+	return nil, f.calls.hit()
+}
+func (f fakePlannerUsecase) GetUserRecommendations(context.Context, string) ([]planner.PlannerRecommendation, error) {
+	return nil, f.calls.hit()
+}
+
+type fakeWorkoutPlanUsecase struct {
+	domainuc.WorkoutPlanUsecase
+	owners owners
+	calls  *usecaseCalls
+}
+
+func (f fakeWorkoutPlanUsecase) GetPlanOwner(_ context.Context, id string) (string, error) {
+	return f.owners.owner(id)
+}
+func (f fakeWorkoutPlanUsecase) GetPlan(context.Context, string) (*workout.WorkoutPlan, error) {
+	return nil, f.calls.hit()
+}
+func (f fakeWorkoutPlanUsecase) ExplainPlan(context.Context, uuid.UUID, string) (*workout.WorkoutExplanation, error) {
+	return nil, f.calls.hit()
+}
+func (f fakeWorkoutPlanUsecase) StartPlan(context.Context, string) (*workout.WorkoutSession, error) {
+	return nil, f.calls.hit()
+}
+func (f fakeWorkoutPlanUsecase) ComparePlan(context.Context, string) (*workout.PlanComparison, error) {
+	return nil, f.calls.hit()
+}
+
+type fakeChatUsecase struct {
+	domainuc.ChatUsecase
+	owners owners
+	calls  *usecaseCalls
+}
+
+func (f fakeChatUsecase) GetThreadOwner(_ context.Context, id string) (string, error) {
+	return f.owners.owner(id)
+}
+func (f fakeChatUsecase) GetThread(context.Context, string) (*chat.Thread, error) {
+	return nil, f.calls.hit()
+}
+func (f fakeChatUsecase) DeleteThread(context.Context, string) error {
+	return f.calls.hit()
+}
+func (f fakeChatUsecase) ListMessages(context.Context, string) ([]chat.Message, error) {
+	return nil, f.calls.hit()
+}
+func (f fakeChatUsecase) SendMessage(context.Context, uuid.UUID, string, string) (*chat.Message, error) {
+	return nil, f.calls.hit()
+}
+
+type fakeAgentUsecase struct {
+	domainuc.AgentUsecase
+	owners owners
+	calls  *usecaseCalls
+}
+
+func (f fakeAgentUsecase) GetActionOwner(_ context.Context, id string) (string, error) {
+	return f.owners.owner(id)
+}
+func (f fakeAgentUsecase) GetAction(context.Context, string) (*agent.Action, error) {
+	return nil, f.calls.hit()
+}
+func (f fakeAgentUsecase) ConfirmAction(context.Context, string) (*agent.Action, error) {
+	return nil, f.calls.hit()
+}
+func (f fakeAgentUsecase) RejectAction(context.Context, string) (*agent.Action, error) {
+	return nil, f.calls.hit()
+}
+
+type fakeAuthUsecase struct {
+	domainuc.AuthUsecase
+	owners owners
+	calls  *usecaseCalls
+}
+
+func (f fakeAuthUsecase) GetSessionOwner(_ context.Context, id string) (string, error) {
+	return f.owners.owner(id)
+}
+func (f fakeAuthUsecase) RevokeSession(context.Context, string) error {
+	return f.calls.hit()
+}
+
+type ownershipFixture struct {
+	router *gin.Engine
+	keys   *jwtkeys.Keyring
+	calls  *usecaseCalls
+	// resource is owned by userA.
+	resource string
+}
+
+var (
+	userA = uuid.NewString()
+	userB = uuid.NewString()
+	admin = uuid.NewString()
+)
+
+func newOwnershipFixture(t *testing.T) *ownershipFixture {
+	t.Helper()
+	keys, err := jwtkeys.NewHMAC("router-test-secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &ownershipFixture{keys: keys, calls: &usecaseCalls{}, resource: uuid.NewString()}
+	own := owners{f.resource: userA}
+
+	f.router = SetupRouter(
+		handler.NewWorkoutHandler(fakeWorkoutUsecase{owners: own, calls: f.calls}),
+		handler.NewSplitHandler(fakeSplitUsecase{owners: own, calls: f.calls}),
+		handler.NewNutritionHandler(fakeNutritionUsecase{calls: f.calls}),
+		handler.NewPlannerHandler(fakePlannerUsecase{calls: f.calls}),
+		handler.NewExerciseHandler(nil),
+		handler.NewAICoachHandler(nil),
+		handler.NewAuthHandler(fakeAuthUsecase{owners: own, calls: f.calls}),
+		handler.NewAdminHandler(nil, nil),
+		handler.NewRecordHandler(nil),
+		handler.NewAnalyticsHandler(nil),
+		handler.NewUserHandler(nil),
+		handler.NewChatHandler(fakeChatUsecase{owners: own, calls: f.calls}),
+		handler.NewAgentHandler(fakeAgentUsecase{owners: own, calls: f.calls}),
+		handler.NewWorkoutPlanHandler(fakeWorkoutPlanUsecase{owners: own, calls: f.calls}),
+		keys,
+		nil,
+		nil,
+		RateLimits{},
+	)
+	return f
+}
+
+func (f *ownershipFixture) token(t *testing.T, userID, role string) string {
+	t.Helper()
+	return f.signedToken(t, userID, role, true)
+}
+
+func (f *ownershipFixture) signedToken(t *testing.T, userID, role string, emailVerified bool) string {
+	t.Helper()
+	signed, err := f.keys.Sign(jwt.MapClaims{
+		"user_id":        userID,
+		"sid":            uuid.NewString(),
+		"role":           role,
+		"email_verified": emailVerified,
+		"exp":            time.Now().Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func (f *ownershipFixture) do(t *testing.T, method, path, token string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader("{}"))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, req)
+	return w
+}
+
+// guardedRoutes lists every route behind RequireSelf ({user}) or
+// RequireOwner ({id}). {child} is a nested id the guard does not look at.
+var guardedRoutes = []struct {
+	method string
+	path   string
+}{
+	{http.MethodDelete, "/api/v1/auth/sessions/{id}"},
+
+	{http.MethodGet, "/api/v1/workouts/{id}"},
+	{http.MethodPut, "/api/v1/workouts/{id}"},
+	{http.MethodPatch, "/api/v1/workouts/{id}"},
+	{http.MethodDelete, "/api/v1/workouts/{id}"},
+	{http.MethodPost, "/api/v1/workouts/{id}/finish"},
+	{http.MethodPost, "/api/v1/workouts/{id}/exercises/{child}/sets"},
+	{http.MethodPut, "/api/v1/workouts/{id}/sets/{child}"},
+	{http.MethodDelete, "/api/v1/workouts/{id}/sets/{child}"},
+	{http.MethodGet, "/api/v1/workouts/user/{user}"},
+
+	{http.MethodGet, "/api/v1/splits/{id}"},
+	{http.MethodPut, "/api/v1/splits/{id}"},
+	{http.MethodPost, "/api/v1/splits/{id}/activate"},
+	{http.MethodPost, "/api/v1/splits/{id}/deactivate"},
+	{http.MethodGet, "/api/v1/splits/user/{user}"},
+
+	{http.MethodGet, "/api/v1/nutrition/user/{user}?date=2026-03-02"},
+
+	{http.MethodPost, "/api/v1/planner/generate/{user}"},
+	{http.MethodGet, "/api/v1/planner/user/{user}"},
+
+	{http.MethodGet, "/api/v1/ai/workout-plans/{id}"},
+	{http.MethodPost, "/api/v1/ai/workout-plans/{id}/explain"},
+	{http.MethodPost, "/api/v1/ai/workout-plans/{id}/start"},
+	{http.MethodGet, "/api/v1/ai/workout-plans/{id}/comparison"},
+
+	{http.MethodGet, "/api/v1/ai/chat/threads/{id}"},
+	{http.MethodDelete, "/api/v1/ai/chat/threads/{id}"},
+	{http.MethodGet, "/api/v1/ai/chat/threads/{id}/messages"},
+	{http.MethodPost, "/api/v1/ai/chat/threads/{id}/messages"},
+
+	{http.MethodGet, "/api/v1/ai/agent/actions/{id}"},
+	{http.MethodPost, "/api/v1/ai/agent/actions/{id}/confirm"},
+	{http.MethodPost, "/api/v1/ai/agent/actions/{id}/reject"},
+}
+
+func TestOwnershipGuards(t *testing.T) {
+	f := newOwnershipFixture(t)
+	callers := []struct {
+		name    string
+		userID  string
+		role    string
+		allowed bool
+	}{
+		{name: "owner", userID: userA, role: "user", allowed: true},
+		{name: "other user", userID: userB, role: "user", allowed: false},
+		{name: "admin", userID: admin, role: "admin", allowed: true},
+	}
+
+	for _, route := range guardedRoutes {
+		path := strings.NewReplacer("{id}", f.resource, "{user}", userA, "{child}", uuid.NewString()).Replace(route.path)
+		for _, caller := range callers {
+			t.Run(route.method+" "+route.path+" as "+caller.name, func(t *testing.T) {
+				before := f.calls.count()
+				w := f.do(t, route.method, path, f.token(t, caller.userID, caller.role))
+
+				if !caller.allowed {
+					if w.Code != http.StatusForbidden {
+						t.Errorf("status = %d, want 403: %s", w.Code, w.Body)
+					}
+					if f.calls.count() != before {
+						t.Error("the request reached the usecase")
+					}
+					return
+				}
+				// Past the guard the handler either rejects the empty body
+				// or calls the usecase.
+				if w.Code != http.StatusBadRequest && w.Code != http.StatusConflict {
+					t.Errorf("status = %d, want the handler's 400 or 409: %s", w.Code, w.Body)
+				}
+			})
+		}
+	}
+}
+
+func TestOwnerGuardsReportUnknownResources(t *testing.T) {
+	f := newOwnershipFixture(t)
+	for _, route := range guardedRoutes {
+		if !strings.Contains(route.path, "{id}") {
+			continue
+		}
+		for _, id := range []string{uuid.NewString(), "not-a-uuid"} {
+			want := http.StatusNotFound
+			if id == "not-a-uuid" {
+				want = http.StatusBadRequest
+			}
+			path := strings.NewReplacer("{id}", id, "{child}", uuid.NewString()).Replace(route.path)
+			w := f.do(t, route.method, path, f.token(t, userA, "user"))
+			if w.Code != want {
+				t.Errorf("%s %s = %d, want %d", route.method, path, w.Code, want)
+			}
+		}
+	}
+	if n := f.calls.count(); n != 0 {
+		t.Errorf("%d requests for unknown resources reached a usecase", n)
+	}
+}
+
+// TestAIRoutesRequireAVerifiedEmail covers every route under /ai and the
+// planner, which calls the model too.
+func TestAIRoutesRequireAVerifiedEmail(t *testing.T) {
+	f := newOwnershipFixture(t)
+	unverified := f.signedToken(t, userA, "user", false)
+
+	var checked int
+	for _, route := range f.router.Routes() {
+		if !strings.HasPrefix(route.Path, "/api/v1/ai/") && !strings.HasPrefix(route.Path, "/api/v1/planner/generate/") {
+			continue
+		}
+		checked++
+		path := strings.NewReplacer(":user_id", userA, ":id", f.resource, ":set_id", uuid.NewString(), ":workout_exercise_id", uuid.NewString()).Replace(route.Path)
+		if w := f.do(t, route.Method, path, unverified); w.Code != http.StatusForbidden {
+			t.Errorf("%s %s with an unverified email = %d, want 403: %s", route.Method, path, w.Code, w.Body)
+		}
+	}
+	if checked == 0 {
+		t.Fatal("no AI routes found")
+	}
+	if n := f.calls.count(); n != 0 {
+		t.Errorf("%d requests with an unverified email reached a usecase", n)
+	}
+
+	// The same caller, verified, gets past the check.
+	w := f.do(t, http.MethodGet, "/api/v1/ai/chat/threads/"+f.resource, f.token(t, userA, "user"))
+	if w.Code == http.StatusForbidden {
+		t.Errorf("verified caller = %d: %s", w.Code, w.Body)
+	}
+}
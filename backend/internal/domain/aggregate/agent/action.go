@@ -0,0 +1,70 @@
+package agent
+
+import (
+	"encoding/json"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/coach"
+)
+
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusApplied  Status = "applied"
+	StatusRejected Status = "rejected"
+)
+
+const (
+	// ActionTTL is how long a proposed change can still be confirmed.
+	ActionTTL = 30 * time.Minute
+	// MaxMessageLen bounds what a user can ask the agent in one request.
+	MaxMessageLen = 2000
+)
+
+// Action is a change proposed by one of the agent's write tools. Nothing is
+// applied until the user confirms it.
+type Action struct {
+	ID        string
+	UserID    string
+	Tool      string
+	Arguments json.RawMessage
+	Summary   string
+	Changes   []Change
+	// BaseVersion fingerprints the data the changes were computed against;
+	// confirming fails if that data has changed since.
+	BaseVersion string
+	Status      Status
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+	ResolvedAt  *time.Time
+}
+
+// Expired reports whether a pending action can no longer be confirmed.
+func (a Action) Expired(now time.Time) bool {
+	return a.Status == StatusPending && !now.Before(a.ExpiresAt)
+}
+
+// Change is one line of the dry-run diff shown before confirming. Before is
+// empty for additions and After for removals.
+type Change struct {
+	Path   string `json:"path"`
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
+}
+
+// Step records one tool call the agent made.
+type Step struct {
+	Tool      string
+	Arguments json.RawMessage
+	Result    string
+	Error     string
+}
+
+// Result is the agent's answer together with the changes it proposed.
+type Result struct {
+	Reply   string
+	Steps   []Step
+	Actions []Action
+	Source  coach.Source
+}
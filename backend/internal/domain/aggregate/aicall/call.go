@@ -0,0 +1,100 @@
+package aicall
+
+import "time"
+
+type Outcome string
+
+const (
+	OutcomeOK            Outcome = "ok"
+	OutcomeInvalidOutput Outcome = "invalid_output"
+	OutcomeError         Outcome = "error"
+)
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 200
+)
+
+// Call is one model request made by the orchestrator. Repairs of an invalid
+// answer are separate calls with Attempt > 0.
+type Call struct {
+	ID               string
+	UserID           *string
+	Operation        string
+	Attempt          int
+	Provider         string
+	Model            string
+	PromptHash       string
+	PromptVersion    string
+	Response         string
+	Outcome          Outcome
+	Error            string
+	LatencyMs        int
+	PromptTokens     int
+	CompletionTokens int
+	TotalTokens      int
+	CreatedAt        time.Time
+}
+
+// Filter narrows the call log. Zero values mean "no filter". From is
+// inclusive and To exclusive.
+type Filter struct {
+	UserID    string
+	Operation string
+	Outcome   Outcome
+	From      *time.Time
+	To        *time.Time
+	Cursor    string
+	Limit     int
+}
+
+type Page struct {
+	Items      []Call
+	NextCursor string
+}
+
+type GroupBy string
+
+const (
+	GroupByDay       GroupBy = "day"
+	GroupByOperation GroupBy = "operation"
+	GroupByUser      GroupBy = "user"
+	GroupByModel     GroupBy = "model"
+)
+
+func ParseGroupBy(s string) (GroupBy, bool) {
+	switch g := GroupBy(s); g {
+	case GroupByDay, GroupByOperation, GroupByUser, GroupByModel:
+		return g, true
+	case "":
+		return GroupByDay, true
+	default:
+		return "", false
+	}
+}
+
+// Stat aggregates the calls sharing one group key.
+type Stat struct {
+	Key              string
+	Calls            int
+	InvalidOutputs   int
+	Errors           int
+	PromptTokens     int64
+	CompletionTokens int64
+	TotalTokens      int64
+	AvgLatencyMs     float64
+}
+
+// Usage is what a user (or everyone) consumed within a window.
+type Usage struct {
+	Calls  int64
+	Tokens int64
+}
+
+// Quota caps daily usage. Zero fields are unlimited.
+type Quota struct {
+	UserDailyCalls    int64
+	UserDailyTokens   int64
+	GlobalDailyCalls  int64
+	GlobalDailyTokens int64
+}
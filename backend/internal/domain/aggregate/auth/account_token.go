@@ -0,0 +1,29 @@
+package auth
+
+import "time"
+
+// Purposes of single-use account tokens.
+const (
+	TokenPurposePasswordReset     = "password_reset"
+	TokenPurposeEmailVerification = "email_verification"
+)
+
+// AccountToken is a single-use token mailed to a user to reset their
+// password or verify their email. Only its SHA-256 hash is stored.
+type AccountToken struct {
+	ID        string
+	UserID    string
+	Purpose   string
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+// AccountMail configures the account emails: AppURL is the web app the
+// links point to, and the TTLs bound how long each link works.
+type AccountMail struct {
+	AppURL           string
+	PasswordResetTTL time.Duration
+	VerificationTTL  time.Duration
+}
@@ -0,0 +1,26 @@
+package auth
+
+import "time"
+
+// Identity links a user to an account at an external sign-in provider
+// ("google", "github", ...). Subject is the provider's stable user id.
+type Identity struct {
+	ID          string
+	UserID      string
+	Provider    string
+	Subject     string
+	Email       string
+	CreatedAt   time.Time
+	LastLoginAt time.Time
+}
+
+// OAuthState is kept between the redirect to a provider and its callback.
+// State is echoed back by the provider, Nonce comes back inside the ID
+// token and CodeVerifier proves the code exchange is ours (PKCE).
+type OAuthState struct {
+	State        string
+	Provider     string
+	Nonce        string
+	CodeVerifier string
+	CreatedAt    time.Time
+}
@@ -0,0 +1,37 @@
+package auth
+
+import "time"
+
+// maxLockout caps the lockout when no shorter MaxDelay is configured.
+const maxLockout = 24 * time.Hour
+
+// LockoutPolicy slows down password guessing. From Threshold consecutive
+// failures on, each failure locks the account for that client for
+// BaseDelay, doubled per further failure and capped at MaxDelay. Failures
+// are forgotten after Window without a new one, or on a successful login.
+type LockoutPolicy struct {
+	Threshold int
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	Window    time.Duration
+}
+
+func (p LockoutPolicy) Enabled() bool {
+	return p.Threshold > 0 && p.BaseDelay > 0
+}
+
+// Delay is the lockout after the given number of consecutive failures.
+func (p LockoutPolicy) Delay(failures int) time.Duration {
+	if !p.Enabled() || failures < p.Threshold {
+		return 0
+	}
+	limit := maxLockout
+	if p.MaxDelay > 0 && p.MaxDelay < limit {
+		limit = p.MaxDelay
+	}
+	d := p.BaseDelay
+	for i := p.Threshold; i < failures && d < limit; i++ {
+		d *= 2
+	}
+	return min(d, limit)
+}
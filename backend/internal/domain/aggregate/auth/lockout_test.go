@@ -0,0 +1,35 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLockoutDelay(t *testing.T) {
+	policy := LockoutPolicy{Threshold: 5, BaseDelay: time.Minute, MaxDelay: 10 * time.Minute}
+
+	tests := []struct {
+		name     string
+		policy   LockoutPolicy
+		failures int
+		want     time.Duration
+	}{
+		{"below the threshold", policy, 4, 0},
+		{"at the threshold", policy, 5, time.Minute},
+		{"doubles per failure", policy, 6, 2 * time.Minute},
+		{"doubles again", policy, 8, 8 * time.Minute},
+		{"capped at MaxDelay", policy, 9, 10 * time.Minute},
+		{"stays capped", policy, 1000, 10 * time.Minute},
+		{"without MaxDelay caps at a day", LockoutPolicy{Threshold: 1, BaseDelay: time.Hour}, 40, 24 * time.Hour},
+		{"MaxDelay above a day is still capped", LockoutPolicy{Threshold: 1, BaseDelay: time.Hour, MaxDelay: 72 * time.Hour}, 40, 24 * time.Hour},
+		{"disabled without a threshold", LockoutPolicy{BaseDelay: time.Minute}, 10, 0},
+		{"disabled without a delay", LockoutPolicy{Threshold: 3}, 10, 0},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.policy.Delay(tc.failures); got != tc.want {
+				t.Errorf("Delay(%d) = %v, want %v", tc.failures, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package auth
+
+import "time"
+
+// Session is one signed-in device. It owns a chain of refresh tokens, each
+// replacing the previous one; revoking the session ends the chain.
+type Session struct {
+	ID         string
+	UserID     string
+	UserAgent  string
+	IP         string
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+	// ExpiresAt moves forward with every refresh.
+	ExpiresAt time.Time
+	RevokedAt *time.Time
+}
+
+func (s Session) Active(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// RefreshToken is stored by hash only. UsedAt is set when it is exchanged;
+// presenting it again means it leaked.
+type RefreshToken struct {
+	ID        string
+	SessionID string
+	TokenHash string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+}
+
+// Client describes the device signing in.
+type Client struct {
+	UserAgent string
+	IP        string
+}
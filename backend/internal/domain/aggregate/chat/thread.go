@@ -0,0 +1,51 @@
+package chat
+
+import (
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/coach"
+)
+
+type Role string
+
+const (
+	RoleUser      Role = "user"
+	RoleAssistant Role = "assistant"
+)
+
+const (
+	// MaxMessageLen bounds what a user can send in one message.
+	MaxMessageLen = 2000
+	// HistoryLimit is how many earlier messages are offered to the model
+	// (before the token budget trims them further).
+	HistoryLimit = 20
+	// DefaultTitle names threads created without a title.
+	DefaultTitle = "New chat"
+)
+
+type Thread struct {
+	ID        string
+	UserID    string
+	Title     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type Message struct {
+	ID        string
+	ThreadID  string
+	Role      Role
+	Content   string
+	Citations []Citation
+	// Source is set on assistant messages only.
+	Source    coach.Source
+	CreatedAt time.Time
+}
+
+// Citation points to a piece of user data an assistant reply relied on,
+// e.g. Kind "session" with Ref set to the workout session id.
+type Citation struct {
+	Kind  string `json:"kind"`
+	Ref   string `json:"ref,omitempty"`
+	Label string `json:"label"`
+}
@@ -0,0 +1,65 @@
+package coach
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// Persona is the character the coach writes in.
+type Persona string
+
+const (
+	PersonaBrutal     Persona = "brutal"
+	PersonaSupportive Persona = "supportive"
+	PersonaClinical   Persona = "clinical"
+	// PersonaCustom uses the user's own description of the coach.
+	PersonaCustom Persona = "custom"
+)
+
+const (
+	DefaultPersona  = PersonaSupportive
+	DefaultLanguage = "en"
+
+	// MaxCustomPersonaLen bounds the user-written persona description.
+	MaxCustomPersonaLen = 500
+)
+
+func ParsePersona(s string) (Persona, bool) {
+	switch p := Persona(strings.ToLower(strings.TrimSpace(s))); p {
+	case PersonaBrutal, PersonaSupportive, PersonaClinical, PersonaCustom:
+		return p, true
+	case "":
+		return DefaultPersona, true
+	default:
+		return DefaultPersona, false
+	}
+}
+
+// Voice is how AI answers should sound for one user.
+type Voice struct {
+	Persona       Persona
+	CustomPersona string
+	Language      string
+	Profanity     bool
+}
+
+func DefaultVoice() Voice {
+	return Voice{Persona: DefaultPersona, Language: DefaultLanguage}
+}
+
+// Key identifies the voice in cache keys: two voices share a key only if
+// they would produce the same kind of message.
+func (v Voice) Key() string {
+	persona := string(v.Persona)
+	if v.Persona == PersonaCustom {
+		sum := sha256.Sum256([]byte(v.CustomPersona))
+		persona += "-" + hex.EncodeToString(sum[:4])
+	}
+	profanity := "clean"
+	if v.Profanity {
+		profanity = "profane"
+	}
+	return fmt.Sprintf("%s:%s:%s", persona, v.Language, profanity)
+}
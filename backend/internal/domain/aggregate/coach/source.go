@@ -0,0 +1,22 @@
+package coach
+
+// Source tells which engine produced a coaching answer.
+type Source string
+
+const (
+	SourceAI    Source = "ai"
+	SourceRules Source = "rules"
+)
+
+type Motivation struct {
+	Message string
+	Source  Source
+}
+
+type CoachingSuggestions struct {
+	Suggestions []string
+	Source      Source
+	// PromptVariant is the prompt template version the user was assigned;
+	// empty for rule-based answers.
+	PromptVariant string
+}
@@ -1,6 +1,10 @@
 package planner
 
-import "time"
+import (
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/coach"
+)
 
 type PlannerRecommendation struct {
 	ID                 string
@@ -8,5 +12,7 @@ type PlannerRecommendation struct {
 	WorkoutSessionID   *string
 	Recommendation     string
 	RecommendationType string
+	Source             coach.Source
+	PromptVariant      string
 	CreatedAt          time.Time
 }
@@ -0,0 +1,15 @@
+package prompt
+
+import "time"
+
+// Template is one version of a prompt, written with text/template. Among
+// the versions of the same prompt, users are spread over those with a
+// positive Weight, in proportion to it; a zero weight keeps a version
+// around without assigning anyone to it.
+type Template struct {
+	Name      string
+	Version   string
+	Body      string
+	Weight    int
+	CreatedAt time.Time
+}
@@ -0,0 +1,26 @@
+package ratelimit
+
+import "time"
+
+// Policy allows Limit requests per sliding Window. Name scopes the
+// counters, so two policies never share them. A zero Limit disables it.
+type Policy struct {
+	Name   string
+	Limit  int
+	Window time.Duration
+}
+
+func (p Policy) Enabled() bool {
+	return p.Limit > 0 && p.Window > 0
+}
+
+// Decision is the outcome of one request against a policy.
+type Decision struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is how long until the window has room for a full Limit again;
+	// RetryAfter, set when the request was refused, until the next slot.
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
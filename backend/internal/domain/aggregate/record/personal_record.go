@@ -0,0 +1,39 @@
+package record
+
+import "time"
+
+type RecordType string
+
+const (
+	RecordHeaviestWeight RecordType = "heaviest_weight"
+	RecordRepsAtWeight   RecordType = "reps_at_weight"
+	RecordEstimated1RM   RecordType = "estimated_1rm"
+	RecordSessionVolume  RecordType = "session_volume"
+)
+
+// PersonalRecord is a user's best for one exercise and record type.
+// Value is in kg for weight/e1RM/volume records and in reps for
+// reps_at_weight, which is additionally keyed by Weight.
+type PersonalRecord struct {
+	ID               string
+	UserID           string
+	ExerciseID       string
+	Type             RecordType
+	Value            float64
+	Weight           float64
+	Reps             int
+	Formula          string
+	WorkoutSessionID *string
+	WorkoutSetID     *string
+	AchievedAt       time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// WeightKey is the part of the record identity beyond (user, exercise, type).
+func (r PersonalRecord) WeightKey() float64 {
+	if r.Type == RecordRepsAtWeight {
+		return r.Weight
+	}
+	return 0
+}
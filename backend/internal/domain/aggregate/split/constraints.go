@@ -0,0 +1,183 @@
+package split
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Constraints are what a generated split has to fit: the lifter's level,
+// the equipment they have, how long a session may take and what they must
+// avoid.
+type Constraints struct {
+	ExperienceLevel string
+	// Equipment is the canonical equipment available. Empty means a full
+	// gym.
+	Equipment []string
+	// MaxSessionMinutes of zero means no limit.
+	MaxSessionMinutes int
+	// Injuries is free text for the coach; ExcludedMovements are checked.
+	Injuries          string
+	ExcludedMovements []string
+	// PreferredDays are lowercase weekday abbreviations ("mon" ... "sun").
+	PreferredDays []string
+}
+
+const (
+	ExperienceBeginner     = "beginner"
+	ExperienceIntermediate = "intermediate"
+	ExperienceAdvanced     = "advanced"
+
+	DefaultExperienceLevel = ExperienceIntermediate
+)
+
+const (
+	MaxSessionMinutes    = 240
+	MaxInjuriesLen       = 500
+	MaxExcludedMovements = 20
+	MaxMovementLen       = 60
+)
+
+func ParseExperienceLevel(s string) (string, bool) {
+	switch level := strings.ToLower(strings.TrimSpace(s)); level {
+	case ExperienceBeginner, ExperienceIntermediate, ExperienceAdvanced:
+		return level, true
+	case "":
+		return DefaultExperienceLevel, true
+	default:
+		return DefaultExperienceLevel, false
+	}
+}
+
+// Equipment names. They match the equipment words exercise names use
+// ("Dumbbell Row", "EZ Bar Curl"), so generated exercises can be checked
+// against them.
+const (
+	EquipmentBarbell    = "barbell"
+	EquipmentDumbbell   = "dumbbell"
+	EquipmentCable      = "cable"
+	EquipmentMachine    = "machine"
+	EquipmentKettlebell = "kettlebell"
+	EquipmentSmith      = "smith"
+	EquipmentEZBar      = "ez"
+	EquipmentBand       = "band"
+	EquipmentLandmine   = "landmine"
+	EquipmentBodyweight = "bodyweight"
+)
+
+// equipmentAliases maps accepted spellings and presets to equipment. A nil
+// entry is the full gym preset.
+var equipmentAliases = map[string][]string{
+	"full_gym":       nil,
+	"home_gym":       {EquipmentBarbell, EquipmentDumbbell, EquipmentBand, EquipmentBodyweight},
+	"dumbbells_only": {EquipmentDumbbell, EquipmentBodyweight},
+	"bodyweight":     {EquipmentBodyweight},
+	"barbell":        {EquipmentBarbell},
+	"dumbbell":       {EquipmentDumbbell},
+	"dumbbells":      {EquipmentDumbbell},
+	"cable":          {EquipmentCable},
+	"cables":         {EquipmentCable},
+	"machine":        {EquipmentMachine},
+	"machines":       {EquipmentMachine},
+	"kettlebell":     {EquipmentKettlebell},
+	"kettlebells":    {EquipmentKettlebell},
+	"smith":          {EquipmentSmith},
+	"smith_machine":  {EquipmentSmith},
+	"ez":             {EquipmentEZBar},
+	"ez_bar":         {EquipmentEZBar},
+	"band":           {EquipmentBand},
+	"bands":          {EquipmentBand},
+	"landmine":       {EquipmentLandmine},
+}
+
+// ParseEquipment expands presets and aliases into sorted canonical
+// equipment. "full_gym" anywhere in items means no restriction and yields
+// an empty list.
+func ParseEquipment(items []string) ([]string, error) {
+	set := map[string]bool{}
+	for _, item := range items {
+		key := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(item)), " ", "_")
+		expanded, ok := equipmentAliases[key]
+		if !ok {
+			return nil, fmt.Errorf("unknown equipment %q", item)
+		}
+		if expanded == nil {
+			return []string{}, nil
+		}
+		for _, e := range expanded {
+			set[e] = true
+		}
+	}
+	out := make([]string, 0, len(set))
+	for e := range set {
+		out = append(out, e)
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+var weekdays = []string{"mon", "tue", "wed", "thu", "fri", "sat", "sun"}
+
+// ParseWeekdays accepts full or abbreviated English weekday names and
+// returns them abbreviated, in week order, without duplicates.
+func ParseWeekdays(items []string) ([]string, error) {
+	set := map[string]bool{}
+	for _, item := range items {
+		day := strings.ToLower(strings.TrimSpace(item))
+		if len(day) < 3 {
+			return nil, fmt.Errorf("unknown weekday %q", item)
+		}
+		found := false
+		for _, w := range weekdays {
+			if strings.HasPrefix(day, w) {
+				set[w] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown weekday %q", item)
+		}
+	}
+	out := make([]string, 0, len(set))
+	for _, w := range weekdays {
+		if set[w] {
+			out = append(out, w)
+		}
+	}
+	return out, nil
+}
+
+// ParseMovements trims and deduplicates excluded movements.
+func ParseMovements(items []string) ([]string, error) {
+	if len(items) > MaxExcludedMovements {
+		return nil, fmt.Errorf("at most %d excluded movements", MaxExcludedMovements)
+	}
+	seen := map[string]bool{}
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		m := strings.TrimSpace(item)
+		if m == "" {
+			continue
+		}
+		if len([]rune(m)) > MaxMovementLen {
+			return nil, fmt.Errorf("excluded movement %q is too long", m)
+		}
+		if key := strings.ToLower(m); !seen[key] {
+			seen[key] = true
+			out = append(out, m)
+		}
+	}
+	return out, nil
+}
+
+// ConstraintsOverride replaces stored constraints field by field. Nil
+// fields keep the stored value.
+type ConstraintsOverride struct {
+	ExperienceLevel   *string
+	Equipment         *[]string
+	MaxSessionMinutes *int
+	Injuries          *string
+	ExcludedMovements *[]string
+	PreferredDays     *[]string
+}
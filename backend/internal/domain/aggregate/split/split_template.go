@@ -29,3 +29,19 @@ type SplitExercise struct {
 	TargetWeight float64
 	Notes        string
 }
+
+// UnresolvedExercise is a generated exercise that matched no library
+// exercise confidently. It is kept in the split without an exercise id
+// (its name in Notes) until the user maps it.
+type UnresolvedExercise struct {
+	DayOrder    int
+	DayName     string
+	Name        string
+	Suggestions []ExerciseSuggestion
+}
+
+type ExerciseSuggestion struct {
+	ExerciseID string
+	Name       string
+	Score      float64
+}
@@ -0,0 +1,43 @@
+package user
+
+import "time"
+
+// Preferences are per-user settings that tune how the app computes and
+// presents training data.
+type Preferences struct {
+	UserID        string
+	LoadModel     string
+	BodyWeightKg  *float64
+	CoachPersona  string
+	CustomPersona string
+	Language      string
+	Profanity     bool
+
+	// Split generation constraints; see split.Constraints.
+	ExperienceLevel   string
+	Equipment         []string
+	MaxSessionMinutes int
+	Injuries          string
+	ExcludedMovements []string
+	PreferredDays     []string
+
+	UpdatedAt time.Time
+}
+
+// PreferencesPatch updates only the fields that are set. A BodyWeightKg or
+// MaxSessionMinutes of zero clears the stored value.
+type PreferencesPatch struct {
+	LoadModel     *string
+	BodyWeightKg  *float64
+	CoachPersona  *string
+	CustomPersona *string
+	Language      *string
+	Profanity     *bool
+
+	ExperienceLevel   *string
+	Equipment         *[]string
+	MaxSessionMinutes *int
+	Injuries          *string
+	ExcludedMovements *[]string
+	PreferredDays     *[]string
+}
@@ -8,6 +8,12 @@ type User struct {
 	Email        string
 	PasswordHash string
 	Role         string
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	// EmailVerifiedAt is set once the user follows a verification link.
+	EmailVerifiedAt *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+func (u User) EmailVerified() bool {
+	return u.EmailVerifiedAt != nil
 }
@@ -0,0 +1,152 @@
+package workout
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Comparison outcomes for one exercise.
+const (
+	OutcomeExceeded  = "exceeded"
+	OutcomeHit       = "hit"
+	OutcomeMissed    = "missed"
+	OutcomeSkipped   = "skipped"
+	OutcomeUnplanned = "unplanned"
+)
+
+// weightTolerance absorbs rounding when comparing logged and planned kg.
+const weightTolerance = 0.01
+
+// PlanComparison is how a session went against the plan it was started
+// from.
+type PlanComparison struct {
+	PlanID        string
+	SessionID     string
+	SessionStatus string
+	Exercises     []ExerciseComparison
+}
+
+// ExerciseComparison compares the working sets (warm-ups excluded) of one
+// exercise. ActualReps is the average over working sets and ActualWeight the
+// heaviest working set. RepsDelta is zero inside the planned range and the
+// distance to its nearest end outside it.
+type ExerciseComparison struct {
+	ExerciseID     string
+	Name           string
+	Outcome        string
+	PlannedSets    int
+	ActualSets     int
+	SetsDelta      int
+	PlannedRepsMin int
+	PlannedRepsMax int
+	ActualReps     float64
+	RepsDelta      float64
+	PlannedWeight  float64
+	ActualWeight   float64
+	WeightDelta    float64
+}
+
+// ComparePlan lines up plan and session by exercise id. names labels
+// exercises logged outside the plan; it may be nil.
+func ComparePlan(plan WorkoutPlan, session WorkoutSession, names map[string]string) PlanComparison {
+	out := PlanComparison{PlanID: plan.ID, SessionID: session.ID, SessionStatus: session.Status}
+
+	actual := make(map[string][]WorkoutSet, len(session.Exercises))
+	order := make([]string, 0, len(session.Exercises))
+	for _, ex := range session.Exercises {
+		if _, ok := actual[ex.ExerciseID]; !ok {
+			order = append(order, ex.ExerciseID)
+		}
+		for _, set := range ex.Sets {
+			if !strings.EqualFold(strings.TrimSpace(set.SetType), "warmup") {
+				actual[ex.ExerciseID] = append(actual[ex.ExerciseID], set)
+			}
+		}
+	}
+
+	planned := make(map[string]bool, len(plan.Exercises))
+	for _, p := range plan.Exercises {
+		c := ExerciseComparison{
+			ExerciseID:     p.ExerciseID,
+			Name:           p.Name,
+			PlannedSets:    p.Sets,
+			PlannedRepsMin: p.RepsMin,
+			PlannedRepsMax: p.RepsMax,
+			PlannedWeight:  p.Weight,
+		}
+		if p.ExerciseID != "" {
+			planned[p.ExerciseID] = true
+			fillActual(&c, actual[p.ExerciseID])
+		}
+		c.SetsDelta = c.ActualSets - c.PlannedSets
+		c.WeightDelta = round2(c.ActualWeight - c.PlannedWeight)
+		switch {
+		case c.ActualReps < float64(c.PlannedRepsMin):
+			c.RepsDelta = round2(c.ActualReps - float64(c.PlannedRepsMin))
+		case c.PlannedRepsMax > 0 && c.ActualReps > float64(c.PlannedRepsMax):
+			c.RepsDelta = round2(c.ActualReps - float64(c.PlannedRepsMax))
+		}
+		c.Outcome = outcome(c)
+		if c.ActualSets == 0 {
+			c.RepsDelta, c.WeightDelta = 0, 0
+		}
+		out.Exercises = append(out.Exercises, c)
+	}
+
+	for _, id := range order {
+		if planned[id] || len(actual[id]) == 0 {
+			continue
+		}
+		c := ExerciseComparison{ExerciseID: id, Name: names[id], Outcome: OutcomeUnplanned}
+		fillActual(&c, actual[id])
+		c.SetsDelta = c.ActualSets
+		out.Exercises = append(out.Exercises, c)
+	}
+	return out
+}
+
+func fillActual(c *ExerciseComparison, sets []WorkoutSet) {
+	if len(sets) == 0 {
+		return
+	}
+	reps := 0
+	for _, set := range sets {
+		reps += set.Reps
+		if set.Weight > c.ActualWeight {
+			c.ActualWeight = set.Weight
+		}
+	}
+	c.ActualSets = len(sets)
+	c.ActualReps = round2(float64(reps) / float64(len(sets)))
+}
+
+func outcome(c ExerciseComparison) string {
+	switch {
+	case c.ActualSets == 0:
+		return OutcomeSkipped
+	case c.ActualSets < c.PlannedSets,
+		c.ActualReps < float64(c.PlannedRepsMin),
+		c.ActualWeight < c.PlannedWeight-weightTolerance:
+		return OutcomeMissed
+	case c.ActualWeight > c.PlannedWeight+weightTolerance,
+		c.PlannedRepsMax > 0 && c.ActualReps > float64(c.PlannedRepsMax):
+		return OutcomeExceeded
+	default:
+		return OutcomeHit
+	}
+}
+
+// Summary describes the comparison in one line, for prompts.
+func (c ExerciseComparison) Summary() string {
+	planned := fmt.Sprintf("planned %dx%d-%d @ %.1f kg", c.PlannedSets, c.PlannedRepsMin, c.PlannedRepsMax, c.PlannedWeight)
+	if c.ActualSets == 0 {
+		return planned + ", not performed (" + c.Outcome + ")"
+	}
+	return fmt.Sprintf("%s, did %d sets averaging %.1f reps with a top set of %.1f kg (%s)",
+		planned, c.ActualSets, c.ActualReps, c.ActualWeight, c.Outcome)
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -1,8 +1,11 @@
 package workout
 
+import "S.P.A.R.T.A/backend/internal/domain/aggregate/coach"
+
 type WorkoutExplanation struct {
 	Summary       string
 	ExerciseNotes []WorkoutExerciseNote
+	Source        coach.Source
 }
 
 type WorkoutExerciseNote struct {
@@ -1,19 +1,40 @@
 package workout
 
-import "time"
+import (
+	"time"
 
-// WorkoutPlan is an AI-generated plan/suggestion (not a logged session).
-// It is safe to return via transport DTOs without coupling the domain to delivery.
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/coach"
+)
+
+const (
+	DefaultPlanPageSize = 20
+	MaxPlanPageSize     = 100
+)
+
+// WorkoutPlan is an AI-generated plan for one split day. It is stored so it
+// can be explained later and started as a draft session.
 type WorkoutPlan struct {
-	UserID     string
-	SplitDayID string
-	Date       time.Time
-	Exercises  []WorkoutPlanExercise
+	ID            string
+	UserID        string
+	SplitDayID    string
+	SplitDayName  string
+	Fatigue       int
+	Date          time.Time
+	Exercises     []WorkoutPlanExercise
+	Source        coach.Source
+	PromptVersion string
+	// SessionID is the session started from the plan, if any.
+	SessionID *string
 }
 
 type WorkoutPlanExercise struct {
-	Name     string
-	Sets     int
-	RepRange string
-	Weight   float64
+	// ExerciseID is empty when the name matched no library exercise; such
+	// exercises are left out when the plan is started.
+	ExerciseID string
+	Name       string
+	Sets       int
+	RepRange   string
+	RepsMin    int
+	RepsMax    int
+	Weight     float64
 }
@@ -2,10 +2,20 @@ package workout
 
 import "time"
 
+// Session statuses. A draft is a session started from a plan and not yet
+// finished; drafts are left out of load, analytics and records.
+const (
+	SessionStatusDraft     = "draft"
+	SessionStatusCompleted = "completed"
+)
+
 type WorkoutSession struct {
-	ID          string
-	UserID      string
-	SplitDayID  *string
+	ID         string
+	UserID     string
+	SplitDayID *string
+	// PlanID is the workout plan the session was started from, if any.
+	PlanID      *string
+	Status      string
 	SessionDate time.Time
 	DurationMin int
 	Notes       string
@@ -0,0 +1,25 @@
+package workout
+
+import "time"
+
+const (
+	DefaultSessionPageSize = 30
+	MaxSessionPageSize     = 100
+)
+
+// SessionFilter narrows a user's session history. Zero values mean "no filter".
+// Cursor is the opaque value returned as SessionPage.NextCursor.
+type SessionFilter struct {
+	From       *time.Time
+	To         *time.Time
+	SplitDayID string
+	ExerciseID string
+	Status     string
+	Cursor     string
+	Limit      int
+}
+
+type SessionPage struct {
+	Items      []WorkoutSession
+	NextCursor string
+}
@@ -0,0 +1,23 @@
+package workout
+
+import "time"
+
+// WorkoutSessionPatch is a partial update of a logged session.
+// Nil header fields are left untouched. Exercises and sets are matched by ID:
+// known IDs are updated in place, entries without an ID are appended and IDs
+// listed in the Remove* slices are dropped.
+type WorkoutSessionPatch struct {
+	SplitDayID        *string
+	SessionDate       *time.Time
+	DurationMin       *int
+	Notes             *string
+	Exercises         []WorkoutExercisePatch
+	RemoveExerciseIDs []string
+}
+
+type WorkoutExercisePatch struct {
+	ID           string
+	ExerciseID   string
+	Sets         []WorkoutSet
+	RemoveSetIDs []string
+}
@@ -1,6 +1,9 @@
 package errors
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
 
 var (
 	ErrNotFound      = errors.New("not found")
@@ -9,5 +12,20 @@ var (
 	ErrForbidden     = errors.New("forbidden")
 	ErrConflict      = errors.New("conflict")
 	ErrAIUnavailable = errors.New("OpenAI API key not configured")
+	ErrQuotaExceeded = errors.New("quota exceeded")
+	ErrRateLimited   = errors.New("too many requests")
 	ErrInternal      = errors.New("internal error")
 )
+
+// RetryAfterError is ErrRateLimited with the wait before a retry can work.
+type RetryAfterError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RetryAfterError) Error() string {
+	return ErrRateLimited.Error()
+}
+
+func (e *RetryAfterError) Unwrap() error {
+	return ErrRateLimited
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/auth"
+)
+
+type AccountTokenRepository interface {
+	Create(ctx context.Context, token *auth.AccountToken) error
+	// Consume marks an unused, unexpired token as used and returns it.
+	// Unknown, used and expired tokens all return ErrInvalidInput.
+	Consume(ctx context.Context, purpose, tokenHash string, at time.Time) (*auth.AccountToken, error)
+	// InvalidateUserTokens marks every outstanding token of a purpose as used.
+	InvalidateUserTokens(ctx context.Context, userID, purpose string, at time.Time) error
+}
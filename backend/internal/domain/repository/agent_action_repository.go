@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/agent"
+)
+
+type AgentActionRepository interface {
+	Create(ctx context.Context, action *agent.Action) error
+	GetByID(ctx context.Context, id string) (*agent.Action, error)
+	GetOwnerID(ctx context.Context, id string) (string, error)
+	// Resolve moves a pending action to status. It returns ErrConflict when
+	// the action is no longer pending.
+	Resolve(ctx context.Context, id string, status agent.Status, at time.Time) error
+}
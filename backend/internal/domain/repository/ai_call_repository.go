@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/aicall"
+)
+
+type AICallRepository interface {
+	Create(ctx context.Context, call *aicall.Call) error
+	List(ctx context.Context, filter aicall.Filter) (*aicall.Page, error)
+	Stats(ctx context.Context, filter aicall.Filter, groupBy aicall.GroupBy) ([]aicall.Stat, error)
+	// UsageSince sums calls and tokens since the given time. An empty userID
+	// covers every user.
+	UsageSince(ctx context.Context, userID string, since time.Time) (aicall.Usage, error)
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/auth"
+)
+
+type AuthSessionRepository interface {
+	CreateSession(ctx context.Context, session *auth.Session) error
+	GetSession(ctx context.Context, id string) (*auth.Session, error)
+	GetSessionOwnerID(ctx context.Context, id string) (string, error)
+	// ListActiveSessions returns the sessions neither revoked nor expired at
+	// now, most recently used first.
+	ListActiveSessions(ctx context.Context, userID string, now time.Time) ([]auth.Session, error)
+	// TouchSession records a refresh and extends the session to expiresAt.
+	TouchSession(ctx context.Context, id string, at, expiresAt time.Time) error
+	// RevokeSession returns ErrNotFound when the session does not exist or
+	// is already revoked.
+	RevokeSession(ctx context.Context, id string, at time.Time) error
+	// RevokeUserSessions revokes every active session of the user and
+	// returns their ids.
+	RevokeUserSessions(ctx context.Context, userID string, at time.Time) ([]string, error)
+
+	CreateRefreshToken(ctx context.Context, token *auth.RefreshToken) error
+	GetRefreshTokenByHash(ctx context.Context, hash string) (*auth.RefreshToken, error)
+	// MarkRefreshTokenUsed returns ErrConflict when the token was already
+	// used, so two concurrent refreshes cannot both succeed.
+	MarkRefreshTokenUsed(ctx context.Context, id string, at time.Time) error
+}
+
+// TokenRevocationRepository is the fast path checked on every request:
+// revoked sessions, and a per-user token version that invalidates every
+// access token issued before it was bumped.
+type TokenRevocationRepository interface {
+	// RevokeSession marks the session revoked for ttl, which must cover the
+	// lifetime of its access tokens.
+	RevokeSession(ctx context.Context, sessionID string, ttl time.Duration) error
+	// BumpUserVersion increments and returns the user's token version.
+	BumpUserVersion(ctx context.Context, userID string) (int64, error)
+	UserVersion(ctx context.Context, userID string) (int64, error)
+	// TokenState reports in one round trip whether the session is revoked
+	// and the user's current token version.
+	TokenState(ctx context.Context, userID, sessionID string) (revoked bool, version int64, err error)
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/chat"
+)
+
+type ChatRepository interface {
+	CreateThread(ctx context.Context, thread *chat.Thread) error
+	GetThread(ctx context.Context, id string) (*chat.Thread, error)
+	GetThreadOwnerID(ctx context.Context, id string) (string, error)
+	ListThreads(ctx context.Context, userID string) ([]chat.Thread, error)
+	DeleteThread(ctx context.Context, id string) error
+	// AddExchange stores a question and its reply and bumps the thread's
+	// updated_at. Both are written together, so a thread never ends in an
+	// unanswered question.
+	AddExchange(ctx context.Context, question, reply *chat.Message) error
+	// ListMessages returns the last limit messages of a thread, oldest first.
+	ListMessages(ctx context.Context, threadID string, limit int) ([]chat.Message, error)
+}
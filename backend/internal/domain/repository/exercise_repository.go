@@ -10,5 +10,6 @@ type ExerciseRepository interface {
 	Create(ctx context.Context, ex *exercise.Exercise) error
 	GetByID(ctx context.Context, id string) (*exercise.Exercise, error)
 	List(ctx context.Context) ([]exercise.Exercise, error)
+	GetByIDs(ctx context.Context, ids []string) ([]exercise.Exercise, error)
 	AddMedia(ctx context.Context, media *exercise.ExerciseMedia) error
 }
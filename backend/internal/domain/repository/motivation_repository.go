@@ -5,8 +5,11 @@ import (
 	"time"
 )
 
+// MotivationRepository caches one message per user, voice and day. voice is
+// coach.Voice.Key(), so a message written for one persona is never served
+// for another.
 type MotivationRepository interface {
-	GetDailyMotivation(ctx context.Context, userID string, date time.Time) (message string, found bool, err error)
-	SetDailyMotivation(ctx context.Context, userID string, date time.Time, message string, ttl time.Duration) error
-	DeleteDailyMotivation(ctx context.Context, userID string, date time.Time) error
+	GetDailyMotivation(ctx context.Context, userID, voice string, date time.Time) (message string, found bool, err error)
+	SetDailyMotivation(ctx context.Context, userID, voice string, date time.Time, message string, ttl time.Duration) error
+	DeleteDailyMotivation(ctx context.Context, userID, voice string, date time.Time) error
 }
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/record"
+)
+
+type PersonalRecordRepository interface {
+	GetByUser(ctx context.Context, userID string) ([]record.PersonalRecord, error)
+	GetByUserAndExercises(ctx context.Context, userID string, exerciseIDs []string) ([]record.PersonalRecord, error)
+	Upsert(ctx context.Context, rec *record.PersonalRecord) error
+	DeleteByUser(ctx context.Context, userID string) error
+	DeleteByUserAndExercises(ctx context.Context, userID string, exerciseIDs []string) error
+}
@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/prompt"
+)
+
+type PromptTemplateRepository interface {
+	List(ctx context.Context) ([]prompt.Template, error)
+}
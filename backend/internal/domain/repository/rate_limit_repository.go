@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/ratelimit"
+)
+
+type RateLimitRepository interface {
+	// Allow counts a request for key against policy's sliding window. A
+	// refused request is not counted.
+	Allow(ctx context.Context, key string, policy ratelimit.Policy) (ratelimit.Decision, error)
+}
+
+// LoginAttemptRepository tracks failed sign-ins per key (email and client).
+type LoginAttemptRepository interface {
+	// LockedFor reports how long key stays locked out; zero if it is not.
+	LockedFor(ctx context.Context, key string) (time.Duration, error)
+	// RecordFailure counts a failed attempt and returns the consecutive
+	// failures so far. The count is forgotten after window without one.
+	RecordFailure(ctx context.Context, key string, window time.Duration) (int, error)
+	Lock(ctx context.Context, key string, d time.Duration) error
+	Reset(ctx context.Context, key string) error
+}
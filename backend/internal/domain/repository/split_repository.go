@@ -12,6 +12,7 @@ type SplitRepository interface {
 	ActivateTemplate(ctx context.Context, userID string, templateID string) error
 	DeactivateTemplate(ctx context.Context, userID string, templateID string) error
 	GetTemplateByID(ctx context.Context, id string) (*split.SplitTemplate, error)
+	GetTemplateOwnerID(ctx context.Context, id string) (string, error)
 	GetUserTemplates(ctx context.Context, userID string) ([]split.SplitTemplate, error)
 	GetSplitDayByID(ctx context.Context, id string) (*split.SplitDay, error)
 }
@@ -11,4 +11,11 @@ type Registry interface {
 	Workout() WorkoutRepository
 	Split() SplitRepository
 	Exercise() ExerciseRepository
+	PersonalRecord() PersonalRecordRepository
+	Planner() PlannerRepository
+	AgentAction() AgentActionRepository
+	WorkoutPlan() WorkoutPlanRepository
+	AuthSession() AuthSessionRepository
+	AccountToken() AccountTokenRepository
+	UserIdentity() UserIdentityRepository
 }
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/auth"
+)
+
+type UserIdentityRepository interface {
+	// Create returns ErrConflict when the provider account is linked already.
+	Create(ctx context.Context, identity *auth.Identity) error
+	GetByProviderSubject(ctx context.Context, provider, subject string) (*auth.Identity, error)
+	TouchLogin(ctx context.Context, id, email string, at time.Time) error
+}
+
+// OAuthStateRepository holds pending sign-ins until their callback.
+type OAuthStateRepository interface {
+	Save(ctx context.Context, state *auth.OAuthState, ttl time.Duration) error
+	// Take returns and deletes a state, so each one is used once.
+	// Unknown and expired states return ErrNotFound.
+	Take(ctx context.Context, state string) (*auth.OAuthState, error)
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"S.P.A.R.T.A/backend/internal/domain/aggregate/user"
 )
@@ -11,4 +12,8 @@ type UserRepository interface {
 	GetByID(ctx context.Context, id string) (*user.User, error)
 	GetByEmail(ctx context.Context, email string) (*user.User, error)
 	Count(ctx context.Context) (int, error)
+	UpdatePassword(ctx context.Context, id, passwordHash string, at time.Time) error
+	MarkEmailVerified(ctx context.Context, id string, at time.Time) error
+	GetPreferences(ctx context.Context, userID string) (*user.Preferences, error)
+	UpdatePreferences(ctx context.Context, prefs *user.Preferences) error
 }
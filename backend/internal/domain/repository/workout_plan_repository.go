@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/workout"
+)
+
+type WorkoutPlanRepository interface {
+	Create(ctx context.Context, plan *workout.WorkoutPlan) error
+	GetByID(ctx context.Context, id string) (*workout.WorkoutPlan, error)
+	GetOwnerID(ctx context.Context, id string) (string, error)
+	// ListByUser returns the newest plans first.
+	ListByUser(ctx context.Context, userID string, limit int) ([]workout.WorkoutPlan, error)
+	// AttachSession records the session started from the plan. It returns
+	// ErrConflict when the plan already has one.
+	AttachSession(ctx context.Context, planID, sessionID string) error
+	// LatestCompletedWithExercise returns the newest plan containing
+	// exerciseID whose session is completed, or ErrNotFound.
+	LatestCompletedWithExercise(ctx context.Context, userID, exerciseID string) (*workout.WorkoutPlan, error)
+}
@@ -3,11 +3,26 @@ package repository
 import (
 	"S.P.A.R.T.A/backend/internal/domain/aggregate/workout"
 	"context"
+	"time"
 )
 
 type WorkoutRepository interface {
 	CreateSession(ctx context.Context, session *workout.WorkoutSession) error
 	UpdateSession(ctx context.Context, session *workout.WorkoutSession) error
+	UpdateSessionStatus(ctx context.Context, id string, status string) error
+	DeleteSession(ctx context.Context, id string) error
 	GetSessionByID(ctx context.Context, id string) (*workout.WorkoutSession, error)
+	GetSessionOwnerID(ctx context.Context, id string) (string, error)
+	// GetSessionsByUser and GetSessionsByUserInRange return completed
+	// sessions only.
 	GetSessionsByUser(ctx context.Context, userID string) ([]workout.WorkoutSession, error)
+	ListSessionsByUser(ctx context.Context, userID string, filter workout.SessionFilter) (*workout.SessionPage, error)
+	GetSessionsByUserInRange(ctx context.Context, userID string, from time.Time, to time.Time) ([]workout.WorkoutSession, error)
+	// GetSessionsByUserAndExercises returns the completed sessions that
+	// include any of exerciseIDs, newest first, with only those exercises
+	// loaded.
+	GetSessionsByUserAndExercises(ctx context.Context, userID string, exerciseIDs []string) ([]workout.WorkoutSession, error)
+	AddSet(ctx context.Context, workoutExerciseID string, set *workout.WorkoutSet) error
+	UpdateSet(ctx context.Context, set *workout.WorkoutSet) error
+	DeleteSet(ctx context.Context, setID string) error
 }
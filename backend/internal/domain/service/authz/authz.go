@@ -0,0 +1,34 @@
+package authz
+
+import (
+	"strings"
+
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+)
+
+const RoleAdmin = "admin"
+
+// Principal is the authenticated caller as established by the auth middleware.
+type Principal struct {
+	UserID string
+	Role   string
+}
+
+func (p Principal) IsAdmin() bool {
+	return strings.TrimSpace(p.Role) == RoleAdmin
+}
+
+// CanAccess is the single ownership rule for user-owned resources:
+// admins may access anything, everyone else only what they own.
+func CanAccess(p Principal, ownerID string) error {
+	if strings.TrimSpace(p.UserID) == "" {
+		return domainerr.ErrUnauthorized
+	}
+	if p.IsAdmin() {
+		return nil
+	}
+	if ownerID == "" || ownerID != p.UserID {
+		return domainerr.ErrForbidden
+	}
+	return nil
+}
@@ -0,0 +1,326 @@
+// Package exercisematch resolves free-text exercise names (from the AI, from
+// imports, from users) to exercises in the library. Names are compared as
+// token sets after spelling variants and abbreviations are normalized;
+// equipment words are compared separately, against the exercise's equipment
+// as well as its name.
+package exercisematch
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/exercise"
+)
+
+// DefaultThreshold is the lowest score Match accepts. It lets "Bench Press"
+// resolve to "Barbell Bench Press" and "Squat" to "Back Squat", but not
+// "Leg Press" to "Leg Curl".
+const DefaultThreshold = 0.6
+
+// equipmentMismatchPenalty is subtracted when both names state equipment
+// and none of it agrees: a dumbbell press is not a barbell press.
+const equipmentMismatchPenalty = 0.45
+
+// equipmentMatchBonus rewards agreeing equipment, so "Barbell Row" prefers
+// the barbell variant over an equipment-less "Row".
+const equipmentMatchBonus = 0.05
+
+// synonyms maps a single token to its canonical spelling, which may be
+// several tokens.
+var synonyms = map[string]string{
+	"db":            "dumbbell",
+	"dbs":           "dumbbell",
+	"dumbell":       "dumbbell",
+	"dumbells":      "dumbbell",
+	"dumbbells":     "dumbbell",
+	"bb":            "barbell",
+	"barbells":      "barbell",
+	"kb":            "kettlebell",
+	"kettlebells":   "kettlebell",
+	"bw":            "bodyweight",
+	"cables":        "cable",
+	"ohp":           "overhead press",
+	"military":      "overhead",
+	"rdl":           "romanian deadlift",
+	"rdls":          "romanian deadlift",
+	"sldl":          "stiff leg deadlift",
+	"deadlifts":     "deadlift",
+	"pullup":        "pull up",
+	"pullups":       "pull up",
+	"chinup":        "chin up",
+	"chinups":       "chin up",
+	"pushup":        "push up",
+	"pushups":       "push up",
+	"situp":         "sit up",
+	"situps":        "sit up",
+	"ups":           "up",
+	"pulldown":      "pull down",
+	"pulldowns":     "pull down",
+	"pushdown":      "push down",
+	"pushdowns":     "push down",
+	"skullcrusher":  "skull crusher",
+	"skullcrushers": "skull crusher",
+	"flye":          "fly",
+	"flyes":         "fly",
+	"flies":         "fly",
+	"tricep":        "triceps",
+	"bicep":         "biceps",
+	"lats":          "lat",
+	"ez":            "ez bar",
+	"ezbar":         "ez bar",
+}
+
+// equipment are tokens that name equipment rather than the movement.
+var equipment = map[string]bool{
+	"barbell":    true,
+	"dumbbell":   true,
+	"cable":      true,
+	"machine":    true,
+	"kettlebell": true,
+	"smith":      true,
+	"ez":         true,
+	"band":       true,
+	"bodyweight": true,
+	"landmine":   true,
+}
+
+var stopWords = map[string]bool{
+	"a":        true,
+	"an":       true,
+	"the":      true,
+	"with":     true,
+	"and":      true,
+	"of":       true,
+	"on":       true,
+	"using":    true,
+	"exercise": true,
+}
+
+// Normalize lowercases name and reduces everything that is not a letter or
+// digit to single spaces.
+func Normalize(name string) string {
+	var b strings.Builder
+	b.Grow(len(name))
+	lastSpace := true
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			b.WriteRune(r)
+			lastSpace = false
+			continue
+		}
+		if !lastSpace {
+			b.WriteByte(' ')
+			lastSpace = true
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// stem drops a plural "s" ("curls", "raises", "crunches") but keeps words
+// where the s belongs ("press", "triceps").
+func stem(tok string) string {
+	switch {
+	case len(tok) > 4 && (strings.HasSuffix(tok, "ches") || strings.HasSuffix(tok, "shes") || strings.HasSuffix(tok, "sses") || strings.HasSuffix(tok, "xes")):
+		return strings.TrimSuffix(tok, "es")
+	case len(tok) <= 3, strings.HasSuffix(tok, "ss"), strings.HasSuffix(tok, "ps"), strings.HasSuffix(tok, "us"):
+		return tok
+	case strings.HasSuffix(tok, "s"):
+		return strings.TrimSuffix(tok, "s")
+	default:
+		return tok
+	}
+}
+
+// tokens splits name into movement and equipment tokens.
+func tokens(name string) (movement, gear map[string]bool) {
+	movement = map[string]bool{}
+	gear = map[string]bool{}
+	for _, raw := range strings.Fields(Normalize(name)) {
+		expanded := raw
+		if syn, ok := synonyms[raw]; ok {
+			expanded = syn
+		}
+		for _, tok := range strings.Fields(expanded) {
+			tok = stem(tok)
+			if syn, ok := synonyms[tok]; ok && !strings.Contains(syn, " ") {
+				tok = syn
+			}
+			switch {
+			case stopWords[tok]:
+			case equipment[tok]:
+				gear[tok] = true
+			default:
+				movement[tok] = true
+			}
+		}
+	}
+	return movement, gear
+}
+
+type entry struct {
+	exercise   exercise.Exercise
+	normalized string
+	movement   map[string]bool
+	gear       map[string]bool
+}
+
+// Candidate is a library exercise with its similarity to the queried name,
+// between 0 and 1.
+type Candidate struct {
+	Exercise exercise.Exercise
+	Score    float64
+}
+
+// Matcher matches names against a fixed library.
+type Matcher struct {
+	entries   []entry
+	threshold float64
+}
+
+// NewMatcher indexes library. A threshold <= 0 means DefaultThreshold.
+func NewMatcher(library []exercise.Exercise, threshold float64) *Matcher {
+	if threshold <= 0 {
+		threshold = DefaultThreshold
+	}
+	m := &Matcher{threshold: threshold, entries: make([]entry, 0, len(library))}
+	for _, ex := range library {
+		movement, gear := tokens(ex.Name)
+		_, stated := tokens(ex.Equipment)
+		for g := range stated {
+			gear[g] = true
+		}
+		m.entries = append(m.entries, entry{
+			exercise:   ex,
+			normalized: Normalize(ex.Name),
+			movement:   movement,
+			gear:       gear,
+		})
+	}
+	return m
+}
+
+// Match returns the best library exercise for name if it scores at least
+// the threshold. A tie for first place is ambiguous and does not match,
+// unless both are exact (duplicate library names).
+func (m *Matcher) Match(name string) (Candidate, bool) {
+	best := m.Suggest(name, 2)
+	if len(best) == 0 || best[0].Score < m.threshold {
+		return Candidate{}, false
+	}
+	if len(best) == 2 && best[0].Score < 1 && best[1].Score == best[0].Score {
+		return Candidate{}, false
+	}
+	return best[0], true
+}
+
+// Suggest returns up to limit library exercises that resemble name, best
+// first. Ties go to the shorter name.
+func (m *Matcher) Suggest(name string, limit int) []Candidate {
+	normalized := Normalize(name)
+	if normalized == "" || limit <= 0 {
+		return nil
+	}
+	movement, gear := tokens(name)
+
+	var out []Candidate
+	for _, e := range m.entries {
+		score := 1.0
+		if e.normalized != normalized {
+			score = similarity(movement, gear, e)
+		}
+		if score > 0 {
+			out = append(out, Candidate{Exercise: e.exercise, Score: score})
+		}
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Score != out[j].Score {
+			return out[i].Score > out[j].Score
+		}
+		if len(out[i].Exercise.Name) != len(out[j].Exercise.Name) {
+			return len(out[i].Exercise.Name) < len(out[j].Exercise.Name)
+		}
+		return out[i].Exercise.Name < out[j].Exercise.Name
+	})
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
+
+// similarity is the Dice coefficient of the movement tokens, adjusted by
+// whether the stated equipment agrees.
+func similarity(movement, gear map[string]bool, e entry) float64 {
+	if len(movement) == 0 || len(e.movement) == 0 {
+		return 0
+	}
+	common := 0
+	for tok := range movement {
+		if e.movement[tok] {
+			common++
+		}
+	}
+	if common == 0 {
+		return 0
+	}
+	score := 2 * float64(common) / float64(len(movement)+len(e.movement))
+
+	if len(gear) > 0 && len(e.gear) > 0 {
+		agree := false
+		for g := range gear {
+			if e.gear[g] {
+				agree = true
+				break
+			}
+		}
+		if agree {
+			score += equipmentMatchBonus
+		} else {
+			score -= equipmentMismatchPenalty
+		}
+	}
+
+	switch {
+	case score >= 1:
+		// Only an exact name match scores 1.
+		return 0.99
+	case score < 0:
+		return 0
+	default:
+		return score
+	}
+}
+
+// Gear returns the equipment words in text, sorted. text may be an
+// exercise name or an equipment description ("none (bodyweight exercise)").
+func Gear(text string) []string {
+	_, gear := tokens(text)
+	out := make([]string, 0, len(gear))
+	for g := range gear {
+		out = append(out, g)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Mentions reports whether name contains every movement word of movement,
+// so "deadlift" is mentioned by "Romanian Deadlift" and "OHP" by
+// "Seated Overhead Press". Equipment words in movement are ignored unless
+// it has nothing else.
+func Mentions(name, movement string) bool {
+	want, wantGear := tokens(movement)
+	have, haveGear := tokens(name)
+	if len(want) == 0 {
+		want, have = wantGear, haveGear
+	}
+	if len(want) == 0 {
+		return false
+	}
+	for tok := range want {
+		if !have[tok] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,147 @@
+package exercisematch
+
+import (
+	"reflect"
+	"testing"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/exercise"
+)
+
+func library(names ...string) []exercise.Exercise {
+	out := make([]exercise.Exercise, 0, len(names))
+	for _, name := range names {
+		out = append(out, exercise.Exercise{ID: name, Name: name})
+	}
+	return out
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		library   []exercise.Exercise
+		threshold float64
+		query     string
+		want      string
+		wantOK    bool
+	}{
+		{
+			name:    "missing equipment word",
+			library: library("Barbell Bench Press", "Leg Press"),
+			query:   "Bench Press",
+			want:    "Barbell Bench Press", wantOK: true,
+		},
+		{
+			name:    "one shared word is below the threshold",
+			library: library("Leg Curl"),
+			query:   "Leg Press",
+		},
+		{
+			name:      "a lower threshold accepts it",
+			library:   library("Leg Curl"),
+			threshold: 0.5,
+			query:     "Leg Press",
+			want:      "Leg Curl", wantOK: true,
+		},
+		{
+			name:    "extra word on the library side",
+			library: library("Back Squat", "Leg Curl"),
+			query:   "squats",
+			want:    "Back Squat", wantOK: true,
+		},
+		{
+			name:    "abbreviation",
+			library: library("Overhead Press", "Bench Press"),
+			query:   "OHP",
+			want:    "Overhead Press", wantOK: true,
+		},
+		{
+			name:    "abbreviated equipment picks the variant",
+			library: library("Barbell Bench Press", "Dumbbell Bench Press"),
+			query:   "DB bench press",
+			want:    "Dumbbell Bench Press", wantOK: true,
+		},
+		{
+			name:    "disagreeing equipment does not match",
+			library: library("Dumbbell Fly"),
+			query:   "Cable Fly",
+		},
+		{
+			name:    "a tie is ambiguous",
+			library: library("Barbell Bench Press", "Dumbbell Bench Press"),
+			query:   "Bench Press",
+		},
+		{
+			name:    "duplicate exact names still match",
+			library: library("Plank", "Plank"),
+			query:   "plank",
+			want:    "Plank", wantOK: true,
+		},
+		{
+			name:    "empty query",
+			library: library("Plank"),
+			query:   " - ",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := NewMatcher(tc.library, tc.threshold).Match(tc.query)
+			if ok != tc.wantOK || got.Exercise.Name != tc.want {
+				t.Errorf("Match(%q) = %q (%.2f), %v, want %q, %v", tc.query, got.Exercise.Name, got.Score, ok, tc.want, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestMatchUsesTheEquipmentField(t *testing.T) {
+	m := NewMatcher([]exercise.Exercise{
+		{ID: "bb", Name: "Row", Equipment: "barbell"},
+		{ID: "cable", Name: "Row", Equipment: "cable"},
+	}, 0)
+
+	got, ok := m.Match("Cable Row")
+	if !ok || got.Exercise.ID != "cable" {
+		t.Errorf("Match(Cable Row) = %+v, %v, want the cable row", got, ok)
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	m := NewMatcher(library("Incline Bench Press", "Bench Press", "Leg Press", "Plank"), 0)
+
+	got := m.Suggest("bench", 3)
+	var names []string
+	for _, c := range got {
+		names = append(names, c.Exercise.Name)
+	}
+	// Equal scores go to the shorter name; Leg Press and Plank share nothing.
+	if want := []string{"Bench Press", "Incline Bench Press"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("Suggest(bench) = %q, want %q", names, want)
+	}
+	if got[0].Score <= got[1].Score {
+		t.Errorf("scores = %v, %v, want the closer name first", got[0].Score, got[1].Score)
+	}
+
+	if exact := m.Suggest("bench press", 1); len(exact) != 1 || exact[0].Score != 1 {
+		t.Errorf("Suggest(bench press) = %+v, want the exact name at 1", exact)
+	}
+	if none := m.Suggest("bench", 0); none != nil {
+		t.Errorf("Suggest with limit 0 = %+v, want nil", none)
+	}
+}
+
+func TestMentions(t *testing.T) {
+	tests := []struct {
+		name, movement string
+		want           bool
+	}{
+		{"Romanian Deadlift", "deadlift", true},
+		{"Seated Overhead Press", "OHP", true},
+		{"Dumbbell Curl", "dumbbell", true},
+		{"Leg Press", "bench press", false},
+		{"Leg Press", "", false},
+	}
+	for _, tc := range tests {
+		if got := Mentions(tc.name, tc.movement); got != tc.want {
+			t.Errorf("Mentions(%q, %q) = %v, want %v", tc.name, tc.movement, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package muscle
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Landmarks are weekly hard-set volume landmarks for a muscle group:
+// minimum effective (MEV), maximum adaptive (MAV) and maximum recoverable
+// (MRV) volume.
+type Landmarks struct {
+	MEV float64
+	MAV float64
+	MRV float64
+}
+
+// DefaultLandmarks are conservative starting points for an intermediate
+// lifter. They are meant to be tuned, not treated as prescriptions.
+func DefaultLandmarks() map[Group]Landmarks {
+	return map[Group]Landmarks{
+		Chest:      {MEV: 8, MAV: 16, MRV: 22},
+		Back:       {MEV: 10, MAV: 18, MRV: 25},
+		Traps:      {MEV: 0, MAV: 12, MRV: 26},
+		LowerBack:  {MEV: 0, MAV: 6, MRV: 10},
+		FrontDelts: {MEV: 0, MAV: 8, MRV: 12},
+		SideDelts:  {MEV: 8, MAV: 19, MRV: 26},
+		RearDelts:  {MEV: 8, MAV: 19, MRV: 26},
+		Biceps:     {MEV: 8, MAV: 17, MRV: 26},
+		Triceps:    {MEV: 6, MAV: 12, MRV: 18},
+		Forearms:   {MEV: 2, MAV: 10, MRV: 20},
+		Quads:      {MEV: 8, MAV: 15, MRV: 20},
+		Hamstrings: {MEV: 6, MAV: 13, MRV: 20},
+		Glutes:     {MEV: 0, MAV: 8, MRV: 16},
+		Calves:     {MEV: 8, MAV: 14, MRV: 20},
+		Abs:        {MEV: 0, MAV: 20, MRV: 25},
+	}
+}
+
+// ParseLandmarks overrides defaults from a spec like
+// "chest=10:18:24,quads=8:14:18". Entries with unknown groups or malformed
+// numbers are skipped and returned in the second result.
+func ParseLandmarks(spec string, defaults map[Group]Landmarks) (map[Group]Landmarks, []string) {
+	out := make(map[Group]Landmarks, len(defaults))
+	for g, l := range defaults {
+		out[g] = l
+	}
+
+	var skipped []string
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, values, ok := strings.Cut(entry, "=")
+		if !ok {
+			skipped = append(skipped, entry)
+			continue
+		}
+		g, ok := Normalize(name)
+		if !ok {
+			skipped = append(skipped, entry)
+			continue
+		}
+
+		parts := strings.Split(values, ":")
+		if len(parts) != 3 {
+			skipped = append(skipped, entry)
+			continue
+		}
+		var nums [3]float64
+		valid := true
+		for i, p := range parts {
+			v, err := strconv.ParseFloat(strings.TrimSpace(p), 64)
+			if err != nil || v < 0 {
+				valid = false
+				break
+			}
+			nums[i] = v
+		}
+		if !valid || nums[0] > nums[1] || nums[1] > nums[2] {
+			skipped = append(skipped, entry)
+			continue
+		}
+
+		out[g] = Landmarks{MEV: nums[0], MAV: nums[1], MRV: nums[2]}
+	}
+	return out, skipped
+}
+
+// VolumeStatus places weekly hard sets relative to the landmarks.
+type VolumeStatus string
+
+const (
+	StatusUntrained  VolumeStatus = "untrained"
+	StatusBelowMEV   VolumeStatus = "below_mev"
+	StatusProductive VolumeStatus = "productive"
+	StatusHigh       VolumeStatus = "high"
+	StatusAboveMRV   VolumeStatus = "above_mrv"
+)
+
+func (l Landmarks) Status(sets float64) VolumeStatus {
+	switch {
+	case sets <= 0:
+		return StatusUntrained
+	case sets < l.MEV:
+		return StatusBelowMEV
+	case sets <= l.MAV:
+		return StatusProductive
+	case sets <= l.MRV:
+		return StatusHigh
+	default:
+		return StatusAboveMRV
+	}
+}
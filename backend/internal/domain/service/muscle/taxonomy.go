@@ -0,0 +1,265 @@
+// Package muscle normalizes the free-form muscle names stored on exercises
+// into one taxonomy. Seed data uses coarse regions ("legs", "arms"), wger
+// imports use category names plus Latin muscle names ("quadriceps femoris"),
+// and users type whatever they like; everything funnels through Normalize.
+package muscle
+
+import (
+	"sort"
+	"strings"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/exercise"
+)
+
+// Group is a canonical muscle group that volume is counted against.
+type Group string
+
+const (
+	Chest      Group = "chest"
+	Back       Group = "back"
+	Traps      Group = "traps"
+	LowerBack  Group = "lower_back"
+	FrontDelts Group = "front_delts"
+	SideDelts  Group = "side_delts"
+	RearDelts  Group = "rear_delts"
+	Biceps     Group = "biceps"
+	Triceps    Group = "triceps"
+	Forearms   Group = "forearms"
+	Quads      Group = "quads"
+	Hamstrings Group = "hamstrings"
+	Glutes     Group = "glutes"
+	Calves     Group = "calves"
+	Abs        Group = "abs"
+)
+
+// Region is a coarse body area. Some sources only record the region.
+type Region string
+
+const (
+	RegionChest     Region = "chest"
+	RegionBack      Region = "back"
+	RegionShoulders Region = "shoulders"
+	RegionArms      Region = "arms"
+	RegionLegs      Region = "legs"
+	RegionCore      Region = "core"
+)
+
+var groupRegion = map[Group]Region{
+	Chest:      RegionChest,
+	Back:       RegionBack,
+	Traps:      RegionBack,
+	LowerBack:  RegionBack,
+	FrontDelts: RegionShoulders,
+	SideDelts:  RegionShoulders,
+	RearDelts:  RegionShoulders,
+	Biceps:     RegionArms,
+	Triceps:    RegionArms,
+	Forearms:   RegionArms,
+	Quads:      RegionLegs,
+	Hamstrings: RegionLegs,
+	Glutes:     RegionLegs,
+	Calves:     RegionLegs,
+	Abs:        RegionCore,
+}
+
+// AllGroups returns every canonical group in a stable order.
+func AllGroups() []Group {
+	out := make([]Group, 0, len(groupRegion))
+	for g := range groupRegion {
+		out = append(out, g)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+// RegionOf returns the region a group belongs to.
+func RegionOf(g Group) Region {
+	return groupRegion[g]
+}
+
+var groupAliases = map[string]Group{
+	// chest
+	"chest": Chest, "pecs": Chest, "pectorals": Chest, "pectoralis major": Chest,
+	"serratus anterior": Chest,
+	// back
+	"lats": Back, "latissimus dorsi": Back, "upper back": Back, "rhomboids": Back,
+	"mid back": Back, "middle back": Back, "teres major": Back,
+	"traps": Traps, "trapezius": Traps,
+	"lower back": LowerBack, "erector spinae": LowerBack, "spinal erectors": LowerBack,
+	// shoulders
+	"front delts": FrontDelts, "anterior deltoid": FrontDelts, "front deltoid": FrontDelts,
+	"side delts": SideDelts, "lateral deltoid": SideDelts, "medial deltoid": SideDelts,
+	"rear delts": RearDelts, "posterior deltoid": RearDelts, "rear deltoid": RearDelts,
+	// arms
+	"biceps": Biceps, "bicep": Biceps, "biceps brachii": Biceps, "brachialis": Biceps,
+	"triceps": Triceps, "tricep": Triceps, "triceps brachii": Triceps,
+	"forearms": Forearms, "forearm": Forearms, "brachioradialis": Forearms,
+	// legs
+	"quads": Quads, "quadriceps": Quads, "quadriceps femoris": Quads,
+	"hamstrings": Hamstrings, "hamstring": Hamstrings, "biceps femoris": Hamstrings,
+	"glutes": Glutes, "glute": Glutes, "gluteus maximus": Glutes,
+	"calves": Calves, "calf": Calves, "gastrocnemius": Calves, "soleus": Calves,
+	// core
+	"abs": Abs, "abdominals": Abs, "rectus abdominis": Abs, "obliques": Abs,
+	"obliquus externus abdominis": Abs,
+}
+
+var regionAliases = map[string]Region{
+	"chest":      RegionChest,
+	"back":       RegionBack,
+	"shoulders":  RegionShoulders,
+	"shoulder":   RegionShoulders,
+	"delts":      RegionShoulders,
+	"deltoids":   RegionShoulders,
+	"arms":       RegionArms,
+	"legs":       RegionLegs,
+	"lower body": RegionLegs,
+	"core":       RegionCore,
+	"abs":        RegionCore,
+}
+
+// regionDefault is used when only the region is known and nothing else in
+// the exercise narrows it down. Regions without an obvious default stay
+// unresolved rather than guessing.
+var regionDefault = map[Region]Group{
+	RegionChest: Chest,
+	RegionBack:  Back,
+	RegionCore:  Abs,
+}
+
+var regionPriority = map[Region][]Group{
+	RegionChest:     {Chest},
+	RegionBack:      {Back, Traps, LowerBack},
+	RegionShoulders: {FrontDelts, SideDelts, RearDelts},
+	RegionArms:      {Biceps, Triceps, Forearms},
+	RegionLegs:      {Quads, Hamstrings, Glutes, Calves},
+	RegionCore:      {Abs},
+}
+
+// nameHints narrow a region to a group from the exercise name. Order matters:
+// the first matching hint wins.
+var nameHints = []struct {
+	region  Region
+	keyword string
+	group   Group
+}{
+	{RegionLegs, "leg curl", Hamstrings},
+	{RegionLegs, "romanian", Hamstrings},
+	{RegionLegs, "stiff", Hamstrings},
+	{RegionLegs, "good morning", Hamstrings},
+	{RegionLegs, "nordic", Hamstrings},
+	{RegionLegs, "calf", Calves},
+	{RegionLegs, "hip thrust", Glutes},
+	{RegionLegs, "glute", Glutes},
+	{RegionLegs, "squat", Quads},
+	{RegionLegs, "leg press", Quads},
+	{RegionLegs, "leg extension", Quads},
+	{RegionLegs, "lunge", Quads},
+	{RegionLegs, "step up", Quads},
+	{RegionArms, "pushdown", Triceps},
+	{RegionArms, "tricep", Triceps},
+	{RegionArms, "skull", Triceps},
+	{RegionArms, "dip", Triceps},
+	{RegionArms, "wrist", Forearms},
+	{RegionArms, "curl", Biceps},
+	{RegionShoulders, "lateral raise", SideDelts},
+	{RegionShoulders, "side raise", SideDelts},
+	{RegionShoulders, "upright row", SideDelts},
+	{RegionShoulders, "rear delt", RearDelts},
+	{RegionShoulders, "reverse fly", RearDelts},
+	{RegionShoulders, "face pull", RearDelts},
+	{RegionShoulders, "press", FrontDelts},
+	{RegionShoulders, "front raise", FrontDelts},
+	{RegionBack, "shrug", Traps},
+	{RegionBack, "deadlift", LowerBack},
+	{RegionBack, "hyperextension", LowerBack},
+}
+
+func clean(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.NewReplacer("_", " ", "-", " ").Replace(name)
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// Normalize maps a single muscle name to a canonical group. Region-only
+// names ("legs") report ok=false; use Resolve for a whole exercise.
+func Normalize(name string) (Group, bool) {
+	g, ok := groupAliases[clean(name)]
+	return g, ok
+}
+
+// NormalizeRegion maps a coarse region name to a Region.
+func NormalizeRegion(name string) (Region, bool) {
+	r, ok := regionAliases[clean(name)]
+	return r, ok
+}
+
+// Target is a group an exercise trains and how much of each set counts
+// toward it.
+type Target struct {
+	Group  Group
+	Weight float64
+}
+
+// Resolve returns the groups an exercise trains. The primary muscle counts
+// 1.0 per set and each distinct secondary muscle counts secondaryWeight.
+// When the primary is only a region, it is narrowed using the exercise name,
+// then secondary muscles in that region (wger stores the specific muscles
+// there), then a region default. An empty result means the exercise
+// could not be classified.
+func Resolve(ex exercise.Exercise, secondaryWeight float64) []Target {
+	var secondary []Group
+	seen := make(map[Group]bool)
+	for _, name := range ex.SecondaryMuscles {
+		if g, ok := Normalize(name); ok && !seen[g] {
+			seen[g] = true
+			secondary = append(secondary, g)
+		}
+	}
+
+	primary, ok := Normalize(ex.PrimaryMuscle)
+	if !ok {
+		primary, ok = narrowRegion(ex, secondary)
+	}
+
+	var out []Target
+	if ok {
+		out = append(out, Target{Group: primary, Weight: 1})
+	}
+	for _, g := range secondary {
+		if ok && g == primary {
+			continue
+		}
+		if secondaryWeight > 0 {
+			out = append(out, Target{Group: g, Weight: secondaryWeight})
+		}
+	}
+	return out
+}
+
+func narrowRegion(ex exercise.Exercise, secondary []Group) (Group, bool) {
+	region, ok := NormalizeRegion(ex.PrimaryMuscle)
+	if !ok {
+		return "", false
+	}
+
+	name := clean(ex.Name)
+	for _, h := range nameHints {
+		if h.region == region && strings.Contains(name, h.keyword) {
+			return h.group, true
+		}
+	}
+
+	// wger lists primary and secondary muscles together, so prefer the
+	// region's usual prime mover among them.
+	for _, g := range regionPriority[region] {
+		for _, s := range secondary {
+			if s == g {
+				return g, true
+			}
+		}
+	}
+
+	g, ok := regionDefault[region]
+	return g, ok
+}
@@ -0,0 +1,235 @@
+package muscle
+
+import (
+	"reflect"
+	"testing"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/exercise"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name   string
+		want   Group
+		wantOK bool
+	}{
+		{"chest", Chest, true},
+		{"  Quadriceps Femoris ", Quads, true},
+		{"latissimus_dorsi", Back, true},
+		{"rear-delts", RearDelts, true},
+		{"Biceps   Femoris", Hamstrings, true},
+		{"GLUTES", Glutes, true},
+		{"obliques", Abs, true},
+		// Region names are not groups on their own.
+		{"legs", "", false},
+		{"arms", "", false},
+		{"shoulders", "", false},
+		{"", "", false},
+		{"neck", "", false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := Normalize(tc.name)
+			if got != tc.want || ok != tc.wantOK {
+				t.Errorf("Normalize(%q) = %q, %v, want %q, %v", tc.name, got, ok, tc.want, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestNormalizeRegion(t *testing.T) {
+	tests := []struct {
+		name   string
+		want   Region
+		wantOK bool
+	}{
+		{"legs", RegionLegs, true},
+		{"Lower-Body", RegionLegs, true},
+		{"delts", RegionShoulders, true},
+		{"abs", RegionCore, true},
+		{"quads", "", false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := NormalizeRegion(tc.name)
+			if got != tc.want || ok != tc.wantOK {
+				t.Errorf("NormalizeRegion(%q) = %q, %v, want %q, %v", tc.name, got, ok, tc.want, tc.wantOK)
+			}
+		})
+	}
+}
+
+// TestResolveSeedExercises covers the exercises from the seed library, which
+// only record a region as the primary muscle.
+func TestResolveSeedExercises(t *testing.T) {
+	tests := []struct {
+		name    string
+		primary string
+		want    Group
+	}{
+		{"Bench Press", "chest", Chest},
+		{"Squat", "legs", Quads},
+		{"Deadlift", "back", LowerBack},
+		{"Overhead Press", "shoulders", FrontDelts},
+		{"Pull-up", "back", Back},
+		{"Bent-Over Row", "back", Back},
+		{"Biceps Curl", "arms", Biceps},
+		{"Triceps Pushdown", "arms", Triceps},
+		{"Lat Pulldown", "back", Back},
+		{"Leg Press", "legs", Quads},
+		{"Leg Extension", "legs", Quads},
+		{"Leg Curl", "legs", Hamstrings},
+		{"Calf Raise", "legs", Calves},
+		{"Plank", "core", Abs},
+		{"Crunch", "core", Abs},
+		{"Romanian Deadlift", "legs", Hamstrings},
+		{"Hip Thrust", "glutes", Glutes},
+		{"Lunge", "legs", Quads},
+		{"Push-up", "chest", Chest},
+		{"Dumbbell Fly", "chest", Chest},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Resolve(exercise.Exercise{Name: tc.name, PrimaryMuscle: tc.primary}, 0.5)
+			want := []Target{{Group: tc.want, Weight: 1}}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Resolve(%s, %s) = %v, want %v", tc.name, tc.primary, got, want)
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name            string
+		ex              exercise.Exercise
+		secondaryWeight float64
+		want            []Target
+	}{
+		{
+			name: "secondaries count fractionally",
+			ex: exercise.Exercise{
+				Name: "Incline Press", PrimaryMuscle: "Pectoralis major",
+				SecondaryMuscles: []string{"anterior deltoid", "triceps brachii"},
+			},
+			secondaryWeight: 0.5,
+			want:            []Target{{Chest, 1}, {FrontDelts, 0.5}, {Triceps, 0.5}},
+		},
+		{
+			name: "aliases of one group count once",
+			ex: exercise.Exercise{
+				Name: "Row", PrimaryMuscle: "back",
+				SecondaryMuscles: []string{"biceps", "bicep", "brachialis"},
+			},
+			secondaryWeight: 0.5,
+			want:            []Target{{Back, 1}, {Biceps, 0.5}},
+		},
+		{
+			name: "a secondary equal to the primary is skipped",
+			ex: exercise.Exercise{
+				Name: "Fly", PrimaryMuscle: "chest",
+				SecondaryMuscles: []string{"pecs", "front delts"},
+			},
+			secondaryWeight: 0.5,
+			want:            []Target{{Chest, 1}, {FrontDelts, 0.5}},
+		},
+		{
+			name: "no weight drops the secondaries",
+			ex: exercise.Exercise{
+				Name: "Bench", PrimaryMuscle: "chest",
+				SecondaryMuscles: []string{"triceps"},
+			},
+			secondaryWeight: 0,
+			want:            []Target{{Chest, 1}},
+		},
+		{
+			name: "the name wins over the secondaries",
+			ex: exercise.Exercise{
+				Name: "Seated Leg Curl", PrimaryMuscle: "legs",
+				SecondaryMuscles: []string{"calves"},
+			},
+			secondaryWeight: 0.5,
+			want:            []Target{{Hamstrings, 1}, {Calves, 0.5}},
+		},
+		{
+			name: "a region is narrowed by its secondaries in priority order",
+			ex: exercise.Exercise{
+				Name: "Bulgarian Split", PrimaryMuscle: "legs",
+				SecondaryMuscles: []string{"glutes", "quadriceps"},
+			},
+			secondaryWeight: 0.5,
+			want:            []Target{{Quads, 1}, {Glutes, 0.5}},
+		},
+		{
+			name: "legs without a hint stay unresolved",
+			ex: exercise.Exercise{
+				Name: "Sled Push", PrimaryMuscle: "legs",
+			},
+			secondaryWeight: 0.5,
+			want:            nil,
+		},
+		{
+			name: "an unresolved primary keeps its secondaries",
+			ex: exercise.Exercise{
+				Name: "Farmer Carry", PrimaryMuscle: "full body",
+				SecondaryMuscles: []string{"forearms", "traps"},
+			},
+			secondaryWeight: 0.5,
+			want:            []Target{{Forearms, 0.5}, {Traps, 0.5}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Resolve(tc.ex, tc.secondaryWeight)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Resolve = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLandmarksStatus(t *testing.T) {
+	l := Landmarks{MEV: 8, MAV: 16, MRV: 22}
+
+	tests := []struct {
+		sets float64
+		want VolumeStatus
+	}{
+		{0, StatusUntrained},
+		{7.5, StatusBelowMEV},
+		{8, StatusProductive},
+		{16, StatusProductive},
+		{16.5, StatusHigh},
+		{22, StatusHigh},
+		{22.5, StatusAboveMRV},
+	}
+	for _, tc := range tests {
+		if got := l.Status(tc.sets); got != tc.want {
+			t.Errorf("Status(%v) = %s, want %s", tc.sets, got, tc.want)
+		}
+	}
+}
+
+func TestParseLandmarks(t *testing.T) {
+	defaults := map[Group]Landmarks{
+		Chest: {MEV: 8, MAV: 16, MRV: 22},
+		Quads: {MEV: 8, MAV: 15, MRV: 20},
+	}
+
+	got, skipped := ParseLandmarks(" chest=10:18:24, quadriceps=6:12:16,,neck=1:2:3,calves=8:4:20,abs=1:2,biceps=x:1:2,triceps", defaults)
+
+	want := map[Group]Landmarks{
+		Chest: {MEV: 10, MAV: 18, MRV: 24},
+		Quads: {MEV: 6, MAV: 12, MRV: 16},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("landmarks = %v, want %v", got, want)
+	}
+	wantSkipped := []string{"neck=1:2:3", "calves=8:4:20", "abs=1:2", "biceps=x:1:2", "triceps"}
+	if !reflect.DeepEqual(skipped, wantSkipped) {
+		t.Errorf("skipped = %q, want %q", skipped, wantSkipped)
+	}
+	if defaults[Chest].MEV != 8 {
+		t.Error("ParseLandmarks changed the defaults")
+	}
+}
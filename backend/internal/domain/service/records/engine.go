@@ -0,0 +1,209 @@
+package records
+
+import (
+	"sort"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/record"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/workout"
+	"S.P.A.R.T.A/backend/internal/domain/service/training"
+)
+
+// Engine detects personal records from logged sessions. It is pure: callers
+// load the current bests and persist whatever Detect returns.
+type Engine struct {
+	formula training.OneRepMaxFormula
+}
+
+func NewEngine(formula training.OneRepMaxFormula) *Engine {
+	if formula == "" {
+		formula = training.FormulaEpley
+	}
+	return &Engine{formula: formula}
+}
+
+func (e *Engine) Formula() training.OneRepMaxFormula {
+	return e.formula
+}
+
+type recordKey struct {
+	exerciseID string
+	recordType record.RecordType
+	weight     float64
+}
+
+func keyOf(r record.PersonalRecord) recordKey {
+	return recordKey{exerciseID: r.ExerciseID, recordType: r.Type, weight: r.WeightKey()}
+}
+
+// Detect returns the records the session beats, at most one per record key.
+// Existing records keep their ID so callers can upsert in place.
+func (e *Engine) Detect(session workout.WorkoutSession, current []record.PersonalRecord) []record.PersonalRecord {
+	best := make(map[recordKey]record.PersonalRecord, len(current))
+	for _, r := range current {
+		best[keyOf(r)] = r
+	}
+
+	var out []record.PersonalRecord
+	for _, r := range e.candidates(session) {
+		k := keyOf(r)
+		prev, ok := best[k]
+		if ok && !beats(r, prev) {
+			continue
+		}
+		if ok {
+			r.ID = prev.ID
+			r.CreatedAt = prev.CreatedAt
+		}
+		best[k] = r
+		out = append(out, r)
+	}
+	return out
+}
+
+// Replay folds sessions in chronological order and returns the resulting
+// bests. It is used to rebuild records from history.
+func (e *Engine) Replay(sessions []workout.WorkoutSession) []record.PersonalRecord {
+	ordered := make([]workout.WorkoutSession, len(sessions))
+	copy(ordered, sessions)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].SessionDate.Equal(ordered[j].SessionDate) {
+			return ordered[i].CreatedAt.Before(ordered[j].CreatedAt)
+		}
+		return ordered[i].SessionDate.Before(ordered[j].SessionDate)
+	})
+
+	best := make(map[recordKey]record.PersonalRecord)
+	var order []recordKey
+	for _, s := range ordered {
+		for _, r := range e.candidates(s) {
+			k := keyOf(r)
+			prev, ok := best[k]
+			if ok && !beats(r, prev) {
+				continue
+			}
+			if !ok {
+				order = append(order, k)
+			}
+			best[k] = r
+		}
+	}
+
+	out := make([]record.PersonalRecord, 0, len(order))
+	for _, k := range order {
+		out = append(out, best[k])
+	}
+	return out
+}
+
+// Rebuild is Replay for records that are already stored: each result keeps
+// the ID and creation time of the current record with the same key, so it
+// is updated in place.
+func (e *Engine) Rebuild(sessions []workout.WorkoutSession, current []record.PersonalRecord) []record.PersonalRecord {
+	stored := make(map[recordKey]record.PersonalRecord, len(current))
+	for _, r := range current {
+		stored[keyOf(r)] = r
+	}
+
+	out := e.Replay(sessions)
+	for i := range out {
+		if prev, ok := stored[keyOf(out[i])]; ok {
+			out[i].ID = prev.ID
+			out[i].CreatedAt = prev.CreatedAt
+		}
+	}
+	return out
+}
+
+// candidates returns the session's best value per record key.
+func (e *Engine) candidates(s workout.WorkoutSession) []record.PersonalRecord {
+	sessionID := s.ID
+	achievedAt := s.SessionDate
+	if achievedAt.IsZero() {
+		achievedAt = time.Now().UTC()
+	}
+
+	base := record.PersonalRecord{
+		UserID:     s.UserID,
+		AchievedAt: achievedAt,
+	}
+	if sessionID != "" {
+		base.WorkoutSessionID = &sessionID
+	}
+
+	seen := make(map[recordKey]int)
+	var out []record.PersonalRecord
+	keep := func(r record.PersonalRecord) {
+		k := keyOf(r)
+		if i, ok := seen[k]; ok {
+			if beats(r, out[i]) {
+				out[i] = r
+			}
+			return
+		}
+		seen[k] = len(out)
+		out = append(out, r)
+	}
+
+	for _, ex := range s.Exercises {
+		if ex.ExerciseID == "" {
+			continue
+		}
+
+		var volume float64
+		for _, set := range ex.Sets {
+			if training.IsWarmupSet(set) || set.Reps <= 0 || set.Weight <= 0 {
+				continue
+			}
+			volume += float64(set.Reps) * set.Weight
+
+			fromSet := base
+			fromSet.ExerciseID = ex.ExerciseID
+			fromSet.Weight = training.Round2(set.Weight)
+			fromSet.Reps = set.Reps
+			if set.ID != "" {
+				setID := set.ID
+				fromSet.WorkoutSetID = &setID
+			}
+
+			heaviest := fromSet
+			heaviest.Type = record.RecordHeaviestWeight
+			heaviest.Value = fromSet.Weight
+			keep(heaviest)
+
+			repsAt := fromSet
+			repsAt.Type = record.RecordRepsAtWeight
+			repsAt.Value = float64(set.Reps)
+			keep(repsAt)
+
+			if e1rm := training.EstimateOneRepMax(set.Weight, set.Reps, e.formula); e1rm > 0 {
+				est := fromSet
+				est.Type = record.RecordEstimated1RM
+				est.Value = training.Round2(e1rm)
+				est.Formula = string(e.formula)
+				keep(est)
+			}
+		}
+
+		if volume > 0 {
+			vol := base
+			vol.ExerciseID = ex.ExerciseID
+			vol.Type = record.RecordSessionVolume
+			vol.Value = training.Round2(volume)
+			keep(vol)
+		}
+	}
+	return out
+}
+
+// beats reports whether candidate strictly improves on prev. Heaviest-weight
+// ties are broken by reps.
+func beats(candidate, prev record.PersonalRecord) bool {
+	if candidate.Value != prev.Value {
+		return candidate.Value > prev.Value
+	}
+	if candidate.Type == record.RecordHeaviestWeight {
+		return candidate.Reps > prev.Reps
+	}
+	return false
+}
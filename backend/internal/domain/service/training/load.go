@@ -8,6 +8,8 @@ import (
 )
 
 type LoadSummary struct {
+	Model           LoadModelName
+	Unit            string
 	AcuteLoad7d     float64
 	ChronicLoad28d  float64
 	LastSessionLoad float64
@@ -17,9 +19,19 @@ type LoadSummary struct {
 	ACWR            float64
 }
 
+// ComputeLoadSummary summarizes load with the tonnage model.
 func ComputeLoadSummary(sessions []workout.WorkoutSession, now time.Time) LoadSummary {
+	return TonnageModel{}.Summarize(sessions, now)
+}
+
+// rollingHistoryDays is the chronic window of the rolling models.
+const rollingHistoryDays = 28
+
+// rollingSummary sums per-session load over fixed 7/28-day windows.
+// sessions are expected newest first.
+func rollingSummary(sessions []workout.WorkoutSession, now time.Time, sessionLoad func(workout.WorkoutSession) float64) LoadSummary {
 	sevenDaysAgo := now.AddDate(0, 0, -7)
-	twentyEightDaysAgo := now.AddDate(0, 0, -28)
+	twentyEightDaysAgo := now.AddDate(0, 0, -rollingHistoryDays)
 
 	var acute float64
 	var chronic float64
@@ -101,7 +113,7 @@ func EstimateFatigueScore(sum LoadSummary, userReported int) int {
 	return clampInt(int(math.Round(score)), 0, 10)
 }
 
-func sessionLoad(s workout.WorkoutSession) float64 {
+func tonnageLoad(s workout.WorkoutSession) float64 {
 	var total float64
 	for _, ex := range s.Exercises {
 		for _, set := range ex.Sets {
@@ -0,0 +1,254 @@
+package training
+
+import (
+	"strings"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/workout"
+)
+
+type LoadModelName string
+
+const (
+	LoadModelTonnage    LoadModelName = "tonnage"
+	LoadModelSessionRPE LoadModelName = "session_rpe"
+	LoadModelRPETonnage LoadModelName = "rpe_tonnage"
+	LoadModelEWMA       LoadModelName = "ewma"
+)
+
+// Defaults used when a session lacks the data a model needs.
+const (
+	defaultSessionRPE    = 6.0
+	defaultMinutesPerSet = 3
+	defaultSetRPE        = 7.0
+)
+
+// LoadModel turns training history into acute/chronic load. Summaries from
+// every model share the ACWR convention acute / (chronic / 4), so fatigue
+// thresholds apply regardless of the unit.
+type LoadModel interface {
+	Name() LoadModelName
+	Unit() string
+	Summarize(sessions []workout.WorkoutSession, now time.Time) LoadSummary
+	// HistoryDays is how many days of sessions before now Summarize reads.
+	HistoryDays() int
+}
+
+// LoadOptions carries per-user inputs some models can use.
+type LoadOptions struct {
+	// BodyWeightKg lets RPE-weighted tonnage count bodyweight sets.
+	BodyWeightKg float64
+}
+
+// ParseLoadModel accepts a model name (case-insensitive). Unknown names fall
+// back to tonnage and report ok=false.
+func ParseLoadModel(s string) (LoadModelName, bool) {
+	switch LoadModelName(strings.ToLower(strings.TrimSpace(s))) {
+	case LoadModelTonnage:
+		return LoadModelTonnage, true
+	case LoadModelSessionRPE:
+		return LoadModelSessionRPE, true
+	case LoadModelRPETonnage:
+		return LoadModelRPETonnage, true
+	case LoadModelEWMA:
+		return LoadModelEWMA, true
+	default:
+		return LoadModelTonnage, false
+	}
+}
+
+// NewLoadModel returns the model for name, defaulting to tonnage.
+func NewLoadModel(name LoadModelName, opts LoadOptions) LoadModel {
+	switch name {
+	case LoadModelSessionRPE:
+		return SessionRPEModel{}
+	case LoadModelRPETonnage:
+		return RPETonnageModel{BodyWeightKg: opts.BodyWeightKg}
+	case LoadModelEWMA:
+		return EWMAModel{Base: TonnageModel{}}
+	default:
+		return TonnageModel{}
+	}
+}
+
+// TonnageModel is reps × weight summed over 7/28-day windows. Bodyweight
+// sets (weight <= 0) contribute nothing.
+type TonnageModel struct{}
+
+func (TonnageModel) Name() LoadModelName { return LoadModelTonnage }
+func (TonnageModel) HistoryDays() int    { return rollingHistoryDays }
+func (TonnageModel) Unit() string        { return "kg" }
+
+func (m TonnageModel) Summarize(sessions []workout.WorkoutSession, now time.Time) LoadSummary {
+	return withModel(rollingSummary(sessions, now, m.SessionLoad), m)
+}
+
+func (TonnageModel) SessionLoad(s workout.WorkoutSession) float64 {
+	return tonnageLoad(s)
+}
+
+// SessionRPEModel is Foster's session-RPE: session RPE × duration in minutes.
+// Session RPE is the mean RPE of working sets; sessions without RPE use a
+// moderate default and sessions without a duration are estimated from the
+// number of sets.
+type SessionRPEModel struct{}
+
+func (SessionRPEModel) Name() LoadModelName { return LoadModelSessionRPE }
+func (SessionRPEModel) HistoryDays() int    { return rollingHistoryDays }
+func (SessionRPEModel) Unit() string        { return "AU" }
+
+func (m SessionRPEModel) Summarize(sessions []workout.WorkoutSession, now time.Time) LoadSummary {
+	return withModel(rollingSummary(sessions, now, m.SessionLoad), m)
+}
+
+func (SessionRPEModel) SessionLoad(s workout.WorkoutSession) float64 {
+	var rpeSum float64
+	var rpeCount, sets int
+	for _, ex := range s.Exercises {
+		for _, set := range ex.Sets {
+			if IsWarmupSet(set) {
+				continue
+			}
+			sets++
+			if set.RPE > 0 {
+				rpeSum += set.RPE
+				rpeCount++
+			}
+		}
+	}
+	if sets == 0 {
+		return 0
+	}
+
+	rpe := defaultSessionRPE
+	if rpeCount > 0 {
+		rpe = rpeSum / float64(rpeCount)
+	}
+
+	minutes := s.DurationMin
+	if minutes <= 0 {
+		minutes = sets * defaultMinutesPerSet
+	}
+	return rpe * float64(minutes)
+}
+
+// RPETonnageModel scales each set's tonnage by RPE/10, so a set at RPE 10
+// counts fully and easier sets count less. Sets without RPE use a default,
+// and bodyweight sets use BodyWeightKg when known.
+type RPETonnageModel struct {
+	BodyWeightKg float64
+}
+
+func (RPETonnageModel) Name() LoadModelName { return LoadModelRPETonnage }
+func (RPETonnageModel) HistoryDays() int    { return rollingHistoryDays }
+func (RPETonnageModel) Unit() string        { return "kg" }
+
+func (m RPETonnageModel) Summarize(sessions []workout.WorkoutSession, now time.Time) LoadSummary {
+	return withModel(rollingSummary(sessions, now, m.SessionLoad), m)
+}
+
+func (m RPETonnageModel) SessionLoad(s workout.WorkoutSession) float64 {
+	var total float64
+	for _, ex := range s.Exercises {
+		for _, set := range ex.Sets {
+			if IsWarmupSet(set) || set.Reps <= 0 {
+				continue
+			}
+			weight := set.Weight
+			if weight <= 0 {
+				weight = m.BodyWeightKg
+			}
+			if weight <= 0 {
+				continue
+			}
+			rpe := set.RPE
+			if rpe <= 0 {
+				rpe = defaultSetRPE
+			}
+			total += float64(set.Reps) * weight * rpe / 10
+		}
+	}
+	return total
+}
+
+// sessionLoader is implemented by the models that score single sessions.
+type sessionLoader interface {
+	SessionLoad(s workout.WorkoutSession) float64
+}
+
+// EWMAModel replaces fixed windows with exponentially weighted daily load
+// (Williams et al.): lambda = 2/(N+1) with N = 7 (acute) and 28 (chronic).
+// Acute and chronic are reported as 7- and 28-day equivalents so they stay
+// comparable with the rolling models.
+type EWMAModel struct {
+	Base sessionLoader
+}
+
+func (EWMAModel) Name() LoadModelName { return LoadModelEWMA }
+func (EWMAModel) HistoryDays() int    { return ewmaHistoryDays }
+
+func (m EWMAModel) Unit() string {
+	if u, ok := m.Base.(interface{ Unit() string }); ok {
+		return u.Unit()
+	}
+	return "AU"
+}
+
+func (m EWMAModel) Summarize(sessions []workout.WorkoutSession, now time.Time) LoadSummary {
+	base := m.Base
+	if base == nil {
+		base = TonnageModel{}
+	}
+	sum := rollingSummary(sessions, now, base.SessionLoad)
+
+	acute, chronic := ewmaLoads(sessions, now, base.SessionLoad)
+	sum.AcuteLoad7d = Round2(acute * ewmaAcuteDays)
+	sum.ChronicLoad28d = Round2(chronic * ewmaChronicDays)
+	sum.ACWR = 0
+	if chronic > 0 {
+		sum.ACWR = acute / chronic
+	}
+	return withModel(sum, m)
+}
+
+const (
+	ewmaAcuteDays   = 7
+	ewmaChronicDays = 28
+	// ewmaHistoryDays bounds how far back daily loads are replayed; older
+	// contributions are below 1% of the chronic average.
+	ewmaHistoryDays = 4 * ewmaChronicDays
+)
+
+func ewmaLoads(sessions []workout.WorkoutSession, now time.Time, load func(workout.WorkoutSession) float64) (acute, chronic float64) {
+	today := BucketStart(now, BucketDay)
+	start := today.AddDate(0, 0, -ewmaHistoryDays)
+
+	daily := make(map[time.Time]float64)
+	for _, s := range sessions {
+		day := BucketStart(s.SessionDate, BucketDay)
+		if day.Before(start) || day.After(today) {
+			continue
+		}
+		daily[day] += load(s)
+	}
+
+	days := make([]time.Time, 0, ewmaHistoryDays+1)
+	for d := start; !d.After(today); d = d.AddDate(0, 0, 1) {
+		days = append(days, d)
+	}
+
+	la := 2.0 / float64(ewmaAcuteDays+1)
+	lc := 2.0 / float64(ewmaChronicDays+1)
+	for _, d := range days {
+		l := daily[d]
+		acute = l*la + (1-la)*acute
+		chronic = l*lc + (1-lc)*chronic
+	}
+	return acute, chronic
+}
+
+func withModel(sum LoadSummary, m LoadModel) LoadSummary {
+	sum.Model = m.Name()
+	sum.Unit = m.Unit()
+	return sum
+}
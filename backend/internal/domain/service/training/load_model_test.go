@@ -0,0 +1,171 @@
+package training
+
+import (
+	"encoding/json"
+	"flag"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/workout"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+var goldenNow = time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC)
+
+// goldenDay is midnight d days before goldenNow, as session dates are stored.
+func goldenDay(d int) time.Time {
+	return BucketStart(goldenNow, BucketDay).AddDate(0, 0, -d)
+}
+
+// steadyHistory is the same session every day for 16 weeks: a constant
+// training load, with no weekly rhythm for the EWMA to pick up.
+func steadyHistory() []workout.WorkoutSession {
+	var sessions []workout.WorkoutSession
+	for d := 0; d < 16*7; d++ {
+		sessions = append(sessions, workout.WorkoutSession{
+			ID:          "steady",
+			SessionDate: goldenDay(d),
+			DurationMin: 60,
+			Exercises: []workout.WorkoutExercise{
+				{ExerciseID: "squat", Sets: []workout.WorkoutSet{
+					{Reps: 5, Weight: 60, SetType: "warmup"},
+					{Reps: 5, Weight: 100, RPE: 8},
+					{Reps: 5, Weight: 100, RPE: 8},
+					{Reps: 5, Weight: 100, RPE: 8.5},
+				}},
+				{ExerciseID: "pullup", Sets: []workout.WorkoutSet{
+					{Reps: 8, RPE: 7},
+					{Reps: 8, RPE: 7.5},
+				}},
+			},
+		})
+	}
+	return sessions
+}
+
+// rampHistory is four easy weeks, then a week of doubled work logged mostly
+// without RPE or duration. Tonnage spikes; session RPE has to estimate the
+// duration from the set count.
+func rampHistory() []workout.WorkoutSession {
+	var sessions []workout.WorkoutSession
+	for d := 0; d < 35; d += 2 {
+		s := workout.WorkoutSession{
+			ID:          "ramp",
+			SessionDate: goldenDay(d),
+			DurationMin: 45,
+			Exercises: []workout.WorkoutExercise{
+				{ExerciseID: "bench", Sets: []workout.WorkoutSet{
+					{Reps: 10, Weight: 60, RPE: 6},
+					{Reps: 10, Weight: 60, RPE: 6.5},
+				}},
+			},
+		}
+		if d < 7 {
+			s.DurationMin = 0
+			s.Exercises = append(s.Exercises, workout.WorkoutExercise{ExerciseID: "row", Sets: []workout.WorkoutSet{
+				{Reps: 8, Weight: 70},
+				{Reps: 8, Weight: 70},
+				{Reps: 8, Weight: 70, RPE: 9.5},
+				{Reps: 12, Weight: 0},
+			}})
+		}
+		sessions = append(sessions, s)
+	}
+	return sessions
+}
+
+var loadModels = []LoadModel{
+	TonnageModel{},
+	SessionRPEModel{},
+	RPETonnageModel{BodyWeightKg: 80},
+	EWMAModel{Base: TonnageModel{}},
+}
+
+func TestLoadModelsGolden(t *testing.T) {
+	histories := map[string][]workout.WorkoutSession{
+		"steady": steadyHistory(),
+		"ramp":   rampHistory(),
+		"empty":  nil,
+	}
+	for _, m := range loadModels {
+		for name, sessions := range histories {
+			t.Run(string(m.Name())+"/"+name, func(t *testing.T) {
+				got, err := json.MarshalIndent(m.Summarize(sessions, goldenNow), "", "  ")
+				if err != nil {
+					t.Fatal(err)
+				}
+				checkGolden(t, filepath.Join("testdata", "load", string(m.Name())+"_"+name+".golden"), got)
+			})
+		}
+	}
+}
+
+func TestSteadyLoadIsBalanced(t *testing.T) {
+	for _, m := range loadModels {
+		sum := m.Summarize(steadyHistory(), goldenNow)
+		if math.Abs(sum.ACWR-1) > 0.05 {
+			t.Errorf("%s: steady training gives ACWR %.3f, want about 1", m.Name(), sum.ACWR)
+		}
+	}
+}
+
+func TestEWMANeedsItsHistory(t *testing.T) {
+	m := EWMAModel{Base: TonnageModel{}}
+	if m.HistoryDays() <= rollingHistoryDays {
+		t.Fatalf("HistoryDays() = %d, want more than the %d-day rolling window", m.HistoryDays(), rollingHistoryDays)
+	}
+
+	// With only the rolling window loaded the chronic average has not caught
+	// up yet and steady training looks like a spike.
+	cutoff := goldenNow.AddDate(0, 0, -rollingHistoryDays)
+	var short []workout.WorkoutSession
+	for _, s := range steadyHistory() {
+		if !s.SessionDate.Before(cutoff) {
+			short = append(short, s)
+		}
+	}
+	if acwr := m.Summarize(short, goldenNow).ACWR; acwr < 1.1 {
+		t.Fatalf("ACWR from %d days = %.3f; the test no longer shows the starved chronic load", rollingHistoryDays, acwr)
+	}
+}
+
+func TestParseLoadModel(t *testing.T) {
+	for in, want := range map[string]LoadModelName{
+		" EWMA ":      LoadModelEWMA,
+		"session_rpe": LoadModelSessionRPE,
+		"rpe_tonnage": LoadModelRPETonnage,
+		"tonnage":     LoadModelTonnage,
+	} {
+		if got, ok := ParseLoadModel(in); !ok || got != want {
+			t.Errorf("ParseLoadModel(%q) = %q, %v; want %q", in, got, ok, want)
+		}
+	}
+	if got, ok := ParseLoadModel("trimp"); ok || got != LoadModelTonnage {
+		t.Errorf("ParseLoadModel(trimp) = %q, %v; want tonnage, false", got, ok)
+	}
+}
+
+func checkGolden(t *testing.T, path string, got []byte) {
+	t.Helper()
+	got = append(got, '\n')
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run go test -update to create it)", err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("%s differs:\ngot:\n%s\nwant:\n%s", path, got, want)
+	}
+}
@@ -0,0 +1,178 @@
+package training
+
+import (
+	"sort"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/exercise"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/workout"
+	"S.P.A.R.T.A/backend/internal/domain/service/muscle"
+)
+
+// Balance thresholds. Ratios are left/right; anything outside
+// [1/max, max] is flagged once both sides together reach minBalanceSets.
+const (
+	pushPullMaxRatio = 1.5
+	quadHamMaxRatio  = 2.0
+	quadHamMinRatio  = 0.67
+	minBalanceSets   = 4
+)
+
+// MuscleVolumeConfig controls how sets are credited to muscle groups.
+type MuscleVolumeConfig struct {
+	Landmarks       map[muscle.Group]muscle.Landmarks
+	SecondaryWeight float64
+}
+
+type MuscleVolume struct {
+	Group     muscle.Group
+	Region    muscle.Region
+	HardSets  float64
+	Landmarks muscle.Landmarks
+	Status    muscle.VolumeStatus
+}
+
+type BalanceCheck struct {
+	Name       string
+	LeftSets   float64
+	RightSets  float64
+	Ratio      float64
+	Imbalanced bool
+}
+
+type MuscleWeek struct {
+	WeekStart        time.Time
+	Muscles          []MuscleVolume
+	UnclassifiedSets float64
+	Balance          []BalanceCheck
+}
+
+type MuscleVolumeReport struct {
+	Weeks []MuscleWeek
+	// UnclassifiedExerciseIDs lists exercises whose muscles could not be
+	// mapped to the taxonomy, so callers can fix the exercise data.
+	UnclassifiedExerciseIDs []string
+}
+
+// IsHardSet reports whether a set counts toward weekly volume: a non-warm-up
+// set with reps and, when RPE was logged, an RPE of at least 6.
+func IsHardSet(set workout.WorkoutSet) bool {
+	if IsWarmupSet(set) || set.Reps <= 0 {
+		return false
+	}
+	return set.RPE == 0 || set.RPE >= 6
+}
+
+// ComputeWeeklyMuscleVolume credits hard sets to muscle groups per
+// Monday-based week. exercises must contain every exercise referenced by
+// the sessions; missing ones are treated as unclassified.
+func ComputeWeeklyMuscleVolume(
+	sessions []workout.WorkoutSession,
+	exercises map[string]exercise.Exercise,
+	cfg MuscleVolumeConfig,
+) MuscleVolumeReport {
+	targets := make(map[string][]muscle.Target, len(exercises))
+	for id, ex := range exercises {
+		targets[id] = muscle.Resolve(ex, cfg.SecondaryWeight)
+	}
+
+	type weekAcc struct {
+		sets         map[muscle.Group]float64
+		unclassified float64
+	}
+	weeks := make(map[time.Time]*weekAcc)
+	unclassified := make(map[string]struct{})
+
+	for _, s := range sessions {
+		start := BucketStart(s.SessionDate, BucketWeek)
+		acc, ok := weeks[start]
+		if !ok {
+			acc = &weekAcc{sets: make(map[muscle.Group]float64)}
+			weeks[start] = acc
+		}
+
+		for _, ex := range s.Exercises {
+			hard := 0
+			for _, set := range ex.Sets {
+				if IsHardSet(set) {
+					hard++
+				}
+			}
+			if hard == 0 {
+				continue
+			}
+
+			ts := targets[ex.ExerciseID]
+			if len(ts) == 0 {
+				acc.unclassified += float64(hard)
+				if ex.ExerciseID != "" {
+					unclassified[ex.ExerciseID] = struct{}{}
+				}
+				continue
+			}
+			for _, t := range ts {
+				acc.sets[t.Group] += float64(hard) * t.Weight
+			}
+		}
+	}
+
+	var report MuscleVolumeReport
+	for start, acc := range weeks {
+		week := MuscleWeek{
+			WeekStart:        start,
+			UnclassifiedSets: Round2(acc.unclassified),
+		}
+		for _, g := range muscle.AllGroups() {
+			sets := Round2(acc.sets[g])
+			lm := cfg.Landmarks[g]
+			week.Muscles = append(week.Muscles, MuscleVolume{
+				Group:     g,
+				Region:    muscle.RegionOf(g),
+				HardSets:  sets,
+				Landmarks: lm,
+				Status:    lm.Status(sets),
+			})
+		}
+
+		push := acc.sets[muscle.Chest] + acc.sets[muscle.FrontDelts] + acc.sets[muscle.Triceps]
+		pull := acc.sets[muscle.Back] + acc.sets[muscle.RearDelts] + acc.sets[muscle.Biceps]
+		week.Balance = append(week.Balance,
+			balanceCheck("push_pull", push, pull, 1/pushPullMaxRatio, pushPullMaxRatio),
+			balanceCheck("quad_ham", acc.sets[muscle.Quads], acc.sets[muscle.Hamstrings], quadHamMinRatio, quadHamMaxRatio),
+		)
+
+		report.Weeks = append(report.Weeks, week)
+	}
+	sort.Slice(report.Weeks, func(i, j int) bool {
+		return report.Weeks[i].WeekStart.Before(report.Weeks[j].WeekStart)
+	})
+
+	for id := range unclassified {
+		report.UnclassifiedExerciseIDs = append(report.UnclassifiedExerciseIDs, id)
+	}
+	sort.Strings(report.UnclassifiedExerciseIDs)
+
+	return report
+}
+
+// balanceCheck compares left/right volume. When one side is zero the ratio
+// is reported as 0 and the check is flagged if the other side has volume.
+func balanceCheck(name string, left, right, minRatio, maxRatio float64) BalanceCheck {
+	out := BalanceCheck{
+		Name:      name,
+		LeftSets:  Round2(left),
+		RightSets: Round2(right),
+	}
+	if left+right < minBalanceSets {
+		return out
+	}
+	if right == 0 || left == 0 {
+		out.Imbalanced = true
+		return out
+	}
+
+	ratio := left / right
+	out.Ratio = Round2(ratio)
+	out.Imbalanced = ratio < minRatio || ratio > maxRatio
+	return out
+}
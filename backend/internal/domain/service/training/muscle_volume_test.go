@@ -0,0 +1,218 @@
+package training
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/exercise"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/workout"
+	"S.P.A.R.T.A/backend/internal/domain/service/muscle"
+)
+
+func TestIsHardSet(t *testing.T) {
+	tests := []struct {
+		name string
+		set  workout.WorkoutSet
+		want bool
+	}{
+		{"working set without RPE", workout.WorkoutSet{Reps: 8}, true},
+		{"RPE 6 counts", workout.WorkoutSet{Reps: 8, RPE: 6}, true},
+		{"RPE 5.5 is too easy", workout.WorkoutSet{Reps: 8, RPE: 5.5}, false},
+		{"warm-up", workout.WorkoutSet{Reps: 8, SetType: " Warmup "}, false},
+		{"no reps", workout.WorkoutSet{Reps: 0, RPE: 9}, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := IsHardSet(tc.set); got != tc.want {
+				t.Errorf("IsHardSet(%+v) = %v, want %v", tc.set, got, tc.want)
+			}
+		})
+	}
+}
+
+// volumeSession logs n hard sets of each exercise, plus a warm-up and an
+// easy set that must not count.
+func volumeSession(day time.Time, n int, exerciseIDs ...string) workout.WorkoutSession {
+	s := workout.WorkoutSession{SessionDate: day, Status: workout.SessionStatusCompleted}
+	for _, id := range exerciseIDs {
+		ex := workout.WorkoutExercise{ExerciseID: id}
+		ex.Sets = append(ex.Sets,
+			workout.WorkoutSet{Reps: 10, Weight: 40, SetType: "warmup"},
+			workout.WorkoutSet{Reps: 10, Weight: 40, RPE: 4},
+		)
+		for i := 0; i < n; i++ {
+			ex.Sets = append(ex.Sets, workout.WorkoutSet{Reps: 8, Weight: 80, RPE: 8})
+		}
+		s.Exercises = append(s.Exercises, ex)
+	}
+	return s
+}
+
+var volumeExercises = map[string]exercise.Exercise{
+	"bench":    {Name: "Bench Press", PrimaryMuscle: "chest", SecondaryMuscles: []string{"triceps", "front delts"}},
+	"row":      {Name: "Bent-Over Row", PrimaryMuscle: "back", SecondaryMuscles: []string{"biceps", "rear delts"}},
+	"squat":    {Name: "Squat", PrimaryMuscle: "legs", SecondaryMuscles: []string{"glutes"}},
+	"leg-curl": {Name: "Leg Curl", PrimaryMuscle: "legs"},
+	"sled":     {Name: "Sled Push", PrimaryMuscle: "legs"},
+}
+
+// hardSets returns the week's sets per group that has any.
+func hardSets(week MuscleWeek) map[muscle.Group]float64 {
+	out := make(map[muscle.Group]float64)
+	for _, m := range week.Muscles {
+		if m.HardSets != 0 {
+			out[m.Group] = m.HardSets
+		}
+	}
+	return out
+}
+
+func TestComputeWeeklyMuscleVolumeCountsSecondariesFractionally(t *testing.T) {
+	sessions := []workout.WorkoutSession{
+		volumeSession(date(2026, 3, 2), 3, "bench", "squat"),
+		volumeSession(date(2026, 3, 5), 2, "bench"),
+		// Sunday still belongs to the week of Monday 2 March.
+		volumeSession(date(2026, 3, 8), 1, "leg-curl"),
+		volumeSession(date(2026, 3, 9), 4, "row"),
+	}
+
+	report := ComputeWeeklyMuscleVolume(sessions, volumeExercises, MuscleVolumeConfig{
+		Landmarks:       muscle.DefaultLandmarks(),
+		SecondaryWeight: 0.5,
+	})
+
+	if len(report.Weeks) != 2 {
+		t.Fatalf("got %d weeks, want 2", len(report.Weeks))
+	}
+	if !report.Weeks[0].WeekStart.Equal(date(2026, 3, 2)) || !report.Weeks[1].WeekStart.Equal(date(2026, 3, 9)) {
+		t.Errorf("weeks start %v and %v, want the Mondays 2 and 9 March", report.Weeks[0].WeekStart, report.Weeks[1].WeekStart)
+	}
+
+	want := []map[muscle.Group]float64{
+		{
+			muscle.Chest: 5, muscle.Triceps: 2.5, muscle.FrontDelts: 2.5,
+			muscle.Quads: 3, muscle.Glutes: 1.5, muscle.Hamstrings: 1,
+		},
+		{muscle.Back: 4, muscle.Biceps: 2, muscle.RearDelts: 2},
+	}
+	for i, week := range report.Weeks {
+		if got := hardSets(week); !reflect.DeepEqual(got, want[i]) {
+			t.Errorf("week %d hard sets = %v, want %v", i, got, want[i])
+		}
+		if len(week.Muscles) != len(muscle.AllGroups()) {
+			t.Errorf("week %d lists %d groups, want all %d", i, len(week.Muscles), len(muscle.AllGroups()))
+		}
+	}
+
+	withoutSecondaries := ComputeWeeklyMuscleVolume(sessions[:1], volumeExercises, MuscleVolumeConfig{})
+	if got, want := hardSets(withoutSecondaries.Weeks[0]), (map[muscle.Group]float64{muscle.Chest: 3, muscle.Quads: 3}); !reflect.DeepEqual(got, want) {
+		t.Errorf("hard sets without secondary weight = %v, want %v", got, want)
+	}
+}
+
+func TestComputeWeeklyMuscleVolumeClassifiesAgainstLandmarks(t *testing.T) {
+	sessions := []workout.WorkoutSession{
+		volumeSession(date(2026, 3, 2), 5, "bench", "row"),
+		volumeSession(date(2026, 3, 4), 5, "bench", "row"),
+	}
+	landmarks := map[muscle.Group]muscle.Landmarks{
+		muscle.Chest:     {MEV: 4, MAV: 8, MRV: 12},
+		muscle.Back:      {MEV: 4, MAV: 10, MRV: 12},
+		muscle.Triceps:   {MEV: 6, MAV: 10, MRV: 14},
+		muscle.RearDelts: {MEV: 1, MAV: 2, MRV: 4},
+	}
+
+	report := ComputeWeeklyMuscleVolume(sessions, volumeExercises, MuscleVolumeConfig{Landmarks: landmarks, SecondaryWeight: 0.5})
+
+	want := map[muscle.Group]muscle.VolumeStatus{
+		muscle.Chest:     muscle.StatusHigh,
+		muscle.Back:      muscle.StatusProductive,
+		muscle.Triceps:   muscle.StatusBelowMEV,
+		muscle.RearDelts: muscle.StatusAboveMRV,
+		muscle.Quads:     muscle.StatusUntrained,
+	}
+	for _, m := range report.Weeks[0].Muscles {
+		if status, ok := want[m.Group]; ok && m.Status != status {
+			t.Errorf("%s at %v sets = %s, want %s", m.Group, m.HardSets, m.Status, status)
+		}
+		if m.Landmarks != landmarks[m.Group] {
+			t.Errorf("%s landmarks = %+v, want %+v", m.Group, m.Landmarks, landmarks[m.Group])
+		}
+	}
+}
+
+func TestComputeWeeklyMuscleVolumeFlagsImbalances(t *testing.T) {
+	tests := []struct {
+		name     string
+		sessions []workout.WorkoutSession
+		want     []BalanceCheck
+	}{
+		{
+			name:     "balanced",
+			sessions: []workout.WorkoutSession{volumeSession(date(2026, 3, 2), 4, "bench", "row", "squat", "leg-curl")},
+			want: []BalanceCheck{
+				{Name: "push_pull", LeftSets: 8, RightSets: 8, Ratio: 1},
+				{Name: "quad_ham", LeftSets: 4, RightSets: 4, Ratio: 1},
+			},
+		},
+		{
+			name: "push heavy and no hamstrings",
+			sessions: []workout.WorkoutSession{
+				volumeSession(date(2026, 3, 2), 6, "bench"),
+				volumeSession(date(2026, 3, 3), 2, "row", "squat"),
+			},
+			want: []BalanceCheck{
+				{Name: "push_pull", LeftSets: 12, RightSets: 4, Ratio: 3, Imbalanced: true},
+				{Name: "quad_ham", LeftSets: 2, RightSets: 0, Ratio: 0, Imbalanced: false},
+			},
+		},
+		{
+			name: "quads far ahead of hamstrings",
+			sessions: []workout.WorkoutSession{
+				volumeSession(date(2026, 3, 2), 9, "squat"),
+				volumeSession(date(2026, 3, 4), 4, "leg-curl"),
+			},
+			want: []BalanceCheck{
+				{Name: "push_pull", LeftSets: 0, RightSets: 0},
+				{Name: "quad_ham", LeftSets: 9, RightSets: 4, Ratio: 2.25, Imbalanced: true},
+			},
+		},
+		{
+			name:     "one side with no volume at all",
+			sessions: []workout.WorkoutSession{volumeSession(date(2026, 3, 2), 3, "row")},
+			want: []BalanceCheck{
+				{Name: "push_pull", LeftSets: 0, RightSets: 6, Ratio: 0, Imbalanced: true},
+				{Name: "quad_ham", LeftSets: 0, RightSets: 0},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			report := ComputeWeeklyMuscleVolume(tc.sessions, volumeExercises, MuscleVolumeConfig{SecondaryWeight: 0.5})
+			if got := report.Weeks[0].Balance; !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("balance = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestComputeWeeklyMuscleVolumeReportsUnclassifiedExercises(t *testing.T) {
+	sessions := []workout.WorkoutSession{
+		volumeSession(date(2026, 3, 2), 3, "sled", "bench", "unknown"),
+		// Only warm-ups: nothing to credit, so nothing to report.
+		volumeSession(date(2026, 3, 3), 0, "also-unknown"),
+	}
+
+	report := ComputeWeeklyMuscleVolume(sessions, volumeExercises, MuscleVolumeConfig{SecondaryWeight: 0.5})
+
+	if got := report.Weeks[0].UnclassifiedSets; got != 6 {
+		t.Errorf("unclassified sets = %v, want 6", got)
+	}
+	if want := []string{"sled", "unknown"}; !reflect.DeepEqual(report.UnclassifiedExerciseIDs, want) {
+		t.Errorf("unclassified exercises = %q, want %q", report.UnclassifiedExerciseIDs, want)
+	}
+	if got := hardSets(report.Weeks[0])[muscle.Chest]; got != 3 {
+		t.Errorf("chest sets = %v, want 3", got)
+	}
+}
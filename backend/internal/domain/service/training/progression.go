@@ -0,0 +1,162 @@
+package training
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/workout"
+)
+
+// Bucket is the time granularity of a progression series.
+type Bucket string
+
+const (
+	BucketDay   Bucket = "day"
+	BucketWeek  Bucket = "week"
+	BucketMonth Bucket = "month"
+)
+
+// ParseBucket accepts day, week or month (case-insensitive). Empty input
+// defaults to week.
+func ParseBucket(s string) (Bucket, bool) {
+	switch Bucket(strings.ToLower(strings.TrimSpace(s))) {
+	case "", BucketWeek:
+		return BucketWeek, true
+	case BucketDay:
+		return BucketDay, true
+	case BucketMonth:
+		return BucketMonth, true
+	default:
+		return "", false
+	}
+}
+
+// BucketStart truncates t to the start of its bucket in UTC. Weeks start on
+// Monday.
+func BucketStart(t time.Time, b Bucket) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch b {
+	case BucketDay:
+		return day
+	case BucketMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	}
+}
+
+// IsWarmupSet reports whether a set is a warm-up and should be left out of
+// strength and volume metrics.
+func IsWarmupSet(set workout.WorkoutSet) bool {
+	return strings.EqualFold(strings.TrimSpace(set.SetType), "warmup")
+}
+
+// ProgressPoint aggregates one exercise over one bucket.
+type ProgressPoint struct {
+	PeriodStart  time.Time
+	E1RM         float64
+	TopSetWeight float64
+	TopSetReps   int
+	Volume       float64
+	Sets         int
+	Sessions     int
+}
+
+// WeeklySetCount is the number of working sets in a Monday-based week.
+type WeeklySetCount struct {
+	WeekStart time.Time
+	Sets      int
+}
+
+// ExerciseProgression is the time series for one exercise.
+type ExerciseProgression struct {
+	ExerciseID string
+	Bucket     Bucket
+	Formula    OneRepMaxFormula
+	Points     []ProgressPoint
+	WeeklySets []WeeklySetCount
+}
+
+// ComputeExerciseProgression builds per-bucket e1RM, top set, volume and set
+// counts for one exercise, plus a weekly set count series regardless of the
+// chosen bucket. Warm-up sets and sets without load or reps are ignored.
+// Only buckets with at least one working set are returned, oldest first.
+func ComputeExerciseProgression(
+	sessions []workout.WorkoutSession,
+	exerciseID string,
+	bucket Bucket,
+	formula OneRepMaxFormula,
+) ExerciseProgression {
+	out := ExerciseProgression{
+		ExerciseID: exerciseID,
+		Bucket:     bucket,
+		Formula:    formula,
+	}
+
+	points := make(map[time.Time]*ProgressPoint)
+	weekly := make(map[time.Time]int)
+
+	for _, s := range sessions {
+		start := BucketStart(s.SessionDate, bucket)
+		week := BucketStart(s.SessionDate, BucketWeek)
+		trained := false
+
+		for _, ex := range s.Exercises {
+			if ex.ExerciseID != exerciseID {
+				continue
+			}
+			for _, set := range ex.Sets {
+				if IsWarmupSet(set) || set.Reps <= 0 || set.Weight <= 0 {
+					continue
+				}
+
+				p, ok := points[start]
+				if !ok {
+					p = &ProgressPoint{PeriodStart: start}
+					points[start] = p
+				}
+
+				trained = true
+				p.Sets++
+				p.Volume += float64(set.Reps) * set.Weight
+				weekly[week]++
+
+				if e1rm := EstimateOneRepMax(set.Weight, set.Reps, formula); e1rm > p.E1RM {
+					p.E1RM = e1rm
+				}
+				if set.Weight > p.TopSetWeight || (set.Weight == p.TopSetWeight && set.Reps > p.TopSetReps) {
+					p.TopSetWeight = set.Weight
+					p.TopSetReps = set.Reps
+				}
+			}
+		}
+
+		if trained {
+			points[start].Sessions++
+		}
+	}
+
+	out.Points = make([]ProgressPoint, 0, len(points))
+	for _, p := range points {
+		p.E1RM = Round2(p.E1RM)
+		p.Volume = Round2(p.Volume)
+		out.Points = append(out.Points, *p)
+	}
+	sort.Slice(out.Points, func(i, j int) bool {
+		return out.Points[i].PeriodStart.Before(out.Points[j].PeriodStart)
+	})
+
+	out.WeeklySets = make([]WeeklySetCount, 0, len(weekly))
+	for week, n := range weekly {
+		out.WeeklySets = append(out.WeeklySets, WeeklySetCount{WeekStart: week, Sets: n})
+	}
+	sort.Slice(out.WeeklySets, func(i, j int) bool {
+		return out.WeeklySets[i].WeekStart.Before(out.WeeklySets[j].WeekStart)
+	})
+
+	return out
+}
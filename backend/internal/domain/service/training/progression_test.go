@@ -0,0 +1,175 @@
+package training
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/workout"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestBucketStart(t *testing.T) {
+	plus3 := time.FixedZone("UTC+3", 3*60*60)
+
+	tests := []struct {
+		name   string
+		t      time.Time
+		bucket Bucket
+		want   time.Time
+	}{
+		{"day drops the time", time.Date(2026, 3, 4, 17, 45, 0, 0, time.UTC), BucketDay, date(2026, 3, 4)},
+		{"week from Monday", time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC), BucketWeek, date(2026, 3, 2)},
+		{"week from Wednesday", date(2026, 3, 4), BucketWeek, date(2026, 3, 2)},
+		{"week from Sunday goes back six days", time.Date(2026, 3, 8, 23, 59, 0, 0, time.UTC), BucketWeek, date(2026, 3, 2)},
+		{"week across the year boundary", date(2026, 1, 1), BucketWeek, date(2025, 12, 29)},
+		{"month", time.Date(2026, 2, 28, 12, 0, 0, 0, time.UTC), BucketMonth, date(2026, 2, 1)},
+		{"other zones are converted to UTC first", time.Date(2026, 3, 2, 1, 0, 0, 0, plus3), BucketWeek, date(2026, 2, 23)},
+		{"day in another zone", time.Date(2026, 3, 1, 1, 0, 0, 0, plus3), BucketDay, date(2026, 2, 28)},
+		{"month in another zone", time.Date(2026, 3, 1, 1, 0, 0, 0, plus3), BucketMonth, date(2026, 2, 1)},
+		{"unknown bucket is a week", date(2026, 3, 5), Bucket("fortnight"), date(2026, 3, 2)},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := BucketStart(tc.t, tc.bucket)
+			if !got.Equal(tc.want) || got.Location() != time.UTC {
+				t.Errorf("BucketStart(%v, %s) = %v, want %v", tc.t, tc.bucket, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIsWarmupSet(t *testing.T) {
+	for setType, want := range map[string]bool{
+		"warmup":   true,
+		" WarmUp ": true,
+		"WARMUP":   true,
+		"":         false,
+		"working":  false,
+		"warm-up":  false,
+		"drop":     false,
+	} {
+		if got := IsWarmupSet(workout.WorkoutSet{SetType: setType}); got != want {
+			t.Errorf("IsWarmupSet(%q) = %v, want %v", setType, got, want)
+		}
+	}
+}
+
+// progressionSessions are squat sessions over two Monday-based weeks, in no
+// particular order, with sets that must be left out of the metrics.
+func progressionSessions() []workout.WorkoutSession {
+	squat := func(sets ...workout.WorkoutSet) workout.WorkoutExercise {
+		return workout.WorkoutExercise{ExerciseID: "squat", Sets: sets}
+	}
+	return []workout.WorkoutSession{
+		{SessionDate: date(2026, 3, 3), Exercises: []workout.WorkoutExercise{
+			squat(workout.WorkoutSet{Reps: 2, Weight: 105}, workout.WorkoutSet{Reps: 3, Weight: 105}),
+		}},
+		{SessionDate: date(2026, 2, 24), Exercises: []workout.WorkoutExercise{
+			squat(
+				workout.WorkoutSet{Reps: 5, Weight: 60, SetType: "warmup"},
+				workout.WorkoutSet{Reps: 5, Weight: 100},
+				workout.WorkoutSet{Reps: 3, Weight: 100},
+				workout.WorkoutSet{Reps: 10, Weight: 0},
+				workout.WorkoutSet{Reps: 0, Weight: 80},
+			),
+			{ExerciseID: "bench", Sets: []workout.WorkoutSet{{Reps: 5, Weight: 200}}},
+		}},
+		// Only warm-ups: neither a point nor a session.
+		{SessionDate: date(2026, 3, 4), Exercises: []workout.WorkoutExercise{
+			squat(workout.WorkoutSet{Reps: 5, Weight: 50, SetType: "Warmup"}),
+		}},
+		{SessionDate: date(2026, 2, 26), Exercises: []workout.WorkoutExercise{
+			squat(workout.WorkoutSet{Reps: 2, Weight: 102.5}, workout.WorkoutSet{Reps: 6, Weight: 95}),
+		}},
+	}
+}
+
+func TestComputeExerciseProgression(t *testing.T) {
+	weekly := []WeeklySetCount{{WeekStart: date(2026, 2, 23), Sets: 4}, {WeekStart: date(2026, 3, 2), Sets: 2}}
+
+	tests := []struct {
+		name    string
+		bucket  Bucket
+		formula OneRepMaxFormula
+		want    []ProgressPoint
+	}{
+		{
+			name:    "weekly epley",
+			bucket:  BucketWeek,
+			formula: FormulaEpley,
+			want: []ProgressPoint{
+				// 100x5 is the best estimate; 102.5x2 the heaviest set.
+				{PeriodStart: date(2026, 2, 23), E1RM: 116.67, TopSetWeight: 102.5, TopSetReps: 2, Volume: 1575, Sets: 4, Sessions: 2},
+				// At equal weight the set with more reps is the top set.
+				{PeriodStart: date(2026, 3, 2), E1RM: 115.5, TopSetWeight: 105, TopSetReps: 3, Volume: 525, Sets: 2, Sessions: 1},
+			},
+		},
+		{
+			name:    "weekly brzycki",
+			bucket:  BucketWeek,
+			formula: FormulaBrzycki,
+			want: []ProgressPoint{
+				{PeriodStart: date(2026, 2, 23), E1RM: 112.5, TopSetWeight: 102.5, TopSetReps: 2, Volume: 1575, Sets: 4, Sessions: 2},
+				{PeriodStart: date(2026, 3, 2), E1RM: 111.18, TopSetWeight: 105, TopSetReps: 3, Volume: 525, Sets: 2, Sessions: 1},
+			},
+		},
+		{
+			name:    "daily",
+			bucket:  BucketDay,
+			formula: FormulaEpley,
+			want: []ProgressPoint{
+				{PeriodStart: date(2026, 2, 24), E1RM: 116.67, TopSetWeight: 100, TopSetReps: 5, Volume: 800, Sets: 2, Sessions: 1},
+				{PeriodStart: date(2026, 2, 26), E1RM: 114, TopSetWeight: 102.5, TopSetReps: 2, Volume: 775, Sets: 2, Sessions: 1},
+				{PeriodStart: date(2026, 3, 3), E1RM: 115.5, TopSetWeight: 105, TopSetReps: 3, Volume: 525, Sets: 2, Sessions: 1},
+			},
+		},
+		{
+			name:    "monthly",
+			bucket:  BucketMonth,
+			formula: FormulaEpley,
+			want: []ProgressPoint{
+				{PeriodStart: date(2026, 2, 1), E1RM: 116.67, TopSetWeight: 102.5, TopSetReps: 2, Volume: 1575, Sets: 4, Sessions: 2},
+				{PeriodStart: date(2026, 3, 1), E1RM: 115.5, TopSetWeight: 105, TopSetReps: 3, Volume: 525, Sets: 2, Sessions: 1},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ComputeExerciseProgression(progressionSessions(), "squat", tc.bucket, tc.formula)
+
+			if got.ExerciseID != "squat" || got.Bucket != tc.bucket || got.Formula != tc.formula {
+				t.Errorf("header = %s/%s/%s", got.ExerciseID, got.Bucket, got.Formula)
+			}
+			if !reflect.DeepEqual(got.Points, tc.want) {
+				t.Errorf("points:\n got %+v\nwant %+v", got.Points, tc.want)
+			}
+			// Weekly set counts do not depend on the bucket.
+			if !reflect.DeepEqual(got.WeeklySets, weekly) {
+				t.Errorf("weekly sets = %+v, want %+v", got.WeeklySets, weekly)
+			}
+		})
+	}
+}
+
+func TestComputeExerciseProgressionWithoutWorkingSets(t *testing.T) {
+	for name, sessions := range map[string][]workout.WorkoutSession{
+		"no sessions":    nil,
+		"other exercise": progressionSessions()[1:2],
+		"warm-ups only":  progressionSessions()[2:3],
+	} {
+		t.Run(name, func(t *testing.T) {
+			exercise := "squat"
+			if name == "other exercise" {
+				exercise = "deadlift"
+			}
+			got := ComputeExerciseProgression(sessions, exercise, BucketWeek, FormulaEpley)
+			if got.Points == nil || len(got.Points) != 0 || got.WeeklySets == nil || len(got.WeeklySets) != 0 {
+				t.Errorf("got %+v / %+v, want empty non-nil series", got.Points, got.WeeklySets)
+			}
+		})
+	}
+}
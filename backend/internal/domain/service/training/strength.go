@@ -0,0 +1,54 @@
+package training
+
+import (
+	"math"
+	"strings"
+)
+
+// OneRepMaxFormula selects how an estimated 1RM is derived from a set.
+type OneRepMaxFormula string
+
+const (
+	FormulaEpley   OneRepMaxFormula = "epley"
+	FormulaBrzycki OneRepMaxFormula = "brzycki"
+)
+
+// ParseOneRepMaxFormula accepts "epley" or "brzycki" (case-insensitive).
+// Anything else falls back to Epley and reports ok=false.
+func ParseOneRepMaxFormula(s string) (OneRepMaxFormula, bool) {
+	switch OneRepMaxFormula(strings.ToLower(strings.TrimSpace(s))) {
+	case FormulaEpley:
+		return FormulaEpley, true
+	case FormulaBrzycki:
+		return FormulaBrzycki, true
+	default:
+		return FormulaEpley, false
+	}
+}
+
+// EstimateOneRepMax returns the estimated 1RM for a set, or 0 when the set
+// cannot produce a meaningful estimate (no load, no reps, or Brzycki beyond
+// its valid range).
+func EstimateOneRepMax(weight float64, reps int, formula OneRepMaxFormula) float64 {
+	if weight <= 0 || reps <= 0 {
+		return 0
+	}
+	if reps == 1 {
+		return weight
+	}
+
+	switch formula {
+	case FormulaBrzycki:
+		if reps >= 37 {
+			return 0
+		}
+		return weight * 36 / float64(37-reps)
+	default:
+		return weight * (1 + float64(reps)/30)
+	}
+}
+
+// Round2 rounds to two decimals, matching the NUMERIC scale used for loads.
+func Round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
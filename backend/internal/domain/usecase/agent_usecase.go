@@ -0,0 +1,19 @@
+package usecase
+
+import (
+	"context"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/agent"
+	"github.com/google/uuid"
+)
+
+type AgentUsecase interface {
+	// Run lets the agent answer message using its tools. Changes it wants to
+	// make come back as pending actions.
+	Run(ctx context.Context, userID uuid.UUID, message string) (*agent.Result, error)
+	GetAction(ctx context.Context, id string) (*agent.Action, error)
+	GetActionOwner(ctx context.Context, id string) (string, error)
+	// ConfirmAction applies a pending action on behalf of its owner.
+	ConfirmAction(ctx context.Context, id string) (*agent.Action, error)
+	RejectAction(ctx context.Context, id string) (*agent.Action, error)
+}
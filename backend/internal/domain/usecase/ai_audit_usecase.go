@@ -0,0 +1,16 @@
+package usecase
+
+import (
+	"context"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/aicall"
+)
+
+type AIAuditUsecase interface {
+	ListCalls(ctx context.Context, filter aicall.Filter) (*aicall.Page, error)
+	GetCallStats(ctx context.Context, filter aicall.Filter, groupBy aicall.GroupBy) ([]aicall.Stat, error)
+
+	// CheckQuota and Record let the AI orchestrator enforce and log usage.
+	CheckQuota(ctx context.Context, userID string) error
+	Record(ctx context.Context, call aicall.Call)
+}
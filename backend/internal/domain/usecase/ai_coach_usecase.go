@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/coach"
 	"S.P.A.R.T.A/backend/internal/domain/aggregate/planner"
 	"S.P.A.R.T.A/backend/internal/domain/aggregate/split"
 	"S.P.A.R.T.A/backend/internal/domain/aggregate/workout"
@@ -10,11 +11,17 @@ import (
 )
 
 type AICoachUsecase interface {
-	GenerateSplitTemplate(ctx context.Context, userID uuid.UUID, daysPerWeek int, focusMuscle string) (*split.SplitTemplate, error)
+	// GenerateSplitTemplate fits the split to the user's stored constraints,
+	// with overrides taking precedence. It also returns the generated
+	// exercises it could not resolve to the library, for the client to map
+	// by hand.
+	GenerateSplitTemplate(ctx context.Context, userID uuid.UUID, daysPerWeek int, focusMuscle string, overrides split.ConstraintsOverride) (*split.SplitTemplate, []split.UnresolvedExercise, error)
 	SuggestProgressiveOverload(ctx context.Context, userID uuid.UUID, exerciseID uuid.UUID) (*planner.PlannerRecommendation, error)
-	GetDailyMotivation(ctx context.Context, userID uuid.UUID) (string, error)
+	GetDailyMotivation(ctx context.Context, userID uuid.UUID) (*coach.Motivation, error)
 	ResetDailyMotivation(ctx context.Context, userID uuid.UUID) error
 	GenerateWorkoutPlan(ctx context.Context, userID uuid.UUID, splitDayID uuid.UUID, fatigue int) (*workout.WorkoutPlan, error)
-	GetCoachingSuggestions(ctx context.Context, userID uuid.UUID) ([]string, error)
+	GetCoachingSuggestions(ctx context.Context, userID uuid.UUID) (*coach.CoachingSuggestions, error)
 	ExplainWorkoutPlan(ctx context.Context, userID uuid.UUID, plan workout.WorkoutPlan, splitDayName string, fatigue int) (*workout.WorkoutExplanation, error)
+	StreamCoachingSuggestions(ctx context.Context, userID uuid.UUID, onDelta func(string) error) (*coach.CoachingSuggestions, error)
+	StreamExplainWorkoutPlan(ctx context.Context, userID uuid.UUID, plan workout.WorkoutPlan, splitDayName string, fatigue int, onDelta func(string) error) (*workout.WorkoutExplanation, error)
 }
@@ -0,0 +1,26 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/service/training"
+)
+
+type AnalyticsUsecase interface {
+	GetExerciseProgression(
+		ctx context.Context,
+		userID string,
+		exerciseID string,
+		from time.Time,
+		to time.Time,
+		bucket training.Bucket,
+		formula training.OneRepMaxFormula,
+	) (*training.ExerciseProgression, error)
+	GetMuscleVolumeReport(
+		ctx context.Context,
+		userID string,
+		from time.Time,
+		to time.Time,
+	) (*training.MuscleVolumeReport, error)
+}
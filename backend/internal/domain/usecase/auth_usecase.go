@@ -1,14 +1,60 @@
 package usecase
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/auth"
+)
 
 type AuthResult struct {
 	UserID string `json:"user_id"`
-	Token  string `json:"token"`
-	Role   string `json:"role"`
+	// Token is the short-lived access token.
+	Token     string `json:"token"`
+	ExpiresIn int64  `json:"expires_in"`
+	Role      string `json:"role"`
+	SessionID string `json:"session_id"`
+	// RefreshToken can be exchanged once for a new pair at /auth/refresh.
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+	// EmailVerified is false until the user follows the mailed link; AI
+	// endpoints stay closed until then.
+	EmailVerified bool `json:"email_verified"`
 }
 
 type AuthUsecase interface {
-	Register(ctx context.Context, name, email, password, inviteToken string) (*AuthResult, error)
-	Login(ctx context.Context, email, password string) (*AuthResult, error)
+	Register(ctx context.Context, name, email, password, inviteToken string, client auth.Client) (*AuthResult, error)
+	Login(ctx context.Context, email, password string, client auth.Client) (*AuthResult, error)
+	// Refresh rotates a refresh token. Presenting a token that was already
+	// used revokes its whole session.
+	Refresh(ctx context.Context, refreshToken string) (*AuthResult, error)
+	ListSessions(ctx context.Context, userID string) ([]auth.Session, error)
+	GetSessionOwner(ctx context.Context, id string) (string, error)
+	// RevokeSession signs one device out; its access tokens stop working
+	// immediately.
+	RevokeSession(ctx context.Context, id string) error
+	// RevokeAllSessions signs the user out everywhere and invalidates every
+	// access token issued so far. Call it after changing a user's role.
+	RevokeAllSessions(ctx context.Context, userID string) error
+
+	// RequestPasswordReset mails a reset link. Unknown emails succeed
+	// silently so the endpoint does not reveal which accounts exist.
+	RequestPasswordReset(ctx context.Context, email string) error
+	// ResetPassword sets a new password with a mailed token and signs the
+	// user out everywhere.
+	ResetPassword(ctx context.Context, token, newPassword string) error
+	// SendVerificationEmail mails a new verification link, replacing any
+	// earlier one. ErrConflict if the email is already verified.
+	SendVerificationEmail(ctx context.Context, userID string) error
+	VerifyEmail(ctx context.Context, token string) error
+
+	// OAuthProviders names the configured external sign-in providers.
+	OAuthProviders() []string
+	// StartOAuth begins a sign-in at a provider. It returns the URL to send
+	// the browser to and the state the callback must bring back.
+	StartOAuth(ctx context.Context, provider string) (authURL, state string, err error)
+	// CompleteOAuth redeems the provider callback and signs the user in,
+	// linking the provider account by verified email or creating a user on
+	// first use. Unknown providers return ErrNotFound.
+	CompleteOAuth(ctx context.Context, provider, code, state string, client auth.Client) (*AuthResult, error)
 }
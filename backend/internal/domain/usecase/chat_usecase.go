@@ -0,0 +1,20 @@
+package usecase
+
+import (
+	"context"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/chat"
+	"github.com/google/uuid"
+)
+
+type ChatUsecase interface {
+	CreateThread(ctx context.Context, userID uuid.UUID, title string) (*chat.Thread, error)
+	ListThreads(ctx context.Context, userID uuid.UUID) ([]chat.Thread, error)
+	GetThread(ctx context.Context, threadID string) (*chat.Thread, error)
+	GetThreadOwner(ctx context.Context, threadID string) (string, error)
+	DeleteThread(ctx context.Context, threadID string) error
+	ListMessages(ctx context.Context, threadID string) ([]chat.Message, error)
+	// SendMessage stores the user's message, asks the coach with fresh
+	// training context and returns the stored reply.
+	SendMessage(ctx context.Context, userID uuid.UUID, threadID string, content string) (*chat.Message, error)
+}
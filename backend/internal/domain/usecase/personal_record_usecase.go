@@ -0,0 +1,13 @@
+package usecase
+
+import (
+	"context"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/record"
+)
+
+type PersonalRecordUsecase interface {
+	GetUserRecords(ctx context.Context, userID string) ([]record.PersonalRecord, error)
+	GetExerciseRecords(ctx context.Context, userID string, exerciseID string) ([]record.PersonalRecord, error)
+	RebuildUserRecords(ctx context.Context, userID string) (int, error)
+}
@@ -12,5 +12,6 @@ type SplitUsecase interface {
 	ActivateTemplate(ctx context.Context, userID string, templateID string) error
 	DeactivateTemplate(ctx context.Context, userID string, templateID string) error
 	GetTemplate(ctx context.Context, id string) (*split.SplitTemplate, error)
+	GetTemplateOwner(ctx context.Context, id string) (string, error)
 	GetUserTemplates(ctx context.Context, userID string) ([]split.SplitTemplate, error)
 }
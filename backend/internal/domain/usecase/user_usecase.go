@@ -0,0 +1,12 @@
+package usecase
+
+import (
+	"context"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/user"
+)
+
+type UserUsecase interface {
+	GetPreferences(ctx context.Context, userID string) (*user.Preferences, error)
+	UpdatePreferences(ctx context.Context, userID string, patch user.PreferencesPatch) (*user.Preferences, error)
+}
@@ -0,0 +1,21 @@
+package usecase
+
+import (
+	"context"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/workout"
+	"github.com/google/uuid"
+)
+
+type WorkoutPlanUsecase interface {
+	GetPlan(ctx context.Context, id string) (*workout.WorkoutPlan, error)
+	GetPlanOwner(ctx context.Context, id string) (string, error)
+	ListPlans(ctx context.Context, userID string, limit int) ([]workout.WorkoutPlan, error)
+	ExplainPlan(ctx context.Context, userID uuid.UUID, id string) (*workout.WorkoutExplanation, error)
+	StreamExplainPlan(ctx context.Context, userID uuid.UUID, id string, onDelta func(string) error) (*workout.WorkoutExplanation, error)
+	// StartPlan creates a draft session prefilled with the planned sets. A
+	// plan can be started once.
+	StartPlan(ctx context.Context, id string) (*workout.WorkoutSession, error)
+	// ComparePlan compares the session started from the plan with the plan.
+	ComparePlan(ctx context.Context, id string) (*workout.PlanComparison, error)
+}
@@ -3,11 +3,22 @@ package usecase
 import (
 	"context"
 
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/record"
 	"S.P.A.R.T.A/backend/internal/domain/aggregate/workout"
 )
 
 type WorkoutUsecase interface {
-	CreateWorkoutSession(ctx context.Context, session *workout.WorkoutSession) error
+	CreateWorkoutSession(ctx context.Context, session *workout.WorkoutSession) ([]record.PersonalRecord, error)
 	GetWorkoutSession(ctx context.Context, id string) (*workout.WorkoutSession, error)
-	GetUserWorkoutSessions(ctx context.Context, userID string) ([]workout.WorkoutSession, error)
+	GetWorkoutSessionOwner(ctx context.Context, id string) (string, error)
+	GetUserWorkoutSessions(ctx context.Context, userID string, filter workout.SessionFilter) (*workout.SessionPage, error)
+	ReplaceWorkoutSession(ctx context.Context, session *workout.WorkoutSession) (*workout.WorkoutSession, error)
+	PatchWorkoutSession(ctx context.Context, id string, patch workout.WorkoutSessionPatch) (*workout.WorkoutSession, error)
+	// FinishWorkoutSession completes a draft session and returns the
+	// records it set.
+	FinishWorkoutSession(ctx context.Context, id string) (*workout.WorkoutSession, []record.PersonalRecord, error)
+	DeleteWorkoutSession(ctx context.Context, id string) error
+	AddWorkoutSet(ctx context.Context, sessionID string, workoutExerciseID string, set *workout.WorkoutSet) (*workout.WorkoutSession, error)
+	UpdateWorkoutSet(ctx context.Context, sessionID string, set *workout.WorkoutSet) (*workout.WorkoutSession, error)
+	DeleteWorkoutSet(ctx context.Context, sessionID string, setID string) (*workout.WorkoutSession, error)
 }
@@ -0,0 +1,139 @@
+package persistence
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+
+	"github.com/lib/pq"
+)
+
+// fakeDB is a database/sql driver that understands just enough of the
+// repositories' INSERTs to check transaction boundaries. Rows written in a
+// transaction become visible only on commit, and inserts referencing an
+// unknown exercise fail like the exercise_id foreign keys do.
+type fakeDB struct {
+	mu        sync.Mutex
+	exercises map[string]bool
+	rows      map[string]int
+	open      int
+	// autocommit lists statements run outside any transaction.
+	autocommit []string
+}
+
+func newFakeDB(exerciseIDs ...string) *fakeDB {
+	db := &fakeDB{exercises: map[string]bool{}, rows: map[string]int{}}
+	for _, id := range exerciseIDs {
+		db.exercises[id] = true
+	}
+	return db
+}
+
+func (db *fakeDB) count(table string) int {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return db.rows[table]
+}
+
+func (db *fakeDB) openTx() int {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return db.open
+}
+
+func (db *fakeDB) Connect(context.Context) (driver.Conn, error) { return &fakeConn{db: db}, nil }
+func (db *fakeDB) Driver() driver.Driver                        { return fakeDriver{db} }
+
+type fakeDriver struct{ db *fakeDB }
+
+func (d fakeDriver) Open(string) (driver.Conn, error) { return &fakeConn{db: d.db}, nil }
+
+type fakeConn struct {
+	db *fakeDB
+	tx *fakeTx
+}
+
+var insertTable = regexp.MustCompile(`(?is)^\s*INSERT\s+INTO\s+(\w+)`)
+
+// exerciseColumn is the position of exercise_id in the tables that
+// reference exercises.
+var exerciseColumn = map[string]int{
+	"workout_exercises":   2,
+	"split_day_exercises": 2,
+}
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	m := insertTable.FindStringSubmatch(query)
+	if m == nil {
+		return nil, fmt.Errorf("fakedb: unsupported statement %q", query)
+	}
+	table := m[1]
+
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	if col, ok := exerciseColumn[table]; ok && col < len(args) {
+		if id, _ := args[col].Value.(string); id != "" && !c.db.exercises[id] {
+			return nil, &pq.Error{Code: "23503", Message: fmt.Sprintf("insert or update on table %q violates foreign key constraint", table)}
+		}
+	}
+	if c.tx == nil {
+		c.db.autocommit = append(c.db.autocommit, query)
+		c.db.rows[table]++
+	} else {
+		c.tx.pending = append(c.tx.pending, table)
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, fmt.Errorf("fakedb: prepared statements are not supported: %q", query)
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *fakeConn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	if c.tx != nil {
+		return nil, errors.New("fakedb: transaction already open")
+	}
+	c.db.mu.Lock()
+	c.db.open++
+	c.db.mu.Unlock()
+	c.tx = &fakeTx{conn: c}
+	return c.tx, nil
+}
+
+type fakeTx struct {
+	conn    *fakeConn
+	pending []string
+}
+
+func (tx *fakeTx) Commit() error {
+	db := tx.conn.db
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	for _, table := range tx.pending {
+		db.rows[table]++
+	}
+	tx.end()
+	return nil
+}
+
+func (tx *fakeTx) Rollback() error {
+	tx.conn.db.mu.Lock()
+	defer tx.conn.db.mu.Unlock()
+	tx.end()
+	return nil
+}
+
+// end closes the transaction; the caller holds db.mu.
+func (tx *fakeTx) end() {
+	tx.conn.db.open--
+	tx.conn.tx = nil
+}
@@ -30,3 +30,31 @@ func (r *registry) Split() repository.SplitRepository {
 func (r *registry) Exercise() repository.ExerciseRepository {
 	return postgresRepo.NewExerciseRepository(r.tx)
 }
+
+func (r *registry) PersonalRecord() repository.PersonalRecordRepository {
+	return postgresRepo.NewPersonalRecordRepository(r.tx)
+}
+
+func (r *registry) Planner() repository.PlannerRepository {
+	return postgresRepo.NewPlannerRepository(r.tx)
+}
+
+func (r *registry) AgentAction() repository.AgentActionRepository {
+	return postgresRepo.NewAgentActionRepository(r.tx)
+}
+
+func (r *registry) WorkoutPlan() repository.WorkoutPlanRepository {
+	return postgresRepo.NewWorkoutPlanRepository(r.tx)
+}
+
+func (r *registry) AuthSession() repository.AuthSessionRepository {
+	return postgresRepo.NewAuthSessionRepository(r.tx)
+}
+
+func (r *registry) AccountToken() repository.AccountTokenRepository {
+	return postgresRepo.NewAccountTokenRepository(r.tx)
+}
+
+func (r *registry) UserIdentity() repository.UserIdentityRepository {
+	return postgresRepo.NewUserIdentityRepository(r.tx)
+}
@@ -20,10 +20,18 @@ func (u *unitOfWork) Do(ctx context.Context, fn func(r repository.Registry) erro
 		return err
 	}
 
+	// Never leave a transaction open if fn panics.
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
 	registry := NewRegistry(tx)
 
 	if err := fn(registry); err != nil {
-		tx.Rollback()
+		_ = tx.Rollback()
 		return err
 	}
 
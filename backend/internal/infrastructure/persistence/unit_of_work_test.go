@@ -0,0 +1,209 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/split"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/workout"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+	"S.P.A.R.T.A/backend/internal/domain/service/records"
+	domainuc "S.P.A.R.T.A/backend/internal/domain/usecase"
+	postgresRepo "S.P.A.R.T.A/backend/internal/repository/postgres"
+	"S.P.A.R.T.A/backend/internal/usecase"
+)
+
+func newUsecases(db *sql.DB) (domainuc.WorkoutUsecase, domainuc.SplitUsecase) {
+	uow := NewUnitOfWork(db)
+	return usecase.NewWorkoutUsecase(postgresRepo.NewWorkoutRepository(db), uow, records.NewEngine("")),
+		usecase.NewSplitUsecase(postgresRepo.NewSplitRepository(db), uow)
+}
+
+// draftSession has two exercises of two sets each; the second exercise is
+// secondExercise. It is a draft so creating it only inserts.
+func draftSession(userID, firstExercise, secondExercise string) *workout.WorkoutSession {
+	s := &workout.WorkoutSession{
+		ID:          uuid.NewString(),
+		UserID:      userID,
+		Status:      workout.SessionStatusDraft,
+		SessionDate: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+		CreatedAt:   time.Now(),
+	}
+	for _, exerciseID := range []string{firstExercise, secondExercise} {
+		ex := workout.WorkoutExercise{ID: uuid.NewString(), ExerciseID: exerciseID}
+		for i := 1; i <= 2; i++ {
+			ex.Sets = append(ex.Sets, workout.WorkoutSet{ID: uuid.NewString(), SetOrder: i, Reps: 5, Weight: 100, CreatedAt: time.Now()})
+		}
+		s.Exercises = append(s.Exercises, ex)
+	}
+	return s
+}
+
+// splitTemplate has two days of two exercises each; the second exercise of
+// the second day is lastExercise.
+func splitTemplate(userID, exercise, lastExercise string) *split.SplitTemplate {
+	tpl := &split.SplitTemplate{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		Name:      "Upper / Lower",
+		CreatedBy: "user",
+		CreatedAt: time.Now(),
+	}
+	for order := 1; order <= 2; order++ {
+		day := split.SplitDay{ID: uuid.NewString(), DayOrder: order, Name: "Day"}
+		day.Exercises = []split.SplitExercise{{ExerciseID: exercise, TargetSets: 3, TargetReps: 8}}
+		second := exercise
+		if order == 2 {
+			second = lastExercise
+		}
+		day.Exercises = append(day.Exercises, split.SplitExercise{ExerciseID: second, TargetSets: 3, TargetReps: 8})
+		tpl.Days = append(tpl.Days, day)
+	}
+	return tpl
+}
+
+var (
+	workoutTables = []string{"workout_sessions", "workout_exercises", "workout_sets"}
+	splitTables   = []string{"split_templates", "split_days", "split_day_exercises"}
+)
+
+func TestAggregateCreatesAreAllOrNothing(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		tables  []string
+		create  func(w domainuc.WorkoutUsecase, s domainuc.SplitUsecase, lastExercise string) error
+		written []int
+	}{
+		{
+			name:   "workout session",
+			tables: workoutTables,
+			create: func(w domainuc.WorkoutUsecase, _ domainuc.SplitUsecase, lastExercise string) error {
+				_, err := w.CreateWorkoutSession(ctx, draftSession("u1", "squat", lastExercise))
+				return err
+			},
+			written: []int{1, 2, 4},
+		},
+		{
+			name:   "split template",
+			tables: splitTables,
+			create: func(_ domainuc.WorkoutUsecase, s domainuc.SplitUsecase, lastExercise string) error {
+				return s.CreateTemplate(ctx, splitTemplate("u1", "squat", lastExercise))
+			},
+			written: []int{1, 2, 4},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name+" is stored whole", func(t *testing.T) {
+			fake := newFakeDB("squat", "bench")
+			db := sql.OpenDB(fake)
+			defer db.Close()
+			w, s := newUsecases(db)
+
+			if err := tc.create(w, s, "bench"); err != nil {
+				t.Fatalf("create: %v", err)
+			}
+			for i, table := range tc.tables {
+				if got := fake.count(table); got != tc.written[i] {
+					t.Errorf("%s has %d rows, want %d", table, got, tc.written[i])
+				}
+			}
+			if len(fake.autocommit) != 0 {
+				t.Errorf("statements ran outside the transaction: %q", fake.autocommit)
+			}
+		})
+
+		t.Run(tc.name+" with an unknown exercise leaves no rows", func(t *testing.T) {
+			fake := newFakeDB("squat", "bench")
+			db := sql.OpenDB(fake)
+			defer db.Close()
+			w, s := newUsecases(db)
+
+			err := tc.create(w, s, "no-such-exercise")
+			if !errors.Is(err, domainerr.ErrInternal) {
+				t.Fatalf("create error = %v, want ErrInternal", err)
+			}
+			for _, table := range tc.tables {
+				if got := fake.count(table); got != 0 {
+					t.Errorf("%s has %d rows after the failed create, want 0", table, got)
+				}
+			}
+			if len(fake.autocommit) != 0 {
+				t.Errorf("statements ran outside the transaction: %q", fake.autocommit)
+			}
+			if open := fake.openTx(); open != 0 {
+				t.Errorf("%d transactions left open", open)
+			}
+		})
+	}
+}
+
+// TestAggregateCreatesRollBackInPostgres runs the failing creates against a
+// migrated database when TEST_DATABASE_URL is set.
+func TestAggregateCreatesRollBackInPostgres(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	ctx := context.Background()
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	userID, exerciseID := uuid.NewString(), uuid.NewString()
+	if _, err := db.ExecContext(ctx, `INSERT INTO users(id,name,email,created_at,updated_at) VALUES ($1,'uow test',$2,NOW(),NOW())`,
+		userID, userID+"@example.test"); err != nil {
+		t.Fatalf("insert user: %v", err)
+	}
+	if _, err := db.ExecContext(ctx, `INSERT INTO exercises(id,name,created_at) VALUES ($1,'uow test squat',NOW())`, exerciseID); err != nil {
+		t.Fatalf("insert exercise: %v", err)
+	}
+	t.Cleanup(func() {
+		db.ExecContext(ctx, `DELETE FROM workout_sessions WHERE user_id=$1`, userID)
+		db.ExecContext(ctx, `DELETE FROM split_templates WHERE user_id=$1`, userID)
+		db.ExecContext(ctx, `DELETE FROM exercises WHERE id=$1`, exerciseID)
+		db.ExecContext(ctx, `DELETE FROM users WHERE id=$1`, userID)
+	})
+
+	w, s := newUsecases(db)
+	missing := uuid.NewString()
+
+	session := draftSession(userID, exerciseID, missing)
+	if _, err := w.CreateWorkoutSession(ctx, session); err == nil {
+		t.Fatal("CreateWorkoutSession with an unknown exercise succeeded")
+	}
+	tpl := splitTemplate(userID, exerciseID, missing)
+	if err := s.CreateTemplate(ctx, tpl); err == nil {
+		t.Fatal("CreateTemplate with an unknown exercise succeeded")
+	}
+
+	for _, check := range []struct {
+		query string
+		arg   string
+	}{
+		{`SELECT COUNT(*) FROM workout_sessions WHERE id=$1`, session.ID},
+		{`SELECT COUNT(*) FROM workout_exercises WHERE workout_session_id=$1`, session.ID},
+		{`SELECT COUNT(*) FROM workout_sets WHERE id=$1`, session.Exercises[0].Sets[0].ID},
+		{`SELECT COUNT(*) FROM split_templates WHERE id=$1`, tpl.ID},
+		{`SELECT COUNT(*) FROM split_days WHERE split_template_id=$1`, tpl.ID},
+		{`SELECT COUNT(*) FROM split_day_exercises WHERE split_day_id=$1`, tpl.Days[0].ID},
+	} {
+		var n int
+		if err := db.QueryRowContext(ctx, check.query, check.arg).Scan(&n); err != nil {
+			t.Fatalf("%s: %v", check.query, err)
+		}
+		if n != 0 {
+			t.Errorf("%s = %d after the failed create, want 0", check.query, n)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	domainrepo "S.P.A.R.T.A/backend/internal/domain/repository"
+)
+
+type loginAttempts struct {
+	failures    int
+	expiresAt   time.Time
+	lockedUntil time.Time
+}
+
+type loginAttemptRepository struct {
+	mu      sync.Mutex
+	entries map[string]*loginAttempts
+	calls   int
+	now     func() time.Time
+}
+
+func NewLoginAttemptRepository() domainrepo.LoginAttemptRepository {
+	return &loginAttemptRepository{entries: make(map[string]*loginAttempts), now: time.Now}
+}
+
+func (r *loginAttemptRepository) LockedFor(_ context.Context, key string) (time.Duration, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e, ok := r.entries[key]
+	if !ok {
+		return 0, nil
+	}
+	if d := e.lockedUntil.Sub(r.now()); d > 0 {
+		return d, nil
+	}
+	return 0, nil
+}
+
+func (r *loginAttemptRepository) RecordFailure(_ context.Context, key string, window time.Duration) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	r.calls++
+	if r.calls%sweepEvery == 0 {
+		for k, e := range r.entries {
+			if now.After(e.expiresAt) && now.After(e.lockedUntil) {
+				delete(r.entries, k)
+			}
+		}
+	}
+
+	e, ok := r.entries[key]
+	if !ok || !now.Before(e.expiresAt) {
+		e = &loginAttempts{lockedUntil: lockedUntil(e)}
+		r.entries[key] = e
+	}
+	e.failures++
+	e.expiresAt = now.Add(window)
+	return e.failures, nil
+}
+
+func (r *loginAttemptRepository) Lock(_ context.Context, key string, d time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e, ok := r.entries[key]
+	if !ok {
+		e = &loginAttempts{}
+		r.entries[key] = e
+	}
+	e.lockedUntil = r.now().Add(d)
+	if e.expiresAt.Before(e.lockedUntil) {
+		e.expiresAt = e.lockedUntil
+	}
+	return nil
+}
+
+func (r *loginAttemptRepository) Reset(_ context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.entries, key)
+	return nil
+}
+
+func lockedUntil(e *loginAttempts) time.Time {
+	if e == nil {
+		return time.Time{}
+	}
+	return e.lockedUntil
+}
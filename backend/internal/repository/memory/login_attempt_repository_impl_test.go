@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLoginAttemptsAreForgottenAfterTheWindow(t *testing.T) {
+	clock := newClock()
+	r := &loginAttemptRepository{entries: map[string]*loginAttempts{}, now: clock.now}
+	ctx := context.Background()
+
+	for want := 1; want <= 3; want++ {
+		got, err := r.RecordFailure(ctx, "k", time.Hour)
+		if err != nil || got != want {
+			t.Fatalf("RecordFailure = %d, %v, want %d", got, err, want)
+		}
+		clock.advance(30 * time.Minute)
+	}
+
+	// Each failure extends the window; an hour without one resets it.
+	clock.advance(30 * time.Minute)
+	if got, _ := r.RecordFailure(ctx, "k", time.Hour); got != 1 {
+		t.Errorf("failures after a quiet window = %d, want 1", got)
+	}
+}
+
+func TestLoginLockExpires(t *testing.T) {
+	clock := newClock()
+	r := &loginAttemptRepository{entries: map[string]*loginAttempts{}, now: clock.now}
+	ctx := context.Background()
+
+	if _, err := r.RecordFailure(ctx, "k", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Lock(ctx, "k", 10*time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	clock.advance(4 * time.Minute)
+	if d, _ := r.LockedFor(ctx, "k"); d != 6*time.Minute {
+		t.Errorf("LockedFor = %v, want 6m", d)
+	}
+	// The lock outlives the failure window, and so does the count.
+	if got, _ := r.RecordFailure(ctx, "k", time.Minute); got != 2 {
+		t.Errorf("failures while locked = %d, want 2", got)
+	}
+
+	clock.advance(7 * time.Minute)
+	if d, _ := r.LockedFor(ctx, "k"); d != 0 {
+		t.Errorf("LockedFor after the lock = %v, want 0", d)
+	}
+	if d, _ := r.LockedFor(ctx, "other"); d != 0 {
+		t.Errorf("LockedFor of an unknown key = %v, want 0", d)
+	}
+}
+
+func TestLoginAttemptsReset(t *testing.T) {
+	clock := newClock()
+	r := &loginAttemptRepository{entries: map[string]*loginAttempts{}, now: clock.now}
+	ctx := context.Background()
+
+	_, _ = r.RecordFailure(ctx, "k", time.Hour)
+	_ = r.Lock(ctx, "k", time.Minute)
+	if err := r.Reset(ctx, "k"); err != nil {
+		t.Fatal(err)
+	}
+	if d, _ := r.LockedFor(ctx, "k"); d != 0 {
+		t.Errorf("LockedFor after Reset = %v, want 0", d)
+	}
+	if got, _ := r.RecordFailure(ctx, "k", time.Hour); got != 1 {
+		t.Errorf("failures after Reset = %d, want 1", got)
+	}
+}
@@ -0,0 +1,61 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/auth"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+	domainrepo "S.P.A.R.T.A/backend/internal/domain/repository"
+)
+
+type pendingOAuthState struct {
+	state     auth.OAuthState
+	expiresAt time.Time
+}
+
+type oauthStateRepository struct {
+	mu      sync.Mutex
+	pending map[string]pendingOAuthState
+	calls   int
+	now     func() time.Time
+}
+
+func NewOAuthStateRepository() domainrepo.OAuthStateRepository {
+	return &oauthStateRepository{pending: make(map[string]pendingOAuthState), now: time.Now}
+}
+
+func (r *oauthStateRepository) Save(_ context.Context, state *auth.OAuthState, ttl time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	r.calls++
+	if r.calls%sweepEvery == 0 {
+		for k, p := range r.pending {
+			if !now.Before(p.expiresAt) {
+				delete(r.pending, k)
+			}
+		}
+	}
+
+	r.pending[state.State] = pendingOAuthState{state: *state, expiresAt: now.Add(ttl)}
+	return nil
+}
+
+func (r *oauthStateRepository) Take(_ context.Context, state string) (*auth.OAuthState, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p, ok := r.pending[state]
+	if !ok {
+		return nil, domainerr.ErrNotFound
+	}
+	delete(r.pending, state)
+	if !r.now().Before(p.expiresAt) {
+		return nil, domainerr.ErrNotFound
+	}
+	out := p.state
+	return &out, nil
+}
@@ -0,0 +1,74 @@
+// Package memory holds in-process implementations of repositories that
+// normally live in Redis. They suit tests and single-instance setups; state
+// is lost on restart and not shared between instances.
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/ratelimit"
+	domainrepo "S.P.A.R.T.A/backend/internal/domain/repository"
+)
+
+// sweepEvery is how many calls pass between drops of idle keys.
+const sweepEvery = 1024
+
+type rateLimitRepository struct {
+	mu    sync.Mutex
+	hits  map[string][]time.Time
+	calls int
+	now   func() time.Time
+}
+
+func NewRateLimitRepository() domainrepo.RateLimitRepository {
+	return &rateLimitRepository{hits: make(map[string][]time.Time), now: time.Now}
+}
+
+func (r *rateLimitRepository) Allow(_ context.Context, key string, policy ratelimit.Policy) (ratelimit.Decision, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	r.calls++
+	if r.calls%sweepEvery == 0 {
+		r.sweep(now)
+	}
+
+	k := policy.Name + ":" + key
+	hits := dropBefore(r.hits[k], now.Add(-policy.Window))
+
+	d := ratelimit.Decision{Limit: policy.Limit}
+	if len(hits) < policy.Limit {
+		hits = append(hits, now)
+		d.Allowed = true
+	} else {
+		d.RetryAfter = hits[0].Add(policy.Window).Sub(now)
+	}
+	r.hits[k] = hits
+
+	d.Remaining = max(policy.Limit-len(hits), 0)
+	if len(hits) > 0 {
+		d.Reset = hits[len(hits)-1].Add(policy.Window).Sub(now)
+	}
+	return d, nil
+}
+
+// sweep drops keys whose newest hit is older than any sane window.
+func (r *rateLimitRepository) sweep(now time.Time) {
+	for k, hits := range r.hits {
+		if len(hits) == 0 || now.Sub(hits[len(hits)-1]) > 24*time.Hour {
+			delete(r.hits, k)
+		}
+	}
+}
+
+// dropBefore removes hits at or before cutoff; hits are in time order.
+func dropBefore(hits []time.Time, cutoff time.Time) []time.Time {
+	i := 0
+	for i < len(hits) && !hits[i].After(cutoff) {
+		i++
+	}
+	return hits[i:]
+}
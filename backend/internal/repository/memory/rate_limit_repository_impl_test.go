@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/ratelimit"
+)
+
+// fakeClock is a settable time source for the now fields.
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newClock() *fakeClock {
+	return &fakeClock{t: time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)}
+}
+
+func TestRateLimitSlidingWindow(t *testing.T) {
+	clock := newClock()
+	r := &rateLimitRepository{hits: map[string][]time.Time{}, now: clock.now}
+	policy := ratelimit.Policy{Name: "login", Limit: 3, Window: time.Minute}
+	ctx := context.Background()
+
+	allow := func() ratelimit.Decision {
+		t.Helper()
+		d, err := r.Allow(ctx, "ip:1.2.3.4", policy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+
+	// Hits at 0s, 20s and 40s fill the window.
+	for i, remaining := range []int{2, 1, 0} {
+		if d := allow(); !d.Allowed || d.Remaining != remaining || d.Limit != 3 {
+			t.Fatalf("hit %d = %+v, want allowed with %d remaining", i+1, d, remaining)
+		}
+		clock.advance(20 * time.Second)
+	}
+
+	// At 60s the first hit is exactly a window old and drops out.
+	if d := allow(); !d.Allowed || d.Remaining != 0 {
+		t.Fatalf("hit once the first left the window = %+v, want allowed", d)
+	}
+
+	// At 70s the window holds 20s, 40s and 60s: refused until 20s expires.
+	clock.advance(10 * time.Second)
+	d := allow()
+	if d.Allowed || d.RetryAfter != 10*time.Second || d.Reset != 50*time.Second || d.Remaining != 0 {
+		t.Fatalf("hit over the limit = %+v, want refused, retry in 10s, reset in 50s", d)
+	}
+
+	// A refused request is not counted, so the next slot opens on time.
+	clock.advance(10 * time.Second)
+	if d := allow(); !d.Allowed {
+		t.Fatalf("hit after Retry-After = %+v, want allowed", d)
+	}
+}
+
+func TestRateLimitPoliciesAndKeysDoNotShareCounters(t *testing.T) {
+	clock := newClock()
+	r := &rateLimitRepository{hits: map[string][]time.Time{}, now: clock.now}
+	one := ratelimit.Policy{Name: "one", Limit: 1, Window: time.Minute}
+	ctx := context.Background()
+
+	if d, _ := r.Allow(ctx, "ip:a", one); !d.Allowed {
+		t.Fatal("first hit refused")
+	}
+	if d, _ := r.Allow(ctx, "ip:a", one); d.Allowed {
+		t.Fatal("second hit on a limit of one allowed")
+	}
+	if d, _ := r.Allow(ctx, "ip:b", one); !d.Allowed {
+		t.Error("another key shares the counter")
+	}
+	if d, _ := r.Allow(ctx, "ip:a", ratelimit.Policy{Name: "two", Limit: 1, Window: time.Minute}); !d.Allowed {
+		t.Error("another policy shares the counter")
+	}
+}
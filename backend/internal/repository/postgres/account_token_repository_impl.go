@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/auth"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+	domainrepo "S.P.A.R.T.A/backend/internal/domain/repository"
+)
+
+type accountTokenRepository struct {
+	db DBTX
+}
+
+func NewAccountTokenRepository(db DBTX) domainrepo.AccountTokenRepository {
+	return &accountTokenRepository{db: db}
+}
+
+func (r *accountTokenRepository) Create(ctx context.Context, t *auth.AccountToken) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO account_tokens(id, user_id, purpose, token_hash, expires_at, used_at, created_at)
+		 VALUES ($1,$2,$3,$4,$5,$6,$7)`,
+		t.ID, t.UserID, t.Purpose, t.TokenHash, t.ExpiresAt, t.UsedAt, t.CreatedAt,
+	)
+	if err != nil {
+		return domainerr.ErrInternal
+	}
+	return nil
+}
+
+func (r *accountTokenRepository) Consume(ctx context.Context, purpose, tokenHash string, at time.Time) (*auth.AccountToken, error) {
+	row := r.db.QueryRowContext(ctx,
+		`UPDATE account_tokens
+		 SET used_at=$3
+		 WHERE token_hash=$1 AND purpose=$2 AND used_at IS NULL AND expires_at > $3
+		 RETURNING id, user_id, purpose, token_hash, expires_at, used_at, created_at`,
+		tokenHash, purpose, at,
+	)
+
+	var out auth.AccountToken
+	var usedAt sql.NullTime
+	if err := row.Scan(&out.ID, &out.UserID, &out.Purpose, &out.TokenHash, &out.ExpiresAt, &usedAt, &out.CreatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainerr.ErrInvalidInput
+		}
+		return nil, domainerr.ErrInternal
+	}
+	if usedAt.Valid {
+		t := usedAt.Time
+		out.UsedAt = &t
+	}
+	return &out, nil
+}
+
+func (r *accountTokenRepository) InvalidateUserTokens(ctx context.Context, userID, purpose string, at time.Time) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE account_tokens SET used_at=$3 WHERE user_id=$1 AND purpose=$2 AND used_at IS NULL`,
+		userID, purpose, at,
+	)
+	if err != nil {
+		return domainerr.ErrInternal
+	}
+	return nil
+}
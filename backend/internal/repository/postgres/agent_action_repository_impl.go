@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/agent"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+	domainrepo "S.P.A.R.T.A/backend/internal/domain/repository"
+)
+
+type agentActionRepository struct {
+	db DBTX
+}
+
+func NewAgentActionRepository(db DBTX) domainrepo.AgentActionRepository {
+	return &agentActionRepository{db: db}
+}
+
+func (r *agentActionRepository) Create(ctx context.Context, action *agent.Action) error {
+	if action == nil || action.ID == "" || action.UserID == "" {
+		return domainerr.ErrInvalidInput
+	}
+
+	changes := action.Changes
+	if changes == nil {
+		changes = []agent.Change{}
+	}
+	payload, err := json.Marshal(changes)
+	if err != nil {
+		return domainerr.ErrInternal
+	}
+
+	_, err = r.db.ExecContext(ctx,
+		`INSERT INTO agent_actions(id, user_id, tool, arguments, summary, changes, base_version, status, created_at, expires_at)
+		 VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)`,
+		action.ID, action.UserID, action.Tool, []byte(action.Arguments), action.Summary, payload,
+		action.BaseVersion, string(action.Status), action.CreatedAt, action.ExpiresAt,
+	)
+	if err != nil {
+		return domainerr.ErrInternal
+	}
+	return nil
+}
+
+func (r *agentActionRepository) GetByID(ctx context.Context, id string) (*agent.Action, error) {
+	row := r.db.QueryRowContext(ctx,
+		`SELECT id, user_id, tool, arguments, summary, changes, base_version, status, created_at, expires_at, resolved_at
+		 FROM agent_actions
+		 WHERE id=$1`,
+		id,
+	)
+
+	var (
+		a          agent.Action
+		args       []byte
+		changes    []byte
+		status     string
+		resolvedAt sql.NullTime
+	)
+	if err := row.Scan(&a.ID, &a.UserID, &a.Tool, &args, &a.Summary, &changes, &a.BaseVersion, &status, &a.CreatedAt, &a.ExpiresAt, &resolvedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainerr.ErrNotFound
+		}
+		return nil, domainerr.ErrInternal
+	}
+	if err := json.Unmarshal(changes, &a.Changes); err != nil {
+		return nil, domainerr.ErrInternal
+	}
+	a.Arguments = json.RawMessage(args)
+	a.Status = agent.Status(status)
+	if resolvedAt.Valid {
+		t := resolvedAt.Time
+		a.ResolvedAt = &t
+	}
+	return &a, nil
+}
+
+func (r *agentActionRepository) GetOwnerID(ctx context.Context, id string) (string, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT user_id FROM agent_actions WHERE id=$1`, id)
+
+	var ownerID string
+	if err := row.Scan(&ownerID); err != nil {
+		if err == sql.ErrNoRows {
+			return "", domainerr.ErrNotFound
+		}
+		return "", domainerr.ErrInternal
+	}
+	return ownerID, nil
+}
+
+func (r *agentActionRepository) Resolve(ctx context.Context, id string, status agent.Status, at time.Time) error {
+	res, err := r.db.ExecContext(ctx,
+		`UPDATE agent_actions SET status=$2, resolved_at=$3 WHERE id=$1 AND status=$4`,
+		id, string(status), at, string(agent.StatusPending),
+	)
+	if err != nil {
+		return domainerr.ErrInternal
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return domainerr.ErrConflict
+	}
+	return nil
+}
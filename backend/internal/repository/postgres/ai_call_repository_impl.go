@@ -0,0 +1,240 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/aicall"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+	domainrepo "S.P.A.R.T.A/backend/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+type aiCallRepository struct {
+	db DBTX
+}
+
+func NewAICallRepository(db DBTX) domainrepo.AICallRepository {
+	return &aiCallRepository{db: db}
+}
+
+func (r *aiCallRepository) Create(ctx context.Context, call *aicall.Call) error {
+	if call.ID == "" {
+		call.ID = uuid.NewString()
+	}
+	if call.CreatedAt.IsZero() {
+		call.CreatedAt = time.Now().UTC()
+	}
+
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO ai_calls(
+			id, user_id, operation, attempt, provider, model, prompt_hash, prompt_version,
+			response, outcome, error, latency_ms, prompt_tokens, completion_tokens, total_tokens, created_at
+		 ) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16)`,
+		call.ID, call.UserID, call.Operation, call.Attempt, nullString(call.Provider), nullString(call.Model),
+		call.PromptHash, nullString(call.PromptVersion), nullString(call.Response), string(call.Outcome), nullString(call.Error),
+		call.LatencyMs, call.PromptTokens, call.CompletionTokens, call.TotalTokens, call.CreatedAt,
+	)
+	if err != nil {
+		return domainerr.ErrInternal
+	}
+	return nil
+}
+
+// aiCallConditions turns a filter (minus the cursor) into WHERE clauses.
+func aiCallConditions(filter aicall.Filter, args *[]any) []string {
+	arg := func(v any) string {
+		*args = append(*args, v)
+		return "$" + strconv.Itoa(len(*args))
+	}
+
+	conds := []string{"TRUE"}
+	if filter.UserID != "" {
+		conds = append(conds, "user_id = "+arg(filter.UserID))
+	}
+	if filter.Operation != "" {
+		conds = append(conds, "operation = "+arg(filter.Operation))
+	}
+	if filter.Outcome != "" {
+		conds = append(conds, "outcome = "+arg(string(filter.Outcome)))
+	}
+	if filter.From != nil {
+		conds = append(conds, "created_at >= "+arg(*filter.From))
+	}
+	if filter.To != nil {
+		conds = append(conds, "created_at < "+arg(*filter.To))
+	}
+	return conds
+}
+
+func (r *aiCallRepository) List(ctx context.Context, filter aicall.Filter) (*aicall.Page, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = aicall.DefaultPageSize
+	}
+	if limit > aicall.MaxPageSize {
+		limit = aicall.MaxPageSize
+	}
+
+	var args []any
+	conds := aiCallConditions(filter, &args)
+	arg := func(v any) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(len(args))
+	}
+	if filter.Cursor != "" {
+		createdAt, id, err := decodeAICallCursor(filter.Cursor)
+		if err != nil {
+			return nil, domainerr.ErrInvalidInput
+		}
+		conds = append(conds, "(created_at, id) < ("+arg(createdAt)+"::timestamp, "+arg(id)+")")
+	}
+
+	// Fetch one extra row to know whether another page exists.
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, user_id, operation, attempt, provider, model, prompt_hash, prompt_version,
+		        response, outcome, error, latency_ms, prompt_tokens, completion_tokens, total_tokens, created_at
+		 FROM ai_calls
+		 WHERE `+strings.Join(conds, " AND ")+`
+		 ORDER BY created_at DESC, id DESC
+		 LIMIT `+arg(limit+1),
+		args...,
+	)
+	if err != nil {
+		return nil, domainerr.ErrInternal
+	}
+	defer rows.Close()
+
+	items := make([]aicall.Call, 0)
+	for rows.Next() {
+		var (
+			c                                                    aicall.Call
+			userID, provider, model, version, response, errorMsg sql.NullString
+			outcome                                              string
+		)
+		if err := rows.Scan(
+			&c.ID, &userID, &c.Operation, &c.Attempt, &provider, &model, &c.PromptHash, &version,
+			&response, &outcome, &errorMsg, &c.LatencyMs, &c.PromptTokens, &c.CompletionTokens, &c.TotalTokens, &c.CreatedAt,
+		); err != nil {
+			return nil, domainerr.ErrInternal
+		}
+		if userID.Valid {
+			c.UserID = &userID.String
+		}
+		c.Provider = provider.String
+		c.Model = model.String
+		c.PromptVersion = version.String
+		c.Response = response.String
+		c.Outcome = aicall.Outcome(outcome)
+		c.Error = errorMsg.String
+		items = append(items, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, domainerr.ErrInternal
+	}
+
+	page := &aicall.Page{Items: items}
+	if len(items) > limit {
+		page.Items = items[:limit]
+		last := page.Items[limit-1]
+		page.NextCursor = encodeAICallCursor(last.CreatedAt, last.ID)
+	}
+	return page, nil
+}
+
+func (r *aiCallRepository) Stats(ctx context.Context, filter aicall.Filter, groupBy aicall.GroupBy) ([]aicall.Stat, error) {
+	var key, order string
+	switch groupBy {
+	case aicall.GroupByOperation:
+		key, order = "operation", "total_tokens DESC, key"
+	case aicall.GroupByUser:
+		key, order = "COALESCE(user_id::text, '')", "total_tokens DESC, key"
+	case aicall.GroupByModel:
+		key, order = "COALESCE(provider, '') || '/' || COALESCE(model, '')", "total_tokens DESC, key"
+	default:
+		key, order = "to_char(created_at, 'YYYY-MM-DD')", "key"
+	}
+
+	var args []any
+	conds := aiCallConditions(filter, &args)
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+key+` AS key,
+		        COUNT(*),
+		        COUNT(*) FILTER (WHERE outcome = 'invalid_output'),
+		        COUNT(*) FILTER (WHERE outcome = 'error'),
+		        COALESCE(SUM(prompt_tokens), 0),
+		        COALESCE(SUM(completion_tokens), 0),
+		        COALESCE(SUM(total_tokens), 0) AS total_tokens,
+		        COALESCE(AVG(latency_ms), 0)
+		 FROM ai_calls
+		 WHERE `+strings.Join(conds, " AND ")+`
+		 GROUP BY 1
+		 ORDER BY `+order,
+		args...,
+	)
+	if err != nil {
+		return nil, domainerr.ErrInternal
+	}
+	defer rows.Close()
+
+	out := make([]aicall.Stat, 0)
+	for rows.Next() {
+		var s aicall.Stat
+		if err := rows.Scan(&s.Key, &s.Calls, &s.InvalidOutputs, &s.Errors, &s.PromptTokens, &s.CompletionTokens, &s.TotalTokens, &s.AvgLatencyMs); err != nil {
+			return nil, domainerr.ErrInternal
+		}
+		out = append(out, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, domainerr.ErrInternal
+	}
+	return out, nil
+}
+
+func (r *aiCallRepository) UsageSince(ctx context.Context, userID string, since time.Time) (aicall.Usage, error) {
+	query := `SELECT COUNT(*), COALESCE(SUM(total_tokens), 0) FROM ai_calls WHERE created_at >= $1`
+	args := []any{since}
+	if userID != "" {
+		query += ` AND user_id = $2`
+		args = append(args, userID)
+	}
+
+	var u aicall.Usage
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&u.Calls, &u.Tokens); err != nil {
+		return aicall.Usage{}, domainerr.ErrInternal
+	}
+	return u, nil
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func encodeAICallCursor(createdAt time.Time, id string) string {
+	raw := createdAt.UTC().Format(time.RFC3339Nano) + "|" + id
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeAICallCursor(cursor string) (time.Time, string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	createdAtStr, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return time.Time{}, "", domainerr.ErrInvalidInput
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, createdAtStr)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return time.Time{}, "", err
+	}
+	return createdAt, id, nil
+}
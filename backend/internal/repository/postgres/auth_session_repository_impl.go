@@ -0,0 +1,217 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/auth"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+	domainrepo "S.P.A.R.T.A/backend/internal/domain/repository"
+)
+
+type authSessionRepository struct {
+	db DBTX
+}
+
+func NewAuthSessionRepository(db DBTX) domainrepo.AuthSessionRepository {
+	return &authSessionRepository{db: db}
+}
+
+const authSessionColumns = `id, user_id, user_agent, ip, created_at, last_used_at, expires_at, revoked_at`
+
+func (r *authSessionRepository) CreateSession(ctx context.Context, s *auth.Session) error {
+	if s == nil || s.ID == "" || s.UserID == "" {
+		return domainerr.ErrInvalidInput
+	}
+
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO auth_sessions(`+authSessionColumns+`)
+		 VALUES ($1,$2,$3,$4,$5,$6,$7,$8)`,
+		s.ID, s.UserID, s.UserAgent, s.IP, s.CreatedAt, s.LastUsedAt, s.ExpiresAt, s.RevokedAt,
+	)
+	if err != nil {
+		return domainerr.ErrInternal
+	}
+	return nil
+}
+
+func (r *authSessionRepository) GetSession(ctx context.Context, id string) (*auth.Session, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+authSessionColumns+` FROM auth_sessions WHERE id=$1`, id)
+	return scanAuthSession(row)
+}
+
+func (r *authSessionRepository) GetSessionOwnerID(ctx context.Context, id string) (string, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT user_id FROM auth_sessions WHERE id=$1`, id)
+
+	var ownerID string
+	if err := row.Scan(&ownerID); err != nil {
+		if err == sql.ErrNoRows {
+			return "", domainerr.ErrNotFound
+		}
+		return "", domainerr.ErrInternal
+	}
+	return ownerID, nil
+}
+
+func (r *authSessionRepository) ListActiveSessions(ctx context.Context, userID string, now time.Time) ([]auth.Session, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+authSessionColumns+`
+		 FROM auth_sessions
+		 WHERE user_id=$1 AND revoked_at IS NULL AND expires_at > $2
+		 ORDER BY last_used_at DESC, id DESC`,
+		userID, now,
+	)
+	if err != nil {
+		return nil, domainerr.ErrInternal
+	}
+	defer rows.Close()
+
+	var out []auth.Session
+	for rows.Next() {
+		s, err := scanAuthSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, domainerr.ErrInternal
+	}
+	return out, nil
+}
+
+func (r *authSessionRepository) TouchSession(ctx context.Context, id string, at, expiresAt time.Time) error {
+	res, err := r.db.ExecContext(ctx,
+		`UPDATE auth_sessions SET last_used_at=$2, expires_at=$3 WHERE id=$1 AND revoked_at IS NULL`,
+		id, at, expiresAt,
+	)
+	if err != nil {
+		return domainerr.ErrInternal
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return domainerr.ErrNotFound
+	}
+	return nil
+}
+
+func (r *authSessionRepository) RevokeSession(ctx context.Context, id string, at time.Time) error {
+	res, err := r.db.ExecContext(ctx,
+		`UPDATE auth_sessions SET revoked_at=$2 WHERE id=$1 AND revoked_at IS NULL`,
+		id, at,
+	)
+	if err != nil {
+		return domainerr.ErrInternal
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return domainerr.ErrNotFound
+	}
+	return nil
+}
+
+func (r *authSessionRepository) RevokeUserSessions(ctx context.Context, userID string, at time.Time) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`UPDATE auth_sessions SET revoked_at=$2
+		 WHERE user_id=$1 AND revoked_at IS NULL AND expires_at > $2
+		 RETURNING id`,
+		userID, at,
+	)
+	if err != nil {
+		return nil, domainerr.ErrInternal
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, domainerr.ErrInternal
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, domainerr.ErrInternal
+	}
+	return ids, nil
+}
+
+func (r *authSessionRepository) CreateRefreshToken(ctx context.Context, t *auth.RefreshToken) error {
+	if t == nil || t.ID == "" || t.SessionID == "" || t.TokenHash == "" {
+		return domainerr.ErrInvalidInput
+	}
+
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO refresh_tokens(id, session_id, token_hash, created_at, expires_at, used_at)
+		 VALUES ($1,$2,$3,$4,$5,$6)`,
+		t.ID, t.SessionID, t.TokenHash, t.CreatedAt, t.ExpiresAt, t.UsedAt,
+	)
+	if err != nil {
+		return domainerr.ErrInternal
+	}
+	return nil
+}
+
+func (r *authSessionRepository) GetRefreshTokenByHash(ctx context.Context, hash string) (*auth.RefreshToken, error) {
+	row := r.db.QueryRowContext(ctx,
+		`SELECT id, session_id, token_hash, created_at, expires_at, used_at
+		 FROM refresh_tokens
+		 WHERE token_hash=$1`,
+		hash,
+	)
+
+	var (
+		t      auth.RefreshToken
+		usedAt sql.NullTime
+	)
+	if err := row.Scan(&t.ID, &t.SessionID, &t.TokenHash, &t.CreatedAt, &t.ExpiresAt, &usedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainerr.ErrNotFound
+		}
+		return nil, domainerr.ErrInternal
+	}
+	if usedAt.Valid {
+		at := usedAt.Time
+		t.UsedAt = &at
+	}
+	return &t, nil
+}
+
+func (r *authSessionRepository) MarkRefreshTokenUsed(ctx context.Context, id string, at time.Time) error {
+	res, err := r.db.ExecContext(ctx,
+		`UPDATE refresh_tokens SET used_at=$2 WHERE id=$1 AND used_at IS NULL`,
+		id, at,
+	)
+	if err != nil {
+		return domainerr.ErrInternal
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return domainerr.ErrConflict
+	}
+	return nil
+}
+
+// authSessionScanner is satisfied by *sql.Row and *sql.Rows.
+type authSessionScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanAuthSession(row authSessionScanner) (*auth.Session, error) {
+	var (
+		s         auth.Session
+		revokedAt sql.NullTime
+	)
+	if err := row.Scan(&s.ID, &s.UserID, &s.UserAgent, &s.IP, &s.CreatedAt, &s.LastUsedAt, &s.ExpiresAt, &revokedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainerr.ErrNotFound
+		}
+		return nil, domainerr.ErrInternal
+	}
+	if revokedAt.Valid {
+		at := revokedAt.Time
+		s.RevokedAt = &at
+	}
+	return &s, nil
+}
@@ -0,0 +1,198 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/chat"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/coach"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+	domainrepo "S.P.A.R.T.A/backend/internal/domain/repository"
+)
+
+type chatRepository struct {
+	db DBTX
+}
+
+func NewChatRepository(db DBTX) domainrepo.ChatRepository {
+	return &chatRepository{db: db}
+}
+
+func (r *chatRepository) CreateThread(ctx context.Context, thread *chat.Thread) error {
+	if thread == nil || thread.ID == "" {
+		return domainerr.ErrInvalidInput
+	}
+
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO chat_threads(id, user_id, title, created_at, updated_at) VALUES ($1,$2,$3,$4,$5)`,
+		thread.ID, thread.UserID, thread.Title, thread.CreatedAt, thread.UpdatedAt,
+	)
+	if err != nil {
+		return domainerr.ErrInternal
+	}
+	return nil
+}
+
+func (r *chatRepository) GetThread(ctx context.Context, id string) (*chat.Thread, error) {
+	row := r.db.QueryRowContext(ctx,
+		`SELECT id, user_id, title, created_at, updated_at FROM chat_threads WHERE id=$1`,
+		id,
+	)
+
+	var t chat.Thread
+	if err := row.Scan(&t.ID, &t.UserID, &t.Title, &t.CreatedAt, &t.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainerr.ErrNotFound
+		}
+		return nil, domainerr.ErrInternal
+	}
+	return &t, nil
+}
+
+func (r *chatRepository) GetThreadOwnerID(ctx context.Context, id string) (string, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT user_id FROM chat_threads WHERE id=$1`, id)
+
+	var ownerID string
+	if err := row.Scan(&ownerID); err != nil {
+		if err == sql.ErrNoRows {
+			return "", domainerr.ErrNotFound
+		}
+		return "", domainerr.ErrInternal
+	}
+	return ownerID, nil
+}
+
+func (r *chatRepository) ListThreads(ctx context.Context, userID string) ([]chat.Thread, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, user_id, title, created_at, updated_at
+		 FROM chat_threads
+		 WHERE user_id=$1
+		 ORDER BY updated_at DESC, id DESC
+		 LIMIT 50`,
+		userID,
+	)
+	if err != nil {
+		return nil, domainerr.ErrInternal
+	}
+	defer rows.Close()
+
+	items := make([]chat.Thread, 0)
+	for rows.Next() {
+		var t chat.Thread
+		if err := rows.Scan(&t.ID, &t.UserID, &t.Title, &t.CreatedAt, &t.UpdatedAt); err != nil {
+			return nil, domainerr.ErrInternal
+		}
+		items = append(items, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, domainerr.ErrInternal
+	}
+	return items, nil
+}
+
+func (r *chatRepository) DeleteThread(ctx context.Context, id string) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM chat_threads WHERE id=$1`, id)
+	if err != nil {
+		return domainerr.ErrInternal
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return domainerr.ErrNotFound
+	}
+	return nil
+}
+
+func (r *chatRepository) AddExchange(ctx context.Context, question, reply *chat.Message) error {
+	if question == nil || reply == nil || question.ID == "" || reply.ID == "" || question.ThreadID == "" || reply.ThreadID != question.ThreadID {
+		return domainerr.ErrInvalidInput
+	}
+
+	questionCitations, err := citationsJSON(question)
+	if err != nil {
+		return err
+	}
+	replyCitations, err := citationsJSON(reply)
+	if err != nil {
+		return err
+	}
+
+	// One statement so the messages and the thread's updated_at move together.
+	res, err := r.db.ExecContext(ctx,
+		`WITH inserted AS (
+			INSERT INTO chat_messages(id, thread_id, role, content, citations, source, created_at)
+			VALUES ($1,$2,$3,$4,$5,$6,$7), ($8,$2,$9,$10,$11,$12,$13)
+			RETURNING thread_id
+		 )
+		 UPDATE chat_threads SET updated_at=$13 WHERE id IN (SELECT thread_id FROM inserted)`,
+		question.ID, question.ThreadID, string(question.Role), question.Content, questionCitations, nullString(string(question.Source)), question.CreatedAt,
+		reply.ID, string(reply.Role), reply.Content, replyCitations, nullString(string(reply.Source)), reply.CreatedAt,
+	)
+	if err != nil {
+		return domainerr.ErrInternal
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return domainerr.ErrNotFound
+	}
+	return nil
+}
+
+// citationsJSON encodes msg's citations, an empty list when it has none.
+func citationsJSON(msg *chat.Message) ([]byte, error) {
+	citations := msg.Citations
+	if citations == nil {
+		citations = []chat.Citation{}
+	}
+	payload, err := json.Marshal(citations)
+	if err != nil {
+		return nil, domainerr.ErrInternal
+	}
+	return payload, nil
+}
+
+func (r *chatRepository) ListMessages(ctx context.Context, threadID string, limit int) ([]chat.Message, error) {
+	if limit <= 0 {
+		limit = chat.HistoryLimit
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, thread_id, role, content, citations, source, created_at
+		 FROM (
+			SELECT id, thread_id, role, content, citations, source, created_at
+			FROM chat_messages
+			WHERE thread_id=$1
+			ORDER BY created_at DESC, id DESC
+			LIMIT $2
+		 ) recent
+		 ORDER BY created_at ASC, id ASC`,
+		threadID, limit,
+	)
+	if err != nil {
+		return nil, domainerr.ErrInternal
+	}
+	defer rows.Close()
+
+	items := make([]chat.Message, 0)
+	for rows.Next() {
+		var (
+			m         chat.Message
+			role      string
+			citations []byte
+			source    sql.NullString
+		)
+		if err := rows.Scan(&m.ID, &m.ThreadID, &role, &m.Content, &citations, &source, &m.CreatedAt); err != nil {
+			return nil, domainerr.ErrInternal
+		}
+		if err := json.Unmarshal(citations, &m.Citations); err != nil {
+			return nil, domainerr.ErrInternal
+		}
+		m.Role = chat.Role(role)
+		m.Source = coach.Source(source.String)
+		items = append(items, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, domainerr.ErrInternal
+	}
+	return items, nil
+}
@@ -151,6 +151,39 @@ func (r *exerciseRepository) List(ctx context.Context) ([]exercise.Exercise, err
 	return items, nil
 }
 
+// GetByIDs loads exercises without media; unknown ids are skipped.
+func (r *exerciseRepository) GetByIDs(ctx context.Context, ids []string) ([]exercise.Exercise, error) {
+	items := make([]exercise.Exercise, 0, len(ids))
+	if len(ids) == 0 {
+		return items, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id,name,primary_muscle,secondary_muscles,equipment,created_at
+		 FROM exercises
+		 WHERE id = ANY($1)`,
+		pq.Array(ids),
+	)
+	if err != nil {
+		return nil, domainerr.ErrInternal
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var ex exercise.Exercise
+		var secondary pq.StringArray
+		if err := rows.Scan(&ex.ID, &ex.Name, &ex.PrimaryMuscle, &secondary, &ex.Equipment, &ex.CreatedAt); err != nil {
+			return nil, domainerr.ErrInternal
+		}
+		ex.SecondaryMuscles = []string(secondary)
+		items = append(items, ex)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, domainerr.ErrInternal
+	}
+	return items, nil
+}
+
 func (r *exerciseRepository) AddMedia(ctx context.Context, media *exercise.ExerciseMedia) error {
 	_, err := r.db.ExecContext(ctx,
 		`INSERT INTO exercise_media(id,exercise_id,media_type,media_url,thumbnail_url,created_at)
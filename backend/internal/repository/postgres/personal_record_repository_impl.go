@@ -0,0 +1,175 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/record"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+	domainrepo "S.P.A.R.T.A/backend/internal/domain/repository"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type personalRecordRepository struct {
+	db DBTX
+}
+
+func NewPersonalRecordRepository(db DBTX) domainrepo.PersonalRecordRepository {
+	return &personalRecordRepository{db: db}
+}
+
+const personalRecordColumns = `
+	id, user_id, exercise_id, record_type, value, weight, reps, formula,
+	workout_session_id, workout_set_id, achieved_at, created_at, updated_at
+`
+
+func (r *personalRecordRepository) GetByUser(ctx context.Context, userID string) ([]record.PersonalRecord, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+personalRecordColumns+`
+		 FROM personal_records
+		 WHERE user_id = $1
+		 ORDER BY exercise_id, record_type, weight_key`,
+		userID,
+	)
+	if err != nil {
+		return nil, domainerr.ErrInternal
+	}
+	defer rows.Close()
+
+	return scanPersonalRecords(rows)
+}
+
+func (r *personalRecordRepository) GetByUserAndExercises(
+	ctx context.Context,
+	userID string,
+	exerciseIDs []string,
+) ([]record.PersonalRecord, error) {
+	if len(exerciseIDs) == 0 {
+		return nil, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+personalRecordColumns+`
+		 FROM personal_records
+		 WHERE user_id = $1 AND exercise_id = ANY($2)
+		 ORDER BY exercise_id, record_type, weight_key`,
+		userID, pq.Array(exerciseIDs),
+	)
+	if err != nil {
+		return nil, domainerr.ErrInternal
+	}
+	defer rows.Close()
+
+	return scanPersonalRecords(rows)
+}
+
+func (r *personalRecordRepository) Upsert(ctx context.Context, rec *record.PersonalRecord) error {
+	if rec == nil || rec.UserID == "" || rec.ExerciseID == "" || rec.Type == "" {
+		return domainerr.ErrInvalidInput
+	}
+
+	now := time.Now().UTC()
+	if rec.ID == "" {
+		rec.ID = uuid.NewString()
+	}
+	if rec.CreatedAt.IsZero() {
+		rec.CreatedAt = now
+	}
+	rec.UpdatedAt = now
+
+	var formula any
+	if rec.Formula != "" {
+		formula = rec.Formula
+	}
+
+	err := r.db.QueryRowContext(ctx,
+		`INSERT INTO personal_records
+		 (id, user_id, exercise_id, record_type, weight_key, value, weight, reps, formula,
+		  workout_session_id, workout_set_id, achieved_at, created_at, updated_at)
+		 VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14)
+		 ON CONFLICT (user_id, exercise_id, record_type, weight_key) DO UPDATE SET
+		   value = EXCLUDED.value,
+		   weight = EXCLUDED.weight,
+		   reps = EXCLUDED.reps,
+		   formula = EXCLUDED.formula,
+		   workout_session_id = EXCLUDED.workout_session_id,
+		   workout_set_id = EXCLUDED.workout_set_id,
+		   achieved_at = EXCLUDED.achieved_at,
+		   updated_at = EXCLUDED.updated_at
+		 RETURNING id, created_at`,
+		rec.ID, rec.UserID, rec.ExerciseID, string(rec.Type), rec.WeightKey(), rec.Value, rec.Weight, rec.Reps, formula,
+		rec.WorkoutSessionID, rec.WorkoutSetID, rec.AchievedAt, rec.CreatedAt, rec.UpdatedAt,
+	).Scan(&rec.ID, &rec.CreatedAt)
+	if err != nil {
+		return domainerr.ErrInternal
+	}
+	return nil
+}
+
+func (r *personalRecordRepository) DeleteByUser(ctx context.Context, userID string) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM personal_records WHERE user_id = $1`, userID); err != nil {
+		return domainerr.ErrInternal
+	}
+	return nil
+}
+
+func (r *personalRecordRepository) DeleteByUserAndExercises(ctx context.Context, userID string, exerciseIDs []string) error {
+	if len(exerciseIDs) == 0 {
+		return nil
+	}
+	if _, err := r.db.ExecContext(ctx,
+		`DELETE FROM personal_records WHERE user_id = $1 AND exercise_id = ANY($2)`,
+		userID, pq.Array(exerciseIDs),
+	); err != nil {
+		return domainerr.ErrInternal
+	}
+	return nil
+}
+
+func scanPersonalRecords(rows *sql.Rows) ([]record.PersonalRecord, error) {
+	var out []record.PersonalRecord
+	for rows.Next() {
+		var (
+			rec       record.PersonalRecord
+			recType   string
+			formula   sql.NullString
+			sessionID sql.NullString
+			setID     sql.NullString
+		)
+		if err := rows.Scan(
+			&rec.ID,
+			&rec.UserID,
+			&rec.ExerciseID,
+			&recType,
+			&rec.Value,
+			&rec.Weight,
+			&rec.Reps,
+			&formula,
+			&sessionID,
+			&setID,
+			&rec.AchievedAt,
+			&rec.CreatedAt,
+			&rec.UpdatedAt,
+		); err != nil {
+			return nil, domainerr.ErrInternal
+		}
+
+		rec.Type = record.RecordType(recType)
+		rec.Formula = formula.String
+		if sessionID.Valid {
+			v := sessionID.String
+			rec.WorkoutSessionID = &v
+		}
+		if setID.Valid {
+			v := setID.String
+			rec.WorkoutSetID = &v
+		}
+		out = append(out, rec)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, domainerr.ErrInternal
+	}
+	return out, nil
+}
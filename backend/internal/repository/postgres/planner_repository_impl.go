@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/coach"
 	"S.P.A.R.T.A/backend/internal/domain/aggregate/planner"
 	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
 	domainrepo "S.P.A.R.T.A/backend/internal/domain/repository"
@@ -19,15 +20,16 @@ func NewPlannerRepository(db DBTX) domainrepo.PlannerRepository {
 
 func (r *plannerRepository) SaveRecommendation(ctx context.Context, rec *planner.PlannerRecommendation) error {
 	_, err := r.db.ExecContext(ctx,
-		`INSERT INTO planner_recommendations(id,user_id,workout_session_id,recommendation,recommendation_type,created_at)
-		 VALUES ($1,$2,$3,$4,$5,$6)`,
-		rec.ID, rec.UserID, rec.WorkoutSessionID, rec.Recommendation, rec.RecommendationType, rec.CreatedAt)
+		`INSERT INTO planner_recommendations(id,user_id,workout_session_id,recommendation,recommendation_type,source,prompt_variant,created_at)
+		 VALUES ($1,$2,$3,$4,$5,$6,$7,$8)`,
+		rec.ID, rec.UserID, rec.WorkoutSessionID, rec.Recommendation, rec.RecommendationType,
+		sql.NullString{String: string(rec.Source), Valid: rec.Source != ""}, nullString(rec.PromptVariant), rec.CreatedAt)
 	return err
 }
 
 func (r *plannerRepository) GetUserRecommendations(ctx context.Context, userID string) ([]planner.PlannerRecommendation, error) {
 	rows, err := r.db.QueryContext(ctx,
-		`SELECT id,user_id,workout_session_id,recommendation,recommendation_type,created_at
+		`SELECT id,user_id,workout_session_id,recommendation,recommendation_type,source,prompt_variant,created_at
 		 FROM planner_recommendations
 		 WHERE user_id=$1
 		 ORDER BY created_at DESC
@@ -42,13 +44,15 @@ func (r *plannerRepository) GetUserRecommendations(ctx context.Context, userID s
 	items := make([]planner.PlannerRecommendation, 0)
 	for rows.Next() {
 		var rec planner.PlannerRecommendation
-		var workoutSessionID sql.NullString
+		var workoutSessionID, source, promptVariant sql.NullString
 		if err := rows.Scan(
 			&rec.ID,
 			&rec.UserID,
 			&workoutSessionID,
 			&rec.Recommendation,
 			&rec.RecommendationType,
+			&source,
+			&promptVariant,
 			&rec.CreatedAt,
 		); err != nil {
 			return nil, domainerr.ErrInternal
@@ -57,6 +61,8 @@ func (r *plannerRepository) GetUserRecommendations(ctx context.Context, userID s
 			s := workoutSessionID.String
 			rec.WorkoutSessionID = &s
 		}
+		rec.Source = coach.Source(source.String)
+		rec.PromptVariant = promptVariant.String
 		items = append(items, rec)
 	}
 	if err := rows.Err(); err != nil {
@@ -0,0 +1,41 @@
+package postgres
+
+import (
+	"context"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/prompt"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+	domainrepo "S.P.A.R.T.A/backend/internal/domain/repository"
+)
+
+type promptTemplateRepository struct {
+	db DBTX
+}
+
+func NewPromptTemplateRepository(db DBTX) domainrepo.PromptTemplateRepository {
+	return &promptTemplateRepository{db: db}
+}
+
+func (r *promptTemplateRepository) List(ctx context.Context) ([]prompt.Template, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT name, version, body, weight, created_at
+		 FROM prompt_templates
+		 ORDER BY name, version`)
+	if err != nil {
+		return nil, domainerr.ErrInternal
+	}
+	defer rows.Close()
+
+	items := make([]prompt.Template, 0)
+	for rows.Next() {
+		var t prompt.Template
+		if err := rows.Scan(&t.Name, &t.Version, &t.Body, &t.Weight, &t.CreatedAt); err != nil {
+			return nil, domainerr.ErrInternal
+		}
+		items = append(items, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, domainerr.ErrInternal
+	}
+	return items, nil
+}
@@ -8,6 +8,7 @@ import (
 	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
 	domainrepo "S.P.A.R.T.A/backend/internal/domain/repository"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 type splitRepository struct {
@@ -32,14 +33,20 @@ func (r *splitRepository) CreateTemplate(ctx context.Context, tpl *split.SplitTe
 	}
 
 	for _, day := range tpl.Days {
-		_, err := r.db.ExecContext(ctx,
+		// A day ID of another template is left alone and reported.
+		res, err := r.db.ExecContext(ctx,
 			`INSERT INTO split_days(id,split_template_id,day_order,name)
-			 VALUES ($1,$2,$3,$4)`,
+			 VALUES ($1,$2,$3,$4)
+			 ON CONFLICT (id) DO UPDATE SET day_order=EXCLUDED.day_order, name=EXCLUDED.name
+			 WHERE split_days.split_template_id=EXCLUDED.split_template_id`,
 			day.ID, tpl.ID, day.DayOrder, day.Name,
 		)
 		if err != nil {
 			return domainerr.ErrInternal
 		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			return domainerr.ErrInvalidInput
+		}
 
 		for _, ex := range day.Exercises {
 			var exerciseID any
@@ -91,7 +98,13 @@ func (r *splitRepository) UpdateTemplate(ctx context.Context, tpl *split.SplitTe
 		return domainerr.ErrNotFound
 	}
 
-	// Rewrite days/exercises for the template.
+	// Days are updated in place so workout plans and sessions that point at
+	// them keep their split_day_id; only days left out of tpl are deleted.
+	// Exercises are rewritten.
+	dayIDs := make([]string, 0, len(tpl.Days))
+	for _, day := range tpl.Days {
+		dayIDs = append(dayIDs, day.ID)
+	}
 	_, err = r.db.ExecContext(ctx,
 		`DELETE FROM split_day_exercises
 		 WHERE split_day_id IN (SELECT id FROM split_days WHERE split_template_id=$1)`,
@@ -101,8 +114,8 @@ func (r *splitRepository) UpdateTemplate(ctx context.Context, tpl *split.SplitTe
 		return domainerr.ErrInternal
 	}
 	_, err = r.db.ExecContext(ctx,
-		`DELETE FROM split_days WHERE split_template_id=$1`,
-		tpl.ID,
+		`DELETE FROM split_days WHERE split_template_id=$1 AND NOT (id = ANY($2))`,
+		tpl.ID, pq.Array(dayIDs),
 	)
 	if err != nil {
 		return domainerr.ErrInternal
@@ -225,6 +238,19 @@ func (r *splitRepository) GetTemplateByID(ctx context.Context, id string) (*spli
 	return &out, nil
 }
 
+func (r *splitRepository) GetTemplateOwnerID(ctx context.Context, id string) (string, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT user_id FROM split_templates WHERE id=$1`, id)
+
+	var ownerID sql.NullString
+	if err := row.Scan(&ownerID); err != nil {
+		if err == sql.ErrNoRows {
+			return "", domainerr.ErrNotFound
+		}
+		return "", domainerr.ErrInternal
+	}
+	return ownerID.String, nil
+}
+
 func (r *splitRepository) GetUserTemplates(ctx context.Context, userID string) ([]split.SplitTemplate, error) {
 	rows, err := r.db.QueryContext(ctx,
 		`SELECT id,user_id,name,description,created_by,focus_muscle,is_active,created_at
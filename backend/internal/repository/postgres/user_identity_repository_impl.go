@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/auth"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+	domainrepo "S.P.A.R.T.A/backend/internal/domain/repository"
+	"github.com/lib/pq"
+)
+
+type userIdentityRepository struct {
+	db DBTX
+}
+
+func NewUserIdentityRepository(db DBTX) domainrepo.UserIdentityRepository {
+	return &userIdentityRepository{db: db}
+}
+
+func (r *userIdentityRepository) Create(ctx context.Context, i *auth.Identity) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO user_identities(id, user_id, provider, subject, email, created_at, last_login_at)
+		 VALUES ($1,$2,$3,$4,$5,$6,$7)`,
+		i.ID, i.UserID, i.Provider, i.Subject, i.Email, i.CreatedAt, i.LastLoginAt,
+	)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && string(pqErr.Code) == "23505" {
+			return domainerr.ErrConflict
+		}
+		return domainerr.ErrInternal
+	}
+	return nil
+}
+
+func (r *userIdentityRepository) GetByProviderSubject(ctx context.Context, provider, subject string) (*auth.Identity, error) {
+	row := r.db.QueryRowContext(ctx,
+		`SELECT id, user_id, provider, subject, email, created_at, last_login_at
+		 FROM user_identities WHERE provider=$1 AND subject=$2`,
+		provider, subject,
+	)
+
+	var out auth.Identity
+	if err := row.Scan(&out.ID, &out.UserID, &out.Provider, &out.Subject, &out.Email, &out.CreatedAt, &out.LastLoginAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainerr.ErrNotFound
+		}
+		return nil, domainerr.ErrInternal
+	}
+	return &out, nil
+}
+
+func (r *userIdentityRepository) TouchLogin(ctx context.Context, id, email string, at time.Time) error {
+	res, err := r.db.ExecContext(ctx,
+		`UPDATE user_identities SET email=$2, last_login_at=$3 WHERE id=$1`,
+		id, email, at,
+	)
+	if err != nil {
+		return domainerr.ErrInternal
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return domainerr.ErrNotFound
+	}
+	return nil
+}
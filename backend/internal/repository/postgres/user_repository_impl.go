@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"S.P.A.R.T.A/backend/internal/domain/aggregate/user"
 	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
@@ -20,9 +21,9 @@ func NewUserRepository(db DBTX) domainrepo.UserRepository {
 
 func (r *userRepository) Create(ctx context.Context, u *user.User) error {
 	_, err := r.db.ExecContext(ctx,
-		`INSERT INTO users(id,name,email,password_hash,role,created_at,updated_at)
-		 VALUES ($1,$2,$3,$4,$5,$6,$7)`,
-		u.ID, u.Name, u.Email, u.PasswordHash, u.Role, u.CreatedAt, u.UpdatedAt,
+		`INSERT INTO users(id,name,email,password_hash,role,email_verified_at,created_at,updated_at)
+		 VALUES ($1,$2,$3,$4,$5,$6,$7,$8)`,
+		u.ID, u.Name, u.Email, u.PasswordHash, u.Role, u.EmailVerifiedAt, u.CreatedAt, u.UpdatedAt,
 	)
 	if err != nil {
 		if pqErr, ok := err.(*pq.Error); ok {
@@ -38,33 +39,39 @@ func (r *userRepository) Create(ctx context.Context, u *user.User) error {
 
 func (r *userRepository) GetByID(ctx context.Context, id string) (*user.User, error) {
 	row := r.db.QueryRowContext(ctx,
-		`SELECT id,name,email,password_hash,role,created_at,updated_at FROM users WHERE id=$1`,
+		`SELECT `+userColumns+` FROM users WHERE id=$1`,
 		id,
 	)
-
-	var out user.User
-	if err := row.Scan(&out.ID, &out.Name, &out.Email, &out.PasswordHash, &out.Role, &out.CreatedAt, &out.UpdatedAt); err != nil {
-		if err == sql.ErrNoRows {
-			return nil, domainerr.ErrNotFound
-		}
-		return nil, domainerr.ErrInternal
-	}
-	return &out, nil
+	return scanUser(row)
 }
 
 func (r *userRepository) GetByEmail(ctx context.Context, email string) (*user.User, error) {
 	row := r.db.QueryRowContext(ctx,
-		`SELECT id,name,email,password_hash,role,created_at,updated_at FROM users WHERE email=$1`,
+		`SELECT `+userColumns+` FROM users WHERE email=$1`,
 		email,
 	)
+	return scanUser(row)
+}
+
+const userColumns = `id,name,email,password_hash,role,email_verified_at,created_at,updated_at`
+
+type userScanner interface {
+	Scan(dest ...any) error
+}
 
+func scanUser(row userScanner) (*user.User, error) {
 	var out user.User
-	if err := row.Scan(&out.ID, &out.Name, &out.Email, &out.PasswordHash, &out.Role, &out.CreatedAt, &out.UpdatedAt); err != nil {
+	var verifiedAt sql.NullTime
+	if err := row.Scan(&out.ID, &out.Name, &out.Email, &out.PasswordHash, &out.Role, &verifiedAt, &out.CreatedAt, &out.UpdatedAt); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, domainerr.ErrNotFound
 		}
 		return nil, domainerr.ErrInternal
 	}
+	if verifiedAt.Valid {
+		t := verifiedAt.Time
+		out.EmailVerifiedAt = &t
+	}
 	return &out, nil
 }
 
@@ -76,3 +83,123 @@ func (r *userRepository) Count(ctx context.Context) (int, error) {
 	}
 	return n, nil
 }
+
+func (r *userRepository) UpdatePassword(ctx context.Context, id, passwordHash string, at time.Time) error {
+	res, err := r.db.ExecContext(ctx,
+		`UPDATE users SET password_hash=$2, updated_at=$3 WHERE id=$1`,
+		id, passwordHash, at,
+	)
+	if err != nil {
+		return domainerr.ErrInternal
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return domainerr.ErrNotFound
+	}
+	return nil
+}
+
+// MarkEmailVerified keeps the first verification time.
+func (r *userRepository) MarkEmailVerified(ctx context.Context, id string, at time.Time) error {
+	res, err := r.db.ExecContext(ctx,
+		`UPDATE users SET email_verified_at=COALESCE(email_verified_at, $2), updated_at=$2 WHERE id=$1`,
+		id, at,
+	)
+	if err != nil {
+		return domainerr.ErrInternal
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return domainerr.ErrNotFound
+	}
+	return nil
+}
+
+func (r *userRepository) GetPreferences(ctx context.Context, userID string) (*user.Preferences, error) {
+	row := r.db.QueryRowContext(ctx,
+		`SELECT id,load_model,body_weight_kg,coach_persona,custom_persona,language,profanity,
+		        experience_level,equipment,max_session_minutes,injuries,excluded_movements,preferred_days,updated_at
+		 FROM users WHERE id=$1`,
+		userID,
+	)
+
+	var out user.Preferences
+	var bodyWeight sql.NullFloat64
+	var customPersona sql.NullString
+	var maxSessionMinutes sql.NullInt64
+	var injuries sql.NullString
+	var updatedAt sql.NullTime
+	if err := row.Scan(
+		&out.UserID,
+		&out.LoadModel,
+		&bodyWeight,
+		&out.CoachPersona,
+		&customPersona,
+		&out.Language,
+		&out.Profanity,
+		&out.ExperienceLevel,
+		pq.Array(&out.Equipment),
+		&maxSessionMinutes,
+		&injuries,
+		pq.Array(&out.ExcludedMovements),
+		pq.Array(&out.PreferredDays),
+		&updatedAt,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainerr.ErrNotFound
+		}
+		return nil, domainerr.ErrInternal
+	}
+	if bodyWeight.Valid {
+		v := bodyWeight.Float64
+		out.BodyWeightKg = &v
+	}
+	out.CustomPersona = customPersona.String
+	out.MaxSessionMinutes = int(maxSessionMinutes.Int64)
+	out.Injuries = injuries.String
+	out.UpdatedAt = updatedAt.Time
+	return &out, nil
+}
+
+func (r *userRepository) UpdatePreferences(ctx context.Context, prefs *user.Preferences) error {
+	if prefs == nil || prefs.UserID == "" {
+		return domainerr.ErrInvalidInput
+	}
+
+	res, err := r.db.ExecContext(ctx,
+		`UPDATE users
+		 SET load_model=$2, body_weight_kg=$3, coach_persona=$4, custom_persona=$5, language=$6, profanity=$7,
+		     experience_level=$8, equipment=$9, max_session_minutes=$10, injuries=$11, excluded_movements=$12, preferred_days=$13,
+		     updated_at=$14
+		 WHERE id=$1`,
+		prefs.UserID, prefs.LoadModel, prefs.BodyWeightKg,
+		prefs.CoachPersona, nullString(prefs.CustomPersona), prefs.Language, prefs.Profanity,
+		prefs.ExperienceLevel, pq.Array(nonNilStrings(prefs.Equipment)), nullInt(prefs.MaxSessionMinutes), nullString(prefs.Injuries),
+		pq.Array(nonNilStrings(prefs.ExcludedMovements)), pq.Array(nonNilStrings(prefs.PreferredDays)),
+		prefs.UpdatedAt,
+	)
+	if err != nil {
+		return domainerr.ErrInternal
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return domainerr.ErrInternal
+	}
+	if n == 0 {
+		return domainerr.ErrNotFound
+	}
+	return nil
+}
+
+// nullInt stores zero as NULL.
+func nullInt(n int) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(n), Valid: n != 0}
+}
+
+// nonNilStrings keeps pq from writing NULL into NOT NULL array columns.
+func nonNilStrings(items []string) []string {
+	if items == nil {
+		return []string{}
+	}
+	return items
+}
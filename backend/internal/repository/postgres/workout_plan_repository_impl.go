@@ -0,0 +1,187 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/coach"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/workout"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+	domainrepo "S.P.A.R.T.A/backend/internal/domain/repository"
+)
+
+type workoutPlanRepository struct {
+	db DBTX
+}
+
+func NewWorkoutPlanRepository(db DBTX) domainrepo.WorkoutPlanRepository {
+	return &workoutPlanRepository{db: db}
+}
+
+// planExerciseRow is the JSONB shape of a plan exercise.
+type planExerciseRow struct {
+	ExerciseID string  `json:"exercise_id,omitempty"`
+	Name       string  `json:"name"`
+	Sets       int     `json:"sets"`
+	RepRange   string  `json:"rep_range"`
+	RepsMin    int     `json:"reps_min"`
+	RepsMax    int     `json:"reps_max"`
+	Weight     float64 `json:"weight"`
+}
+
+const workoutPlanColumns = `id, user_id, split_day_id, split_day_name, fatigue, exercises, source, prompt_version, session_id, created_at`
+
+func (r *workoutPlanRepository) Create(ctx context.Context, plan *workout.WorkoutPlan) error {
+	if plan == nil || plan.ID == "" || plan.UserID == "" {
+		return domainerr.ErrInvalidInput
+	}
+
+	rows := make([]planExerciseRow, 0, len(plan.Exercises))
+	for _, ex := range plan.Exercises {
+		rows = append(rows, planExerciseRow(ex))
+	}
+	payload, err := json.Marshal(rows)
+	if err != nil {
+		return domainerr.ErrInternal
+	}
+
+	_, err = r.db.ExecContext(ctx,
+		`INSERT INTO workout_plans(`+workoutPlanColumns+`)
+		 VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)`,
+		plan.ID, plan.UserID, nullString(plan.SplitDayID), plan.SplitDayName, plan.Fatigue, payload,
+		string(plan.Source), nullString(plan.PromptVersion), plan.SessionID, plan.Date,
+	)
+	if err != nil {
+		return domainerr.ErrInternal
+	}
+	return nil
+}
+
+func (r *workoutPlanRepository) GetByID(ctx context.Context, id string) (*workout.WorkoutPlan, error) {
+	row := r.db.QueryRowContext(ctx,
+		`SELECT `+workoutPlanColumns+` FROM workout_plans WHERE id=$1`,
+		id,
+	)
+	return scanWorkoutPlan(row)
+}
+
+func (r *workoutPlanRepository) GetOwnerID(ctx context.Context, id string) (string, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT user_id FROM workout_plans WHERE id=$1`, id)
+
+	var ownerID string
+	if err := row.Scan(&ownerID); err != nil {
+		if err == sql.ErrNoRows {
+			return "", domainerr.ErrNotFound
+		}
+		return "", domainerr.ErrInternal
+	}
+	return ownerID, nil
+}
+
+func (r *workoutPlanRepository) ListByUser(ctx context.Context, userID string, limit int) ([]workout.WorkoutPlan, error) {
+	if limit <= 0 || limit > workout.MaxPlanPageSize {
+		limit = workout.DefaultPlanPageSize
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+workoutPlanColumns+`
+		 FROM workout_plans
+		 WHERE user_id=$1
+		 ORDER BY created_at DESC, id DESC
+		 LIMIT $2`,
+		userID, limit,
+	)
+	if err != nil {
+		return nil, domainerr.ErrInternal
+	}
+	defer rows.Close()
+
+	var out []workout.WorkoutPlan
+	for rows.Next() {
+		plan, err := scanWorkoutPlan(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *plan)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, domainerr.ErrInternal
+	}
+	return out, nil
+}
+
+func (r *workoutPlanRepository) AttachSession(ctx context.Context, planID, sessionID string) error {
+	res, err := r.db.ExecContext(ctx,
+		`UPDATE workout_plans SET session_id=$2 WHERE id=$1 AND session_id IS NULL`,
+		planID, sessionID,
+	)
+	if err != nil {
+		return domainerr.ErrInternal
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return domainerr.ErrConflict
+	}
+	return nil
+}
+
+func (r *workoutPlanRepository) LatestCompletedWithExercise(ctx context.Context, userID, exerciseID string) (*workout.WorkoutPlan, error) {
+	// JSONB containment only compares the keys given, so the filter holds
+	// the exercise id alone.
+	filter, err := json.Marshal([]map[string]string{{"exercise_id": exerciseID}})
+	if err != nil {
+		return nil, domainerr.ErrInternal
+	}
+
+	row := r.db.QueryRowContext(ctx,
+		`SELECT wp.id, wp.user_id, wp.split_day_id, wp.split_day_name, wp.fatigue, wp.exercises,
+		        wp.source, wp.prompt_version, wp.session_id, wp.created_at
+		 FROM workout_plans wp
+		 JOIN workout_sessions ws ON ws.id = wp.session_id
+		 WHERE wp.user_id=$1 AND ws.status=$3 AND wp.exercises @> $2::jsonb
+		 ORDER BY ws.session_date DESC, ws.created_at DESC
+		 LIMIT 1`,
+		userID, filter, workout.SessionStatusCompleted,
+	)
+	return scanWorkoutPlan(row)
+}
+
+// planScanner is satisfied by *sql.Row and *sql.Rows.
+type planScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanWorkoutPlan(row planScanner) (*workout.WorkoutPlan, error) {
+	var (
+		p             workout.WorkoutPlan
+		splitDayID    sql.NullString
+		exercises     []byte
+		source        string
+		promptVersion sql.NullString
+		sessionID     sql.NullString
+	)
+	if err := row.Scan(&p.ID, &p.UserID, &splitDayID, &p.SplitDayName, &p.Fatigue, &exercises,
+		&source, &promptVersion, &sessionID, &p.Date); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainerr.ErrNotFound
+		}
+		return nil, domainerr.ErrInternal
+	}
+
+	var rows []planExerciseRow
+	if err := json.Unmarshal(exercises, &rows); err != nil {
+		return nil, domainerr.ErrInternal
+	}
+	for _, ex := range rows {
+		p.Exercises = append(p.Exercises, workout.WorkoutPlanExercise(ex))
+	}
+	p.SplitDayID = splitDayID.String
+	p.Source = coach.Source(source)
+	p.PromptVersion = promptVersion.String
+	if sessionID.Valid {
+		id := sessionID.String
+		p.SessionID = &id
+	}
+	return &p, nil
+}
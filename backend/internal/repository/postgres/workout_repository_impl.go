@@ -3,10 +3,16 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
 
 	"S.P.A.R.T.A/backend/internal/domain/aggregate/workout"
 	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
 	domainrepo "S.P.A.R.T.A/backend/internal/domain/repository"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 type workoutRepository struct {
@@ -24,71 +30,33 @@ func (r *workoutRepository) CreateSession(
 
 	sessionQuery := `
 		INSERT INTO workout_sessions
-		(id, user_id, split_day_id, session_date, duration_minutes, notes, created_at)
-		VALUES ($1,$2,$3,$4,$5,$6,$7)
+		(id, user_id, split_day_id, plan_id, status, session_date, duration_minutes, notes, created_at)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)
 	`
 
+	status := session.Status
+	if status == "" {
+		status = workout.SessionStatusCompleted
+	}
+
 	_, err := r.db.ExecContext(
 		ctx,
 		sessionQuery,
 		session.ID,
 		session.UserID,
 		session.SplitDayID,
+		session.PlanID,
+		status,
 		session.SessionDate,
 		session.DurationMin,
 		session.Notes,
 		session.CreatedAt,
 	)
 	if err != nil {
-		return err
-	}
-
-	for _, ex := range session.Exercises {
-
-		exQuery := `
-			INSERT INTO workout_exercises
-			(id, workout_session_id, exercise_id)
-			VALUES ($1,$2,$3)
-		`
-
-		_, err = r.db.ExecContext(
-			ctx,
-			exQuery,
-			ex.ID,
-			session.ID,
-			ex.ExerciseID,
-		)
-		if err != nil {
-			return err
-		}
-
-		for _, set := range ex.Sets {
-
-			setQuery := `
-				INSERT INTO workout_sets
-				(id, workout_exercise_id, set_order, reps, weight, rpe, set_type, created_at)
-				VALUES ($1,$2,$3,$4,$5,$6,$7,$8)
-			`
-
-			_, err = r.db.ExecContext(
-				ctx,
-				setQuery,
-				set.ID,
-				ex.ID,
-				set.SetOrder,
-				set.Reps,
-				set.Weight,
-				set.RPE,
-				set.SetType,
-				set.CreatedAt,
-			)
-			if err != nil {
-				return err
-			}
-		}
+		return domainerr.ErrInternal
 	}
 
-	return nil
+	return r.insertExercises(ctx, session.ID, session.Exercises)
 }
 
 func (r *workoutRepository) UpdateSession(
@@ -117,6 +85,135 @@ func (r *workoutRepository) UpdateSession(
 	if rows == 0 {
 		return domainerr.ErrNotFound
 	}
+
+	// Rewrite exercises/sets for the session (sets cascade on delete).
+	_, err = r.db.ExecContext(ctx,
+		`DELETE FROM workout_exercises WHERE workout_session_id=$1`,
+		session.ID,
+	)
+	if err != nil {
+		return domainerr.ErrInternal
+	}
+
+	return r.insertExercises(ctx, session.ID, session.Exercises)
+}
+
+func (r *workoutRepository) UpdateSessionStatus(ctx context.Context, id string, status string) error {
+	if id == "" || status == "" {
+		return domainerr.ErrInvalidInput
+	}
+
+	res, err := r.db.ExecContext(ctx, `UPDATE workout_sessions SET status=$2 WHERE id=$1`, id, status)
+	if err != nil {
+		return domainerr.ErrInternal
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return domainerr.ErrNotFound
+	}
+	return nil
+}
+
+func (r *workoutRepository) DeleteSession(ctx context.Context, id string) error {
+	if id == "" {
+		return domainerr.ErrInvalidInput
+	}
+
+	res, err := r.db.ExecContext(ctx, `DELETE FROM workout_sessions WHERE id=$1`, id)
+	if err != nil {
+		return domainerr.ErrInternal
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return domainerr.ErrNotFound
+	}
+	return nil
+}
+
+func (r *workoutRepository) AddSet(ctx context.Context, workoutExerciseID string, set *workout.WorkoutSet) error {
+	if workoutExerciseID == "" || set == nil || set.ID == "" {
+		return domainerr.ErrInvalidInput
+	}
+	return r.insertSet(ctx, workoutExerciseID, *set)
+}
+
+func (r *workoutRepository) UpdateSet(ctx context.Context, set *workout.WorkoutSet) error {
+	if set == nil || set.ID == "" {
+		return domainerr.ErrInvalidInput
+	}
+
+	res, err := r.db.ExecContext(ctx,
+		`UPDATE workout_sets
+		 SET set_order=$2, reps=$3, weight=$4, rpe=$5, set_type=$6
+		 WHERE id=$1`,
+		set.ID, set.SetOrder, set.Reps, set.Weight, set.RPE, set.SetType,
+	)
+	if err != nil {
+		return domainerr.ErrInternal
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return domainerr.ErrNotFound
+	}
+	return nil
+}
+
+func (r *workoutRepository) DeleteSet(ctx context.Context, setID string) error {
+	if setID == "" {
+		return domainerr.ErrInvalidInput
+	}
+
+	res, err := r.db.ExecContext(ctx, `DELETE FROM workout_sets WHERE id=$1`, setID)
+	if err != nil {
+		return domainerr.ErrInternal
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return domainerr.ErrNotFound
+	}
+	return nil
+}
+
+func (r *workoutRepository) insertExercises(ctx context.Context, sessionID string, exercises []workout.WorkoutExercise) error {
+	for _, ex := range exercises {
+		_, err := r.db.ExecContext(ctx,
+			`INSERT INTO workout_exercises
+			 (id, workout_session_id, exercise_id)
+			 VALUES ($1,$2,$3)`,
+			ex.ID,
+			sessionID,
+			ex.ExerciseID,
+		)
+		if err != nil {
+			return domainerr.ErrInternal
+		}
+
+		for _, set := range ex.Sets {
+			if err := r.insertSet(ctx, ex.ID, set); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *workoutRepository) insertSet(ctx context.Context, workoutExerciseID string, set workout.WorkoutSet) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO workout_sets
+		 (id, workout_exercise_id, set_order, reps, weight, rpe, set_type, created_at)
+		 VALUES ($1,$2,$3,$4,$5,$6,$7,$8)`,
+		set.ID,
+		workoutExerciseID,
+		set.SetOrder,
+		set.Reps,
+		set.Weight,
+		set.RPE,
+		set.SetType,
+		set.CreatedAt,
+	)
+	if err != nil {
+		return domainerr.ErrInternal
+	}
 	return nil
 }
 
@@ -124,125 +221,331 @@ func (r *workoutRepository) GetSessionByID(
 	ctx context.Context,
 	id string,
 ) (*workout.WorkoutSession, error) {
-	row := r.db.QueryRowContext(ctx,
-		`SELECT id,user_id,split_day_id,session_date,duration_minutes,notes,created_at
+	sessions, err := r.querySessions(ctx,
+		`SELECT id,user_id,split_day_id,plan_id,status,session_date,duration_minutes,notes,created_at
 		 FROM workout_sessions
 		 WHERE id=$1`,
 		id,
 	)
+	if err != nil {
+		return nil, err
+	}
+	if len(sessions) == 0 {
+		return nil, domainerr.ErrNotFound
+	}
+	return &sessions[0], nil
+}
+
+func (r *workoutRepository) GetSessionOwnerID(ctx context.Context, id string) (string, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT user_id FROM workout_sessions WHERE id=$1`, id)
 
-	var out workout.WorkoutSession
-	var splitDay sql.NullString
-	if err := row.Scan(
-		&out.ID,
-		&out.UserID,
-		&splitDay,
-		&out.SessionDate,
-		&out.DurationMin,
-		&out.Notes,
-		&out.CreatedAt,
-	); err != nil {
+	var ownerID sql.NullString
+	if err := row.Scan(&ownerID); err != nil {
 		if err == sql.ErrNoRows {
-			return nil, domainerr.ErrNotFound
+			return "", domainerr.ErrNotFound
 		}
-		return nil, domainerr.ErrInternal
-	}
-	if splitDay.Valid {
-		s := splitDay.String
-		out.SplitDayID = &s
+		return "", domainerr.ErrInternal
 	}
+	return ownerID.String, nil
+}
 
-	exRows, err := r.db.QueryContext(ctx,
-		`SELECT id, exercise_id
-		 FROM workout_exercises
-		 WHERE workout_session_id=$1`,
-		out.ID,
-	)
+// GetSessionsByUser returns the most recent page of a user's completed
+// sessions.
+func (r *workoutRepository) GetSessionsByUser(
+	ctx context.Context,
+	userID string,
+) ([]workout.WorkoutSession, error) {
+	page, err := r.ListSessionsByUser(ctx, userID, workout.SessionFilter{Status: workout.SessionStatusCompleted})
 	if err != nil {
-		return nil, domainerr.ErrInternal
+		return nil, err
 	}
-	defer exRows.Close()
+	return page.Items, nil
+}
 
-	for exRows.Next() {
-		var ex workout.WorkoutExercise
-		if err := exRows.Scan(&ex.ID, &ex.ExerciseID); err != nil {
-			return nil, domainerr.ErrInternal
-		}
+func (r *workoutRepository) ListSessionsByUser(
+	ctx context.Context,
+	userID string,
+	filter workout.SessionFilter,
+) (*workout.SessionPage, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = workout.DefaultSessionPageSize
+	}
+	if limit > workout.MaxSessionPageSize {
+		limit = workout.MaxSessionPageSize
+	}
 
-		setRows, err := r.db.QueryContext(ctx,
-			`SELECT id,set_order,reps,weight,rpe,set_type,created_at
-			 FROM workout_sets
-			 WHERE workout_exercise_id=$1
-			 ORDER BY set_order ASC`,
-			ex.ID,
-		)
-		if err != nil {
-			return nil, domainerr.ErrInternal
-		}
+	conds := []string{"ws.user_id=$1"}
+	args := []any{userID}
+	arg := func(v any) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(len(args))
+	}
 
-		for setRows.Next() {
-			var s workout.WorkoutSet
-			if err := setRows.Scan(
-				&s.ID,
-				&s.SetOrder,
-				&s.Reps,
-				&s.Weight,
-				&s.RPE,
-				&s.SetType,
-				&s.CreatedAt,
-			); err != nil {
-				_ = setRows.Close()
-				return nil, domainerr.ErrInternal
-			}
-			ex.Sets = append(ex.Sets, s)
+	if filter.From != nil {
+		conds = append(conds, "ws.session_date >= "+arg(*filter.From)+"::date")
+	}
+	if filter.To != nil {
+		conds = append(conds, "ws.session_date <= "+arg(*filter.To)+"::date")
+	}
+	if filter.SplitDayID != "" {
+		conds = append(conds, "ws.split_day_id = "+arg(filter.SplitDayID))
+	}
+	if filter.Status != "" {
+		conds = append(conds, "ws.status = "+arg(filter.Status))
+	}
+	if filter.ExerciseID != "" {
+		conds = append(conds,
+			"EXISTS (SELECT 1 FROM workout_exercises we WHERE we.workout_session_id = ws.id AND we.exercise_id = "+arg(filter.ExerciseID)+")")
+	}
+	if filter.Cursor != "" {
+		cur, err := decodeSessionCursor(filter.Cursor)
+		if err != nil {
+			return nil, domainerr.ErrInvalidInput
 		}
-		_ = setRows.Close()
-		out.Exercises = append(out.Exercises, ex)
+		conds = append(conds, "(ws.session_date, ws.created_at, ws.id) < ("+
+			arg(cur.SessionDate)+"::date, "+arg(cur.CreatedAt)+"::timestamp, "+arg(cur.ID)+")")
 	}
-	if err := exRows.Err(); err != nil {
-		return nil, domainerr.ErrInternal
+
+	// Fetch one extra row to know whether another page exists.
+	query := `SELECT ws.id,ws.user_id,ws.split_day_id,ws.plan_id,ws.status,ws.session_date,ws.duration_minutes,ws.notes,ws.created_at
+		 FROM workout_sessions ws
+		 WHERE ` + strings.Join(conds, " AND ") + `
+		 ORDER BY ws.session_date DESC, ws.created_at DESC, ws.id DESC
+		 LIMIT ` + arg(limit+1)
+
+	sessions, err := r.querySessions(ctx, query, args...)
+	if err != nil {
+		return nil, err
 	}
 
-	return &out, nil
+	page := &workout.SessionPage{Items: sessions}
+	if len(sessions) > limit {
+		page.Items = sessions[:limit]
+		page.NextCursor = encodeSessionCursor(page.Items[limit-1])
+	}
+	return page, nil
 }
 
-func (r *workoutRepository) GetSessionsByUser(
+// GetSessionsByUserInRange returns every completed session between from and
+// to (inclusive dates), newest first. It is unbounded and meant for
+// analytics/load math.
+func (r *workoutRepository) GetSessionsByUserInRange(
 	ctx context.Context,
 	userID string,
+	from time.Time,
+	to time.Time,
 ) ([]workout.WorkoutSession, error) {
-	rows, err := r.db.QueryContext(ctx,
-		`SELECT id
+	return r.querySessions(ctx,
+		`SELECT id,user_id,split_day_id,plan_id,status,session_date,duration_minutes,notes,created_at
 		 FROM workout_sessions
-		 WHERE user_id=$1
-		 ORDER BY session_date DESC, created_at DESC
-		 LIMIT 30`,
-		userID,
+		 WHERE user_id=$1 AND status=$4 AND session_date >= $2::date AND session_date <= $3::date
+		 ORDER BY session_date DESC, created_at DESC, id DESC`,
+		userID, from, to, workout.SessionStatusCompleted,
+	)
+}
+
+// GetSessionsByUserAndExercises is the history a records replay needs:
+// other exercises are left out of the sessions and the query alike.
+func (r *workoutRepository) GetSessionsByUserAndExercises(
+	ctx context.Context,
+	userID string,
+	exerciseIDs []string,
+) ([]workout.WorkoutSession, error) {
+	if len(exerciseIDs) == 0 {
+		return []workout.WorkoutSession{}, nil
+	}
+	sessions, err := r.scanSessions(ctx,
+		`SELECT s.id,s.user_id,s.split_day_id,s.plan_id,s.status,s.session_date,s.duration_minutes,s.notes,s.created_at
+		 FROM workout_sessions s
+		 WHERE s.user_id=$1 AND s.status=$2
+		   AND EXISTS (
+		     SELECT 1 FROM workout_exercises we
+		     WHERE we.workout_session_id = s.id AND we.exercise_id = ANY($3)
+		   )
+		 ORDER BY s.session_date DESC, s.created_at DESC, s.id DESC`,
+		userID, workout.SessionStatusCompleted, pq.Array(exerciseIDs),
 	)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.loadExercises(ctx, sessions, exerciseIDs); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// querySessions runs a session header query and loads exercises and sets for
+// all returned sessions in two more batched queries.
+func (r *workoutRepository) querySessions(ctx context.Context, query string, args ...any) ([]workout.WorkoutSession, error) {
+	sessions, err := r.scanSessions(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.loadExercises(ctx, sessions, nil); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+func (r *workoutRepository) scanSessions(ctx context.Context, query string, args ...any) ([]workout.WorkoutSession, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, domainerr.ErrInternal
 	}
 	defer rows.Close()
 
-	ids := make([]string, 0)
+	sessions := make([]workout.WorkoutSession, 0)
 	for rows.Next() {
-		var id string
-		if err := rows.Scan(&id); err != nil {
+		var s workout.WorkoutSession
+		var splitDay sql.NullString
+		var planID sql.NullString
+		var notes sql.NullString
+		if err := rows.Scan(
+			&s.ID,
+			&s.UserID,
+			&splitDay,
+			&planID,
+			&s.Status,
+			&s.SessionDate,
+			&s.DurationMin,
+			&notes,
+			&s.CreatedAt,
+		); err != nil {
 			return nil, domainerr.ErrInternal
 		}
-		ids = append(ids, id)
+		if splitDay.Valid {
+			id := splitDay.String
+			s.SplitDayID = &id
+		}
+		if planID.Valid {
+			id := planID.String
+			s.PlanID = &id
+		}
+		s.Notes = notes.String
+		sessions = append(sessions, s)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, domainerr.ErrInternal
 	}
+	return sessions, nil
+}
 
-	items := make([]workout.WorkoutSession, 0, len(ids))
-	for _, id := range ids {
-		s, err := r.GetSessionByID(ctx, id)
-		if err != nil {
-			return nil, err
+// loadExercises fills in the exercises and sets of sessions; with
+// exerciseIDs, only those exercises.
+func (r *workoutRepository) loadExercises(ctx context.Context, sessions []workout.WorkoutSession, exerciseIDs []string) error {
+	if len(sessions) == 0 {
+		return nil
+	}
+
+	sessionIDs := make([]string, 0, len(sessions))
+	sessionIdx := make(map[string]int, len(sessions))
+	for i, s := range sessions {
+		sessionIDs = append(sessionIDs, s.ID)
+		sessionIdx[s.ID] = i
+	}
+	args := []any{pq.Array(sessionIDs)}
+	exerciseFilter := ""
+	if exerciseIDs != nil {
+		exerciseFilter = " AND we.exercise_id = ANY($2)"
+		args = append(args, pq.Array(exerciseIDs))
+	}
+
+	setRows, err := r.db.QueryContext(ctx,
+		`SELECT ws.id, ws.workout_exercise_id, ws.set_order, ws.reps, ws.weight, ws.rpe, ws.set_type, ws.created_at
+		 FROM workout_sets ws
+		 JOIN workout_exercises we ON we.id = ws.workout_exercise_id
+		 WHERE we.workout_session_id = ANY($1)`+exerciseFilter+`
+		 ORDER BY ws.set_order ASC`,
+		args...,
+	)
+	if err != nil {
+		return domainerr.ErrInternal
+	}
+	defer setRows.Close()
+
+	setsByExercise := make(map[string][]workout.WorkoutSet)
+	for setRows.Next() {
+		var set workout.WorkoutSet
+		var exerciseEntryID string
+		var rpe sql.NullFloat64
+		var setType sql.NullString
+		if err := setRows.Scan(
+			&set.ID,
+			&exerciseEntryID,
+			&set.SetOrder,
+			&set.Reps,
+			&set.Weight,
+			&rpe,
+			&setType,
+			&set.CreatedAt,
+		); err != nil {
+			return domainerr.ErrInternal
+		}
+		set.RPE = rpe.Float64
+		set.SetType = setType.String
+		setsByExercise[exerciseEntryID] = append(setsByExercise[exerciseEntryID], set)
+	}
+	if err := setRows.Err(); err != nil {
+		return domainerr.ErrInternal
+	}
+
+	exRows, err := r.db.QueryContext(ctx,
+		`SELECT we.id, we.workout_session_id, we.exercise_id
+		 FROM workout_exercises we
+		 WHERE we.workout_session_id = ANY($1)`+exerciseFilter,
+		args...,
+	)
+	if err != nil {
+		return domainerr.ErrInternal
+	}
+	defer exRows.Close()
+
+	for exRows.Next() {
+		var ex workout.WorkoutExercise
+		var sessionID string
+		if err := exRows.Scan(&ex.ID, &sessionID, &ex.ExerciseID); err != nil {
+			return domainerr.ErrInternal
 		}
-		items = append(items, *s)
+		ex.Sets = setsByExercise[ex.ID]
+		i := sessionIdx[sessionID]
+		sessions[i].Exercises = append(sessions[i].Exercises, ex)
+	}
+	if err := exRows.Err(); err != nil {
+		return domainerr.ErrInternal
 	}
+	return nil
+}
+
+type sessionCursor struct {
+	SessionDate string
+	CreatedAt   time.Time
+	ID          string
+}
 
-	return items, nil
+func encodeSessionCursor(s workout.WorkoutSession) string {
+	raw := s.SessionDate.Format("2006-01-02") + "|" + s.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + s.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeSessionCursor(cursor string) (sessionCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return sessionCursor{}, err
+	}
+	parts := strings.Split(string(raw), "|")
+	if len(parts) != 3 {
+		return sessionCursor{}, domainerr.ErrInvalidInput
+	}
+	if _, err := time.Parse("2006-01-02", parts[0]); err != nil {
+		return sessionCursor{}, err
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, parts[1])
+	if err != nil {
+		return sessionCursor{}, err
+	}
+	if _, err := uuid.Parse(parts[2]); err != nil {
+		return sessionCursor{}, err
+	}
+	return sessionCursor{SessionDate: parts[0], CreatedAt: createdAt, ID: parts[2]}, nil
 }
@@ -0,0 +1,66 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+	domainrepo "S.P.A.R.T.A/backend/internal/domain/repository"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type loginAttemptRepository struct {
+	client *redis.Client
+}
+
+func NewLoginAttemptRepository(client *redis.Client) domainrepo.LoginAttemptRepository {
+	return &loginAttemptRepository{client: client}
+}
+
+func (r *loginAttemptRepository) LockedFor(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := r.client.PTTL(ctx, loginLockKey(key)).Result()
+	if err != nil {
+		return 0, domainerr.ErrInternal
+	}
+	// Negative values mean no key or no expiry.
+	if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
+
+func (r *loginAttemptRepository) RecordFailure(ctx context.Context, key string, window time.Duration) (int, error) {
+	var incr *redis.IntCmd
+	_, err := r.client.TxPipelined(ctx, func(p redis.Pipeliner) error {
+		incr = p.Incr(ctx, loginFailuresKey(key))
+		p.PExpire(ctx, loginFailuresKey(key), window)
+		return nil
+	})
+	if err != nil {
+		return 0, domainerr.ErrInternal
+	}
+	return int(incr.Val()), nil
+}
+
+func (r *loginAttemptRepository) Lock(ctx context.Context, key string, d time.Duration) error {
+	if err := r.client.Set(ctx, loginLockKey(key), "1", d).Err(); err != nil {
+		return domainerr.ErrInternal
+	}
+	return nil
+}
+
+func (r *loginAttemptRepository) Reset(ctx context.Context, key string) error {
+	if err := r.client.Del(ctx, loginFailuresKey(key), loginLockKey(key)).Err(); err != nil {
+		return domainerr.ErrInternal
+	}
+	return nil
+}
+
+func loginFailuresKey(key string) string {
+	return "auth:login_failures:" + key
+}
+
+func loginLockKey(key string) string {
+	return "auth:login_lock:" + key
+}
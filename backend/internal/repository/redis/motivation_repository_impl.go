@@ -20,8 +20,8 @@ func NewMotivationRepository(client *redis.Client) domainrepo.MotivationReposito
 	return &motivationRepository{client: client}
 }
 
-func (r *motivationRepository) GetDailyMotivation(ctx context.Context, userID string, date time.Time) (string, bool, error) {
-	key := motivationKey(userID, date)
+func (r *motivationRepository) GetDailyMotivation(ctx context.Context, userID, voice string, date time.Time) (string, bool, error) {
+	key := motivationKey(userID, voice, date)
 
 	val, err := r.client.Get(ctx, key).Result()
 	if err == nil {
@@ -33,8 +33,8 @@ func (r *motivationRepository) GetDailyMotivation(ctx context.Context, userID st
 	return "", false, domainerr.ErrInternal
 }
 
-func (r *motivationRepository) SetDailyMotivation(ctx context.Context, userID string, date time.Time, message string, ttl time.Duration) error {
-	key := motivationKey(userID, date)
+func (r *motivationRepository) SetDailyMotivation(ctx context.Context, userID, voice string, date time.Time, message string, ttl time.Duration) error {
+	key := motivationKey(userID, voice, date)
 
 	if err := r.client.Set(ctx, key, message, ttl).Err(); err != nil {
 		return domainerr.ErrInternal
@@ -42,14 +42,14 @@ func (r *motivationRepository) SetDailyMotivation(ctx context.Context, userID st
 	return nil
 }
 
-func (r *motivationRepository) DeleteDailyMotivation(ctx context.Context, userID string, date time.Time) error {
-	key := motivationKey(userID, date)
+func (r *motivationRepository) DeleteDailyMotivation(ctx context.Context, userID, voice string, date time.Time) error {
+	key := motivationKey(userID, voice, date)
 	if err := r.client.Del(ctx, key).Err(); err != nil {
 		return domainerr.ErrInternal
 	}
 	return nil
 }
 
-func motivationKey(userID string, date time.Time) string {
-	return fmt.Sprintf("motivation:%s:%s", userID, date.UTC().Format("2006-01-02"))
+func motivationKey(userID, voice string, date time.Time) string {
+	return fmt.Sprintf("motivation:%s:%s:%s", userID, voice, date.UTC().Format("2006-01-02"))
 }
@@ -0,0 +1,54 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/auth"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+	domainrepo "S.P.A.R.T.A/backend/internal/domain/repository"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type oauthStateRepository struct {
+	client *redis.Client
+}
+
+func NewOAuthStateRepository(client *redis.Client) domainrepo.OAuthStateRepository {
+	return &oauthStateRepository{client: client}
+}
+
+func (r *oauthStateRepository) Save(ctx context.Context, state *auth.OAuthState, ttl time.Duration) error {
+	b, err := json.Marshal(state)
+	if err != nil {
+		return domainerr.ErrInternal
+	}
+	if err := r.client.Set(ctx, oauthStateKey(state.State), b, ttl).Err(); err != nil {
+		return domainerr.ErrInternal
+	}
+	return nil
+}
+
+func (r *oauthStateRepository) Take(ctx context.Context, state string) (*auth.OAuthState, error) {
+	// GETDEL makes concurrent callbacks with the same state race for it.
+	b, err := r.client.GetDel(ctx, oauthStateKey(state)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, domainerr.ErrNotFound
+	}
+	if err != nil {
+		return nil, domainerr.ErrInternal
+	}
+
+	var out auth.OAuthState
+	if err := json.Unmarshal(b, &out); err != nil {
+		return nil, domainerr.ErrInternal
+	}
+	return &out, nil
+}
+
+func oauthStateKey(state string) string {
+	return "auth:oauth_state:" + state
+}
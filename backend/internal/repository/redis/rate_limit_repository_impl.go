@@ -0,0 +1,92 @@
+package redis
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/ratelimit"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+	domainrepo "S.P.A.R.T.A/backend/internal/domain/repository"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// slidingWindowScript keeps one sorted-set member per counted request,
+// scored by its time in milliseconds (Lua numbers reach Redis with 14
+// significant digits, enough for epoch milliseconds). It drops members older than the
+// window, adds the request if there is room, and returns whether it did,
+// the count, and the oldest and newest scores.
+var slidingWindowScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+local count = redis.call('ZCARD', KEYS[1])
+local allowed = 0
+if count < limit then
+  redis.call('ZADD', KEYS[1], ARGV[1], ARGV[4])
+  count = count + 1
+  allowed = 1
+end
+redis.call('PEXPIRE', KEYS[1], window)
+local oldest = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+local newest = redis.call('ZRANGE', KEYS[1], -1, -1, 'WITHSCORES')
+return {allowed, count, oldest[2] or ARGV[1], newest[2] or ARGV[1]}
+`)
+
+type rateLimitRepository struct {
+	client *redis.Client
+}
+
+func NewRateLimitRepository(client *redis.Client) domainrepo.RateLimitRepository {
+	return &rateLimitRepository{client: client}
+}
+
+func (r *rateLimitRepository) Allow(ctx context.Context, key string, policy ratelimit.Policy) (ratelimit.Decision, error) {
+	now := time.Now().UnixMilli()
+	window := policy.Window.Milliseconds()
+
+	member := make([]byte, 8)
+	if _, err := rand.Read(member); err != nil {
+		return ratelimit.Decision{}, domainerr.ErrInternal
+	}
+
+	res, err := slidingWindowScript.Run(ctx, r.client,
+		[]string{rateLimitKey(policy.Name, key)},
+		now, window, policy.Limit, hex.EncodeToString(member),
+	).Slice()
+	if err != nil || len(res) != 4 {
+		return ratelimit.Decision{}, domainerr.ErrInternal
+	}
+	allowed, _ := res[0].(int64)
+	count, _ := res[1].(int64)
+	oldest, err1 := toMillis(res[2])
+	newest, err2 := toMillis(res[3])
+	if err1 != nil || err2 != nil {
+		return ratelimit.Decision{}, domainerr.ErrInternal
+	}
+
+	d := ratelimit.Decision{
+		Allowed:   allowed == 1,
+		Limit:     policy.Limit,
+		Remaining: max(policy.Limit-int(count), 0),
+		Reset:     time.Duration(newest+window-now) * time.Millisecond,
+	}
+	if !d.Allowed {
+		d.RetryAfter = time.Duration(oldest+window-now) * time.Millisecond
+	}
+	return d, nil
+}
+
+func toMillis(v any) (int64, error) {
+	s, _ := v.(string)
+	f, err := strconv.ParseFloat(s, 64)
+	return int64(f), err
+}
+
+func rateLimitKey(policy, key string) string {
+	return "ratelimit:" + policy + ":" + key
+}
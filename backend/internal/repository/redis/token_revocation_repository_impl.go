@@ -0,0 +1,76 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+	domainrepo "S.P.A.R.T.A/backend/internal/domain/repository"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type tokenRevocationRepository struct {
+	client *redis.Client
+}
+
+func NewTokenRevocationRepository(client *redis.Client) domainrepo.TokenRevocationRepository {
+	return &tokenRevocationRepository{client: client}
+}
+
+func (r *tokenRevocationRepository) RevokeSession(ctx context.Context, sessionID string, ttl time.Duration) error {
+	if err := r.client.Set(ctx, revokedSessionKey(sessionID), "1", ttl).Err(); err != nil {
+		return domainerr.ErrInternal
+	}
+	return nil
+}
+
+func (r *tokenRevocationRepository) BumpUserVersion(ctx context.Context, userID string) (int64, error) {
+	v, err := r.client.Incr(ctx, userVersionKey(userID)).Result()
+	if err != nil {
+		return 0, domainerr.ErrInternal
+	}
+	return v, nil
+}
+
+func (r *tokenRevocationRepository) UserVersion(ctx context.Context, userID string) (int64, error) {
+	val, err := r.client.Get(ctx, userVersionKey(userID)).Result()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, domainerr.ErrInternal
+	}
+	v, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return 0, domainerr.ErrInternal
+	}
+	return v, nil
+}
+
+func (r *tokenRevocationRepository) TokenState(ctx context.Context, userID, sessionID string) (bool, int64, error) {
+	vals, err := r.client.MGet(ctx, revokedSessionKey(sessionID), userVersionKey(userID)).Result()
+	if err != nil || len(vals) != 2 {
+		return false, 0, domainerr.ErrInternal
+	}
+
+	revoked := vals[0] != nil
+	var version int64
+	if s, ok := vals[1].(string); ok {
+		version, err = strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return false, 0, domainerr.ErrInternal
+		}
+	}
+	return revoked, version, nil
+}
+
+func revokedSessionKey(sessionID string) string {
+	return "auth:revoked_session:" + sessionID
+}
+
+func userVersionKey(userID string) string {
+	return "auth:user_version:" + userID
+}
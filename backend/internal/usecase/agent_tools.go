@@ -0,0 +1,678 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/ai/orchestrator"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/agent"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/coach"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/exercise"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/planner"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/split"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/workout"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+	domainrepo "S.P.A.R.T.A/backend/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// maxAgentToolCalls bounds the tool calls of one agent run, whatever the
+// model asks for.
+const maxAgentToolCalls = 12
+
+// agentTool is one tool offered to the agent. Read tools answer directly.
+// Write tools only plan: the proposed change is stored as a pending action
+// and apply runs when the user confirms it.
+type agentTool struct {
+	spec  orchestrator.ToolSpec
+	read  func(ctx context.Context, sb *toolSandbox, args json.RawMessage) (any, error)
+	plan  func(ctx context.Context, sb *toolSandbox, args json.RawMessage) (*agent.Action, error)
+	apply func(ctx context.Context, r domainrepo.Registry, action agent.Action) error
+}
+
+var agentTools = []agentTool{
+	{
+		spec: orchestrator.ToolSpec{
+			Name:        "get_recent_sessions",
+			Description: "List the athlete's most recent workout sessions with exercises and sets, newest first.",
+			Parameters: json.RawMessage(`{
+  "type": "object",
+  "additionalProperties": false,
+  "required": ["limit"],
+  "properties": {
+    "limit": {"type": "integer", "description": "1-10 sessions"}
+  }
+}`),
+		},
+		read: readRecentSessions,
+	},
+	{
+		spec: orchestrator.ToolSpec{
+			Name:        "get_exercise_history",
+			Description: "List the athlete's sets of one exercise across recent sessions, newest first.",
+			Parameters: json.RawMessage(`{
+  "type": "object",
+  "additionalProperties": false,
+  "required": ["exercise_id", "limit"],
+  "properties": {
+    "exercise_id": {"type": "string"},
+    "limit": {"type": "integer", "description": "1-20 sessions"}
+  }
+}`),
+		},
+		read: readExerciseHistory,
+	},
+	{
+		spec: orchestrator.ToolSpec{
+			Name:        "search_exercises",
+			Description: "Find exercises in the library by name, muscle or equipment. Returns exercise ids usable in split days.",
+			Parameters: json.RawMessage(`{
+  "type": "object",
+  "additionalProperties": false,
+  "required": ["query", "limit"],
+  "properties": {
+    "query": {"type": "string"},
+    "limit": {"type": "integer", "description": "1-20 results"}
+  }
+}`),
+		},
+		read: readSearchExercises,
+	},
+	{
+		spec: orchestrator.ToolSpec{
+			Name:        "update_split_day",
+			Description: "Propose new contents for one split day. The whole exercise list is replaced. The athlete must confirm before it is applied.",
+			Parameters: json.RawMessage(`{
+  "type": "object",
+  "additionalProperties": false,
+  "required": ["split_day_id", "name", "exercises"],
+  "properties": {
+    "split_day_id": {"type": "string"},
+    "name": {"type": "string", "description": "new day name, or empty to keep it"},
+    "exercises": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "additionalProperties": false,
+        "required": ["exercise_id", "target_sets", "target_reps", "target_weight", "notes"],
+        "properties": {
+          "exercise_id": {"type": "string"},
+          "target_sets": {"type": "integer"},
+          "target_reps": {"type": "integer"},
+          "target_weight": {"type": "number"},
+          "notes": {"type": "string"}
+        }
+      }
+    }
+  }
+}`),
+		},
+		plan:  planUpdateSplitDay,
+		apply: applyUpdateSplitDay,
+	},
+	{
+		spec: orchestrator.ToolSpec{
+			Name:        "schedule_deload",
+			Description: "Propose a deload period that is added to the athlete's recommendations. The athlete must confirm before it is applied.",
+			Parameters: json.RawMessage(`{
+  "type": "object",
+  "additionalProperties": false,
+  "required": ["start_date", "days", "volume_percent", "reason"],
+  "properties": {
+    "start_date": {"type": "string", "description": "YYYY-MM-DD, today or within 28 days"},
+    "days": {"type": "integer", "description": "3-14"},
+    "volume_percent": {"type": "integer", "description": "share of usual sets to keep, 30-90"},
+    "reason": {"type": "string"}
+  }
+}`),
+		},
+		plan:  planScheduleDeload,
+		apply: applyScheduleDeload,
+	},
+}
+
+func findAgentTool(name string) *agentTool {
+	for i := range agentTools {
+		if agentTools[i].spec.Name == name {
+			return &agentTools[i]
+		}
+	}
+	return nil
+}
+
+// toolSandbox runs tool calls for one agent run. Every tool is bound to
+// userID: reads only see that user's data and writes are stored as pending
+// actions owned by them, so the model cannot reach anyone else's records
+// whatever ids it passes.
+type toolSandbox struct {
+	u         *agentUsecase
+	userID    string
+	calls     int
+	exercises map[string]exercise.Exercise
+	actions   []agent.Action
+}
+
+func (sb *toolSandbox) Tools() []orchestrator.ToolSpec {
+	specs := make([]orchestrator.ToolSpec, 0, len(agentTools))
+	for _, t := range agentTools {
+		specs = append(specs, t.spec)
+	}
+	return specs
+}
+
+func (sb *toolSandbox) Execute(ctx context.Context, call orchestrator.ToolCall) (string, error) {
+	sb.calls++
+	if sb.calls > maxAgentToolCalls {
+		return "", fmt.Errorf("%w: tool call limit of %d reached, answer with what you have", domainerr.ErrInvalidInput, maxAgentToolCalls)
+	}
+
+	tool := findAgentTool(call.Name)
+	if tool == nil {
+		return "", fmt.Errorf("%w: unknown tool %q", domainerr.ErrNotFound, call.Name)
+	}
+
+	var result any
+	if tool.read != nil {
+		out, err := tool.read(ctx, sb, call.Arguments)
+		if err != nil {
+			return "", err
+		}
+		result = out
+	} else {
+		action, err := sb.propose(ctx, tool, call.Arguments)
+		if err != nil {
+			return "", err
+		}
+		result = map[string]any{
+			"action_id": action.ID,
+			"status":    "awaiting_user_confirmation",
+			"summary":   action.Summary,
+			"changes":   action.Changes,
+		}
+	}
+
+	raw, err := json.Marshal(result)
+	if err != nil {
+		return "", domainerr.ErrInternal
+	}
+	return string(raw), nil
+}
+
+// propose dry-runs a write tool and stores the result as a pending action.
+func (sb *toolSandbox) propose(ctx context.Context, tool *agentTool, args json.RawMessage) (*agent.Action, error) {
+	action, err := tool.plan(ctx, sb, args)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	action.ID = uuid.NewString()
+	action.UserID = sb.userID
+	action.Tool = tool.spec.Name
+	action.Arguments = args
+	action.Status = agent.StatusPending
+	action.CreatedAt = now
+	action.ExpiresAt = now.Add(agent.ActionTTL)
+	if err := sb.u.actionRepository.Create(ctx, action); err != nil {
+		return nil, err
+	}
+	sb.actions = append(sb.actions, *action)
+	return action, nil
+}
+
+// library loads the exercise library once per run.
+func (sb *toolSandbox) library(ctx context.Context) (map[string]exercise.Exercise, error) {
+	if sb.exercises != nil {
+		return sb.exercises, nil
+	}
+	exs, err := sb.u.exerciseUsecase.ListExercises(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sb.exercises = make(map[string]exercise.Exercise, len(exs))
+	for _, ex := range exs {
+		sb.exercises[ex.ID] = ex
+	}
+	return sb.exercises, nil
+}
+
+// decodeToolArgs decodes args strictly so that misspelled fields are
+// reported to the model instead of being silently ignored.
+func decodeToolArgs(args json.RawMessage, dst any) error {
+	if len(bytes.TrimSpace(args)) == 0 {
+		args = json.RawMessage(`{}`)
+	}
+	dec := json.NewDecoder(bytes.NewReader(args))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(dst); err != nil {
+		return fmt.Errorf("%w: invalid arguments: %v", domainerr.ErrInvalidInput, err)
+	}
+	return nil
+}
+
+func clampLimit(n, def, max int) int {
+	if n <= 0 {
+		return def
+	}
+	if n > max {
+		return max
+	}
+	return n
+}
+
+type toolSet struct {
+	Reps   int     `json:"reps"`
+	Weight float64 `json:"weight"`
+	RPE    float64 `json:"rpe,omitempty"`
+}
+
+type toolSessionExercise struct {
+	ExerciseID string    `json:"exercise_id"`
+	Name       string    `json:"name"`
+	Sets       []toolSet `json:"sets"`
+}
+
+type toolSession struct {
+	ID          string                `json:"id"`
+	Date        string                `json:"date"`
+	DurationMin int                   `json:"duration_min,omitempty"`
+	Notes       string                `json:"notes,omitempty"`
+	Exercises   []toolSessionExercise `json:"exercises"`
+}
+
+func toToolSession(s workout.WorkoutSession, library map[string]exercise.Exercise, onlyExerciseID string) toolSession {
+	out := toolSession{
+		ID:          s.ID,
+		Date:        s.SessionDate.Format("2006-01-02"),
+		DurationMin: s.DurationMin,
+		Notes:       strings.TrimSpace(s.Notes),
+		Exercises:   []toolSessionExercise{},
+	}
+	for _, ex := range s.Exercises {
+		if onlyExerciseID != "" && ex.ExerciseID != onlyExerciseID {
+			continue
+		}
+		te := toolSessionExercise{ExerciseID: ex.ExerciseID, Name: library[ex.ExerciseID].Name, Sets: []toolSet{}}
+		for _, set := range ex.Sets {
+			te.Sets = append(te.Sets, toolSet{Reps: set.Reps, Weight: set.Weight, RPE: set.RPE})
+		}
+		out.Exercises = append(out.Exercises, te)
+	}
+	return out
+}
+
+func readRecentSessions(ctx context.Context, sb *toolSandbox, raw json.RawMessage) (any, error) {
+	var args struct {
+		Limit int `json:"limit"`
+	}
+	if err := decodeToolArgs(raw, &args); err != nil {
+		return nil, err
+	}
+
+	page, err := sb.u.workoutUsecase.GetUserWorkoutSessions(ctx, sb.userID, workout.SessionFilter{Status: workout.SessionStatusCompleted, Limit: clampLimit(args.Limit, 5, 10)})
+	if err != nil {
+		return nil, err
+	}
+	library, err := sb.library(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]toolSession, 0, len(page.Items))
+	for _, s := range page.Items {
+		out = append(out, toToolSession(s, library, ""))
+	}
+	return out, nil
+}
+
+func readExerciseHistory(ctx context.Context, sb *toolSandbox, raw json.RawMessage) (any, error) {
+	var args struct {
+		ExerciseID string `json:"exercise_id"`
+		Limit      int    `json:"limit"`
+	}
+	if err := decodeToolArgs(raw, &args); err != nil {
+		return nil, err
+	}
+
+	library, err := sb.library(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := library[args.ExerciseID]; !ok {
+		return nil, fmt.Errorf("%w: exercise %q is not in the library, use search_exercises", domainerr.ErrNotFound, args.ExerciseID)
+	}
+
+	page, err := sb.u.workoutUsecase.GetUserWorkoutSessions(ctx, sb.userID, workout.SessionFilter{
+		ExerciseID: args.ExerciseID,
+		Status:     workout.SessionStatusCompleted,
+		Limit:      clampLimit(args.Limit, 10, 20),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]toolSession, 0, len(page.Items))
+	for _, s := range page.Items {
+		out = append(out, toToolSession(s, library, args.ExerciseID))
+	}
+	return out, nil
+}
+
+func readSearchExercises(ctx context.Context, sb *toolSandbox, raw json.RawMessage) (any, error) {
+	var args struct {
+		Query string `json:"query"`
+		Limit int    `json:"limit"`
+	}
+	if err := decodeToolArgs(raw, &args); err != nil {
+		return nil, err
+	}
+	query := strings.ToLower(strings.TrimSpace(args.Query))
+	if query == "" {
+		return nil, fmt.Errorf("%w: query must not be empty", domainerr.ErrInvalidInput)
+	}
+
+	library, err := sb.library(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	type hit struct {
+		ID            string `json:"id"`
+		Name          string `json:"name"`
+		PrimaryMuscle string `json:"primary_muscle"`
+		Equipment     string `json:"equipment"`
+	}
+	hits := make([]hit, 0)
+	for _, ex := range library {
+		haystack := strings.ToLower(ex.Name + " " + ex.PrimaryMuscle + " " + ex.Equipment)
+		if strings.Contains(haystack, query) {
+			hits = append(hits, hit{ID: ex.ID, Name: ex.Name, PrimaryMuscle: ex.PrimaryMuscle, Equipment: ex.Equipment})
+		}
+	}
+	sort.Slice(hits, func(i, j int) bool { return hits[i].Name < hits[j].Name })
+	if limit := clampLimit(args.Limit, 10, 20); len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits, nil
+}
+
+type splitDayArgs struct {
+	SplitDayID string `json:"split_day_id"`
+	Name       string `json:"name"`
+	Exercises  []struct {
+		ExerciseID   string  `json:"exercise_id"`
+		TargetSets   int     `json:"target_sets"`
+		TargetReps   int     `json:"target_reps"`
+		TargetWeight float64 `json:"target_weight"`
+		Notes        string  `json:"notes"`
+	} `json:"exercises"`
+}
+
+// findUserSplitDay looks the day up among userID's own templates, so a day
+// of someone else's split reads as not found.
+func findUserSplitDay(templates []split.SplitTemplate, dayID string) (*split.SplitTemplate, int, error) {
+	for i := range templates {
+		for j := range templates[i].Days {
+			if templates[i].Days[j].ID == dayID {
+				return &templates[i], j, nil
+			}
+		}
+	}
+	return nil, 0, fmt.Errorf("%w: split day %q not found in the athlete's splits", domainerr.ErrNotFound, dayID)
+}
+
+// splitDayVersion fingerprints a day's contents for the stale-diff check.
+func splitDayVersion(day split.SplitDay) string {
+	raw, _ := json.Marshal(struct {
+		Name      string
+		Exercises []split.SplitExercise
+	}{day.Name, day.Exercises})
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// buildSplitDay validates args and returns the proposed day.
+func buildSplitDay(current split.SplitDay, args splitDayArgs, library map[string]exercise.Exercise) (split.SplitDay, error) {
+	if len(args.Exercises) == 0 || len(args.Exercises) > 12 {
+		return split.SplitDay{}, fmt.Errorf("%w: exercises must contain between 1 and 12 entries", domainerr.ErrInvalidInput)
+	}
+
+	day := split.SplitDay{ID: current.ID, DayOrder: current.DayOrder, Name: current.Name}
+	if name := strings.TrimSpace(args.Name); name != "" {
+		day.Name = name
+	}
+	var problems []string
+	for i, ex := range args.Exercises {
+		lib, ok := library[ex.ExerciseID]
+		switch {
+		case !ok:
+			problems = append(problems, fmt.Sprintf("exercises[%d]: unknown exercise_id %q", i, ex.ExerciseID))
+		case ex.TargetSets < 1 || ex.TargetSets > 10:
+			problems = append(problems, fmt.Sprintf("exercises[%d]: target_sets must be 1-10", i))
+		case ex.TargetReps < 1 || ex.TargetReps > 100:
+			problems = append(problems, fmt.Sprintf("exercises[%d]: target_reps must be 1-100", i))
+		case ex.TargetWeight < 0:
+			problems = append(problems, fmt.Sprintf("exercises[%d]: target_weight must not be negative", i))
+		}
+		day.Exercises = append(day.Exercises, split.SplitExercise{
+			ExerciseID:   ex.ExerciseID,
+			ExerciseName: lib.Name,
+			TargetSets:   ex.TargetSets,
+			TargetReps:   ex.TargetReps,
+			TargetWeight: ex.TargetWeight,
+			Notes:        strings.TrimSpace(ex.Notes),
+		})
+	}
+	if len(problems) > 0 {
+		return split.SplitDay{}, fmt.Errorf("%w: %s", domainerr.ErrInvalidInput, strings.Join(problems, "; "))
+	}
+	return day, nil
+}
+
+func describeSplitExercise(ex split.SplitExercise) string {
+	s := fmt.Sprintf("%dx%d", ex.TargetSets, ex.TargetReps)
+	if ex.TargetWeight > 0 {
+		s += fmt.Sprintf(" @ %.1fkg", ex.TargetWeight)
+	}
+	if ex.Notes != "" {
+		s += " (" + ex.Notes + ")"
+	}
+	return s
+}
+
+func splitExerciseLabel(ex split.SplitExercise) string {
+	if ex.ExerciseName != "" {
+		return ex.ExerciseName
+	}
+	if ex.Notes != "" {
+		return ex.Notes
+	}
+	return ex.ExerciseID
+}
+
+// diffSplitDay lists what changes between two versions of a day, matching
+// exercises by id (or label for free-text entries).
+func diffSplitDay(before, after split.SplitDay) []agent.Change {
+	var changes []agent.Change
+	if before.Name != after.Name {
+		changes = append(changes, agent.Change{Path: "name", Before: before.Name, After: after.Name})
+	}
+
+	key := func(ex split.SplitExercise) string {
+		if ex.ExerciseID != "" {
+			return ex.ExerciseID
+		}
+		return strings.ToLower(splitExerciseLabel(ex))
+	}
+	old := make(map[string]split.SplitExercise, len(before.Exercises))
+	for _, ex := range before.Exercises {
+		old[key(ex)] = ex
+	}
+	seen := make(map[string]bool, len(after.Exercises))
+	for _, ex := range after.Exercises {
+		k := key(ex)
+		seen[k] = true
+		prev, ok := old[k]
+		switch {
+		case !ok:
+			changes = append(changes, agent.Change{Path: "exercises/" + splitExerciseLabel(ex), After: describeSplitExercise(ex)})
+		case describeSplitExercise(prev) != describeSplitExercise(ex):
+			changes = append(changes, agent.Change{Path: "exercises/" + splitExerciseLabel(ex), Before: describeSplitExercise(prev), After: describeSplitExercise(ex)})
+		}
+	}
+	for _, ex := range before.Exercises {
+		if !seen[key(ex)] {
+			changes = append(changes, agent.Change{Path: "exercises/" + splitExerciseLabel(ex), Before: describeSplitExercise(ex)})
+		}
+	}
+	return changes
+}
+
+func planUpdateSplitDay(ctx context.Context, sb *toolSandbox, raw json.RawMessage) (*agent.Action, error) {
+	var args splitDayArgs
+	if err := decodeToolArgs(raw, &args); err != nil {
+		return nil, err
+	}
+
+	templates, err := sb.u.splitUsecase.GetUserTemplates(ctx, sb.userID)
+	if err != nil {
+		return nil, err
+	}
+	tpl, idx, err := findUserSplitDay(templates, args.SplitDayID)
+	if err != nil {
+		return nil, err
+	}
+	library, err := sb.library(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	current := tpl.Days[idx]
+	proposed, err := buildSplitDay(current, args, library)
+	if err != nil {
+		return nil, err
+	}
+	changes := diffSplitDay(current, proposed)
+	if len(changes) == 0 {
+		return nil, fmt.Errorf("%w: the proposed day is identical to the current one", domainerr.ErrInvalidInput)
+	}
+
+	return &agent.Action{
+		Summary:     fmt.Sprintf("Update %q in %q (%d changes)", current.Name, tpl.Name, len(changes)),
+		Changes:     changes,
+		BaseVersion: splitDayVersion(current),
+	}, nil
+}
+
+func applyUpdateSplitDay(ctx context.Context, r domainrepo.Registry, action agent.Action) error {
+	var args splitDayArgs
+	if err := decodeToolArgs(action.Arguments, &args); err != nil {
+		return err
+	}
+
+	// Ownership is checked again: the day must still belong to the user who
+	// owns the action.
+	templates, err := r.Split().GetUserTemplates(ctx, action.UserID)
+	if err != nil {
+		return err
+	}
+	tpl, idx, err := findUserSplitDay(templates, args.SplitDayID)
+	if err != nil {
+		return err
+	}
+	if splitDayVersion(tpl.Days[idx]) != action.BaseVersion {
+		return fmt.Errorf("%w: the split day changed after this proposal was made", domainerr.ErrConflict)
+	}
+
+	exs, err := r.Exercise().List(ctx)
+	if err != nil {
+		return err
+	}
+	library := make(map[string]exercise.Exercise, len(exs))
+	for _, ex := range exs {
+		library[ex.ID] = ex
+	}
+	day, err := buildSplitDay(tpl.Days[idx], args, library)
+	if err != nil {
+		return err
+	}
+
+	tpl.Days[idx] = day
+	return r.Split().UpdateTemplate(ctx, tpl)
+}
+
+type deloadArgs struct {
+	StartDate     string `json:"start_date"`
+	Days          int    `json:"days"`
+	VolumePercent int    `json:"volume_percent"`
+	Reason        string `json:"reason"`
+}
+
+func (a deloadArgs) recommendation() (string, error) {
+	start, err := time.Parse("2006-01-02", strings.TrimSpace(a.StartDate))
+	if err != nil {
+		return "", fmt.Errorf("%w: start_date must be YYYY-MM-DD", domainerr.ErrInvalidInput)
+	}
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	if start.Before(today) || start.After(today.AddDate(0, 0, 28)) {
+		return "", fmt.Errorf("%w: start_date must be between today and 28 days from now", domainerr.ErrInvalidInput)
+	}
+	if a.Days < 3 || a.Days > 14 {
+		return "", fmt.Errorf("%w: days must be 3-14", domainerr.ErrInvalidInput)
+	}
+	if a.VolumePercent < 30 || a.VolumePercent > 90 {
+		return "", fmt.Errorf("%w: volume_percent must be 30-90", domainerr.ErrInvalidInput)
+	}
+
+	end := start.AddDate(0, 0, a.Days-1)
+	text := fmt.Sprintf("Deload from %s to %s: do about %d%% of your usual sets at the same weights, and stop well short of failure.",
+		start.Format("2006-01-02"), end.Format("2006-01-02"), a.VolumePercent)
+	if reason := strings.TrimSpace(a.Reason); reason != "" {
+		text += " Reason: " + reason
+	}
+	return text, nil
+}
+
+func planScheduleDeload(ctx context.Context, sb *toolSandbox, raw json.RawMessage) (*agent.Action, error) {
+	var args deloadArgs
+	if err := decodeToolArgs(raw, &args); err != nil {
+		return nil, err
+	}
+	text, err := args.recommendation()
+	if err != nil {
+		return nil, err
+	}
+
+	return &agent.Action{
+		Summary: fmt.Sprintf("Schedule a %d-day deload starting %s", args.Days, strings.TrimSpace(args.StartDate)),
+		Changes: []agent.Change{{Path: "recommendations/deload", After: text}},
+	}, nil
+}
+
+func applyScheduleDeload(ctx context.Context, r domainrepo.Registry, action agent.Action) error {
+	var args deloadArgs
+	if err := decodeToolArgs(action.Arguments, &args); err != nil {
+		return err
+	}
+	// Re-validated: the start date may have passed since the proposal.
+	text, err := args.recommendation()
+	if err != nil {
+		return err
+	}
+
+	return r.Planner().SaveRecommendation(ctx, &planner.PlannerRecommendation{
+		ID:                 uuid.NewString(),
+		UserID:             action.UserID,
+		Recommendation:     text,
+		RecommendationType: "deload",
+		Source:             coach.SourceAI,
+		CreatedAt:          time.Now(),
+	})
+}
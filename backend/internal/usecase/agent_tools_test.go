@@ -0,0 +1,300 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/ai/orchestrator"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/agent"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/exercise"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/split"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+	domainrepo "S.P.A.R.T.A/backend/internal/domain/repository"
+	domainuc "S.P.A.R.T.A/backend/internal/domain/usecase"
+)
+
+// agentStore holds the splits and actions the agent tools touch, keyed by
+// owner and id. writes counts every split update.
+type agentStore struct {
+	templates map[string][]split.SplitTemplate
+	actions   map[string]agent.Action
+	exercises []exercise.Exercise
+	writes    int
+}
+
+func cloneTemplates(in []split.SplitTemplate) []split.SplitTemplate {
+	out := slices.Clone(in)
+	for i := range out {
+		out[i].Days = slices.Clone(out[i].Days)
+		for j := range out[i].Days {
+			out[i].Days[j].Exercises = slices.Clone(out[i].Days[j].Exercises)
+		}
+	}
+	return out
+}
+
+func (s *agentStore) clone() *agentStore {
+	out := &agentStore{templates: map[string][]split.SplitTemplate{}, actions: maps.Clone(s.actions), exercises: s.exercises, writes: s.writes}
+	for user, tpls := range s.templates {
+		out.templates[user] = cloneTemplates(tpls)
+	}
+	return out
+}
+
+func (s *agentStore) userTemplates(userID string) []split.SplitTemplate {
+	return cloneTemplates(s.templates[userID])
+}
+
+func (s *agentStore) updateTemplate(tpl *split.SplitTemplate) error {
+	for user, tpls := range s.templates {
+		for i := range tpls {
+			if tpls[i].ID == tpl.ID {
+				s.templates[user][i] = cloneTemplates([]split.SplitTemplate{*tpl})[0]
+				s.writes++
+				return nil
+			}
+		}
+	}
+	return domainerr.ErrNotFound
+}
+
+type agentSplitUsecase struct {
+	domainuc.SplitUsecase
+	store *agentStore
+}
+
+func (u agentSplitUsecase) GetUserTemplates(_ context.Context, userID string) ([]split.SplitTemplate, error) {
+	return u.store.userTemplates(userID), nil
+}
+
+func (u agentSplitUsecase) UpdateTemplate(_ context.Context, tpl *split.SplitTemplate) error {
+	return u.store.updateTemplate(tpl)
+}
+
+type agentExerciseUsecase struct {
+	domainuc.ExerciseUsecase
+	store *agentStore
+}
+
+func (u agentExerciseUsecase) ListExercises(context.Context) ([]exercise.Exercise, error) {
+	return u.store.exercises, nil
+}
+
+type agentActionRepo struct {
+	domainrepo.AgentActionRepository
+	store *agentStore
+}
+
+func (r agentActionRepo) Create(_ context.Context, action *agent.Action) error {
+	r.store.actions[action.ID] = *action
+	return nil
+}
+
+func (r agentActionRepo) GetByID(_ context.Context, id string) (*agent.Action, error) {
+	action, ok := r.store.actions[id]
+	if !ok {
+		return nil, domainerr.ErrNotFound
+	}
+	return &action, nil
+}
+
+func (r agentActionRepo) Resolve(_ context.Context, id string, status agent.Status, at time.Time) error {
+	action, ok := r.store.actions[id]
+	if !ok || action.Status != agent.StatusPending {
+		return domainerr.ErrConflict
+	}
+	action.Status = status
+	action.ResolvedAt = &at
+	r.store.actions[id] = action
+	return nil
+}
+
+type agentSplitRepo struct {
+	domainrepo.SplitRepository
+	store *agentStore
+}
+
+func (r agentSplitRepo) GetUserTemplates(_ context.Context, userID string) ([]split.SplitTemplate, error) {
+	return r.store.userTemplates(userID), nil
+}
+
+func (r agentSplitRepo) UpdateTemplate(_ context.Context, tpl *split.SplitTemplate) error {
+	return r.store.updateTemplate(tpl)
+}
+
+type agentExerciseRepo struct {
+	domainrepo.ExerciseRepository
+	store *agentStore
+}
+
+func (r agentExerciseRepo) List(context.Context) ([]exercise.Exercise, error) {
+	return r.store.exercises, nil
+}
+
+type agentRegistry struct {
+	domainrepo.Registry
+	store *agentStore
+}
+
+func (r agentRegistry) AgentAction() domainrepo.AgentActionRepository {
+	return agentActionRepo{store: r.store}
+}
+func (r agentRegistry) Split() domainrepo.SplitRepository { return agentSplitRepo{store: r.store} }
+func (r agentRegistry) Exercise() domainrepo.ExerciseRepository {
+	return agentExerciseRepo{store: r.store}
+}
+
+// agentUnitOfWork restores the store when fn fails, like a rolled back
+// transaction.
+type agentUnitOfWork struct{ store *agentStore }
+
+func (u agentUnitOfWork) Do(_ context.Context, fn func(r domainrepo.Registry) error) error {
+	before := u.store.clone()
+	if err := fn(agentRegistry{store: u.store}); err != nil {
+		*u.store = *before
+		return err
+	}
+	return nil
+}
+
+const (
+	athlete = "athlete"
+	someone = "someone-else"
+)
+
+// newAgentFixture gives athlete and someone else one split day each.
+func newAgentFixture() (*agentUsecase, *agentStore) {
+	store := &agentStore{
+		templates: map[string][]split.SplitTemplate{
+			athlete: {{ID: "tpl-a", UserID: athlete, Name: "Full Body", Days: []split.SplitDay{
+				{ID: "day-a", DayOrder: 1, Name: "Day A", Exercises: []split.SplitExercise{{ExerciseID: "squat", ExerciseName: "Back Squat", TargetSets: 3, TargetReps: 5}}},
+			}}},
+			someone: {{ID: "tpl-b", UserID: someone, Name: "Upper", Days: []split.SplitDay{
+				{ID: "day-b", DayOrder: 1, Name: "Day B", Exercises: []split.SplitExercise{{ExerciseID: "bench", ExerciseName: "Bench Press", TargetSets: 3, TargetReps: 8}}},
+			}}},
+		},
+		actions: map[string]agent.Action{},
+		exercises: []exercise.Exercise{
+			{ID: "squat", Name: "Back Squat"},
+			{ID: "bench", Name: "Bench Press"},
+			{ID: "row", Name: "Barbell Row"},
+		},
+	}
+	u := &agentUsecase{
+		actionRepository: agentActionRepo{store: store},
+		exerciseUsecase:  agentExerciseUsecase{store: store},
+		splitUsecase:     agentSplitUsecase{store: store},
+		uow:              agentUnitOfWork{store: store},
+	}
+	return u, store
+}
+
+func updateDayCall(dayID string) orchestrator.ToolCall {
+	return orchestrator.ToolCall{ID: "c1", Name: "update_split_day", Arguments: json.RawMessage(`{
+		"split_day_id": "` + dayID + `",
+		"name": "",
+		"exercises": [
+			{"exercise_id": "squat", "target_sets": 5, "target_reps": 5, "target_weight": 100, "notes": ""},
+			{"exercise_id": "row", "target_sets": 3, "target_reps": 8, "target_weight": 0, "notes": ""}
+		]
+	}`)}
+}
+
+func TestUpdateSplitDayRejectsAnotherUsersDay(t *testing.T) {
+	u, store := newAgentFixture()
+	sb := &toolSandbox{u: u, userID: athlete}
+
+	_, err := sb.Execute(context.Background(), updateDayCall("day-b"))
+	if !errors.Is(err, domainerr.ErrNotFound) {
+		t.Fatalf("Execute error = %v, want ErrNotFound", err)
+	}
+	if len(store.actions) != 0 || len(sb.actions) != 0 {
+		t.Errorf("stored %d actions for someone else's day", len(store.actions))
+	}
+	if got := store.templates[someone][0].Days[0]; got.Exercises[0].TargetSets != 3 {
+		t.Errorf("someone else's day changed: %+v", got)
+	}
+}
+
+func TestUpdateSplitDayIsADryRun(t *testing.T) {
+	u, store := newAgentFixture()
+	sb := &toolSandbox{u: u, userID: athlete}
+	before := store.userTemplates(athlete)
+
+	out, err := sb.Execute(context.Background(), updateDayCall("day-a"))
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if !strings.Contains(out, "awaiting_user_confirmation") {
+		t.Errorf("result %s does not ask for confirmation", out)
+	}
+
+	if store.writes != 0 || !slices.EqualFunc(store.userTemplates(athlete)[0].Days, before[0].Days, func(a, b split.SplitDay) bool {
+		return splitDayVersion(a) == splitDayVersion(b)
+	}) {
+		t.Error("proposing a change wrote the split")
+	}
+	if len(sb.actions) != 1 || len(store.actions) != 1 {
+		t.Fatalf("proposed %d actions, stored %d, want 1", len(sb.actions), len(store.actions))
+	}
+	action := store.actions[sb.actions[0].ID]
+	if action.UserID != athlete || action.Status != agent.StatusPending || action.BaseVersion != splitDayVersion(before[0].Days[0]) {
+		t.Errorf("action = %+v", action)
+	}
+	if len(action.Changes) != 2 {
+		t.Errorf("changes = %+v, want the squat change and the added row", action.Changes)
+	}
+}
+
+func TestConfirmActionAppliesTheProposal(t *testing.T) {
+	u, store := newAgentFixture()
+	sb := &toolSandbox{u: u, userID: athlete}
+	if _, err := sb.Execute(context.Background(), updateDayCall("day-a")); err != nil {
+		t.Fatal(err)
+	}
+
+	action, err := u.ConfirmAction(context.Background(), sb.actions[0].ID)
+	if err != nil {
+		t.Fatalf("ConfirmAction: %v", err)
+	}
+	if action.Status != agent.StatusApplied || store.actions[action.ID].Status != agent.StatusApplied {
+		t.Errorf("action status = %s, stored %s", action.Status, store.actions[action.ID].Status)
+	}
+	day := store.templates[athlete][0].Days[0]
+	if store.writes != 1 || len(day.Exercises) != 2 || day.Exercises[0].TargetSets != 5 || day.Exercises[1].ExerciseID != "row" {
+		t.Errorf("day after confirm = %+v", day)
+	}
+
+	if _, err := u.ConfirmAction(context.Background(), action.ID); !errors.Is(err, domainerr.ErrConflict) {
+		t.Errorf("second confirm error = %v, want ErrConflict", err)
+	}
+}
+
+func TestConfirmActionFailsWhenTheDayChanged(t *testing.T) {
+	u, store := newAgentFixture()
+	sb := &toolSandbox{u: u, userID: athlete}
+	if _, err := sb.Execute(context.Background(), updateDayCall("day-a")); err != nil {
+		t.Fatal(err)
+	}
+
+	// The athlete edits the day by hand after the proposal was made.
+	store.templates[athlete][0].Days[0].Exercises[0].TargetReps = 3
+	edited := store.userTemplates(athlete)[0].Days[0]
+
+	_, err := u.ConfirmAction(context.Background(), sb.actions[0].ID)
+	if !errors.Is(err, domainerr.ErrConflict) {
+		t.Fatalf("ConfirmAction error = %v, want ErrConflict", err)
+	}
+	if got := store.templates[athlete][0].Days[0]; splitDayVersion(got) != splitDayVersion(edited) || store.writes != 0 {
+		t.Errorf("stale proposal overwrote the day: %+v", got)
+	}
+	if status := store.actions[sb.actions[0].ID].Status; status != agent.StatusPending {
+		t.Errorf("action is %s after the failed confirm, want it left pending", status)
+	}
+}
@@ -0,0 +1,191 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"S.P.A.R.T.A/backend/internal/ai/orchestrator"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/agent"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/split"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+	domainrepo "S.P.A.R.T.A/backend/internal/domain/repository"
+	domainuc "S.P.A.R.T.A/backend/internal/domain/usecase"
+	"github.com/google/uuid"
+)
+
+type agentUsecase struct {
+	orchestrator     orchestrator.Orchestrator
+	actionRepository domainrepo.AgentActionRepository
+	workoutUsecase   domainuc.WorkoutUsecase
+	exerciseUsecase  domainuc.ExerciseUsecase
+	splitUsecase     domainuc.SplitUsecase
+	userRepository   domainrepo.UserRepository
+	uow              domainrepo.UnitOfWork
+}
+
+func NewAgentUsecase(
+	orchestrator orchestrator.Orchestrator,
+	actionRepository domainrepo.AgentActionRepository,
+	workoutUsecase domainuc.WorkoutUsecase,
+	exerciseUsecase domainuc.ExerciseUsecase,
+	splitUsecase domainuc.SplitUsecase,
+	userRepository domainrepo.UserRepository,
+	uow domainrepo.UnitOfWork,
+) domainuc.AgentUsecase {
+	return &agentUsecase{
+		orchestrator:     orchestrator,
+		actionRepository: actionRepository,
+		workoutUsecase:   workoutUsecase,
+		exerciseUsecase:  exerciseUsecase,
+		splitUsecase:     splitUsecase,
+		userRepository:   userRepository,
+		uow:              uow,
+	}
+}
+
+func (u *agentUsecase) Run(ctx context.Context, userID uuid.UUID, message string) (*agent.Result, error) {
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return nil, fmt.Errorf("%w: message must not be empty", domainerr.ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(message) > agent.MaxMessageLen {
+		return nil, fmt.Errorf("%w: message must be at most %d characters", domainerr.ErrInvalidInput, agent.MaxMessageLen)
+	}
+
+	templates, err := u.splitUsecase.GetUserTemplates(ctx, userID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	sb := &toolSandbox{u: u, userID: userID.String()}
+	out, err := u.orchestrator.RunAgent(ctx, orchestrator.AgentInput{
+		UserID:   userID.String(),
+		Date:     time.Now().UTC().Format("2006-01-02"),
+		Context:  splitContext(templates),
+		Question: message,
+		Tools:    sb,
+		Voice:    voiceFor(ctx, u.userRepository, userID.String()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &agent.Result{
+		Reply:   out.Reply,
+		Steps:   make([]agent.Step, 0, len(out.Steps)),
+		Actions: sb.actions,
+		Source:  sourceOrAI(out.Source),
+	}
+	if res.Actions == nil {
+		res.Actions = []agent.Action{}
+	}
+	for _, st := range out.Steps {
+		res.Steps = append(res.Steps, agent.Step{Tool: st.Tool, Arguments: st.Arguments, Result: st.Result, Error: st.Error})
+	}
+	return res, nil
+}
+
+// splitContext lists the days of the active split (or the newest one when
+// none is active) so the agent knows which split_day_id to edit.
+func splitContext(templates []split.SplitTemplate) []orchestrator.ContextItem {
+	var tpl *split.SplitTemplate
+	for i := range templates {
+		if templates[i].IsActive {
+			tpl = &templates[i]
+			break
+		}
+	}
+	if tpl == nil && len(templates) > 0 {
+		tpl = &templates[0]
+	}
+	if tpl == nil {
+		return nil
+	}
+
+	state := "inactive"
+	if tpl.IsActive {
+		state = "active"
+	}
+	items := make([]orchestrator.ContextItem, 0, len(tpl.Days))
+	for _, d := range tpl.Days {
+		parts := make([]string, 0, len(d.Exercises))
+		for _, ex := range d.Exercises {
+			parts = append(parts, splitExerciseLabel(ex)+" "+describeSplitExercise(ex))
+		}
+		items = append(items, orchestrator.ContextItem{
+			ID:    "split_day:" + d.ID,
+			Kind:  "split_day",
+			Label: d.Name,
+			Text:  fmt.Sprintf("Day %d %q of %s split %q (split_day_id %s): %s", d.DayOrder, d.Name, state, tpl.Name, d.ID, strings.Join(parts, "; ")),
+		})
+	}
+	return items
+}
+
+func (u *agentUsecase) GetAction(ctx context.Context, id string) (*agent.Action, error) {
+	return u.actionRepository.GetByID(ctx, id)
+}
+
+func (u *agentUsecase) GetActionOwner(ctx context.Context, id string) (string, error) {
+	return u.actionRepository.GetOwnerID(ctx, id)
+}
+
+func (u *agentUsecase) ConfirmAction(ctx context.Context, id string) (*agent.Action, error) {
+	action, err := u.pendingAction(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	tool := findAgentTool(action.Tool)
+	if tool == nil || tool.apply == nil {
+		return nil, fmt.Errorf("%w: action %s has no apply step", domainerr.ErrInternal, action.Tool)
+	}
+
+	// Claiming the action and applying it share one transaction, so a
+	// double confirm applies once and a failed apply leaves it pending.
+	now := time.Now().UTC()
+	err = u.uow.Do(ctx, func(r domainrepo.Registry) error {
+		if err := r.AgentAction().Resolve(ctx, action.ID, agent.StatusApplied, now); err != nil {
+			return err
+		}
+		return tool.apply(ctx, r, *action)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	action.Status = agent.StatusApplied
+	action.ResolvedAt = &now
+	return action, nil
+}
+
+func (u *agentUsecase) RejectAction(ctx context.Context, id string) (*agent.Action, error) {
+	action, err := u.pendingAction(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if err := u.actionRepository.Resolve(ctx, action.ID, agent.StatusRejected, now); err != nil {
+		return nil, err
+	}
+	action.Status = agent.StatusRejected
+	action.ResolvedAt = &now
+	return action, nil
+}
+
+func (u *agentUsecase) pendingAction(ctx context.Context, id string) (*agent.Action, error) {
+	action, err := u.actionRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if action.Status != agent.StatusPending {
+		return nil, fmt.Errorf("%w: action is already %s", domainerr.ErrConflict, action.Status)
+	}
+	if action.Expired(time.Now()) {
+		return nil, fmt.Errorf("%w: action expired, ask the agent again", domainerr.ErrConflict)
+	}
+	return action, nil
+}
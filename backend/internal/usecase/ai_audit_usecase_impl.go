@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/aicall"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+	domainrepo "S.P.A.R.T.A/backend/internal/domain/repository"
+	domainuc "S.P.A.R.T.A/backend/internal/domain/usecase"
+)
+
+type aiAuditUsecase struct {
+	repo  domainrepo.AICallRepository
+	quota aicall.Quota
+}
+
+func NewAIAuditUsecase(repo domainrepo.AICallRepository, quota aicall.Quota) domainuc.AIAuditUsecase {
+	return &aiAuditUsecase{repo: repo, quota: quota}
+}
+
+func (u *aiAuditUsecase) ListCalls(ctx context.Context, filter aicall.Filter) (*aicall.Page, error) {
+	if err := validateAICallFilter(filter); err != nil {
+		return nil, err
+	}
+	return u.repo.List(ctx, filter)
+}
+
+func (u *aiAuditUsecase) GetCallStats(ctx context.Context, filter aicall.Filter, groupBy aicall.GroupBy) ([]aicall.Stat, error) {
+	if err := validateAICallFilter(filter); err != nil {
+		return nil, err
+	}
+	return u.repo.Stats(ctx, filter, groupBy)
+}
+
+func validateAICallFilter(filter aicall.Filter) error {
+	switch filter.Outcome {
+	case "", aicall.OutcomeOK, aicall.OutcomeInvalidOutput, aicall.OutcomeError:
+	default:
+		return fmt.Errorf("%w: unknown outcome %q", domainerr.ErrInvalidInput, filter.Outcome)
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return fmt.Errorf("%w: from must be before to", domainerr.ErrInvalidInput)
+	}
+	return nil
+}
+
+// CheckQuota compares today's usage (UTC) with the configured limits. The
+// global limits are checked first so an exhausted budget is reported the
+// same way to every user.
+func (u *aiAuditUsecase) CheckQuota(ctx context.Context, userID string) error {
+	q := u.quota
+	if q.GlobalDailyCalls <= 0 && q.GlobalDailyTokens <= 0 && q.UserDailyCalls <= 0 && q.UserDailyTokens <= 0 {
+		return nil
+	}
+
+	since := time.Now().UTC().Truncate(24 * time.Hour)
+
+	if q.GlobalDailyCalls > 0 || q.GlobalDailyTokens > 0 {
+		usage, err := u.repo.UsageSince(ctx, "", since)
+		if err != nil {
+			return err
+		}
+		if err := checkLimits("global", usage, q.GlobalDailyCalls, q.GlobalDailyTokens); err != nil {
+			return err
+		}
+	}
+
+	if userID != "" && (q.UserDailyCalls > 0 || q.UserDailyTokens > 0) {
+		usage, err := u.repo.UsageSince(ctx, userID, since)
+		if err != nil {
+			return err
+		}
+		if err := checkLimits("daily", usage, q.UserDailyCalls, q.UserDailyTokens); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkLimits(scope string, usage aicall.Usage, maxCalls, maxTokens int64) error {
+	if maxCalls > 0 && usage.Calls >= maxCalls {
+		return fmt.Errorf("%w: %s AI call limit of %d reached", domainerr.ErrQuotaExceeded, scope, maxCalls)
+	}
+	if maxTokens > 0 && usage.Tokens >= maxTokens {
+		return fmt.Errorf("%w: %s AI token limit of %d reached", domainerr.ErrQuotaExceeded, scope, maxTokens)
+	}
+	return nil
+}
+
+// Record is best-effort: a failed insert is logged and the answer is still
+// returned to the user.
+func (u *aiAuditUsecase) Record(ctx context.Context, call aicall.Call) {
+	if err := u.repo.Create(ctx, &call); err != nil {
+		slog.WarnContext(ctx, "failed to record ai call", "op", call.Operation, "error", err)
+	}
+}
@@ -7,27 +7,36 @@ import (
 	"strconv"
 	"strings"
 	"time"
-	"unicode"
 
 	"S.P.A.R.T.A/backend/internal/ai/orchestrator"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/coach"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/exercise"
 	"S.P.A.R.T.A/backend/internal/domain/aggregate/planner"
 	"S.P.A.R.T.A/backend/internal/domain/aggregate/split"
 	"S.P.A.R.T.A/backend/internal/domain/aggregate/workout"
 	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
 	domainrepo "S.P.A.R.T.A/backend/internal/domain/repository"
+	"S.P.A.R.T.A/backend/internal/domain/service/exercisematch"
 	"S.P.A.R.T.A/backend/internal/domain/service/training"
 	domainuc "S.P.A.R.T.A/backend/internal/domain/usecase"
 	"github.com/google/uuid"
 )
 
+// loadWindowDays is the chronic (28d) window; sessions inside it are the
+// user's recent training. Load models may read further back (HistoryDays).
+const loadWindowDays = 28
+
 type aiCoachUsecase struct {
 	orchestrator        orchestrator.Orchestrator
 	splitRepository     domainrepo.SplitRepository
 	exerciseRepository  domainrepo.ExerciseRepository
 	plannerRepository   domainrepo.PlannerRepository
 	workoutRepository   domainrepo.WorkoutRepository
+	workoutPlanRepo     domainrepo.WorkoutPlanRepository
 	nutritionRepository domainrepo.NutritionRepository
 	motivationRepo      domainrepo.MotivationRepository
+	userRepository      domainrepo.UserRepository
+	uow                 domainrepo.UnitOfWork
 }
 
 func NewAICoachUsecase(
@@ -36,8 +45,11 @@ func NewAICoachUsecase(
 	exerciseRepository domainrepo.ExerciseRepository,
 	plannerRepository domainrepo.PlannerRepository,
 	workoutRepository domainrepo.WorkoutRepository,
+	workoutPlanRepository domainrepo.WorkoutPlanRepository,
 	nutritionRepository domainrepo.NutritionRepository,
 	motivationRepository domainrepo.MotivationRepository,
+	userRepository domainrepo.UserRepository,
+	uow domainrepo.UnitOfWork,
 ) domainuc.AICoachUsecase {
 	return &aiCoachUsecase{
 		orchestrator:        orchestrator,
@@ -45,33 +57,83 @@ func NewAICoachUsecase(
 		exerciseRepository:  exerciseRepository,
 		plannerRepository:   plannerRepository,
 		workoutRepository:   workoutRepository,
+		workoutPlanRepo:     workoutPlanRepository,
 		nutritionRepository: nutritionRepository,
 		motivationRepo:      motivationRepository,
+		userRepository:      userRepository,
+		uow:                 uow,
 	}
 }
 
+// maxPromptLibrary caps how many library names go into the split prompt.
+const maxPromptLibrary = 300
+
+// maxUnresolvedSuggestions bounds the candidates offered per unresolved
+// exercise.
+const maxUnresolvedSuggestions = 3
+
 func (u *aiCoachUsecase) GenerateSplitTemplate(
 	ctx context.Context,
 	userID uuid.UUID,
 	daysPerWeek int,
 	focusMuscle string,
-) (*split.SplitTemplate, error) {
+	overrides split.ConstraintsOverride,
+) (*split.SplitTemplate, []split.UnresolvedExercise, error) {
+	constraints := constraintsFor(ctx, u.userRepository, userID.String())
+	if err := applyConstraintsOverride(&constraints, overrides); err != nil {
+		return nil, nil, err
+	}
+	if n := len(constraints.PreferredDays); n > 0 && daysPerWeek > n {
+		return nil, nil, fmt.Errorf("%w: days_per_week is %d but only %d preferred days are set", domainerr.ErrInvalidInput, daysPerWeek, n)
+	}
+
+	// Best-effort: without a library the split is still generated, just
+	// without exercise ids.
+	var library []exercise.Exercise
+	if u.exerciseRepository != nil {
+		if exs, err := u.exerciseRepository.List(ctx); err == nil {
+			library = exs
+		}
+	}
+	// Drop what the user cannot do before capping, so the cap does not
+	// crowd out the exercises they can.
+	available := orchestrator.AvailableExercises(library, constraints.Equipment)
+	names := make([]string, 0, min(len(available), maxPromptLibrary))
+	for _, ex := range available {
+		if len(names) == maxPromptLibrary {
+			break
+		}
+		names = append(names, ex.Name)
+	}
 
 	aiResult, err := u.orchestrator.GenerateSplit(ctx, orchestrator.SplitInput{
-		UserID:      userID.String(),
-		DaysPerWeek: daysPerWeek,
-		FocusMuscle: focusMuscle,
+		UserID:            userID.String(),
+		DaysPerWeek:       daysPerWeek,
+		ExperienceLevel:   constraints.ExperienceLevel,
+		FocusMuscle:       focusMuscle,
+		Library:           names,
+		Equipment:         constraints.Equipment,
+		MaxSessionMinutes: constraints.MaxSessionMinutes,
+		Injuries:          constraints.Injuries,
+		ExcludedMovements: constraints.ExcludedMovements,
+		PreferredDays:     constraints.PreferredDays,
+		Voice:             voiceFor(ctx, u.userRepository, userID.String()),
 	})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	description := "AI Generated Split"
+	if aiResult.Source == coach.SourceRules {
+		description = "Rule-based Split"
 	}
 
 	template := &split.SplitTemplate{
 		ID:          uuid.NewString(),
 		UserID:      userID.String(),
 		Name:        aiResult.Name,
-		Description: "AI Generated Split",
-		CreatedBy:   "ai",
+		Description: description,
+		CreatedBy:   string(sourceOrAI(aiResult.Source)),
 		FocusMuscle: focusMuscle,
 		IsActive:    false,
 		CreatedAt:   time.Now(),
@@ -102,42 +164,32 @@ func (u *aiCoachUsecase) GenerateSplitTemplate(
 		template.Days = append(template.Days, day)
 	}
 
-	// Best-effort: map AI exercise names to real exercise IDs in our library.
-	// This makes the template immediately usable by downstream endpoints.
-	u.enrichSplitTemplateExerciseIDs(ctx, template)
+	unresolved := resolveSplitExercises(template, library)
 
-	err = u.splitRepository.CreateTemplate(ctx, template)
+	err = u.uow.Do(ctx, func(r domainrepo.Registry) error {
+		return r.Split().CreateTemplate(ctx, template)
+	})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return template, nil
+	return template, unresolved, nil
 }
 
-func (u *aiCoachUsecase) enrichSplitTemplateExerciseIDs(ctx context.Context, tpl *split.SplitTemplate) {
-	if tpl == nil || u.exerciseRepository == nil {
-		return
-	}
-
-	exs, err := u.exerciseRepository.List(ctx)
-	if err != nil {
-		return
-	}
-
-	index := make(map[string]string, len(exs))
-	for _, ex := range exs {
-		key := normalizeExerciseName(ex.Name)
-		if key == "" {
-			continue
-		}
-		if _, exists := index[key]; !exists {
-			index[key] = ex.ID
-		}
+// resolveSplitExercises maps generated exercise names (kept in Notes) to
+// library exercises and returns those below the match threshold. An empty
+// library resolves nothing and reports nothing.
+func resolveSplitExercises(tpl *split.SplitTemplate, library []exercise.Exercise) []split.UnresolvedExercise {
+	if tpl == nil || len(library) == 0 {
+		return nil
 	}
 
+	matcher := exercisematch.NewMatcher(library, 0)
+	var unresolved []split.UnresolvedExercise
 	for di := range tpl.Days {
-		for ei := range tpl.Days[di].Exercises {
-			ex := &tpl.Days[di].Exercises[ei]
+		day := &tpl.Days[di]
+		for ei := range day.Exercises {
+			ex := &day.Exercises[ei]
 			if strings.TrimSpace(ex.ExerciseID) != "" {
 				continue
 			}
@@ -145,36 +197,24 @@ func (u *aiCoachUsecase) enrichSplitTemplateExerciseIDs(ctx context.Context, tpl
 			if name == "" {
 				continue
 			}
-			if id, ok := index[normalizeExerciseName(name)]; ok {
-				ex.ExerciseID = id
+			if c, ok := matcher.Match(name); ok {
+				ex.ExerciseID = c.Exercise.ID
+				ex.ExerciseName = c.Exercise.Name
+				continue
 			}
-		}
-	}
-}
-
-func normalizeExerciseName(name string) string {
-	name = strings.TrimSpace(strings.ToLower(name))
-	if name == "" {
-		return ""
-	}
 
-	var b strings.Builder
-	b.Grow(len(name))
-	lastSpace := false
-	for _, r := range name {
-		if unicode.IsLetter(r) || unicode.IsNumber(r) {
-			b.WriteRune(r)
-			lastSpace = false
-			continue
-		}
-		if !lastSpace {
-			b.WriteByte(' ')
-			lastSpace = true
+			item := split.UnresolvedExercise{DayOrder: day.DayOrder, DayName: day.Name, Name: name}
+			for _, c := range matcher.Suggest(name, maxUnresolvedSuggestions) {
+				item.Suggestions = append(item.Suggestions, split.ExerciseSuggestion{
+					ExerciseID: c.Exercise.ID,
+					Name:       c.Exercise.Name,
+					Score:      c.Score,
+				})
+			}
+			unresolved = append(unresolved, item)
 		}
 	}
-
-	out := strings.TrimSpace(b.String())
-	return out
+	return unresolved
 }
 
 func (u *aiCoachUsecase) SuggestProgressiveOverload(
@@ -184,13 +224,20 @@ func (u *aiCoachUsecase) SuggestProgressiveOverload(
 ) (*planner.PlannerRecommendation, error) {
 	lastWeight, lastReps, perfNotes := u.findLastExercisePerformance(ctx, userID.String(), exerciseID.String())
 
-	result, err := u.orchestrator.SuggestOverload(ctx, orchestrator.OverloadInput{
+	input := orchestrator.OverloadInput{
 		UserID:      userID.String(),
 		ExerciseID:  exerciseID.String(),
 		LastWeight:  lastWeight,
 		LastReps:    lastReps,
 		Performance: perfNotes,
-	})
+		Voice:       voiceFor(ctx, u.userRepository, userID.String()),
+	}
+	if c, ok := u.lastPlanComparison(ctx, userID.String(), exerciseID.String()); ok {
+		input.PlanOutcome = c.Outcome
+		input.PlanComparison = c.Summary()
+	}
+
+	result, err := u.orchestrator.SuggestOverload(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -200,6 +247,7 @@ func (u *aiCoachUsecase) SuggestProgressiveOverload(
 		UserID:             userID.String(),
 		Recommendation:     result.Message,
 		RecommendationType: "progressive_overload",
+		Source:             sourceOrAI(result.Source),
 		CreatedAt:          time.Now(),
 	}
 
@@ -211,23 +259,50 @@ func (u *aiCoachUsecase) SuggestProgressiveOverload(
 	return rec, nil
 }
 
-func (u *aiCoachUsecase) GetDailyMotivation(ctx context.Context, userID uuid.UUID) (string, error) {
+// lastPlanComparison compares the newest completed planned session with the
+// exercise against its plan. Lookup failures only mean there is nothing to
+// compare.
+func (u *aiCoachUsecase) lastPlanComparison(ctx context.Context, userID, exerciseID string) (workout.ExerciseComparison, bool) {
+	if u.workoutPlanRepo == nil {
+		return workout.ExerciseComparison{}, false
+	}
+	plan, err := u.workoutPlanRepo.LatestCompletedWithExercise(ctx, userID, exerciseID)
+	if err != nil || plan.SessionID == nil {
+		return workout.ExerciseComparison{}, false
+	}
+	session, err := u.workoutRepository.GetSessionByID(ctx, *plan.SessionID)
+	if err != nil {
+		return workout.ExerciseComparison{}, false
+	}
+
+	for _, c := range workout.ComparePlan(*plan, *session, nil).Exercises {
+		if c.ExerciseID == exerciseID {
+			return c, true
+		}
+	}
+	return workout.ExerciseComparison{}, false
+}
+
+func (u *aiCoachUsecase) GetDailyMotivation(ctx context.Context, userID uuid.UUID) (*coach.Motivation, error) {
 	now := time.Now().UTC()
 	dateStr := now.Format("2006-01-02")
+	voice := voiceFor(ctx, u.userRepository, userID.String())
 
+	// Only AI answers are cached, so a cache hit is always an AI message.
+	// The cache is per voice, so changing persona takes effect at once.
 	if u.motivationRepo != nil {
-		msg, found, err := u.motivationRepo.GetDailyMotivation(ctx, userID.String(), now)
+		msg, found, err := u.motivationRepo.GetDailyMotivation(ctx, userID.String(), voice.Key(), now)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 		if found {
-			return msg, nil
+			return &coach.Motivation{Message: msg, Source: coach.SourceAI}, nil
 		}
 	}
 
 	sessions, err := u.workoutRepository.GetSessionsByUser(ctx, userID.String())
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	last7Days := 0
@@ -254,26 +329,29 @@ func (u *aiCoachUsecase) GetDailyMotivation(ctx context.Context, userID uuid.UUI
 		LastWorkoutDate:     lastWorkoutDate,
 		LastWorkoutDuration: lastWorkoutDuration,
 		LastWorkoutNotes:    lastWorkoutNotes,
+		Voice:               voice,
 	})
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	msg := strings.TrimSpace(aiOut.Message)
 	if msg == "" {
-		return "", domainerr.ErrInternal
+		return nil, domainerr.ErrInternal
 	}
+	source := sourceOrAI(aiOut.Source)
 
-	if u.motivationRepo != nil {
+	// Rule-based messages are not cached so the next request retries the AI.
+	if u.motivationRepo != nil && source == coach.SourceAI {
 		next := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
 		ttl := next.Sub(now)
 		if ttl < 10*time.Second {
 			ttl = 24 * time.Hour
 		}
-		_ = u.motivationRepo.SetDailyMotivation(ctx, userID.String(), now, msg, ttl)
+		_ = u.motivationRepo.SetDailyMotivation(ctx, userID.String(), voice.Key(), now, msg, ttl)
 	}
 
-	return msg, nil
+	return &coach.Motivation{Message: msg, Source: source}, nil
 }
 
 func (u *aiCoachUsecase) ResetDailyMotivation(ctx context.Context, userID uuid.UUID) error {
@@ -281,7 +359,8 @@ func (u *aiCoachUsecase) ResetDailyMotivation(ctx context.Context, userID uuid.U
 		return nil
 	}
 	now := time.Now().UTC()
-	return u.motivationRepo.DeleteDailyMotivation(ctx, userID.String(), now)
+	voice := voiceFor(ctx, u.userRepository, userID.String())
+	return u.motivationRepo.DeleteDailyMotivation(ctx, userID.String(), voice.Key(), now)
 }
 
 func (u *aiCoachUsecase) GenerateWorkoutPlan(
@@ -294,14 +373,15 @@ func (u *aiCoachUsecase) GenerateWorkoutPlan(
 		return nil, domainerr.ErrInvalidInput
 	}
 
-	sessions, err := u.workoutRepository.GetSessionsByUser(ctx, userID.String())
+	now := time.Now().UTC()
+	model := loadModelFor(ctx, u.userRepository, userID.String())
+	history, sessions, err := loadHistory(ctx, u.workoutRepository, model, userID.String(), now)
 	if err != nil {
 		return nil, err
 	}
 
 	lastVolume := estimateLastVolume(sessions)
-	now := time.Now().UTC()
-	loadSum := training.ComputeLoadSummary(sessions, now)
+	loadSum := model.Summarize(history, now)
 	fatigueEstimated := training.EstimateFatigueScore(loadSum, fatigue)
 
 	splitDay, err := u.splitRepository.GetSplitDayByID(ctx, splitDayID.String())
@@ -310,6 +390,9 @@ func (u *aiCoachUsecase) GenerateWorkoutPlan(
 	}
 
 	plannedExercises := make([]string, 0)
+	// plannedIDs resolves generated names that repeat the split day's
+	// exercises; the rest go through the library matcher.
+	plannedIDs := make(map[string]string)
 	for _, ex := range splitDay.Exercises {
 		if ex.ExerciseID != "" && u.exerciseRepository != nil {
 			dbEx, err := u.exerciseRepository.GetByID(ctx, ex.ExerciseID)
@@ -322,6 +405,7 @@ func (u *aiCoachUsecase) GenerateWorkoutPlan(
 			name := strings.TrimSpace(dbEx.Name)
 			if name != "" {
 				plannedExercises = append(plannedExercises, name)
+				plannedIDs[exercisematch.Normalize(name)] = dbEx.ID
 			}
 			continue
 		}
@@ -338,29 +422,63 @@ func (u *aiCoachUsecase) GenerateWorkoutPlan(
 		SplitDayName:     splitDay.Name,
 		PlannedExercises: plannedExercises,
 		Fatigue:          fatigue,
+		LoadModel:        string(loadSum.Model),
+		LoadUnit:         loadSum.Unit,
 		AcuteLoad7d:      loadSum.AcuteLoad7d,
 		ChronicLoad28d:   loadSum.ChronicLoad28d,
 		ACWR:             loadSum.ACWR,
 		FatigueEstimated: fatigueEstimated,
 		LastVolume:       lastVolume,
+		Voice:            voiceFor(ctx, u.userRepository, userID.String()),
 	})
 	if err != nil {
 		return nil, err
 	}
 
 	plan := &workout.WorkoutPlan{
-		UserID:     userID.String(),
-		SplitDayID: splitDayID.String(),
-		Date:       time.Now().UTC(),
+		ID:            uuid.NewString(),
+		UserID:        userID.String(),
+		SplitDayID:    splitDayID.String(),
+		SplitDayName:  splitDay.Name,
+		Fatigue:       fatigue,
+		Date:          time.Now().UTC(),
+		Source:        sourceOrAI(aiOut.Source),
+		PromptVersion: aiOut.PromptVersion,
+	}
+
+	var matcher *exercisematch.Matcher
+	if u.exerciseRepository != nil {
+		if library, err := u.exerciseRepository.List(ctx); err == nil && len(library) > 0 {
+			matcher = exercisematch.NewMatcher(library, 0)
+		}
 	}
 
 	for _, ex := range aiOut.Exercises {
-		plan.Exercises = append(plan.Exercises, workout.WorkoutPlanExercise{
-			Name:     ex.Name,
-			Sets:     ex.Sets,
-			RepRange: ex.RepRange,
-			Weight:   ex.Weight,
-		})
+		pe := workout.WorkoutPlanExercise{
+			ExerciseID: plannedIDs[exercisematch.Normalize(ex.Name)],
+			Name:       ex.Name,
+			Sets:       ex.Sets,
+			RepRange:   ex.RepRange,
+			Weight:     ex.Weight,
+		}
+		if pe.ExerciseID == "" && matcher != nil {
+			if c, ok := matcher.Match(ex.Name); ok {
+				pe.ExerciseID = c.Exercise.ID
+			}
+		}
+		if lo, hi, ok := orchestrator.ParseRepRange(ex.RepRange); ok {
+			pe.RepsMin, pe.RepsMax = lo, hi
+		} else {
+			reps := parseRepRange(ex.RepRange)
+			pe.RepsMin, pe.RepsMax = reps, reps
+		}
+		plan.Exercises = append(plan.Exercises, pe)
+	}
+
+	if u.workoutPlanRepo != nil {
+		if err := u.workoutPlanRepo.Create(ctx, plan); err != nil {
+			return nil, err
+		}
 	}
 
 	return plan, nil
@@ -424,10 +542,11 @@ func estimateLastVolume(sessions []workout.WorkoutSession) int {
 }
 
 func (u *aiCoachUsecase) findLastExercisePerformance(ctx context.Context, userID, exerciseID string) (lastWeight float64, lastReps int, notes string) {
-	sessions, err := u.workoutRepository.GetSessionsByUser(ctx, userID)
+	page, err := u.workoutRepository.ListSessionsByUser(ctx, userID, workout.SessionFilter{ExerciseID: exerciseID, Status: workout.SessionStatusCompleted, Limit: 1})
 	if err != nil {
 		return 0, 0, ""
 	}
+	sessions := page.Items
 
 	for _, sess := range sessions {
 		for _, ex := range sess.Exercises {
@@ -451,19 +570,48 @@ func (u *aiCoachUsecase) findLastExercisePerformance(ctx context.Context, userID
 	return 0, 0, ""
 }
 
-func (u *aiCoachUsecase) GetCoachingSuggestions(ctx context.Context, userID uuid.UUID) ([]string, error) {
+func (u *aiCoachUsecase) GetCoachingSuggestions(ctx context.Context, userID uuid.UUID) (*coach.CoachingSuggestions, error) {
+	input, err := u.buildCoachingInput(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := u.orchestrator.GenerateCoachingSuggestions(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return &coach.CoachingSuggestions{Suggestions: out.Suggestions, Source: sourceOrAI(out.Source), PromptVariant: out.PromptVersion}, nil
+}
+
+func (u *aiCoachUsecase) StreamCoachingSuggestions(ctx context.Context, userID uuid.UUID, onDelta func(string) error) (*coach.CoachingSuggestions, error) {
+	input, err := u.buildCoachingInput(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := u.orchestrator.StreamCoachingSuggestions(ctx, input, onDelta)
+	if err != nil {
+		return nil, err
+	}
+
+	return &coach.CoachingSuggestions{Suggestions: out.Suggestions, Source: sourceOrAI(out.Source), PromptVariant: out.PromptVersion}, nil
+}
+
+func (u *aiCoachUsecase) buildCoachingInput(ctx context.Context, userID uuid.UUID) (orchestrator.CoachingInput, error) {
 	now := time.Now().UTC()
 	dateStr := now.Format("2006-01-02")
 
-	sessions, err := u.workoutRepository.GetSessionsByUser(ctx, userID.String())
+	model := loadModelFor(ctx, u.userRepository, userID.String())
+	history, sessions, err := loadHistory(ctx, u.workoutRepository, model, userID.String(), now)
 	if err != nil {
-		return nil, err
+		return orchestrator.CoachingInput{}, err
 	}
-	loadSum := training.ComputeLoadSummary(sessions, now)
+	loadSum := model.Summarize(history, now)
 
 	workoutsSummary := ""
 	if len(sessions) == 0 {
-		workoutsSummary = "(no workouts logged)"
+		workoutsSummary = "(no workouts logged in the last 28 days)"
 	} else {
 		last := sessions[0]
 		workoutsSummary = strings.TrimSpace(last.SessionDate.Format("2006-01-02") + " | " + last.Notes)
@@ -474,7 +622,7 @@ func (u *aiCoachUsecase) GetCoachingSuggestions(ctx context.Context, userID uuid
 		n, err := u.nutritionRepository.GetByDate(ctx, userID.String(), dateStr)
 		if err != nil {
 			if !errors.Is(err, domainerr.ErrNotFound) {
-				return nil, err
+				return orchestrator.CoachingInput{}, err
 			}
 		} else if n != nil {
 			nutritionSummary = "protein=" + strconv.Itoa(n.ProteinGrams) + "g calories=" + strconv.Itoa(n.Calories) + " notes=" + strings.TrimSpace(n.Notes)
@@ -486,7 +634,7 @@ func (u *aiCoachUsecase) GetCoachingSuggestions(ctx context.Context, userID uuid
 
 	recs, err := u.plannerRepository.GetUserRecommendations(ctx, userID.String())
 	if err != nil {
-		return nil, err
+		return orchestrator.CoachingInput{}, err
 	}
 	recsSummary := ""
 	if len(recs) > 0 {
@@ -496,30 +644,60 @@ func (u *aiCoachUsecase) GetCoachingSuggestions(ctx context.Context, userID uuid
 		recsSummary = "(no recommendations yet)"
 	}
 
-	out, err := u.orchestrator.GenerateCoachingSuggestions(ctx, orchestrator.CoachingInput{
+	return orchestrator.CoachingInput{
 		UserID:            userID.String(),
 		Date:              dateStr,
+		LoadModel:         string(loadSum.Model),
+		LoadUnit:          loadSum.Unit,
 		AcuteLoad7d:       loadSum.AcuteLoad7d,
 		ChronicLoad28d:    loadSum.ChronicLoad28d,
 		ACWR:              loadSum.ACWR,
 		RecentWorkouts:    workoutsSummary,
 		RecentNutrition:   nutritionSummary,
 		RecentPlannerRecs: recsSummary,
-	})
+		Voice:             voiceFor(ctx, u.userRepository, userID.String()),
+	}, nil
+}
+
+func (u *aiCoachUsecase) ExplainWorkoutPlan(
+	ctx context.Context,
+	userID uuid.UUID,
+	plan workout.WorkoutPlan,
+	splitDayName string,
+	fatigue int,
+) (*workout.WorkoutExplanation, error) {
+	input := buildExplainInput(userID, plan, splitDayName, fatigue)
+	input.Voice = voiceFor(ctx, u.userRepository, userID.String())
+	out, err := u.orchestrator.ExplainWorkoutPlan(ctx, input)
 	if err != nil {
 		return nil, err
 	}
-
-	return out.Suggestions, nil
+	return toWorkoutExplanation(out), nil
 }
 
-func (u *aiCoachUsecase) ExplainWorkoutPlan(
+func (u *aiCoachUsecase) StreamExplainWorkoutPlan(
 	ctx context.Context,
 	userID uuid.UUID,
 	plan workout.WorkoutPlan,
 	splitDayName string,
 	fatigue int,
+	onDelta func(string) error,
 ) (*workout.WorkoutExplanation, error) {
+	input := buildExplainInput(userID, plan, splitDayName, fatigue)
+	input.Voice = voiceFor(ctx, u.userRepository, userID.String())
+	out, err := u.orchestrator.StreamExplainWorkoutPlan(ctx, input, onDelta)
+	if err != nil {
+		return nil, err
+	}
+	return toWorkoutExplanation(out), nil
+}
+
+func buildExplainInput(
+	userID uuid.UUID,
+	plan workout.WorkoutPlan,
+	splitDayName string,
+	fatigue int,
+) orchestrator.ExplainWorkoutPlanInput {
 	ex := make([]orchestrator.ExplainWorkoutExercise, 0, len(plan.Exercises))
 	for _, e := range plan.Exercises {
 		ex = append(ex, orchestrator.ExplainWorkoutExercise{
@@ -530,22 +708,32 @@ func (u *aiCoachUsecase) ExplainWorkoutPlan(
 		})
 	}
 
-	out, err := u.orchestrator.ExplainWorkoutPlan(ctx, orchestrator.ExplainWorkoutPlanInput{
+	return orchestrator.ExplainWorkoutPlanInput{
 		UserID:       userID.String(),
 		SplitDayName: splitDayName,
 		Fatigue:      fatigue,
 		Exercises:    ex,
-	})
-	if err != nil {
-		return nil, err
 	}
+}
 
-	resp := &workout.WorkoutExplanation{Summary: strings.TrimSpace(out.Summary)}
+func toWorkoutExplanation(out *orchestrator.ExplainWorkoutPlanOutput) *workout.WorkoutExplanation {
+	resp := &workout.WorkoutExplanation{
+		Summary: strings.TrimSpace(out.Summary),
+		Source:  sourceOrAI(out.Source),
+	}
 	for _, n := range out.ExerciseNotes {
 		resp.ExerciseNotes = append(resp.ExerciseNotes, workout.WorkoutExerciseNote{
 			Name: strings.TrimSpace(n.Name),
 			Note: strings.TrimSpace(n.Note),
 		})
 	}
-	return resp, nil
+	return resp
+}
+
+// sourceOrAI defaults an untagged orchestrator answer to the AI source.
+func sourceOrAI(s coach.Source) coach.Source {
+	if s == "" {
+		return coach.SourceAI
+	}
+	return s
 }
@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/exercise"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+	domainrepo "S.P.A.R.T.A/backend/internal/domain/repository"
+	"S.P.A.R.T.A/backend/internal/domain/service/training"
+	domainuc "S.P.A.R.T.A/backend/internal/domain/usecase"
+)
+
+const (
+	defaultAnalyticsWindowDays = 90
+	maxAnalyticsWindowDays     = 730
+	defaultMuscleVolumeWeeks   = 4
+	maxMuscleVolumeWindowDays  = 371
+)
+
+type analyticsUsecase struct {
+	workoutRepo    domainrepo.WorkoutRepository
+	exerciseRepo   domainrepo.ExerciseRepository
+	defaultFormula training.OneRepMaxFormula
+	muscleCfg      training.MuscleVolumeConfig
+}
+
+func NewAnalyticsUsecase(
+	workoutRepo domainrepo.WorkoutRepository,
+	exerciseRepo domainrepo.ExerciseRepository,
+	defaultFormula training.OneRepMaxFormula,
+	muscleCfg training.MuscleVolumeConfig,
+) domainuc.AnalyticsUsecase {
+	return &analyticsUsecase{
+		workoutRepo:    workoutRepo,
+		exerciseRepo:   exerciseRepo,
+		defaultFormula: defaultFormula,
+		muscleCfg:      muscleCfg,
+	}
+}
+
+// GetExerciseProgression defaults to the last 90 days when no range is given
+// and rejects ranges longer than two years.
+func (u *analyticsUsecase) GetExerciseProgression(
+	ctx context.Context,
+	userID string,
+	exerciseID string,
+	from time.Time,
+	to time.Time,
+	bucket training.Bucket,
+	formula training.OneRepMaxFormula,
+) (*training.ExerciseProgression, error) {
+	if userID == "" || exerciseID == "" {
+		return nil, domainerr.ErrInvalidInput
+	}
+
+	if to.IsZero() {
+		to = time.Now().UTC()
+	}
+	if from.IsZero() {
+		from = to.AddDate(0, 0, -defaultAnalyticsWindowDays)
+	}
+	if from.After(to) || to.Sub(from) > maxAnalyticsWindowDays*24*time.Hour {
+		return nil, domainerr.ErrInvalidInput
+	}
+
+	if bucket == "" {
+		bucket = training.BucketWeek
+	}
+	if formula == "" {
+		formula = u.defaultFormula
+	}
+
+	sessions, err := u.workoutRepo.GetSessionsByUserInRange(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	progression := training.ComputeExerciseProgression(sessions, exerciseID, bucket, formula)
+	return &progression, nil
+}
+
+// GetMuscleVolumeReport defaults to the last four full-or-partial weeks and
+// rejects ranges longer than a year.
+func (u *analyticsUsecase) GetMuscleVolumeReport(
+	ctx context.Context,
+	userID string,
+	from time.Time,
+	to time.Time,
+) (*training.MuscleVolumeReport, error) {
+	if userID == "" {
+		return nil, domainerr.ErrInvalidInput
+	}
+
+	if to.IsZero() {
+		to = time.Now().UTC()
+	}
+	if from.IsZero() {
+		from = training.BucketStart(to, training.BucketWeek).AddDate(0, 0, -7*(defaultMuscleVolumeWeeks-1))
+	}
+	if from.After(to) || to.Sub(from) > maxMuscleVolumeWindowDays*24*time.Hour {
+		return nil, domainerr.ErrInvalidInput
+	}
+
+	sessions, err := u.workoutRepo.GetSessionsByUserInRange(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	exerciseIDs := make([]string, 0)
+	seen := make(map[string]struct{})
+	for _, s := range sessions {
+		for _, id := range sessionExerciseIDs(s) {
+			if _, ok := seen[id]; ok {
+				continue
+			}
+			seen[id] = struct{}{}
+			exerciseIDs = append(exerciseIDs, id)
+		}
+	}
+
+	items, err := u.exerciseRepo.GetByIDs(ctx, exerciseIDs)
+	if err != nil {
+		return nil, err
+	}
+	exercises := make(map[string]exercise.Exercise, len(items))
+	for _, ex := range items {
+		exercises[ex.ID] = ex
+	}
+
+	report := training.ComputeWeeklyMuscleVolume(sessions, exercises, u.muscleCfg)
+	return &report, nil
+}
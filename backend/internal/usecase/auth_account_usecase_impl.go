@@ -0,0 +1,187 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/client"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/auth"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/user"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+	domainrepo "S.P.A.R.T.A/backend/internal/domain/repository"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const minPasswordLen = 8
+
+func (u *authUsecase) RequestPasswordReset(ctx context.Context, email string) error {
+	email = strings.TrimSpace(strings.ToLower(email))
+	if email == "" {
+		return domainerr.ErrInvalidInput
+	}
+
+	usr, err := u.userRepo.GetByEmail(ctx, email)
+	if errors.Is(err, domainerr.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	raw, err := u.issueAccountToken(ctx, usr.ID, auth.TokenPurposePasswordReset, u.accountMail.PasswordResetTTL)
+	if err != nil {
+		return err
+	}
+
+	link := u.accountLink("/reset-password", raw)
+	err = u.send(ctx, usr.Email, "Reset your S.P.A.R.T.A password", fmt.Sprintf(
+		"Hi %s,\n\nSomeone asked to reset the password of your S.P.A.R.T.A account. To choose a new one, open:\n\n%s\n\nThe link works once and expires in %s. If it wasn't you, ignore this email; your password stays the same.\n",
+		usr.Name, link, humanDuration(u.accountMail.PasswordResetTTL),
+	))
+	if err != nil {
+		// Answer as for an unknown email; the user can ask again.
+		slog.WarnContext(ctx, "failed to send password reset email", "user_id", usr.ID, "error", err)
+	}
+	return nil
+}
+
+func (u *authUsecase) ResetPassword(ctx context.Context, token, newPassword string) error {
+	token = strings.TrimSpace(token)
+	newPassword = strings.TrimSpace(newPassword)
+	if token == "" || len(newPassword) < minPasswordLen {
+		return domainerr.ErrInvalidInput
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return domainerr.ErrInternal
+	}
+
+	now := time.Now().UTC()
+	var userID string
+	err = u.uow.Do(ctx, func(r domainrepo.Registry) error {
+		t, err := r.AccountToken().Consume(ctx, auth.TokenPurposePasswordReset, hashToken(token), now)
+		if err != nil {
+			return err
+		}
+		userID = t.UserID
+		if err := r.User().UpdatePassword(ctx, t.UserID, string(hash), now); err != nil {
+			return err
+		}
+		// The link reached the inbox, which proves the address as well.
+		if err := r.User().MarkEmailVerified(ctx, t.UserID, now); err != nil {
+			return err
+		}
+		return r.AccountToken().InvalidateUserTokens(ctx, t.UserID, auth.TokenPurposePasswordReset, now)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Whoever knew the old password must not stay signed in.
+	return u.RevokeAllSessions(ctx, userID)
+}
+
+func (u *authUsecase) SendVerificationEmail(ctx context.Context, userID string) error {
+	usr, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if usr.EmailVerified() {
+		return domainerr.ErrConflict
+	}
+	if err := u.sendVerification(ctx, usr); err != nil {
+		slog.WarnContext(ctx, "failed to send verification email", "user_id", usr.ID, "error", err)
+		return domainerr.ErrInternal
+	}
+	return nil
+}
+
+func (u *authUsecase) VerifyEmail(ctx context.Context, token string) error {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return domainerr.ErrInvalidInput
+	}
+
+	now := time.Now().UTC()
+	return u.uow.Do(ctx, func(r domainrepo.Registry) error {
+		t, err := r.AccountToken().Consume(ctx, auth.TokenPurposeEmailVerification, hashToken(token), now)
+		if err != nil {
+			return err
+		}
+		if err := r.User().MarkEmailVerified(ctx, t.UserID, now); err != nil {
+			return err
+		}
+		return r.AccountToken().InvalidateUserTokens(ctx, t.UserID, auth.TokenPurposeEmailVerification, now)
+	})
+}
+
+func (u *authUsecase) sendVerification(ctx context.Context, usr *user.User) error {
+	raw, err := u.issueAccountToken(ctx, usr.ID, auth.TokenPurposeEmailVerification, u.accountMail.VerificationTTL)
+	if err != nil {
+		return err
+	}
+
+	link := u.accountLink("/verify-email", raw)
+	return u.send(ctx, usr.Email, "Verify your S.P.A.R.T.A email", fmt.Sprintf(
+		"Hi %s,\n\nConfirm this address to unlock the AI coach:\n\n%s\n\nThe link expires in %s.\n",
+		usr.Name, link, humanDuration(u.accountMail.VerificationTTL),
+	))
+}
+
+// issueAccountToken replaces any outstanding token of the same purpose, so
+// only the latest mailed link works.
+func (u *authUsecase) issueAccountToken(ctx context.Context, userID, purpose string, ttl time.Duration) (string, error) {
+	raw, err := newOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now().UTC()
+	err = u.uow.Do(ctx, func(r domainrepo.Registry) error {
+		if err := r.AccountToken().InvalidateUserTokens(ctx, userID, purpose, now); err != nil {
+			return err
+		}
+		return r.AccountToken().Create(ctx, &auth.AccountToken{
+			ID:        uuid.NewString(),
+			UserID:    userID,
+			Purpose:   purpose,
+			TokenHash: hashToken(raw),
+			ExpiresAt: now.Add(ttl),
+			CreatedAt: now,
+		})
+	})
+	if err != nil {
+		return "", err
+	}
+	return raw, nil
+}
+
+func (u *authUsecase) accountLink(path, token string) string {
+	return strings.TrimRight(u.accountMail.AppURL, "/") + path + "?token=" + url.QueryEscape(token)
+}
+
+func (u *authUsecase) send(ctx context.Context, to, subject, body string) error {
+	if u.mailer == nil {
+		return errors.New("no mailer configured")
+	}
+	return u.mailer.Send(ctx, client.MailMessage{To: to, Subject: subject, Body: body})
+}
+
+// humanDuration renders a link lifetime for an email, e.g. "1 hour".
+func humanDuration(d time.Duration) string {
+	n, unit := int(d/time.Minute), "minute"
+	if d >= time.Hour && d%time.Hour == 0 {
+		n, unit = int(d/time.Hour), "hour"
+	}
+	if n == 1 {
+		return "1 " + unit
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
@@ -0,0 +1,214 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/client"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/auth"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/user"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type authAccountTokenRepo struct{ store *authStore }
+
+func (r authAccountTokenRepo) Create(_ context.Context, token *auth.AccountToken) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.accountTokens[token.TokenHash] = *token
+	return nil
+}
+
+func (r authAccountTokenRepo) Consume(_ context.Context, purpose, tokenHash string, at time.Time) (*auth.AccountToken, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	t, ok := r.store.accountTokens[tokenHash]
+	if !ok || t.Purpose != purpose || t.UsedAt != nil || !at.Before(t.ExpiresAt) {
+		return nil, domainerr.ErrInvalidInput
+	}
+	t.UsedAt = &at
+	r.store.accountTokens[tokenHash] = t
+	return &t, nil
+}
+
+func (r authAccountTokenRepo) InvalidateUserTokens(_ context.Context, userID, purpose string, at time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	for hash, t := range r.store.accountTokens {
+		if t.UserID == userID && t.Purpose == purpose && t.UsedAt == nil {
+			t.UsedAt = &at
+			r.store.accountTokens[hash] = t
+		}
+	}
+	return nil
+}
+
+const testAppURL = "https://app.example"
+
+// withOutbox makes u mail into a fresh outbox directory and returns it.
+func withOutbox(t *testing.T, u *authUsecase) string {
+	t.Helper()
+	dir := t.TempDir()
+	u.mailer = client.NewOutboxMailer(dir, "S.P.A.R.T.A <noreply@example.com>")
+	u.accountMail.AppURL = testAppURL
+	return dir
+}
+
+// mailedToken reads the token from the link to path in the newest mail of
+// the outbox.
+func mailedToken(t *testing.T, outbox, path string) string {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join(outbox, "*.eml"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("no mail in the outbox (%v)", err)
+	}
+	sort.Strings(files)
+	data, err := os.ReadFile(files[len(files)-1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range strings.Split(string(data), "\r\n") {
+		if !strings.HasPrefix(line, testAppURL+path+"?") {
+			continue
+		}
+		link, err := url.Parse(line)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return link.Query().Get("token")
+	}
+	t.Fatalf("no %s link in:\n%s", path, data)
+	return ""
+}
+
+// addUser stores a user who signs in with password.
+func addUser(t *testing.T, store *authStore, id, password string) {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.users[id] = user.User{ID: id, Name: "Ada", Email: id + "@example.com", PasswordHash: string(hash), Role: "user"}
+}
+
+func passwordIs(store *authStore, id, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(store.users[id].PasswordHash), []byte(password)) == nil
+}
+
+func TestResetTokenIsSingleUse(t *testing.T) {
+	u, store := newAuthFixture(t)
+	outbox := withOutbox(t, u)
+	addUser(t, store, "u1", "old password")
+
+	if err := u.RequestPasswordReset(context.Background(), "U1@example.com "); err != nil {
+		t.Fatal(err)
+	}
+	token := mailedToken(t, outbox, "/reset-password")
+
+	if err := u.ResetPassword(context.Background(), token, "new password"); err != nil {
+		t.Fatalf("ResetPassword: %v", err)
+	}
+	if !passwordIs(store, "u1", "new password") || !store.users["u1"].EmailVerified() {
+		t.Error("the reset did not set the password and verify the email")
+	}
+
+	if err := u.ResetPassword(context.Background(), token, "third password"); !errors.Is(err, domainerr.ErrInvalidInput) {
+		t.Errorf("second ResetPassword error = %v, want ErrInvalidInput", err)
+	}
+	if !passwordIs(store, "u1", "new password") {
+		t.Error("a used link changed the password again")
+	}
+}
+
+func TestNewAccountTokenInvalidatesOlderOnes(t *testing.T) {
+	u, store := newAuthFixture(t)
+	outbox := withOutbox(t, u)
+	addUser(t, store, "u1", "old password")
+
+	if err := u.RequestPasswordReset(context.Background(), "u1@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	older := mailedToken(t, outbox, "/reset-password")
+	if err := u.RequestPasswordReset(context.Background(), "u1@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	newer := mailedToken(t, outbox, "/reset-password")
+	if older == newer {
+		t.Fatal("both mails carry the same token")
+	}
+
+	if err := u.ResetPassword(context.Background(), older, "new password"); !errors.Is(err, domainerr.ErrInvalidInput) {
+		t.Errorf("ResetPassword with the older link error = %v, want ErrInvalidInput", err)
+	}
+	if err := u.ResetPassword(context.Background(), newer, "new password"); err != nil {
+		t.Errorf("ResetPassword with the newer link: %v", err)
+	}
+}
+
+func TestResetPasswordRevokesAllSessions(t *testing.T) {
+	u, store := newAuthFixture(t)
+	outbox := withOutbox(t, u)
+	addUser(t, store, "u1", "old password")
+	phone := signIn(t, u, store, "u1")
+	laptop := signIn(t, u, store, "u1")
+
+	if err := u.RequestPasswordReset(context.Background(), "u1@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if err := u.ResetPassword(context.Background(), mailedToken(t, outbox, "/reset-password"), "new password"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, s := range []string{phone.SessionID, laptop.SessionID} {
+		if store.sessions[s].RevokedAt == nil || !store.revoked[s] {
+			t.Errorf("session %s survived the reset", s)
+		}
+	}
+	if store.versions["u1"] == 0 {
+		t.Error("the token version was not bumped")
+	}
+	if _, err := u.Refresh(context.Background(), phone.RefreshToken); !errors.Is(err, domainerr.ErrUnauthorized) {
+		t.Errorf("Refresh after the reset error = %v, want ErrUnauthorized", err)
+	}
+}
+
+func TestRegisterEnforcesMinPasswordLen(t *testing.T) {
+	u, store := newAuthFixture(t)
+	outbox := withOutbox(t, u)
+
+	for _, password := range []string{"", "short", "   seven   "} {
+		if _, err := u.Register(context.Background(), "Ada", "ada@example.com", password, "", auth.Client{}); !errors.Is(err, domainerr.ErrInvalidInput) {
+			t.Errorf("Register with %q error = %v, want ErrInvalidInput", password, err)
+		}
+	}
+	if len(store.users) != 0 {
+		t.Fatalf("short passwords created %d users", len(store.users))
+	}
+
+	res, err := u.Register(context.Background(), "Ada", "ada@example.com", "long enough", "", auth.Client{})
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if res.EmailVerified {
+		t.Error("a new account starts verified")
+	}
+
+	// The verification mail sent on registration proves the address.
+	if err := u.VerifyEmail(context.Background(), mailedToken(t, outbox, "/verify-email")); err != nil {
+		t.Fatalf("VerifyEmail: %v", err)
+	}
+	if !store.users[res.UserID].EmailVerified() {
+		t.Error("the email is still unverified")
+	}
+	if err := u.SendVerificationEmail(context.Background(), res.UserID); !errors.Is(err, domainerr.ErrConflict) {
+		t.Errorf("SendVerificationEmail once verified error = %v, want ErrConflict", err)
+	}
+}
@@ -0,0 +1,208 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/client"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/auth"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/user"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+	domainrepo "S.P.A.R.T.A/backend/internal/domain/repository"
+	domainuc "S.P.A.R.T.A/backend/internal/domain/usecase"
+	"github.com/google/uuid"
+)
+
+const (
+	// oauthStateTTL is how long a user has to finish signing in at the
+	// provider.
+	oauthStateTTL  = 10 * time.Minute
+	maxUserNameLen = 100
+	maxEmailLen    = 150
+)
+
+func (u *authUsecase) OAuthProviders() []string {
+	names := make([]string, 0, len(u.oauthProviders))
+	for name := range u.oauthProviders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (u *authUsecase) StartOAuth(ctx context.Context, providerName string) (string, string, error) {
+	provider, ok := u.oauthProviders[providerName]
+	if !ok || u.oauthStates == nil {
+		return "", "", domainerr.ErrNotFound
+	}
+
+	pending := &auth.OAuthState{Provider: providerName, CreatedAt: time.Now().UTC()}
+	for _, v := range []*string{&pending.State, &pending.Nonce, &pending.CodeVerifier} {
+		raw, err := newOpaqueToken()
+		if err != nil {
+			return "", "", err
+		}
+		*v = raw
+	}
+
+	challenge := sha256.Sum256([]byte(pending.CodeVerifier))
+	authURL, err := provider.AuthCodeURL(ctx, pending.State, pending.Nonce, base64.RawURLEncoding.EncodeToString(challenge[:]))
+	if err != nil {
+		slog.WarnContext(ctx, "oauth provider unavailable", "provider", providerName, "error", err)
+		return "", "", domainerr.ErrInternal
+	}
+	if err := u.oauthStates.Save(ctx, pending, oauthStateTTL); err != nil {
+		return "", "", err
+	}
+	return authURL, pending.State, nil
+}
+
+func (u *authUsecase) CompleteOAuth(ctx context.Context, providerName, code, state string, client auth.Client) (*domainuc.AuthResult, error) {
+	provider, ok := u.oauthProviders[providerName]
+	if !ok || u.oauthStates == nil {
+		return nil, domainerr.ErrNotFound
+	}
+	code, state = strings.TrimSpace(code), strings.TrimSpace(state)
+	if code == "" || state == "" {
+		return nil, domainerr.ErrInvalidInput
+	}
+
+	pending, err := u.oauthStates.Take(ctx, state)
+	if errors.Is(err, domainerr.ErrNotFound) {
+		return nil, domainerr.ErrUnauthorized
+	}
+	if err != nil {
+		return nil, err
+	}
+	if pending.Provider != providerName {
+		return nil, domainerr.ErrUnauthorized
+	}
+
+	identity, err := provider.Exchange(ctx, code, pending.CodeVerifier, pending.Nonce)
+	if err != nil {
+		slog.WarnContext(ctx, "oauth sign-in rejected", "provider", providerName, "error", err)
+		return nil, domainerr.ErrUnauthorized
+	}
+
+	usr, err := u.oauthUser(ctx, providerName, identity)
+	if err != nil {
+		return nil, err
+	}
+	return u.startSession(ctx, usr, client)
+}
+
+// oauthUser finds the user a provider account belongs to. A known identity
+// signs its user in; otherwise the account is linked to the user with the
+// same email, or a new user is created. Both need an email the provider
+// has verified, so nobody can claim an account by typing its address into
+// a provider that never checks it.
+func (u *authUsecase) oauthUser(ctx context.Context, providerName string, identity *client.OAuthIdentity) (*user.User, error) {
+	email := strings.ToLower(strings.TrimSpace(identity.Email))
+	if len(email) > maxEmailLen {
+		email = ""
+	}
+	now := time.Now().UTC()
+
+	var usr *user.User
+	var takenOver bool
+	err := u.uow.Do(ctx, func(r domainrepo.Registry) error {
+		linked, err := r.UserIdentity().GetByProviderSubject(ctx, providerName, identity.Subject)
+		if err == nil {
+			if usr, err = r.User().GetByID(ctx, linked.UserID); err != nil {
+				return err
+			}
+			return r.UserIdentity().TouchLogin(ctx, linked.ID, email, now)
+		}
+		if !errors.Is(err, domainerr.ErrNotFound) {
+			return err
+		}
+
+		if email == "" || !identity.EmailVerified {
+			return domainerr.ErrInvalidInput
+		}
+
+		usr, err = r.User().GetByEmail(ctx, email)
+		switch {
+		case err == nil:
+			if !usr.EmailVerified() {
+				// Whoever registered the address never proved they own it;
+				// the provider's user has. Their password and sessions go.
+				if err := r.User().UpdatePassword(ctx, usr.ID, "", now); err != nil {
+					return err
+				}
+				if err := r.User().MarkEmailVerified(ctx, usr.ID, now); err != nil {
+					return err
+				}
+				usr.PasswordHash, usr.EmailVerifiedAt = "", &now
+				takenOver = true
+			}
+		case errors.Is(err, domainerr.ErrNotFound):
+			if usr, err = newOAuthUser(ctx, r, identity.Name, email, now); err != nil {
+				return err
+			}
+		default:
+			return err
+		}
+
+		return r.UserIdentity().Create(ctx, &auth.Identity{
+			ID:          uuid.NewString(),
+			UserID:      usr.ID,
+			Provider:    providerName,
+			Subject:     identity.Subject,
+			Email:       email,
+			CreatedAt:   now,
+			LastLoginAt: now,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if takenOver {
+		if err := u.RevokeAllSessions(ctx, usr.ID); err != nil {
+			return nil, err
+		}
+	}
+	return usr, nil
+}
+
+// newOAuthUser creates a user without a password; one can be set later
+// through a password reset.
+func newOAuthUser(ctx context.Context, r domainrepo.Registry, name, email string, now time.Time) (*user.User, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name, _, _ = strings.Cut(email, "@")
+	}
+	if runes := []rune(name); len(runes) > maxUserNameLen {
+		name = string(runes[:maxUserNameLen])
+	}
+
+	role := "user"
+	count, err := r.User().Count(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		role = "admin" // one-time bootstrap admin, as in Register
+	}
+
+	usr := &user.User{
+		ID:              uuid.NewString(),
+		Name:            name,
+		Email:           email,
+		Role:            role,
+		EmailVerifiedAt: &now,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if err := r.User().Create(ctx, usr); err != nil {
+		return nil, err
+	}
+	return usr, nil
+}
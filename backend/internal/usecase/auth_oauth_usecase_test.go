@@ -0,0 +1,208 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/client"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/auth"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/user"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+	domainuc "S.P.A.R.T.A/backend/internal/domain/usecase"
+)
+
+type authIdentityRepo struct{ store *authStore }
+
+func identityKey(provider, subject string) string { return provider + "|" + subject }
+
+func (r authIdentityRepo) Create(_ context.Context, identity *auth.Identity) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	key := identityKey(identity.Provider, identity.Subject)
+	if _, ok := r.store.identities[key]; ok {
+		return domainerr.ErrConflict
+	}
+	r.store.identities[key] = *identity
+	return nil
+}
+
+func (r authIdentityRepo) GetByProviderSubject(_ context.Context, provider, subject string) (*auth.Identity, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	identity, ok := r.store.identities[identityKey(provider, subject)]
+	if !ok {
+		return nil, domainerr.ErrNotFound
+	}
+	return &identity, nil
+}
+
+func (r authIdentityRepo) TouchLogin(_ context.Context, id, email string, at time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	for key, identity := range r.store.identities {
+		if identity.ID == id {
+			identity.Email, identity.LastLoginAt = email, at
+			r.store.identities[key] = identity
+			return nil
+		}
+	}
+	return domainerr.ErrNotFound
+}
+
+// fakeOAuthProvider asserts identity for any code, as long as the nonce
+// of the sign-in it started comes back.
+type fakeOAuthProvider struct {
+	identity client.OAuthIdentity
+	nonce    string
+}
+
+func (p *fakeOAuthProvider) AuthCodeURL(_ context.Context, state, nonce, _ string) (string, error) {
+	p.nonce = nonce
+	return "https://provider.example/authorize?state=" + state, nil
+}
+
+func (p *fakeOAuthProvider) Exchange(_ context.Context, _, _, nonce string) (*client.OAuthIdentity, error) {
+	if nonce != p.nonce {
+		return nil, errors.New("nonce mismatch")
+	}
+	identity := p.identity
+	return &identity, nil
+}
+
+// signInWith runs a whole sign-in at the provider.
+func signInWith(u *authUsecase, provider *fakeOAuthProvider) (*domainuc.AuthResult, error) {
+	u.oauthProviders = map[string]client.OAuthProvider{"oidc": provider}
+	_, state, err := u.StartOAuth(context.Background(), "oidc")
+	if err != nil {
+		return nil, err
+	}
+	return u.CompleteOAuth(context.Background(), "oidc", "code", state, auth.Client{UserAgent: "test"})
+}
+
+func TestOAuthKnownSubjectSignsIn(t *testing.T) {
+	u, store := newAuthFixture(t)
+	store.users["u1"] = user.User{ID: "u1", Email: "ada@example.com", Role: "user"}
+	store.identities[identityKey("oidc", "sub-1")] = auth.Identity{ID: "i1", UserID: "u1", Provider: "oidc", Subject: "sub-1"}
+
+	// A known subject signs in even when the provider does not vouch for
+	// the email (yet).
+	res, err := signInWith(u, &fakeOAuthProvider{identity: client.OAuthIdentity{Subject: "sub-1", Email: "Ada@Example.com"}})
+	if err != nil {
+		t.Fatalf("CompleteOAuth: %v", err)
+	}
+	if res.UserID != "u1" || res.RefreshToken == "" {
+		t.Errorf("result = %+v, want a session for u1", res)
+	}
+	if got := store.identities[identityKey("oidc", "sub-1")]; got.Email != "ada@example.com" || got.LastLoginAt.IsZero() {
+		t.Errorf("identity = %+v, want the login recorded", got)
+	}
+}
+
+func TestOAuthUnverifiedEmailCannotBeLinked(t *testing.T) {
+	u, store := newAuthFixture(t)
+	verified := time.Now()
+	store.users["u1"] = user.User{ID: "u1", Email: "ada@example.com", PasswordHash: "hash", EmailVerifiedAt: &verified}
+
+	for _, identity := range []client.OAuthIdentity{
+		{Subject: "sub-9", Email: "ada@example.com"},
+		{Subject: "sub-9", EmailVerified: true},
+	} {
+		if _, err := signInWith(u, &fakeOAuthProvider{identity: identity}); !errors.Is(err, domainerr.ErrInvalidInput) {
+			t.Errorf("CompleteOAuth with %+v error = %v, want ErrInvalidInput", identity, err)
+		}
+	}
+	if len(store.identities) != 0 || len(store.users) != 1 || len(store.sessions) != 0 {
+		t.Errorf("a rejected sign-in stored %d identities, %d users, %d sessions", len(store.identities), len(store.users), len(store.sessions))
+	}
+}
+
+func TestOAuthNewEmailCreatesAVerifiedUser(t *testing.T) {
+	u, store := newAuthFixture(t)
+	store.users["u0"] = user.User{ID: "u0", Email: "admin@example.com", Role: "admin"}
+
+	res, err := signInWith(u, &fakeOAuthProvider{identity: client.OAuthIdentity{Subject: "sub-1", Email: "grace@example.com", EmailVerified: true}})
+	if err != nil {
+		t.Fatalf("CompleteOAuth: %v", err)
+	}
+	created := store.users[res.UserID]
+	if created.Email != "grace@example.com" || created.Name != "grace" || created.Role != "user" || !created.EmailVerified() || created.PasswordHash != "" {
+		t.Errorf("created user = %+v", created)
+	}
+	if got := store.identities[identityKey("oidc", "sub-1")]; got.UserID != res.UserID {
+		t.Errorf("identity = %+v, want it linked to %s", got, res.UserID)
+	}
+}
+
+func TestOAuthTakesOverAnUnverifiedAccount(t *testing.T) {
+	u, store := newAuthFixture(t)
+	store.users["u1"] = user.User{ID: "u1", Email: "ada@example.com", PasswordHash: "squatter's password", Role: "user"}
+	squatter := signIn(t, u, store, "u1")
+
+	res, err := signInWith(u, &fakeOAuthProvider{identity: client.OAuthIdentity{Subject: "sub-1", Email: "ada@example.com", EmailVerified: true}})
+	if err != nil {
+		t.Fatalf("CompleteOAuth: %v", err)
+	}
+	got := store.users["u1"]
+	if res.UserID != "u1" || got.PasswordHash != "" || !got.EmailVerified() || !res.EmailVerified {
+		t.Errorf("user after linking = %+v, result %+v, want u1 verified without a password", got, res)
+	}
+	if store.sessions[squatter.SessionID].RevokedAt == nil || store.versions["u1"] == 0 {
+		t.Error("the earlier sessions survived the take-over")
+	}
+	if store.sessions[res.SessionID].RevokedAt != nil {
+		t.Error("the new session was revoked with the old ones")
+	}
+}
+
+func TestOAuthLinksAVerifiedAccountAsIs(t *testing.T) {
+	u, store := newAuthFixture(t)
+	verified := time.Now()
+	owner := user.User{ID: "u1", Email: "ada@example.com", PasswordHash: "hash", Role: "user", EmailVerifiedAt: &verified}
+	store.users["u1"] = owner
+	existing := signIn(t, u, store, "u1")
+
+	if _, err := signInWith(u, &fakeOAuthProvider{identity: client.OAuthIdentity{Subject: "sub-1", Email: "ada@example.com", EmailVerified: true}}); err != nil {
+		t.Fatalf("CompleteOAuth: %v", err)
+	}
+	if store.users["u1"].PasswordHash != "hash" || store.sessions[existing.SessionID].RevokedAt != nil {
+		t.Error("linking a verified account dropped its password or sessions")
+	}
+}
+
+func TestOAuthStateIsSingleUse(t *testing.T) {
+	u, _ := newAuthFixture(t)
+	provider := &fakeOAuthProvider{identity: client.OAuthIdentity{Subject: "sub-1", Email: "ada@example.com", EmailVerified: true}}
+	u.oauthProviders = map[string]client.OAuthProvider{"oidc": provider}
+	_, state, err := u.StartOAuth(context.Background(), "oidc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := u.CompleteOAuth(context.Background(), "oidc", "code", state, auth.Client{}); err != nil {
+		t.Fatalf("CompleteOAuth: %v", err)
+	}
+	if _, err := u.CompleteOAuth(context.Background(), "oidc", "code", state, auth.Client{}); !errors.Is(err, domainerr.ErrUnauthorized) {
+		t.Errorf("replayed callback error = %v, want ErrUnauthorized", err)
+	}
+}
+
+func TestOAuthRejectsAnotherSignInsNonce(t *testing.T) {
+	u, _ := newAuthFixture(t)
+	provider := &fakeOAuthProvider{identity: client.OAuthIdentity{Subject: "sub-1", Email: "ada@example.com", EmailVerified: true}}
+	u.oauthProviders = map[string]client.OAuthProvider{"oidc": provider}
+	_, first, err := u.StartOAuth(context.Background(), "oidc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A second sign-in starts; the callback of the first carries the
+	// second's ID token.
+	if _, _, err := u.StartOAuth(context.Background(), "oidc"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := u.CompleteOAuth(context.Background(), "oidc", "code", first, auth.Client{}); !errors.Is(err, domainerr.ErrUnauthorized) {
+		t.Errorf("CompleteOAuth error = %v, want ErrUnauthorized", err)
+	}
+}
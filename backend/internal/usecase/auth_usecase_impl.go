@@ -2,39 +2,115 @@ package usecase
 
 import (
 	"context"
+	"crypto/rand"
 	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
+	"errors"
+	"log/slog"
 	"strings"
 	"time"
 
+	"S.P.A.R.T.A/backend/internal/client"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/auth"
 	"S.P.A.R.T.A/backend/internal/domain/aggregate/user"
 	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
 	domainrepo "S.P.A.R.T.A/backend/internal/domain/repository"
 	domainuc "S.P.A.R.T.A/backend/internal/domain/usecase"
+	"S.P.A.R.T.A/backend/pkg/jwtkeys"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
-const defaultTokenTTL = 7 * 24 * time.Hour
+const (
+	defaultAccessTokenTTL   = 15 * time.Minute
+	defaultRefreshTokenTTL  = 30 * 24 * time.Hour
+	defaultPasswordResetTTL = time.Hour
+	defaultVerificationTTL  = 48 * time.Hour
+	defaultLockoutWindow    = time.Hour
+
+	// opaqueTokenBytes of randomness, base64url encoded, for refresh and
+	// account tokens.
+	opaqueTokenBytes = 32
+	maxUserAgentLen  = 255
+	maxIPLen         = 64
+)
 
 type authUsecase struct {
-	userRepo   domainrepo.UserRepository
-	inviteRepo domainrepo.AdminInviteRepository
-	jwtSecret  string
+	userRepo    domainrepo.UserRepository
+	inviteRepo  domainrepo.AdminInviteRepository
+	sessionRepo domainrepo.AuthSessionRepository
+	revocations domainrepo.TokenRevocationRepository
+	uow         domainrepo.UnitOfWork
+	keys        *jwtkeys.Keyring
+	accessTTL   time.Duration
+	refreshTTL  time.Duration
+	mailer      client.Mailer
+	accountMail auth.AccountMail
+	attempts    domainrepo.LoginAttemptRepository
+	lockout     auth.LockoutPolicy
+	// oauthProviders are the external sign-in providers by name.
+	oauthProviders map[string]client.OAuthProvider
+	oauthStates    domainrepo.OAuthStateRepository
 }
 
-func NewAuthUsecase(userRepo domainrepo.UserRepository, inviteRepo domainrepo.AdminInviteRepository, jwtSecret string) domainuc.AuthUsecase {
-	return &authUsecase{userRepo: userRepo, inviteRepo: inviteRepo, jwtSecret: jwtSecret}
+func NewAuthUsecase(
+	userRepo domainrepo.UserRepository,
+	inviteRepo domainrepo.AdminInviteRepository,
+	sessionRepo domainrepo.AuthSessionRepository,
+	revocations domainrepo.TokenRevocationRepository,
+	uow domainrepo.UnitOfWork,
+	keys *jwtkeys.Keyring,
+	accessTTL, refreshTTL time.Duration,
+	mailer client.Mailer,
+	accountMail auth.AccountMail,
+	attempts domainrepo.LoginAttemptRepository,
+	lockout auth.LockoutPolicy,
+	oauthProviders map[string]client.OAuthProvider,
+	oauthStates domainrepo.OAuthStateRepository,
+) domainuc.AuthUsecase {
+	if accessTTL <= 0 {
+		accessTTL = defaultAccessTokenTTL
+	}
+	if refreshTTL <= 0 {
+		refreshTTL = defaultRefreshTokenTTL
+	}
+	if lockout.Window <= 0 {
+		lockout.Window = defaultLockoutWindow
+	}
+	if accountMail.PasswordResetTTL <= 0 {
+		accountMail.PasswordResetTTL = defaultPasswordResetTTL
+	}
+	if accountMail.VerificationTTL <= 0 {
+		accountMail.VerificationTTL = defaultVerificationTTL
+	}
+	return &authUsecase{
+		userRepo:    userRepo,
+		inviteRepo:  inviteRepo,
+		sessionRepo: sessionRepo,
+		revocations: revocations,
+		uow:         uow,
+		keys:        keys,
+		accessTTL:   accessTTL,
+		refreshTTL:  refreshTTL,
+		mailer:      mailer,
+		accountMail: accountMail,
+		attempts:    attempts,
+		lockout:     lockout,
+
+		oauthProviders: oauthProviders,
+		oauthStates:    oauthStates,
+	}
 }
 
-func (u *authUsecase) Register(ctx context.Context, name, email, password, inviteToken string) (*domainuc.AuthResult, error) {
+func (u *authUsecase) Register(ctx context.Context, name, email, password, inviteToken string, client auth.Client) (*domainuc.AuthResult, error) {
 	name = strings.TrimSpace(name)
 	email = strings.TrimSpace(strings.ToLower(email))
 	password = strings.TrimSpace(password)
 	inviteToken = strings.TrimSpace(inviteToken)
 
-	if name == "" || email == "" || password == "" {
+	if name == "" || email == "" || len(password) < minPasswordLen {
 		return nil, domainerr.ErrInvalidInput
 	}
 
@@ -61,8 +137,7 @@ func (u *authUsecase) Register(ctx context.Context, name, email, password, invit
 		if u.inviteRepo == nil {
 			return nil, domainerr.ErrInternal
 		}
-		h := sha256.Sum256([]byte(inviteToken))
-		reservedInviteTokenHash = hex.EncodeToString(h[:])
+		reservedInviteTokenHash = hashToken(inviteToken)
 		invite, err := u.inviteRepo.ReserveByTokenHash(ctx, reservedInviteTokenHash, time.Now().UTC())
 		if err != nil {
 			return nil, err
@@ -96,54 +171,314 @@ func (u *authUsecase) Register(ctx context.Context, name, email, password, invit
 		_ = u.inviteRepo.AttachUsedBy(ctx, reservedInviteTokenHash, domainUser.ID)
 	}
 
-	token, err := u.generateToken(domainUser.ID, domainUser.Role)
-	if err != nil {
-		return nil, err
+	// The account works without it; a failed email can be resent.
+	if err := u.sendVerification(ctx, domainUser); err != nil {
+		slog.WarnContext(ctx, "failed to send verification email", "user_id", domainUser.ID, "error", err)
 	}
 
-	return &domainuc.AuthResult{UserID: domainUser.ID, Token: token, Role: domainUser.Role}, nil
+	return u.startSession(ctx, domainUser, client)
 }
 
-func (u *authUsecase) Login(ctx context.Context, email, password string) (*domainuc.AuthResult, error) {
+func (u *authUsecase) Login(ctx context.Context, email, password string, client auth.Client) (*domainuc.AuthResult, error) {
 	email = strings.TrimSpace(strings.ToLower(email))
 	password = strings.TrimSpace(password)
 	if email == "" || password == "" {
 		return nil, domainerr.ErrInvalidInput
 	}
 
+	attemptKey := loginAttemptKey(email, client.IP)
+	if wait := u.lockedFor(ctx, attemptKey); wait > 0 {
+		return nil, &domainerr.RetryAfterError{RetryAfter: wait}
+	}
+
 	usr, err := u.userRepo.GetByEmail(ctx, email)
 	if err != nil {
 		if err == domainerr.ErrNotFound {
+			u.recordLoginFailure(ctx, attemptKey)
 			return nil, domainerr.ErrUnauthorized
 		}
 		return nil, err
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(usr.PasswordHash), []byte(password)); err != nil {
+		u.recordLoginFailure(ctx, attemptKey)
 		return nil, domainerr.ErrUnauthorized
 	}
 
-	token, err := u.generateToken(usr.ID, usr.Role)
+	if u.attempts != nil {
+		if err := u.attempts.Reset(ctx, attemptKey); err != nil {
+			slog.WarnContext(ctx, "failed to reset login failures", "error", err)
+		}
+	}
+
+	return u.startSession(ctx, usr, client)
+}
+
+// loginAttemptKey scopes failures to an email and client, so guessing from
+// one address cannot lock the owner out everywhere. The email is hashed to
+// keep it out of the attempt store.
+func loginAttemptKey(email, ip string) string {
+	return hashToken(email + "|" + ip)
+}
+
+// lockedFor fails open: an unavailable attempt store must not block every
+// sign-in.
+func (u *authUsecase) lockedFor(ctx context.Context, key string) time.Duration {
+	if u.attempts == nil || !u.lockout.Enabled() {
+		return 0
+	}
+	wait, err := u.attempts.LockedFor(ctx, key)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to read login lockout", "error", err)
+		return 0
+	}
+	return wait
+}
+
+func (u *authUsecase) recordLoginFailure(ctx context.Context, key string) {
+	if u.attempts == nil || !u.lockout.Enabled() {
+		return
+	}
+	failures, err := u.attempts.RecordFailure(ctx, key, u.lockout.Window)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to record login failure", "error", err)
+		return
+	}
+	if d := u.lockout.Delay(failures); d > 0 {
+		if err := u.attempts.Lock(ctx, key, d); err != nil {
+			slog.WarnContext(ctx, "failed to lock login", "error", err)
+		}
+	}
+}
+
+// startSession signs a device in: a new session with its first refresh
+// token, and an access token bound to it.
+func (u *authUsecase) startSession(ctx context.Context, usr *user.User, client auth.Client) (*domainuc.AuthResult, error) {
+	now := time.Now().UTC()
+	session := &auth.Session{
+		ID:         uuid.NewString(),
+		UserID:     usr.ID,
+		UserAgent:  truncate(strings.TrimSpace(client.UserAgent), maxUserAgentLen),
+		IP:         truncate(strings.TrimSpace(client.IP), maxIPLen),
+		CreatedAt:  now,
+		LastUsedAt: now,
+		ExpiresAt:  now.Add(u.refreshTTL),
+	}
+
+	var refresh string
+	err := u.uow.Do(ctx, func(r domainrepo.Registry) error {
+		if err := r.AuthSession().CreateSession(ctx, session); err != nil {
+			return err
+		}
+		var err error
+		refresh, err = u.issueRefreshToken(ctx, r.AuthSession(), session.ID, now)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &domainuc.AuthResult{UserID: usr.ID, Token: token, Role: usr.Role}, nil
+	return u.result(ctx, usr, session, refresh, now)
 }
 
-func (u *authUsecase) generateToken(userID string, role string) (string, error) {
+func (u *authUsecase) Refresh(ctx context.Context, refreshToken string) (*domainuc.AuthResult, error) {
+	refreshToken = strings.TrimSpace(refreshToken)
+	if refreshToken == "" {
+		return nil, domainerr.ErrInvalidInput
+	}
 	now := time.Now().UTC()
+
+	stored, err := u.sessionRepo.GetRefreshTokenByHash(ctx, hashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, domainerr.ErrNotFound) {
+			return nil, domainerr.ErrUnauthorized
+		}
+		return nil, err
+	}
+	if stored.UsedAt != nil {
+		// A rotated token came back: whoever holds the chain is not the
+		// only one with it. End the session for both.
+		_ = u.RevokeSession(ctx, stored.SessionID)
+		return nil, domainerr.ErrUnauthorized
+	}
+	if !now.Before(stored.ExpiresAt) {
+		return nil, domainerr.ErrUnauthorized
+	}
+
+	session, err := u.sessionRepo.GetSession(ctx, stored.SessionID)
+	if err != nil {
+		if errors.Is(err, domainerr.ErrNotFound) {
+			return nil, domainerr.ErrUnauthorized
+		}
+		return nil, err
+	}
+	if !session.Active(now) {
+		return nil, domainerr.ErrUnauthorized
+	}
+
+	// The role is read again, so a changed role applies from the next
+	// refresh on.
+	usr, err := u.userRepo.GetByID(ctx, session.UserID)
+	if err != nil {
+		if errors.Is(err, domainerr.ErrNotFound) {
+			return nil, domainerr.ErrUnauthorized
+		}
+		return nil, err
+	}
+
+	var refresh string
+	err = u.uow.Do(ctx, func(r domainrepo.Registry) error {
+		if err := r.AuthSession().MarkRefreshTokenUsed(ctx, stored.ID, now); err != nil {
+			return err
+		}
+		session.LastUsedAt = now
+		session.ExpiresAt = now.Add(u.refreshTTL)
+		if err := r.AuthSession().TouchSession(ctx, session.ID, session.LastUsedAt, session.ExpiresAt); err != nil {
+			return err
+		}
+		var err error
+		refresh, err = u.issueRefreshToken(ctx, r.AuthSession(), session.ID, now)
+		return err
+	})
+	if errors.Is(err, domainerr.ErrConflict) {
+		// Used concurrently by another request: treat it as reuse.
+		_ = u.RevokeSession(ctx, session.ID)
+		return nil, domainerr.ErrUnauthorized
+	}
+	if errors.Is(err, domainerr.ErrNotFound) {
+		return nil, domainerr.ErrUnauthorized
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return u.result(ctx, usr, session, refresh, now)
+}
+
+func (u *authUsecase) ListSessions(ctx context.Context, userID string) ([]auth.Session, error) {
+	return u.sessionRepo.ListActiveSessions(ctx, userID, time.Now().UTC())
+}
+
+func (u *authUsecase) GetSessionOwner(ctx context.Context, id string) (string, error) {
+	return u.sessionRepo.GetSessionOwnerID(ctx, id)
+}
+
+func (u *authUsecase) RevokeSession(ctx context.Context, id string) error {
+	if err := u.sessionRepo.RevokeSession(ctx, id, time.Now().UTC()); err != nil {
+		return err
+	}
+	return u.revokeAccess(ctx, id)
+}
+
+func (u *authUsecase) RevokeAllSessions(ctx context.Context, userID string) error {
+	ids, err := u.sessionRepo.RevokeUserSessions(ctx, userID, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := u.revokeAccess(ctx, id); err != nil {
+			return err
+		}
+	}
+	if u.revocations == nil {
+		return nil
+	}
+	_, err = u.revocations.BumpUserVersion(ctx, userID)
+	return err
+}
+
+// revokeAccess lists the session as revoked for as long as its access
+// tokens live.
+func (u *authUsecase) revokeAccess(ctx context.Context, sessionID string) error {
+	if u.revocations == nil {
+		return nil
+	}
+	return u.revocations.RevokeSession(ctx, sessionID, u.accessTTL)
+}
+
+func (u *authUsecase) issueRefreshToken(ctx context.Context, repo domainrepo.AuthSessionRepository, sessionID string, now time.Time) (string, error) {
+	raw, err := newOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+
+	err = repo.CreateRefreshToken(ctx, &auth.RefreshToken{
+		ID:        uuid.NewString(),
+		SessionID: sessionID,
+		TokenHash: hashToken(raw),
+		CreatedAt: now,
+		ExpiresAt: now.Add(u.refreshTTL),
+	})
+	if err != nil {
+		return "", err
+	}
+	return raw, nil
+}
+
+func (u *authUsecase) result(ctx context.Context, usr *user.User, session *auth.Session, refresh string, now time.Time) (*domainuc.AuthResult, error) {
+	var version int64
+	if u.revocations != nil {
+		v, err := u.revocations.UserVersion(ctx, usr.ID)
+		if err != nil {
+			return nil, err
+		}
+		version = v
+	}
+
+	token, err := u.generateToken(usr, session.ID, version, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domainuc.AuthResult{
+		UserID:           usr.ID,
+		Token:            token,
+		ExpiresIn:        int64(u.accessTTL / time.Second),
+		Role:             usr.Role,
+		SessionID:        session.ID,
+		RefreshToken:     refresh,
+		RefreshExpiresAt: session.ExpiresAt,
+		EmailVerified:    usr.EmailVerified(),
+	}, nil
+}
+
+func (u *authUsecase) generateToken(usr *user.User, sessionID string, version int64, now time.Time) (string, error) {
 	claims := jwt.MapClaims{
-		"user_id": userID,
-		"role":    role,
-		"iat":     now.Unix(),
-		"exp":     now.Add(defaultTokenTTL).Unix(),
+		"user_id":        usr.ID,
+		"role":           usr.Role,
+		"sid":            sessionID,
+		"ver":            version,
+		"email_verified": usr.EmailVerified(),
+		"iat":            now.Unix(),
+		"exp":            now.Add(u.accessTTL).Unix(),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signed, err := token.SignedString([]byte(u.jwtSecret))
+	signed, err := u.keys.Sign(claims)
 	if err != nil {
 		return "", domainerr.ErrInternal
 	}
 	return signed, nil
 }
+
+// newOpaqueToken returns a random URL-safe token; only its hash is stored.
+func newOpaqueToken() (string, error) {
+	buf := make([]byte, opaqueTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", domainerr.ErrInternal
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func hashToken(raw string) string {
+	h := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(h[:])
+}
+
+// truncate keeps the first n runes of s, so a multi-byte character is
+// never cut in half.
+func truncate(s string, n int) string {
+	if runes := []rune(s); len(runes) > n {
+		return string(runes[:n])
+	}
+	return s
+}
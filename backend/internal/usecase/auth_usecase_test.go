@@ -0,0 +1,458 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/delivery/http/middleware"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/auth"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/user"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+	domainrepo "S.P.A.R.T.A/backend/internal/domain/repository"
+	domainuc "S.P.A.R.T.A/backend/internal/domain/usecase"
+	"S.P.A.R.T.A/backend/internal/repository/memory"
+	"S.P.A.R.T.A/backend/pkg/jwtkeys"
+	"github.com/gin-gonic/gin"
+)
+
+// authStore holds users, sessions, refresh tokens and the revocation list.
+// It is safe for concurrent use.
+type authStore struct {
+	mu       sync.Mutex
+	users    map[string]user.User
+	sessions map[string]auth.Session
+	tokens   map[string]auth.RefreshToken
+	revoked  map[string]bool
+	versions map[string]int64
+	// identities are keyed by provider and subject, account tokens by
+	// hash.
+	identities    map[string]auth.Identity
+	accountTokens map[string]auth.AccountToken
+}
+
+func newAuthStore() *authStore {
+	return &authStore{
+		users:    map[string]user.User{},
+		sessions: map[string]auth.Session{},
+		tokens:   map[string]auth.RefreshToken{},
+		revoked:  map[string]bool{},
+		versions: map[string]int64{},
+
+		identities:    map[string]auth.Identity{},
+		accountTokens: map[string]auth.AccountToken{},
+	}
+}
+
+type authUserRepo struct {
+	domainrepo.UserRepository
+	store *authStore
+}
+
+func (r authUserRepo) Create(_ context.Context, u *user.User) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.users[u.ID] = *u
+	return nil
+}
+
+func (r authUserRepo) Count(context.Context) (int, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	return len(r.store.users), nil
+}
+
+func (r authUserRepo) GetByID(_ context.Context, id string) (*user.User, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	u, ok := r.store.users[id]
+	if !ok {
+		return nil, domainerr.ErrNotFound
+	}
+	return &u, nil
+}
+
+func (r authUserRepo) GetByEmail(_ context.Context, email string) (*user.User, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	for _, u := range r.store.users {
+		if u.Email == email {
+			return &u, nil
+		}
+	}
+	return nil, domainerr.ErrNotFound
+}
+
+func (r authUserRepo) UpdatePassword(_ context.Context, id, passwordHash string, at time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	u, ok := r.store.users[id]
+	if !ok {
+		return domainerr.ErrNotFound
+	}
+	u.PasswordHash, u.UpdatedAt = passwordHash, at
+	r.store.users[id] = u
+	return nil
+}
+
+func (r authUserRepo) MarkEmailVerified(_ context.Context, id string, at time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	u, ok := r.store.users[id]
+	if !ok {
+		return domainerr.ErrNotFound
+	}
+	u.EmailVerifiedAt = &at
+	r.store.users[id] = u
+	return nil
+}
+
+type authSessionRepo struct {
+	domainrepo.AuthSessionRepository
+	store *authStore
+}
+
+func (r authSessionRepo) CreateSession(_ context.Context, s *auth.Session) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.sessions[s.ID] = *s
+	return nil
+}
+
+func (r authSessionRepo) GetSession(_ context.Context, id string) (*auth.Session, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	s, ok := r.store.sessions[id]
+	if !ok {
+		return nil, domainerr.ErrNotFound
+	}
+	return &s, nil
+}
+
+func (r authSessionRepo) TouchSession(_ context.Context, id string, at, expiresAt time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	s, ok := r.store.sessions[id]
+	if !ok {
+		return domainerr.ErrNotFound
+	}
+	s.LastUsedAt, s.ExpiresAt = at, expiresAt
+	r.store.sessions[id] = s
+	return nil
+}
+
+func (r authSessionRepo) RevokeSession(_ context.Context, id string, at time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	s, ok := r.store.sessions[id]
+	if !ok || s.RevokedAt != nil {
+		return domainerr.ErrNotFound
+	}
+	s.RevokedAt = &at
+	r.store.sessions[id] = s
+	return nil
+}
+
+func (r authSessionRepo) RevokeUserSessions(_ context.Context, userID string, at time.Time) ([]string, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	var ids []string
+	for id, s := range r.store.sessions {
+		if s.UserID == userID && s.RevokedAt == nil {
+			s.RevokedAt = &at
+			r.store.sessions[id] = s
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func (r authSessionRepo) CreateRefreshToken(_ context.Context, t *auth.RefreshToken) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.tokens[t.TokenHash] = *t
+	return nil
+}
+
+func (r authSessionRepo) GetRefreshTokenByHash(_ context.Context, hash string) (*auth.RefreshToken, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	t, ok := r.store.tokens[hash]
+	if !ok {
+		return nil, domainerr.ErrNotFound
+	}
+	return &t, nil
+}
+
+// MarkRefreshTokenUsed is the conditional update of the postgres
+// repository: only an unused token can be marked.
+func (r authSessionRepo) MarkRefreshTokenUsed(_ context.Context, id string, at time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	for hash, t := range r.store.tokens {
+		if t.ID != id {
+			continue
+		}
+		if t.UsedAt != nil {
+			return domainerr.ErrConflict
+		}
+		t.UsedAt = &at
+		r.store.tokens[hash] = t
+		return nil
+	}
+	return domainerr.ErrNotFound
+}
+
+type authRevocations struct {
+	domainrepo.TokenRevocationRepository
+	store *authStore
+}
+
+func (r authRevocations) RevokeSession(_ context.Context, sessionID string, _ time.Duration) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.revoked[sessionID] = true
+	return nil
+}
+
+func (r authRevocations) BumpUserVersion(_ context.Context, userID string) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.versions[userID]++
+	return r.store.versions[userID], nil
+}
+
+func (r authRevocations) UserVersion(_ context.Context, userID string) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	return r.store.versions[userID], nil
+}
+
+func (r authRevocations) TokenState(_ context.Context, userID, sessionID string) (bool, int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	return r.store.revoked[sessionID], r.store.versions[userID], nil
+}
+
+type authRegistry struct {
+	domainrepo.Registry
+	store *authStore
+}
+
+func (r authRegistry) AuthSession() domainrepo.AuthSessionRepository {
+	return authSessionRepo{store: r.store}
+}
+
+func (r authRegistry) User() domainrepo.UserRepository { return authUserRepo{store: r.store} }
+
+func (r authRegistry) UserIdentity() domainrepo.UserIdentityRepository {
+	return authIdentityRepo{store: r.store}
+}
+
+func (r authRegistry) AccountToken() domainrepo.AccountTokenRepository {
+	return authAccountTokenRepo{store: r.store}
+}
+
+// authUnitOfWork runs one transaction at a time. Nothing is rolled back:
+// the transactions under test only fail before their first write.
+type authUnitOfWork struct {
+	mu    *sync.Mutex
+	store *authStore
+}
+
+func (u authUnitOfWork) Do(_ context.Context, fn func(r domainrepo.Registry) error) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return fn(authRegistry{store: u.store})
+}
+
+const testJWTSecret = "test-secret"
+
+func newAuthFixture(t *testing.T) (*authUsecase, *authStore) {
+	t.Helper()
+	keys, err := jwtkeys.NewHMAC(testJWTSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := newAuthStore()
+	u := NewAuthUsecase(
+		authUserRepo{store: store}, nil, authSessionRepo{store: store}, authRevocations{store: store},
+		authUnitOfWork{mu: &sync.Mutex{}, store: store}, keys, 0, 0,
+		nil, auth.AccountMail{}, nil, auth.LockoutPolicy{}, nil, memory.NewOAuthStateRepository(),
+	).(*authUsecase)
+	return u, store
+}
+
+// signIn starts a session for a user, as Login does, creating the user
+// when there is none yet.
+func signIn(t *testing.T, u *authUsecase, store *authStore, userID string) *domainuc.AuthResult {
+	t.Helper()
+	usr, ok := store.users[userID]
+	if !ok {
+		usr = user.User{ID: userID, Email: userID + "@example.com", Role: "user"}
+		store.users[userID] = usr
+	}
+	res, err := u.startSession(context.Background(), &usr, auth.Client{UserAgent: "test", IP: "127.0.0.1"})
+	if err != nil {
+		t.Fatalf("startSession: %v", err)
+	}
+	return res
+}
+
+func TestRefreshRotatesTheToken(t *testing.T) {
+	u, store := newAuthFixture(t)
+	first := signIn(t, u, store, "u1")
+
+	next, err := u.Refresh(context.Background(), first.RefreshToken)
+	if err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	if next.RefreshToken == first.RefreshToken || next.SessionID != first.SessionID {
+		t.Errorf("Refresh = %+v, want a new token for the same session", next)
+	}
+	if used := store.tokens[hashToken(first.RefreshToken)].UsedAt; used == nil {
+		t.Error("the exchanged token was not marked used")
+	}
+}
+
+func TestRefreshReuseRevokesTheSession(t *testing.T) {
+	u, store := newAuthFixture(t)
+	first := signIn(t, u, store, "u1")
+	next, err := u.Refresh(context.Background(), first.RefreshToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The old token comes back, from whoever copied it.
+	if _, err := u.Refresh(context.Background(), first.RefreshToken); !errors.Is(err, domainerr.ErrUnauthorized) {
+		t.Fatalf("reused Refresh error = %v, want ErrUnauthorized", err)
+	}
+	if store.sessions[first.SessionID].RevokedAt == nil || !store.revoked[first.SessionID] {
+		t.Error("reuse did not revoke the session")
+	}
+	// The legitimate holder of the chain is signed out too.
+	if _, err := u.Refresh(context.Background(), next.RefreshToken); !errors.Is(err, domainerr.ErrUnauthorized) {
+		t.Errorf("Refresh after reuse error = %v, want ErrUnauthorized", err)
+	}
+}
+
+// A token marked used between the read and the write is reuse as well.
+func TestRefreshConflictCountsAsReuse(t *testing.T) {
+	u, store := newAuthFixture(t)
+	first := signIn(t, u, store, "u1")
+	u.sessionRepo = markedAfterReadRepo{authSessionRepo{store: store}}
+
+	if _, err := u.Refresh(context.Background(), first.RefreshToken); !errors.Is(err, domainerr.ErrUnauthorized) {
+		t.Fatalf("Refresh error = %v, want ErrUnauthorized", err)
+	}
+	if store.sessions[first.SessionID].RevokedAt == nil || !store.revoked[first.SessionID] {
+		t.Error("a conflicting refresh did not revoke the session")
+	}
+}
+
+// markedAfterReadRepo lets another request use the token right after it
+// was read.
+type markedAfterReadRepo struct{ authSessionRepo }
+
+func (r markedAfterReadRepo) GetRefreshTokenByHash(ctx context.Context, hash string) (*auth.RefreshToken, error) {
+	t, err := r.authSessionRepo.GetRefreshTokenByHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.MarkRefreshTokenUsed(ctx, t.ID, time.Now()); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func TestConcurrentRefreshSucceedsOnce(t *testing.T) {
+	u, store := newAuthFixture(t)
+	first := signIn(t, u, store, "u1")
+
+	const n = 8
+	var wg sync.WaitGroup
+	errs := make([]error, n)
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = u.Refresh(context.Background(), first.RefreshToken)
+		}()
+	}
+	wg.Wait()
+
+	succeeded := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			succeeded++
+		case !errors.Is(err, domainerr.ErrUnauthorized):
+			t.Errorf("Refresh error = %v, want ErrUnauthorized", err)
+		}
+	}
+	if succeeded != 1 {
+		t.Errorf("%d concurrent refreshes succeeded, want 1", succeeded)
+	}
+	if store.sessions[first.SessionID].RevokedAt == nil {
+		t.Error("the session outlived a reused refresh token")
+	}
+}
+
+func TestRevokeAllSessionsRejectsOlderAccessTokens(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	u, store := newAuthFixture(t)
+	before := signIn(t, u, store, "u1")
+
+	r := gin.New()
+	r.GET("/me", middleware.NewAuthMiddleware(u.keys, authRevocations{store: store}.TokenState).RequireAuth(), func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+	get := func(token string) int {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/me", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	if code := get(before.Token); code != http.StatusNoContent {
+		t.Fatalf("GET /me before revoking = %d", code)
+	}
+	if err := u.RevokeAllSessions(context.Background(), "u1"); err != nil {
+		t.Fatal(err)
+	}
+	// Forget the revoked session, so only the version check is left to
+	// reject the token.
+	delete(store.revoked, before.SessionID)
+	if code := get(before.Token); code != http.StatusUnauthorized {
+		t.Errorf("GET /me with a token from before RevokeAllSessions = %d, want 401", code)
+	}
+
+	after := signIn(t, u, store, "u1")
+	if code := get(after.Token); code != http.StatusNoContent {
+		t.Errorf("GET /me with a token from a new sign-in = %d, want 204", code)
+	}
+}
+
+func TestTruncateKeepsWholeRunes(t *testing.T) {
+	tests := []struct {
+		in   string
+		n    int
+		want string
+	}{
+		{in: "curl/8.0", n: 255, want: "curl/8.0"},
+		{in: "abcdef", n: 3, want: "abc"},
+		{in: "Müller", n: 2, want: "Mü"},
+		{in: strings.Repeat("é", 300), n: 255, want: strings.Repeat("é", 255)},
+	}
+	for _, tc := range tests {
+		if got := truncate(tc.in, tc.n); got != tc.want {
+			t.Errorf("truncate(%q, %d) = %q, want %q", tc.in, tc.n, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,356 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"S.P.A.R.T.A/backend/internal/ai/orchestrator"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/chat"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/workout"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+	domainrepo "S.P.A.R.T.A/backend/internal/domain/repository"
+	domainuc "S.P.A.R.T.A/backend/internal/domain/usecase"
+	"github.com/google/uuid"
+)
+
+// How much data the chat context offers before the token budget trims it.
+const (
+	chatRecentSessions  = 5
+	chatNutritionDays   = 3
+	chatRecommendations = 3
+	maxChatTitleLen     = 120
+)
+
+type chatUsecase struct {
+	chatRepository      domainrepo.ChatRepository
+	orchestrator        orchestrator.Orchestrator
+	workoutRepository   domainrepo.WorkoutRepository
+	exerciseRepository  domainrepo.ExerciseRepository
+	nutritionRepository domainrepo.NutritionRepository
+	plannerRepository   domainrepo.PlannerRepository
+	splitRepository     domainrepo.SplitRepository
+	userRepository      domainrepo.UserRepository
+	tokenBudget         int
+}
+
+func NewChatUsecase(
+	chatRepository domainrepo.ChatRepository,
+	orchestrator orchestrator.Orchestrator,
+	workoutRepository domainrepo.WorkoutRepository,
+	exerciseRepository domainrepo.ExerciseRepository,
+	nutritionRepository domainrepo.NutritionRepository,
+	plannerRepository domainrepo.PlannerRepository,
+	splitRepository domainrepo.SplitRepository,
+	userRepository domainrepo.UserRepository,
+	tokenBudget int,
+) domainuc.ChatUsecase {
+	return &chatUsecase{
+		chatRepository:      chatRepository,
+		orchestrator:        orchestrator,
+		workoutRepository:   workoutRepository,
+		exerciseRepository:  exerciseRepository,
+		nutritionRepository: nutritionRepository,
+		plannerRepository:   plannerRepository,
+		splitRepository:     splitRepository,
+		userRepository:      userRepository,
+		tokenBudget:         tokenBudget,
+	}
+}
+
+func (u *chatUsecase) CreateThread(ctx context.Context, userID uuid.UUID, title string) (*chat.Thread, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		title = chat.DefaultTitle
+	}
+	if utf8.RuneCountInString(title) > maxChatTitleLen {
+		return nil, fmt.Errorf("%w: title must be at most %d characters", domainerr.ErrInvalidInput, maxChatTitleLen)
+	}
+
+	now := time.Now().UTC()
+	thread := &chat.Thread{
+		ID:        uuid.NewString(),
+		UserID:    userID.String(),
+		Title:     title,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := u.chatRepository.CreateThread(ctx, thread); err != nil {
+		return nil, err
+	}
+	return thread, nil
+}
+
+func (u *chatUsecase) ListThreads(ctx context.Context, userID uuid.UUID) ([]chat.Thread, error) {
+	return u.chatRepository.ListThreads(ctx, userID.String())
+}
+
+func (u *chatUsecase) GetThread(ctx context.Context, threadID string) (*chat.Thread, error) {
+	return u.chatRepository.GetThread(ctx, threadID)
+}
+
+func (u *chatUsecase) GetThreadOwner(ctx context.Context, threadID string) (string, error) {
+	return u.chatRepository.GetThreadOwnerID(ctx, threadID)
+}
+
+func (u *chatUsecase) DeleteThread(ctx context.Context, threadID string) error {
+	return u.chatRepository.DeleteThread(ctx, threadID)
+}
+
+func (u *chatUsecase) ListMessages(ctx context.Context, threadID string) ([]chat.Message, error) {
+	if _, err := u.chatRepository.GetThread(ctx, threadID); err != nil {
+		return nil, err
+	}
+	// The API shows the whole thread; the history limit only applies to
+	// what is sent to the model.
+	return u.chatRepository.ListMessages(ctx, threadID, 500)
+}
+
+func (u *chatUsecase) SendMessage(ctx context.Context, userID uuid.UUID, threadID string, content string) (*chat.Message, error) {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return nil, fmt.Errorf("%w: content must not be empty", domainerr.ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(content) > chat.MaxMessageLen {
+		return nil, fmt.Errorf("%w: content must be at most %d characters", domainerr.ErrInvalidInput, chat.MaxMessageLen)
+	}
+
+	history, err := u.chatRepository.ListMessages(ctx, threadID, chat.HistoryLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := u.buildContext(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The question is only stored with its answer: a failed call leaves the
+	// thread as it was, and the user can simply ask again.
+	question := &chat.Message{
+		ID:        uuid.NewString(),
+		ThreadID:  threadID,
+		Role:      chat.RoleUser,
+		Content:   content,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	turns := make([]orchestrator.ChatTurn, 0, len(history))
+	for _, m := range history {
+		turns = append(turns, orchestrator.ChatTurn{Role: string(m.Role), Content: m.Content})
+	}
+
+	out, err := u.orchestrator.Chat(ctx, orchestrator.ChatInput{
+		UserID:      userID.String(),
+		Context:     items,
+		History:     turns,
+		Question:    content,
+		TokenBudget: u.tokenBudget,
+		Voice:       voiceFor(ctx, u.userRepository, userID.String()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &chat.Message{
+		ID:        uuid.NewString(),
+		ThreadID:  threadID,
+		Role:      chat.RoleAssistant,
+		Content:   out.Reply,
+		Citations: resolveCitations(out.Citations, items),
+		Source:    sourceOrAI(out.Source),
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := u.chatRepository.AddExchange(ctx, question, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+// buildContext collects the data the coach may talk about, most important
+// first: load, active split, recent sessions, nutrition, recommendations.
+func (u *chatUsecase) buildContext(ctx context.Context, userID uuid.UUID) ([]orchestrator.ContextItem, error) {
+	now := time.Now().UTC()
+	uid := userID.String()
+	var items []orchestrator.ContextItem
+
+	model := loadModelFor(ctx, u.userRepository, uid)
+	history, sessions, err := loadHistory(ctx, u.workoutRepository, model, uid, now)
+	if err != nil {
+		return nil, err
+	}
+	loadSum := model.Summarize(history, now)
+	items = append(items, orchestrator.ContextItem{
+		ID:    "load",
+		Kind:  "load",
+		Label: "Training load",
+		Text: fmt.Sprintf("Training load (%s, %s): acute 7d %.0f, chronic 28d %.0f, ACWR %.2f, %d sessions in 7 days, %d in 28 days",
+			loadSum.Model, loadSum.Unit, loadSum.AcuteLoad7d, loadSum.ChronicLoad28d, loadSum.ACWR, loadSum.Sessions7d, loadSum.Sessions28d),
+	})
+
+	templates, err := u.splitRepository.GetUserTemplates(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	for _, tpl := range templates {
+		if !tpl.IsActive {
+			continue
+		}
+		days := make([]string, 0, len(tpl.Days))
+		for _, d := range tpl.Days {
+			days = append(days, d.Name)
+		}
+		items = append(items, orchestrator.ContextItem{
+			ID:    "split:" + tpl.ID,
+			Kind:  "split",
+			Label: tpl.Name,
+			Text:  fmt.Sprintf("Active split %q: %s", tpl.Name, strings.Join(days, ", ")),
+		})
+		break
+	}
+
+	recent := sessions
+	if len(recent) > chatRecentSessions {
+		recent = recent[:chatRecentSessions]
+	}
+	names := u.exerciseNames(ctx, recent)
+	for _, s := range recent {
+		date := s.SessionDate.Format("2006-01-02")
+		items = append(items, orchestrator.ContextItem{
+			ID:    "session:" + s.ID,
+			Kind:  "session",
+			Label: "Workout on " + date,
+			Text:  describeSession(s, names),
+		})
+	}
+
+	if u.nutritionRepository != nil {
+		for i := 0; i < chatNutritionDays; i++ {
+			date := now.AddDate(0, 0, -i).Format("2006-01-02")
+			n, err := u.nutritionRepository.GetByDate(ctx, uid, date)
+			if err != nil {
+				if errors.Is(err, domainerr.ErrNotFound) {
+					continue
+				}
+				return nil, err
+			}
+			text := fmt.Sprintf("Nutrition on %s: protein %dg, calories %d", date, n.ProteinGrams, n.Calories)
+			if notes := strings.TrimSpace(n.Notes); notes != "" {
+				text += ", notes: " + notes
+			}
+			items = append(items, orchestrator.ContextItem{
+				ID:    "nutrition:" + date,
+				Kind:  "nutrition",
+				Label: "Nutrition on " + date,
+				Text:  text,
+			})
+		}
+	}
+
+	recs, err := u.plannerRepository.GetUserRecommendations(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	if len(recs) > chatRecommendations {
+		recs = recs[:chatRecommendations]
+	}
+	for _, r := range recs {
+		items = append(items, orchestrator.ContextItem{
+			ID:    "rec:" + r.ID,
+			Kind:  "recommendation",
+			Label: "Recommendation from " + r.CreatedAt.Format("2006-01-02"),
+			Text:  fmt.Sprintf("Recommendation (%s, %s): %s", r.RecommendationType, r.CreatedAt.Format("2006-01-02"), strings.TrimSpace(r.Recommendation)),
+		})
+	}
+	return items, nil
+}
+
+// exerciseNames looks up the library names of every exercise in sessions.
+// Names are a nicety; a failed lookup leaves the ids unresolved.
+func (u *chatUsecase) exerciseNames(ctx context.Context, sessions []workout.WorkoutSession) map[string]string {
+	names := make(map[string]string)
+	if u.exerciseRepository == nil {
+		return names
+	}
+
+	var ids []string
+	for _, s := range sessions {
+		for _, ex := range s.Exercises {
+			if _, ok := names[ex.ExerciseID]; !ok {
+				names[ex.ExerciseID] = ""
+				ids = append(ids, ex.ExerciseID)
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return names
+	}
+
+	exs, err := u.exerciseRepository.GetByIDs(ctx, ids)
+	if err != nil {
+		return names
+	}
+	for _, ex := range exs {
+		names[ex.ID] = ex.Name
+	}
+	return names
+}
+
+func describeSession(s workout.WorkoutSession, names map[string]string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Workout on %s", s.SessionDate.Format("2006-01-02"))
+	if s.DurationMin > 0 {
+		fmt.Fprintf(&b, " (%d min)", s.DurationMin)
+	}
+
+	parts := make([]string, 0, len(s.Exercises))
+	for _, ex := range s.Exercises {
+		name := names[ex.ExerciseID]
+		if name == "" {
+			name = "exercise " + ex.ExerciseID
+		}
+		top := 0.0
+		for _, set := range ex.Sets {
+			if set.Weight > top {
+				top = set.Weight
+			}
+		}
+		part := fmt.Sprintf("%s %d sets", name, len(ex.Sets))
+		if top > 0 {
+			part += fmt.Sprintf(" top %.1fkg", top)
+		}
+		parts = append(parts, part)
+	}
+	sort.Strings(parts)
+	if len(parts) > 0 {
+		b.WriteString(": " + strings.Join(parts, "; "))
+	}
+	if notes := strings.TrimSpace(s.Notes); notes != "" {
+		b.WriteString(". Notes: " + notes)
+	}
+	return b.String()
+}
+
+// resolveCitations turns the context ids the model cited into citations the
+// client can link, e.g. "session:<id>" into Kind "session" and Ref "<id>".
+func resolveCitations(ids []string, items []orchestrator.ContextItem) []chat.Citation {
+	byID := make(map[string]orchestrator.ContextItem, len(items))
+	for _, item := range items {
+		byID[item.ID] = item
+	}
+
+	out := make([]chat.Citation, 0, len(ids))
+	for _, id := range ids {
+		item, ok := byID[id]
+		if !ok {
+			continue
+		}
+		_, ref, _ := strings.Cut(item.ID, ":")
+		out = append(out, chat.Citation{Kind: item.Kind, Ref: ref, Label: item.Label})
+	}
+	return out
+}
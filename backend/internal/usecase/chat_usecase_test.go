@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"S.P.A.R.T.A/backend/internal/ai/orchestrator"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/chat"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/coach"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/planner"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/split"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+	domainrepo "S.P.A.R.T.A/backend/internal/domain/repository"
+	domainuc "S.P.A.R.T.A/backend/internal/domain/usecase"
+	"github.com/google/uuid"
+)
+
+type memChatRepo struct {
+	domainrepo.ChatRepository
+	messages []chat.Message
+}
+
+func (r *memChatRepo) ListMessages(_ context.Context, _ string, _ int) ([]chat.Message, error) {
+	return r.messages, nil
+}
+
+func (r *memChatRepo) AddExchange(_ context.Context, question, reply *chat.Message) error {
+	r.messages = append(r.messages, *question, *reply)
+	return nil
+}
+
+type emptySplitRepo struct{ domainrepo.SplitRepository }
+
+func (emptySplitRepo) GetUserTemplates(context.Context, string) ([]split.SplitTemplate, error) {
+	return nil, nil
+}
+
+type emptyPlannerRepo struct{ domainrepo.PlannerRepository }
+
+func (emptyPlannerRepo) GetUserRecommendations(context.Context, string) ([]planner.PlannerRecommendation, error) {
+	return nil, nil
+}
+
+// chatOrchestrator answers Chat with reply, or fails with err.
+type chatOrchestrator struct {
+	orchestrator.Orchestrator
+	reply string
+	err   error
+	seen  []orchestrator.ChatInput
+}
+
+func (o *chatOrchestrator) Chat(_ context.Context, input orchestrator.ChatInput) (*orchestrator.ChatOutput, error) {
+	o.seen = append(o.seen, input)
+	if o.err != nil {
+		return nil, o.err
+	}
+	return &orchestrator.ChatOutput{Reply: o.reply, Source: coach.SourceRules}, nil
+}
+
+func newChatFixture(orch *chatOrchestrator) (domainuc.ChatUsecase, *memChatRepo) {
+	repo := &memChatRepo{}
+	uc := NewChatUsecase(repo, orch, &rangeWorkoutRepo{}, nil, nil, emptyPlannerRepo{}, emptySplitRepo{}, nil, 2000)
+	return uc, repo
+}
+
+func TestSendMessageStoresNothingWhenTheCoachFails(t *testing.T) {
+	orch := &chatOrchestrator{err: domainerr.ErrAIUnavailable}
+	uc, repo := newChatFixture(orch)
+
+	_, err := uc.SendMessage(context.Background(), uuid.New(), "t1", "How was my week?")
+	if !errors.Is(err, domainerr.ErrAIUnavailable) {
+		t.Fatalf("SendMessage error = %v, want ErrAIUnavailable", err)
+	}
+	if len(repo.messages) != 0 {
+		t.Fatalf("stored %d messages after a failed answer, want none", len(repo.messages))
+	}
+
+	// Asking again does not send the failed question as history.
+	orch.err = nil
+	orch.reply = "Solid week."
+	if _, err := uc.SendMessage(context.Background(), uuid.New(), "t1", "How was my week?"); err != nil {
+		t.Fatal(err)
+	}
+	if got := orch.seen[1].History; len(got) != 0 {
+		t.Fatalf("retry was sent history %v, want none", got)
+	}
+}
+
+func TestSendMessageStoresQuestionAndReplyInOrder(t *testing.T) {
+	orch := &chatOrchestrator{reply: "Sleep more."}
+	uc, repo := newChatFixture(orch)
+
+	reply, err := uc.SendMessage(context.Background(), uuid.New(), "t1", "  Why am I tired?  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(repo.messages) != 2 {
+		t.Fatalf("stored %d messages, want the question and the reply", len(repo.messages))
+	}
+	question, stored := repo.messages[0], repo.messages[1]
+	if question.Role != chat.RoleUser || question.Content != "Why am I tired?" || question.ThreadID != "t1" {
+		t.Errorf("question = %+v", question)
+	}
+	if stored.ID != reply.ID || stored.Role != chat.RoleAssistant || stored.Content != "Sleep more." || stored.Source != coach.SourceRules {
+		t.Errorf("reply = %+v", stored)
+	}
+	if stored.CreatedAt.Before(question.CreatedAt) {
+		t.Errorf("reply at %v precedes the question at %v", stored.CreatedAt, question.CreatedAt)
+	}
+}
@@ -0,0 +1,244 @@
+package usecase
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/record"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/workout"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+	domainrepo "S.P.A.R.T.A/backend/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// memStore is an in-memory stand-in for the tables the usecases under test
+// touch. It mimics the foreign keys that matter: records lose their session
+// and set references when those rows are deleted.
+type memStore struct {
+	sessions map[string]workout.WorkoutSession
+	records  map[string]record.PersonalRecord
+}
+
+func newMemStore() *memStore {
+	return &memStore{
+		sessions: map[string]workout.WorkoutSession{},
+		records:  map[string]record.PersonalRecord{},
+	}
+}
+
+func (s *memStore) clone() *memStore {
+	out := &memStore{sessions: map[string]workout.WorkoutSession{}, records: maps.Clone(s.records)}
+	for id, sess := range s.sessions {
+		out.sessions[id] = cloneSession(sess)
+	}
+	return out
+}
+
+// dropSetRefs is ON DELETE SET NULL for personal_records.workout_set_id.
+func (s *memStore) dropSetRefs(sess workout.WorkoutSession) {
+	for _, ex := range sess.Exercises {
+		for _, set := range ex.Sets {
+			for id, rec := range s.records {
+				if rec.WorkoutSetID != nil && *rec.WorkoutSetID == set.ID {
+					rec.WorkoutSetID = nil
+					s.records[id] = rec
+				}
+			}
+		}
+	}
+}
+
+// memUnitOfWork runs fn against the store and restores the previous state
+// when fn fails, like a rolled back transaction.
+type memUnitOfWork struct{ store *memStore }
+
+func (u memUnitOfWork) Do(_ context.Context, fn func(r domainrepo.Registry) error) error {
+	before := u.store.clone()
+	if err := fn(memRegistry{store: u.store}); err != nil {
+		*u.store = *before
+		return err
+	}
+	return nil
+}
+
+type memRegistry struct {
+	domainrepo.Registry
+	store *memStore
+}
+
+func (r memRegistry) Workout() domainrepo.WorkoutRepository {
+	return memWorkoutRepo{store: r.store}
+}
+
+func (r memRegistry) PersonalRecord() domainrepo.PersonalRecordRepository {
+	return memRecordRepo{store: r.store}
+}
+
+type memWorkoutRepo struct {
+	domainrepo.WorkoutRepository
+	store *memStore
+}
+
+func (r memWorkoutRepo) CreateSession(_ context.Context, s *workout.WorkoutSession) error {
+	r.store.sessions[s.ID] = cloneSession(*s)
+	return nil
+}
+
+// UpdateSession deletes and re-inserts the session's exercises and sets,
+// as the postgres repository does.
+func (r memWorkoutRepo) UpdateSession(_ context.Context, s *workout.WorkoutSession) error {
+	old, ok := r.store.sessions[s.ID]
+	if !ok {
+		return domainerr.ErrNotFound
+	}
+	r.store.dropSetRefs(old)
+	r.store.sessions[s.ID] = cloneSession(*s)
+	return nil
+}
+
+func (r memWorkoutRepo) UpdateSessionStatus(_ context.Context, id string, status string) error {
+	s, ok := r.store.sessions[id]
+	if !ok {
+		return domainerr.ErrNotFound
+	}
+	s.Status = status
+	r.store.sessions[id] = s
+	return nil
+}
+
+func (r memWorkoutRepo) DeleteSession(_ context.Context, id string) error {
+	old, ok := r.store.sessions[id]
+	if !ok {
+		return domainerr.ErrNotFound
+	}
+	r.store.dropSetRefs(old)
+	for rid, rec := range r.store.records {
+		if rec.WorkoutSessionID != nil && *rec.WorkoutSessionID == id {
+			rec.WorkoutSessionID = nil
+			r.store.records[rid] = rec
+		}
+	}
+	delete(r.store.sessions, id)
+	return nil
+}
+
+func (r memWorkoutRepo) GetSessionByID(_ context.Context, id string) (*workout.WorkoutSession, error) {
+	s, ok := r.store.sessions[id]
+	if !ok {
+		return nil, domainerr.ErrNotFound
+	}
+	s = cloneSession(s)
+	return &s, nil
+}
+
+func (r memWorkoutRepo) GetSessionsByUserInRange(_ context.Context, userID string, from, to time.Time) ([]workout.WorkoutSession, error) {
+	var out []workout.WorkoutSession
+	for _, s := range r.store.sessions {
+		if s.UserID == userID && s.Status == workout.SessionStatusCompleted && !s.SessionDate.Before(from) && !s.SessionDate.After(to) {
+			out = append(out, cloneSession(s))
+		}
+	}
+	slices.SortFunc(out, func(a, b workout.WorkoutSession) int { return b.SessionDate.Compare(a.SessionDate) })
+	return out, nil
+}
+
+func (r memWorkoutRepo) GetSessionsByUserAndExercises(_ context.Context, userID string, exerciseIDs []string) ([]workout.WorkoutSession, error) {
+	var out []workout.WorkoutSession
+	for _, s := range r.store.sessions {
+		if s.UserID != userID || s.Status != workout.SessionStatusCompleted {
+			continue
+		}
+		s = cloneSession(s)
+		s.Exercises = slices.DeleteFunc(s.Exercises, func(ex workout.WorkoutExercise) bool {
+			return !slices.Contains(exerciseIDs, ex.ExerciseID)
+		})
+		if len(s.Exercises) > 0 {
+			out = append(out, s)
+		}
+	}
+	slices.SortFunc(out, func(a, b workout.WorkoutSession) int { return b.SessionDate.Compare(a.SessionDate) })
+	return out, nil
+}
+
+func (r memWorkoutRepo) AddSet(_ context.Context, workoutExerciseID string, set *workout.WorkoutSet) error {
+	for id, s := range r.store.sessions {
+		for i := range s.Exercises {
+			if s.Exercises[i].ID == workoutExerciseID {
+				s.Exercises[i].Sets = append(s.Exercises[i].Sets, *set)
+				r.store.sessions[id] = s
+				return nil
+			}
+		}
+	}
+	return domainerr.ErrNotFound
+}
+
+func (r memWorkoutRepo) UpdateSet(_ context.Context, set *workout.WorkoutSet) error {
+	return r.editSet(set.ID, func(sets []workout.WorkoutSet, i int) []workout.WorkoutSet {
+		sets[i] = *set
+		return sets
+	})
+}
+
+func (r memWorkoutRepo) DeleteSet(_ context.Context, setID string) error {
+	return r.editSet(setID, func(sets []workout.WorkoutSet, i int) []workout.WorkoutSet {
+		r.store.dropSetRefs(workout.WorkoutSession{Exercises: []workout.WorkoutExercise{{Sets: sets[i : i+1]}}})
+		return slices.Delete(sets, i, i+1)
+	})
+}
+
+func (r memWorkoutRepo) editSet(setID string, edit func(sets []workout.WorkoutSet, i int) []workout.WorkoutSet) error {
+	for id, s := range r.store.sessions {
+		for e := range s.Exercises {
+			for i := range s.Exercises[e].Sets {
+				if s.Exercises[e].Sets[i].ID == setID {
+					s.Exercises[e].Sets = edit(s.Exercises[e].Sets, i)
+					r.store.sessions[id] = s
+					return nil
+				}
+			}
+		}
+	}
+	return domainerr.ErrNotFound
+}
+
+type memRecordRepo struct {
+	domainrepo.PersonalRecordRepository
+	store *memStore
+}
+
+func (r memRecordRepo) GetByUserAndExercises(_ context.Context, userID string, exerciseIDs []string) ([]record.PersonalRecord, error) {
+	var out []record.PersonalRecord
+	for _, rec := range r.store.records {
+		if rec.UserID == userID && slices.Contains(exerciseIDs, rec.ExerciseID) {
+			out = append(out, rec)
+		}
+	}
+	return out, nil
+}
+
+// Upsert keeps one record per (user, exercise, type, weight key).
+func (r memRecordRepo) Upsert(_ context.Context, rec *record.PersonalRecord) error {
+	for id, stored := range r.store.records {
+		if stored.UserID == rec.UserID && stored.ExerciseID == rec.ExerciseID && stored.Type == rec.Type && stored.WeightKey() == rec.WeightKey() {
+			rec.ID = id
+			rec.CreatedAt = stored.CreatedAt
+		}
+	}
+	if rec.ID == "" {
+		rec.ID = uuid.NewString()
+	}
+	r.store.records[rec.ID] = *rec
+	return nil
+}
+
+func (r memRecordRepo) DeleteByUserAndExercises(_ context.Context, userID string, exerciseIDs []string) error {
+	for id, rec := range r.store.records {
+		if rec.UserID == userID && slices.Contains(exerciseIDs, rec.ExerciseID) {
+			delete(r.store.records, id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/workout"
+	domainrepo "S.P.A.R.T.A/backend/internal/domain/repository"
+	"S.P.A.R.T.A/backend/internal/domain/service/training"
+)
+
+// rangeWorkoutRepo serves GetSessionsByUserInRange from sessions (newest
+// first) and remembers the range asked for.
+type rangeWorkoutRepo struct {
+	domainrepo.WorkoutRepository
+	sessions []workout.WorkoutSession
+	from     time.Time
+}
+
+func (r *rangeWorkoutRepo) GetSessionsByUserInRange(_ context.Context, _ string, from, to time.Time) ([]workout.WorkoutSession, error) {
+	r.from = from
+	var out []workout.WorkoutSession
+	for _, s := range r.sessions {
+		if !s.SessionDate.Before(from) && !s.SessionDate.After(to) {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+func TestLoadHistoryReadsWhatTheModelNeeds(t *testing.T) {
+	now := time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC)
+	today := training.BucketStart(now, training.BucketDay)
+	repo := &rangeWorkoutRepo{}
+	for d := 0; d < 200; d++ {
+		repo.sessions = append(repo.sessions, workout.WorkoutSession{
+			SessionDate: today.AddDate(0, 0, -d),
+			Exercises:   []workout.WorkoutExercise{{Sets: []workout.WorkoutSet{{Reps: 5, Weight: 100}}}},
+		})
+	}
+
+	for _, name := range []training.LoadModelName{training.LoadModelTonnage, training.LoadModelEWMA} {
+		model := training.NewLoadModel(name, training.LoadOptions{})
+		history, recent, err := loadHistory(context.Background(), repo, model, "u1", now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if days := int(now.Sub(repo.from).Hours() / 24); days < model.HistoryDays() {
+			t.Errorf("%s: loaded %d days, the model reads %d", name, days, model.HistoryDays())
+		}
+		if len(recent) != loadWindowDays {
+			t.Errorf("%s: %d recent sessions, want the %d inside loadWindowDays", name, len(recent), loadWindowDays)
+		}
+		if acwr := model.Summarize(history, now).ACWR; acwr < 0.95 || acwr > 1.05 {
+			t.Errorf("%s: daily training gives ACWR %.3f, want about 1", name, acwr)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/record"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+	domainrepo "S.P.A.R.T.A/backend/internal/domain/repository"
+	"S.P.A.R.T.A/backend/internal/domain/service/records"
+	domainuc "S.P.A.R.T.A/backend/internal/domain/usecase"
+)
+
+type personalRecordUsecase struct {
+	recordRepo  domainrepo.PersonalRecordRepository
+	workoutRepo domainrepo.WorkoutRepository
+	engine      *records.Engine
+	uow         domainrepo.UnitOfWork
+}
+
+func NewPersonalRecordUsecase(
+	recordRepo domainrepo.PersonalRecordRepository,
+	workoutRepo domainrepo.WorkoutRepository,
+	engine *records.Engine,
+	uow domainrepo.UnitOfWork,
+) domainuc.PersonalRecordUsecase {
+	return &personalRecordUsecase{
+		recordRepo:  recordRepo,
+		workoutRepo: workoutRepo,
+		engine:      engine,
+		uow:         uow,
+	}
+}
+
+func (u *personalRecordUsecase) GetUserRecords(ctx context.Context, userID string) ([]record.PersonalRecord, error) {
+	if userID == "" {
+		return nil, domainerr.ErrInvalidInput
+	}
+	return u.recordRepo.GetByUser(ctx, userID)
+}
+
+func (u *personalRecordUsecase) GetExerciseRecords(
+	ctx context.Context,
+	userID string,
+	exerciseID string,
+) ([]record.PersonalRecord, error) {
+	if userID == "" || exerciseID == "" {
+		return nil, domainerr.ErrInvalidInput
+	}
+	return u.recordRepo.GetByUserAndExercises(ctx, userID, []string{exerciseID})
+}
+
+// RebuildUserRecords recomputes a user's records from their full history and
+// replaces the stored ones. It returns the number of records written.
+func (u *personalRecordUsecase) RebuildUserRecords(ctx context.Context, userID string) (int, error) {
+	if userID == "" {
+		return 0, domainerr.ErrInvalidInput
+	}
+
+	sessions, err := u.workoutRepo.GetSessionsByUserInRange(ctx, userID, time.Unix(0, 0).UTC(), time.Now().UTC())
+	if err != nil {
+		return 0, err
+	}
+
+	rebuilt := u.engine.Replay(sessions)
+
+	err = u.uow.Do(ctx, func(r domainrepo.Registry) error {
+		if err := r.PersonalRecord().DeleteByUser(ctx, userID); err != nil {
+			return err
+		}
+		for i := range rebuilt {
+			if err := r.PersonalRecord().Upsert(ctx, &rebuilt[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(rebuilt), nil
+}
@@ -30,13 +30,13 @@ func (u *plannerUsecase) GenerateRecommendation(ctx context.Context, userID stri
 		return nil, domainerr.ErrInternal
 	}
 
-	suggestions, err := u.aiCoach.GetCoachingSuggestions(ctx, uid)
+	coaching, err := u.aiCoach.GetCoachingSuggestions(ctx, uid)
 	if err != nil {
 		return nil, err
 	}
 
-	lines := make([]string, 0, len(suggestions))
-	for _, s := range suggestions {
+	lines := make([]string, 0, len(coaching.Suggestions))
+	for _, s := range coaching.Suggestions {
 		item := strings.TrimSpace(s)
 		if item == "" {
 			continue
@@ -54,6 +54,8 @@ func (u *plannerUsecase) GenerateRecommendation(ctx context.Context, userID stri
 		UserID:             userID,
 		Recommendation:     recText,
 		RecommendationType: "ai_coaching",
+		Source:             coaching.Source,
+		PromptVariant:      coaching.PromptVariant,
 		CreatedAt:          time.Now().UTC(),
 	}
 
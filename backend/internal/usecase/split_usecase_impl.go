@@ -10,22 +10,67 @@ import (
 
 type splitUsecase struct {
 	repo domainrepo.SplitRepository
+	uow  domainrepo.UnitOfWork
 }
 
-func NewSplitUsecase(repo domainrepo.SplitRepository) domainuc.SplitUsecase {
-	return &splitUsecase{repo: repo}
+func NewSplitUsecase(repo domainrepo.SplitRepository, uow domainrepo.UnitOfWork) domainuc.SplitUsecase {
+	return &splitUsecase{repo: repo, uow: uow}
 }
 
 func (u *splitUsecase) CreateTemplate(ctx context.Context, tpl *split.SplitTemplate) error {
-	return u.repo.CreateTemplate(ctx, tpl)
+	return u.uow.Do(ctx, func(r domainrepo.Registry) error {
+		return r.Split().CreateTemplate(ctx, tpl)
+	})
 }
 
 func (u *splitUsecase) UpdateTemplate(ctx context.Context, tpl *split.SplitTemplate) error {
-	return u.repo.UpdateTemplate(ctx, tpl)
+	// UpdateTemplate rewrites days/exercises; a failure halfway must not leave
+	// the template without days.
+	return u.uow.Do(ctx, func(r domainrepo.Registry) error {
+		existing, err := r.Split().GetTemplateByID(ctx, tpl.ID)
+		if err != nil {
+			return err
+		}
+		keepDayIDs(tpl, existing)
+		return r.Split().UpdateTemplate(ctx, tpl)
+	})
+}
+
+// keepDayIDs gives each day of tpl that is not already one of existing's
+// days the ID of the existing day at the same position, so plans and
+// sessions logged against that day stay linked to it.
+func keepDayIDs(tpl *split.SplitTemplate, existing *split.SplitTemplate) {
+	known := make(map[string]bool, len(existing.Days))
+	byOrder := make(map[int]string, len(existing.Days))
+	for _, day := range existing.Days {
+		known[day.ID] = true
+		byOrder[day.DayOrder] = day.ID
+	}
+
+	taken := make(map[string]bool, len(tpl.Days))
+	for _, day := range tpl.Days {
+		if known[day.ID] {
+			taken[day.ID] = true
+		}
+	}
+	for i := range tpl.Days {
+		day := &tpl.Days[i]
+		if known[day.ID] {
+			continue
+		}
+		if id, ok := byOrder[day.DayOrder]; ok && !taken[id] {
+			day.ID = id
+			taken[id] = true
+		}
+	}
 }
 
 func (u *splitUsecase) ActivateTemplate(ctx context.Context, userID string, templateID string) error {
-	return u.repo.ActivateTemplate(ctx, userID, templateID)
+	// Deactivate-all + activate-one must be atomic, otherwise a missing template
+	// leaves the user with no active split.
+	return u.uow.Do(ctx, func(r domainrepo.Registry) error {
+		return r.Split().ActivateTemplate(ctx, userID, templateID)
+	})
 }
 
 func (u *splitUsecase) DeactivateTemplate(ctx context.Context, userID string, templateID string) error {
@@ -36,6 +81,10 @@ func (u *splitUsecase) GetTemplate(ctx context.Context, id string) (*split.Split
 	return u.repo.GetTemplateByID(ctx, id)
 }
 
+func (u *splitUsecase) GetTemplateOwner(ctx context.Context, id string) (string, error) {
+	return u.repo.GetTemplateOwnerID(ctx, id)
+}
+
 func (u *splitUsecase) GetUserTemplates(ctx context.Context, userID string) ([]split.SplitTemplate, error) {
 	return u.repo.GetUserTemplates(ctx, userID)
 }
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/split"
+)
+
+func TestKeepDayIDs(t *testing.T) {
+	existing := &split.SplitTemplate{Days: []split.SplitDay{
+		{ID: "push", DayOrder: 1},
+		{ID: "pull", DayOrder: 2},
+		{ID: "legs", DayOrder: 3},
+	}}
+
+	tests := []struct {
+		name string
+		days []split.SplitDay
+		want []string
+	}{
+		{
+			name: "new IDs take the ID of the day at the same position",
+			days: []split.SplitDay{{ID: "n1", DayOrder: 1}, {ID: "n2", DayOrder: 2}, {ID: "n3", DayOrder: 3}},
+			want: []string{"push", "pull", "legs"},
+		},
+		{
+			name: "known IDs are kept even when moved",
+			days: []split.SplitDay{{ID: "legs", DayOrder: 1}, {ID: "n2", DayOrder: 2}, {ID: "n3", DayOrder: 3}},
+			want: []string{"legs", "pull", "n3"},
+		},
+		{
+			name: "added days keep their new ID",
+			days: []split.SplitDay{{ID: "n1", DayOrder: 1}, {ID: "n4", DayOrder: 4}},
+			want: []string{"push", "n4"},
+		},
+		{
+			name: "two days at one position share nothing",
+			days: []split.SplitDay{{ID: "n1", DayOrder: 2}, {ID: "n2", DayOrder: 2}},
+			want: []string{"pull", "n2"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tpl := &split.SplitTemplate{Days: tc.days}
+			keepDayIDs(tpl, existing)
+			var got []string
+			for _, d := range tpl.Days {
+				got = append(got, d.ID)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("day IDs = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,259 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/coach"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/split"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/user"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/workout"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+	domainrepo "S.P.A.R.T.A/backend/internal/domain/repository"
+	"S.P.A.R.T.A/backend/internal/domain/service/training"
+	domainuc "S.P.A.R.T.A/backend/internal/domain/usecase"
+)
+
+type userUsecase struct {
+	userRepo domainrepo.UserRepository
+}
+
+func NewUserUsecase(userRepo domainrepo.UserRepository) domainuc.UserUsecase {
+	return &userUsecase{userRepo: userRepo}
+}
+
+func (u *userUsecase) GetPreferences(ctx context.Context, userID string) (*user.Preferences, error) {
+	if userID == "" {
+		return nil, domainerr.ErrInvalidInput
+	}
+	return u.userRepo.GetPreferences(ctx, userID)
+}
+
+func (u *userUsecase) UpdatePreferences(
+	ctx context.Context,
+	userID string,
+	patch user.PreferencesPatch,
+) (*user.Preferences, error) {
+	if userID == "" {
+		return nil, domainerr.ErrInvalidInput
+	}
+
+	prefs, err := u.userRepo.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if patch.LoadModel != nil {
+		model, ok := training.ParseLoadModel(*patch.LoadModel)
+		if !ok {
+			return nil, domainerr.ErrInvalidInput
+		}
+		prefs.LoadModel = string(model)
+	}
+	if patch.BodyWeightKg != nil {
+		switch w := *patch.BodyWeightKg; {
+		case w < 0:
+			return nil, domainerr.ErrInvalidInput
+		case w == 0:
+			prefs.BodyWeightKg = nil
+		default:
+			prefs.BodyWeightKg = &w
+		}
+	}
+	if patch.CoachPersona != nil {
+		persona, ok := coach.ParsePersona(*patch.CoachPersona)
+		if !ok {
+			return nil, domainerr.ErrInvalidInput
+		}
+		prefs.CoachPersona = string(persona)
+	}
+	if patch.CustomPersona != nil {
+		custom := strings.TrimSpace(*patch.CustomPersona)
+		if len([]rune(custom)) > coach.MaxCustomPersonaLen {
+			return nil, domainerr.ErrInvalidInput
+		}
+		prefs.CustomPersona = custom
+	}
+	if prefs.CoachPersona == string(coach.PersonaCustom) && prefs.CustomPersona == "" {
+		return nil, fmt.Errorf("%w: custom_persona is required for the custom persona", domainerr.ErrInvalidInput)
+	}
+	if patch.Language != nil {
+		lang := strings.TrimSpace(*patch.Language)
+		if lang == "" {
+			lang = coach.DefaultLanguage
+		}
+		prefs.Language = lang
+	}
+	if patch.Profanity != nil {
+		prefs.Profanity = *patch.Profanity
+	}
+	if err := applyConstraintsPatch(prefs, patch); err != nil {
+		return nil, err
+	}
+	prefs.UpdatedAt = time.Now().UTC()
+
+	if err := u.userRepo.UpdatePreferences(ctx, prefs); err != nil {
+		return nil, err
+	}
+	return prefs, nil
+}
+
+func applyConstraintsPatch(prefs *user.Preferences, patch user.PreferencesPatch) error {
+	c := constraintsFromPreferences(prefs)
+	err := applyConstraintsOverride(&c, split.ConstraintsOverride{
+		ExperienceLevel:   patch.ExperienceLevel,
+		Equipment:         patch.Equipment,
+		MaxSessionMinutes: patch.MaxSessionMinutes,
+		Injuries:          patch.Injuries,
+		ExcludedMovements: patch.ExcludedMovements,
+		PreferredDays:     patch.PreferredDays,
+	})
+	if err != nil {
+		return err
+	}
+
+	prefs.ExperienceLevel = c.ExperienceLevel
+	prefs.Equipment = c.Equipment
+	prefs.MaxSessionMinutes = c.MaxSessionMinutes
+	prefs.Injuries = c.Injuries
+	prefs.ExcludedMovements = c.ExcludedMovements
+	prefs.PreferredDays = c.PreferredDays
+	return nil
+}
+
+// applyConstraintsOverride validates the set fields of o and copies them
+// onto c.
+func applyConstraintsOverride(c *split.Constraints, o split.ConstraintsOverride) error {
+	if o.ExperienceLevel != nil {
+		level, ok := split.ParseExperienceLevel(*o.ExperienceLevel)
+		if !ok {
+			return fmt.Errorf("%w: experience_level must be beginner, intermediate or advanced", domainerr.ErrInvalidInput)
+		}
+		c.ExperienceLevel = level
+	}
+	if o.Equipment != nil {
+		equipment, err := split.ParseEquipment(*o.Equipment)
+		if err != nil {
+			return fmt.Errorf("%w: %v", domainerr.ErrInvalidInput, err)
+		}
+		c.Equipment = equipment
+	}
+	if o.MaxSessionMinutes != nil {
+		minutes := *o.MaxSessionMinutes
+		if minutes < 0 || minutes > split.MaxSessionMinutes {
+			return fmt.Errorf("%w: max_session_minutes must be between 0 and %d", domainerr.ErrInvalidInput, split.MaxSessionMinutes)
+		}
+		c.MaxSessionMinutes = minutes
+	}
+	if o.Injuries != nil {
+		injuries := strings.TrimSpace(*o.Injuries)
+		if len([]rune(injuries)) > split.MaxInjuriesLen {
+			return fmt.Errorf("%w: injuries must be at most %d characters", domainerr.ErrInvalidInput, split.MaxInjuriesLen)
+		}
+		c.Injuries = injuries
+	}
+	if o.ExcludedMovements != nil {
+		movements, err := split.ParseMovements(*o.ExcludedMovements)
+		if err != nil {
+			return fmt.Errorf("%w: %v", domainerr.ErrInvalidInput, err)
+		}
+		c.ExcludedMovements = movements
+	}
+	if o.PreferredDays != nil {
+		days, err := split.ParseWeekdays(*o.PreferredDays)
+		if err != nil {
+			return fmt.Errorf("%w: %v", domainerr.ErrInvalidInput, err)
+		}
+		c.PreferredDays = days
+	}
+	return nil
+}
+
+func constraintsFromPreferences(prefs *user.Preferences) split.Constraints {
+	level, _ := split.ParseExperienceLevel(prefs.ExperienceLevel)
+	return split.Constraints{
+		ExperienceLevel:   level,
+		Equipment:         prefs.Equipment,
+		MaxSessionMinutes: prefs.MaxSessionMinutes,
+		Injuries:          prefs.Injuries,
+		ExcludedMovements: prefs.ExcludedMovements,
+		PreferredDays:     prefs.PreferredDays,
+	}
+}
+
+// constraintsFor returns the split constraints stored in the user's
+// preferences, or defaults when they cannot be read.
+func constraintsFor(ctx context.Context, userRepo domainrepo.UserRepository, userID string) split.Constraints {
+	out := split.Constraints{ExperienceLevel: split.DefaultExperienceLevel}
+	if userRepo == nil {
+		return out
+	}
+	prefs, err := userRepo.GetPreferences(ctx, userID)
+	if err != nil || prefs == nil {
+		return out
+	}
+	return constraintsFromPreferences(prefs)
+}
+
+// voiceFor returns how AI answers should sound for the user, falling back
+// to the default voice when preferences cannot be read.
+func voiceFor(ctx context.Context, userRepo domainrepo.UserRepository, userID string) coach.Voice {
+	if userRepo == nil {
+		return coach.DefaultVoice()
+	}
+	prefs, err := userRepo.GetPreferences(ctx, userID)
+	if err != nil || prefs == nil {
+		return coach.DefaultVoice()
+	}
+
+	persona, _ := coach.ParsePersona(prefs.CoachPersona)
+	voice := coach.Voice{
+		Persona:   persona,
+		Language:  prefs.Language,
+		Profanity: prefs.Profanity,
+	}
+	if persona == coach.PersonaCustom {
+		voice.CustomPersona = prefs.CustomPersona
+	}
+	if voice.Language == "" {
+		voice.Language = coach.DefaultLanguage
+	}
+	return voice
+}
+
+// loadModelFor returns the user's preferred load model, falling back to
+// tonnage when preferences cannot be read.
+func loadModelFor(ctx context.Context, userRepo domainrepo.UserRepository, userID string) training.LoadModel {
+	if userRepo == nil {
+		return training.TonnageModel{}
+	}
+	prefs, err := userRepo.GetPreferences(ctx, userID)
+	if err != nil || prefs == nil {
+		return training.TonnageModel{}
+	}
+
+	name, _ := training.ParseLoadModel(prefs.LoadModel)
+	opts := training.LoadOptions{}
+	if prefs.BodyWeightKg != nil {
+		opts.BodyWeightKg = *prefs.BodyWeightKg
+	}
+	return training.NewLoadModel(name, opts)
+}
+
+// loadHistory fetches the sessions model needs, newest first, and returns
+// them together with the part inside loadWindowDays.
+func loadHistory(ctx context.Context, workoutRepo domainrepo.WorkoutRepository, model training.LoadModel, userID string, now time.Time) (history, recent []workout.WorkoutSession, err error) {
+	days := max(model.HistoryDays(), loadWindowDays)
+	history, err = workoutRepo.GetSessionsByUserInRange(ctx, userID, now.AddDate(0, 0, -days), now)
+	if err != nil {
+		return nil, nil, err
+	}
+	cutoff := now.AddDate(0, 0, -loadWindowDays)
+	n := 0
+	for n < len(history) && !history[n].SessionDate.Before(cutoff) {
+		n++
+	}
+	return history, history[:n], nil
+}
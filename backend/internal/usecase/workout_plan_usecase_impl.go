@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/workout"
+	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
+	domainrepo "S.P.A.R.T.A/backend/internal/domain/repository"
+	domainuc "S.P.A.R.T.A/backend/internal/domain/usecase"
+	"github.com/google/uuid"
+)
+
+// plannedSetType is the set type of the sets a started plan prefills.
+const plannedSetType = "working"
+
+type workoutPlanUsecase struct {
+	planRepo     domainrepo.WorkoutPlanRepository
+	workoutRepo  domainrepo.WorkoutRepository
+	exerciseRepo domainrepo.ExerciseRepository
+	aiCoach      domainuc.AICoachUsecase
+	uow          domainrepo.UnitOfWork
+}
+
+func NewWorkoutPlanUsecase(
+	planRepo domainrepo.WorkoutPlanRepository,
+	workoutRepo domainrepo.WorkoutRepository,
+	exerciseRepo domainrepo.ExerciseRepository,
+	aiCoach domainuc.AICoachUsecase,
+	uow domainrepo.UnitOfWork,
+) domainuc.WorkoutPlanUsecase {
+	return &workoutPlanUsecase{
+		planRepo:     planRepo,
+		workoutRepo:  workoutRepo,
+		exerciseRepo: exerciseRepo,
+		aiCoach:      aiCoach,
+		uow:          uow,
+	}
+}
+
+func (u *workoutPlanUsecase) GetPlan(ctx context.Context, id string) (*workout.WorkoutPlan, error) {
+	return u.planRepo.GetByID(ctx, id)
+}
+
+func (u *workoutPlanUsecase) GetPlanOwner(ctx context.Context, id string) (string, error) {
+	return u.planRepo.GetOwnerID(ctx, id)
+}
+
+func (u *workoutPlanUsecase) ListPlans(ctx context.Context, userID string, limit int) ([]workout.WorkoutPlan, error) {
+	if limit < 0 || limit > workout.MaxPlanPageSize {
+		return nil, domainerr.ErrInvalidInput
+	}
+	return u.planRepo.ListByUser(ctx, userID, limit)
+}
+
+func (u *workoutPlanUsecase) ExplainPlan(ctx context.Context, userID uuid.UUID, id string) (*workout.WorkoutExplanation, error) {
+	plan, err := u.planRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return u.aiCoach.ExplainWorkoutPlan(ctx, userID, *plan, plan.SplitDayName, plan.Fatigue)
+}
+
+func (u *workoutPlanUsecase) StreamExplainPlan(
+	ctx context.Context,
+	userID uuid.UUID,
+	id string,
+	onDelta func(string) error,
+) (*workout.WorkoutExplanation, error) {
+	plan, err := u.planRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return u.aiCoach.StreamExplainWorkoutPlan(ctx, userID, *plan, plan.SplitDayName, plan.Fatigue, onDelta)
+}
+
+func (u *workoutPlanUsecase) StartPlan(ctx context.Context, id string) (*workout.WorkoutSession, error) {
+	plan, err := u.planRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if plan.SessionID != nil {
+		return nil, domainerr.ErrConflict
+	}
+
+	session := draftSessionFromPlan(*plan, time.Now())
+	if len(session.Exercises) == 0 {
+		// Nothing in the plan resolved to a library exercise.
+		return nil, domainerr.ErrInvalidInput
+	}
+
+	err = u.uow.Do(ctx, func(r domainrepo.Registry) error {
+		if err := r.Workout().CreateSession(ctx, session); err != nil {
+			return err
+		}
+		return r.WorkoutPlan().AttachSession(ctx, plan.ID, session.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return u.workoutRepo.GetSessionByID(ctx, session.ID)
+}
+
+// draftSessionFromPlan prefills one working set per planned set at the
+// bottom of the rep range. Exercises without a library id are left out.
+func draftSessionFromPlan(plan workout.WorkoutPlan, now time.Time) *workout.WorkoutSession {
+	planID := plan.ID
+	session := &workout.WorkoutSession{
+		ID:          uuid.NewString(),
+		UserID:      plan.UserID,
+		PlanID:      &planID,
+		Status:      workout.SessionStatusDraft,
+		SessionDate: now.UTC().Truncate(24 * time.Hour),
+		CreatedAt:   now,
+	}
+	if plan.SplitDayID != "" {
+		splitDayID := plan.SplitDayID
+		session.SplitDayID = &splitDayID
+	}
+
+	for _, p := range plan.Exercises {
+		if p.ExerciseID == "" || p.Sets <= 0 {
+			continue
+		}
+		we := workout.WorkoutExercise{ID: uuid.NewString(), ExerciseID: p.ExerciseID}
+		for i := 0; i < p.Sets; i++ {
+			we.Sets = append(we.Sets, workout.WorkoutSet{
+				ID:        uuid.NewString(),
+				SetOrder:  i + 1,
+				Reps:      p.RepsMin,
+				Weight:    p.Weight,
+				SetType:   plannedSetType,
+				CreatedAt: now,
+			})
+		}
+		session.Exercises = append(session.Exercises, we)
+	}
+	return session
+}
+
+func (u *workoutPlanUsecase) ComparePlan(ctx context.Context, id string) (*workout.PlanComparison, error) {
+	plan, err := u.planRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if plan.SessionID == nil {
+		// Not started yet, or its session was deleted.
+		return nil, domainerr.ErrConflict
+	}
+
+	session, err := u.workoutRepo.GetSessionByID(ctx, *plan.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string]string{}
+	if u.exerciseRepo != nil {
+		if library, err := u.exerciseRepo.List(ctx); err == nil {
+			for _, ex := range library {
+				names[ex.ID] = ex.Name
+			}
+		}
+	}
+
+	comparison := workout.ComparePlan(*plan, *session, names)
+	return &comparison, nil
+}
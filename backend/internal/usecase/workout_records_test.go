@@ -0,0 +1,195 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/record"
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/workout"
+	"S.P.A.R.T.A/backend/internal/domain/service/records"
+	domainuc "S.P.A.R.T.A/backend/internal/domain/usecase"
+)
+
+const recordsUser = "u1"
+
+var recordsDay = time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+// newRecordsFixture logs two completed squat sessions: 100 kg on day 0 and
+// 140 kg on day 7, the latter also with a bench set.
+func newRecordsFixture(t *testing.T) (domainuc.WorkoutUsecase, *memStore) {
+	t.Helper()
+	store := newMemStore()
+	uc := NewWorkoutUsecase(memWorkoutRepo{store: store}, memUnitOfWork{store: store}, records.NewEngine(""))
+
+	for _, s := range []workout.WorkoutSession{
+		{ID: "s1", SessionDate: recordsDay, Exercises: []workout.WorkoutExercise{
+			{ID: "s1-squat", ExerciseID: "squat", Sets: []workout.WorkoutSet{{ID: "s1-set1", Reps: 5, Weight: 100}}},
+		}},
+		{ID: "s2", SessionDate: recordsDay.AddDate(0, 0, 7), Exercises: []workout.WorkoutExercise{
+			{ID: "s2-squat", ExerciseID: "squat", Sets: []workout.WorkoutSet{{ID: "s2-set1", Reps: 3, Weight: 140}}},
+			{ID: "s2-bench", ExerciseID: "bench", Sets: []workout.WorkoutSet{{ID: "s2-set2", Reps: 5, Weight: 80}}},
+		}},
+	} {
+		s.UserID = recordsUser
+		if _, err := uc.CreateWorkoutSession(context.Background(), &s); err != nil {
+			t.Fatalf("CreateWorkoutSession(%s): %v", s.ID, err)
+		}
+	}
+	return uc, store
+}
+
+// heaviest returns the stored heaviest-weight record for exerciseID.
+func heaviest(t *testing.T, store *memStore, exerciseID string) *record.PersonalRecord {
+	t.Helper()
+	for _, rec := range store.records {
+		if rec.ExerciseID == exerciseID && rec.Type == record.RecordHeaviestWeight {
+			return &rec
+		}
+	}
+	return nil
+}
+
+// assertRecordRefs checks that every record points at a session and set
+// that exist.
+func assertRecordRefs(t *testing.T, store *memStore) {
+	t.Helper()
+	for _, rec := range store.records {
+		if rec.WorkoutSessionID == nil || rec.WorkoutSetID == nil {
+			if rec.Type != record.RecordSessionVolume || rec.WorkoutSessionID == nil {
+				t.Errorf("%s/%s lost its references: session %v set %v", rec.ExerciseID, rec.Type, rec.WorkoutSessionID, rec.WorkoutSetID)
+			}
+			continue
+		}
+		sess, ok := store.sessions[*rec.WorkoutSessionID]
+		if !ok || !sessionHasSet(&sess, *rec.WorkoutSetID) {
+			t.Errorf("%s/%s points at missing session %s set %s", rec.ExerciseID, rec.Type, *rec.WorkoutSessionID, *rec.WorkoutSetID)
+		}
+	}
+}
+
+func TestWorkoutEditsRecomputeRecords(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		edit func(t *testing.T, uc domainuc.WorkoutUsecase)
+		// wantSquat is the heaviest squat afterwards; 0 means no record.
+		wantSquat float64
+		wantBench float64
+	}{
+		{
+			name: "update set lowers the record",
+			edit: func(t *testing.T, uc domainuc.WorkoutUsecase) {
+				_, err := uc.UpdateWorkoutSet(ctx, "s2", &workout.WorkoutSet{ID: "s2-set1", Reps: 3, Weight: 90})
+				if err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantSquat: 100, wantBench: 80,
+		},
+		{
+			name: "delete set drops the exercise's records",
+			edit: func(t *testing.T, uc domainuc.WorkoutUsecase) {
+				if _, err := uc.DeleteWorkoutSet(ctx, "s2", "s2-set2"); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantSquat: 140, wantBench: 0,
+		},
+		{
+			name: "add set raises the record",
+			edit: func(t *testing.T, uc domainuc.WorkoutUsecase) {
+				_, err := uc.AddWorkoutSet(ctx, "s1", "s1-squat", &workout.WorkoutSet{ID: "s1-set9", Reps: 1, Weight: 150})
+				if err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantSquat: 150, wantBench: 80,
+		},
+		{
+			name: "delete session falls back to older history",
+			edit: func(t *testing.T, uc domainuc.WorkoutUsecase) {
+				if err := uc.DeleteWorkoutSession(ctx, "s2"); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantSquat: 100, wantBench: 0,
+		},
+		{
+			name: "replace keeps records attached to the re-inserted sets",
+			edit: func(t *testing.T, uc domainuc.WorkoutUsecase) {
+				_, err := uc.ReplaceWorkoutSession(ctx, &workout.WorkoutSession{ID: "s2", SessionDate: recordsDay.AddDate(0, 0, 7), Exercises: []workout.WorkoutExercise{
+					{ID: "s2-squat", ExerciseID: "squat", Sets: []workout.WorkoutSet{{ID: "s2-set1", Reps: 3, Weight: 140}}},
+					{ID: "s2-bench", ExerciseID: "bench", Sets: []workout.WorkoutSet{{ID: "s2-set2", Reps: 5, Weight: 80}}},
+				}})
+				if err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantSquat: 140, wantBench: 80,
+		},
+		{
+			name: "patch moving sets to another exercise moves the records",
+			edit: func(t *testing.T, uc domainuc.WorkoutUsecase) {
+				_, err := uc.PatchWorkoutSession(ctx, "s2", workout.WorkoutSessionPatch{
+					Exercises: []workout.WorkoutExercisePatch{{ID: "s2-bench", ExerciseID: "squat"}},
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantSquat: 140, wantBench: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			uc, store := newRecordsFixture(t)
+			squatID := heaviest(t, store, "squat").ID
+
+			tc.edit(t, uc)
+
+			for exercise, want := range map[string]float64{"squat": tc.wantSquat, "bench": tc.wantBench} {
+				got := heaviest(t, store, exercise)
+				switch {
+				case want == 0 && got != nil:
+					t.Errorf("%s heaviest = %v, want no record", exercise, got.Value)
+				case want != 0 && got == nil:
+					t.Errorf("%s heaviest missing, want %v", exercise, want)
+				case want != 0 && got.Value != want:
+					t.Errorf("%s heaviest = %v, want %v", exercise, got.Value, want)
+				}
+			}
+			if got := heaviest(t, store, "squat"); got != nil && got.ID != squatID {
+				t.Errorf("squat record was re-created (id %s, was %s)", got.ID, squatID)
+			}
+			assertRecordRefs(t, store)
+		})
+	}
+}
+
+func TestDraftEditsLeaveRecordsAlone(t *testing.T) {
+	ctx := context.Background()
+	uc, store := newRecordsFixture(t)
+
+	draft := workout.WorkoutSession{ID: "d1", UserID: recordsUser, Status: workout.SessionStatusDraft, SessionDate: recordsDay.AddDate(0, 0, 8), Exercises: []workout.WorkoutExercise{
+		{ID: "d1-squat", ExerciseID: "squat", Sets: []workout.WorkoutSet{{ID: "d1-set1", Reps: 1, Weight: 200}}},
+	}}
+	if _, err := uc.CreateWorkoutSession(ctx, &draft); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := uc.UpdateWorkoutSet(ctx, "d1", &workout.WorkoutSet{ID: "d1-set1", Reps: 1, Weight: 210}); err != nil {
+		t.Fatal(err)
+	}
+	if got := heaviest(t, store, "squat"); got == nil || got.Value != 140 {
+		t.Fatalf("heaviest squat = %v, want 140 from the completed session", got)
+	}
+
+	if _, _, err := uc.FinishWorkoutSession(ctx, "d1"); err != nil {
+		t.Fatal(err)
+	}
+	if got := heaviest(t, store, "squat"); got == nil || got.Value != 210 {
+		t.Fatalf("heaviest squat after finishing = %v, want 210", got)
+	}
+}
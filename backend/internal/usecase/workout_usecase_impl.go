@@ -2,39 +2,204 @@ package usecase
 
 import (
 	"context"
+	"slices"
+	"time"
 
+	"S.P.A.R.T.A/backend/internal/domain/aggregate/record"
 	"S.P.A.R.T.A/backend/internal/domain/aggregate/workout"
 	domainerr "S.P.A.R.T.A/backend/internal/domain/errors"
 	domainrepo "S.P.A.R.T.A/backend/internal/domain/repository"
+	"S.P.A.R.T.A/backend/internal/domain/service/records"
 	domainuc "S.P.A.R.T.A/backend/internal/domain/usecase"
+	"github.com/google/uuid"
 )
 
 type workoutUsecase struct {
 	workoutRepo domainrepo.WorkoutRepository
+	uow         domainrepo.UnitOfWork
+	records     *records.Engine
 }
 
 func NewWorkoutUsecase(
 	workoutRepo domainrepo.WorkoutRepository,
+	uow domainrepo.UnitOfWork,
+	recordEngine *records.Engine,
 ) domainuc.WorkoutUsecase {
 	return &workoutUsecase{
 		workoutRepo: workoutRepo,
+		uow:         uow,
+		records:     recordEngine,
 	}
 }
 
 func (u *workoutUsecase) CreateWorkoutSession(
 	ctx context.Context,
 	session *workout.WorkoutSession,
-) error {
+) ([]record.PersonalRecord, error) {
 
 	if session.UserID == "" {
-		return domainerr.ErrInvalidInput
+		return nil, domainerr.ErrInvalidInput
 	}
 
 	if len(session.Exercises) == 0 {
-		return domainerr.ErrInvalidInput
+		return nil, domainerr.ErrInvalidInput
+	}
+
+	if session.Status == "" {
+		session.Status = workout.SessionStatusCompleted
+	}
+
+	// The session header, its exercises and their sets form one aggregate:
+	// either everything is persisted or nothing is. Records set by the
+	// session are written in the same transaction.
+	var newRecords []record.PersonalRecord
+	err := u.uow.Do(ctx, func(r domainrepo.Registry) error {
+		if err := r.Workout().CreateSession(ctx, session); err != nil {
+			return err
+		}
+		if session.Status != workout.SessionStatusCompleted {
+			return nil
+		}
+		detected, err := u.detectRecords(ctx, r, *session)
+		newRecords = detected
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newRecords, nil
+}
+
+// FinishWorkoutSession completes a draft session. Records are detected
+// only now, so a draft never counts towards them.
+func (u *workoutUsecase) FinishWorkoutSession(
+	ctx context.Context,
+	id string,
+) (*workout.WorkoutSession, []record.PersonalRecord, error) {
+	var newRecords []record.PersonalRecord
+	err := u.uow.Do(ctx, func(r domainrepo.Registry) error {
+		session, err := r.Workout().GetSessionByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if session.Status != workout.SessionStatusDraft {
+			return domainerr.ErrConflict
+		}
+		if err := r.Workout().UpdateSessionStatus(ctx, id, workout.SessionStatusCompleted); err != nil {
+			return err
+		}
+		session.Status = workout.SessionStatusCompleted
+
+		detected, err := u.detectRecords(ctx, r, *session)
+		newRecords = detected
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	session, err := u.workoutRepo.GetSessionByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return session, newRecords, nil
+}
+
+// detectRecords upserts and returns the records set by a completed session.
+func (u *workoutUsecase) detectRecords(
+	ctx context.Context,
+	r domainrepo.Registry,
+	session workout.WorkoutSession,
+) ([]record.PersonalRecord, error) {
+	if u.records == nil {
+		return nil, nil
 	}
 
-	return u.workoutRepo.CreateSession(ctx, session)
+	current, err := r.PersonalRecord().GetByUserAndExercises(ctx, session.UserID, sessionExerciseIDs(session))
+	if err != nil {
+		return nil, err
+	}
+
+	detected := u.records.Detect(session, current)
+	for i := range detected {
+		if err := r.PersonalRecord().Upsert(ctx, &detected[i]); err != nil {
+			return nil, err
+		}
+	}
+	return detected, nil
+}
+
+// replayRecords recomputes the user's records for exerciseIDs from every
+// completed session that logged them. Unlike detectRecords it can lower or drop a
+// record, which editing or deleting logged sets requires, and it points the
+// records at the sets as they are stored now.
+func (u *workoutUsecase) replayRecords(
+	ctx context.Context,
+	r domainrepo.Registry,
+	userID string,
+	exerciseIDs []string,
+) error {
+	if u.records == nil || len(exerciseIDs) == 0 {
+		return nil
+	}
+
+	history, err := r.Workout().GetSessionsByUserAndExercises(ctx, userID, exerciseIDs)
+	if err != nil {
+		return err
+	}
+	current, err := r.PersonalRecord().GetByUserAndExercises(ctx, userID, exerciseIDs)
+	if err != nil {
+		return err
+	}
+
+	rebuilt := u.records.Rebuild(history, current)
+	if err := r.PersonalRecord().DeleteByUserAndExercises(ctx, userID, exerciseIDs); err != nil {
+		return err
+	}
+	for i := range rebuilt {
+		if err := r.PersonalRecord().Upsert(ctx, &rebuilt[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// replayIfCompleted replays the records of the exercises in before and
+// after. Drafts never count towards records, so their edits are skipped.
+func (u *workoutUsecase) replayIfCompleted(
+	ctx context.Context,
+	r domainrepo.Registry,
+	before workout.WorkoutSession,
+	after *workout.WorkoutSession,
+) error {
+	if before.Status != workout.SessionStatusCompleted {
+		return nil
+	}
+	ids := sessionExerciseIDs(before)
+	if after != nil {
+		for _, id := range sessionExerciseIDs(*after) {
+			if !slices.Contains(ids, id) {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return u.replayRecords(ctx, r, before.UserID, ids)
+}
+
+func sessionExerciseIDs(s workout.WorkoutSession) []string {
+	seen := make(map[string]struct{}, len(s.Exercises))
+	ids := make([]string, 0, len(s.Exercises))
+	for _, ex := range s.Exercises {
+		if ex.ExerciseID == "" {
+			continue
+		}
+		if _, ok := seen[ex.ExerciseID]; ok {
+			continue
+		}
+		seen[ex.ExerciseID] = struct{}{}
+		ids = append(ids, ex.ExerciseID)
+	}
+	return ids
 }
 
 func (u *workoutUsecase) GetWorkoutSession(
@@ -44,9 +209,311 @@ func (u *workoutUsecase) GetWorkoutSession(
 	return u.workoutRepo.GetSessionByID(ctx, id)
 }
 
+func (u *workoutUsecase) GetWorkoutSessionOwner(ctx context.Context, id string) (string, error) {
+	return u.workoutRepo.GetSessionOwnerID(ctx, id)
+}
+
 func (u *workoutUsecase) GetUserWorkoutSessions(
 	ctx context.Context,
 	userID string,
-) ([]workout.WorkoutSession, error) {
-	return u.workoutRepo.GetSessionsByUser(ctx, userID)
+	filter workout.SessionFilter,
+) (*workout.SessionPage, error) {
+	if filter.From != nil && filter.To != nil && filter.From.After(*filter.To) {
+		return nil, domainerr.ErrInvalidInput
+	}
+	return u.workoutRepo.ListSessionsByUser(ctx, userID, filter)
+}
+
+func (u *workoutUsecase) ReplaceWorkoutSession(
+	ctx context.Context,
+	session *workout.WorkoutSession,
+) (*workout.WorkoutSession, error) {
+	if session == nil || session.ID == "" {
+		return nil, domainerr.ErrInvalidInput
+	}
+	if len(session.Exercises) == 0 {
+		return nil, domainerr.ErrInvalidInput
+	}
+
+	err := u.uow.Do(ctx, func(r domainrepo.Registry) error {
+		existing, err := r.Workout().GetSessionByID(ctx, session.ID)
+		if err != nil {
+			return err
+		}
+		// Ownership, creation time, status and origin are immutable here.
+		session.UserID = existing.UserID
+		session.CreatedAt = existing.CreatedAt
+		session.Status = existing.Status
+		session.PlanID = existing.PlanID
+		if err := r.Workout().UpdateSession(ctx, session); err != nil {
+			return err
+		}
+		return u.replayIfCompleted(ctx, r, *existing, session)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return u.workoutRepo.GetSessionByID(ctx, session.ID)
+}
+
+func (u *workoutUsecase) PatchWorkoutSession(
+	ctx context.Context,
+	id string,
+	patch workout.WorkoutSessionPatch,
+) (*workout.WorkoutSession, error) {
+	err := u.uow.Do(ctx, func(r domainrepo.Registry) error {
+		existing, err := r.Workout().GetSessionByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		before := cloneSession(*existing)
+		if err := applyWorkoutSessionPatch(existing, patch); err != nil {
+			return err
+		}
+		if len(existing.Exercises) == 0 {
+			return domainerr.ErrInvalidInput
+		}
+		if err := r.Workout().UpdateSession(ctx, existing); err != nil {
+			return err
+		}
+		return u.replayIfCompleted(ctx, r, before, existing)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return u.workoutRepo.GetSessionByID(ctx, id)
+}
+
+// DeleteWorkoutSession removes a session and recomputes the records it
+// held from the rest of the history.
+func (u *workoutUsecase) DeleteWorkoutSession(ctx context.Context, id string) error {
+	return u.uow.Do(ctx, func(r domainrepo.Registry) error {
+		existing, err := r.Workout().GetSessionByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := r.Workout().DeleteSession(ctx, id); err != nil {
+			return err
+		}
+		return u.replayIfCompleted(ctx, r, *existing, nil)
+	})
+}
+
+func (u *workoutUsecase) AddWorkoutSet(
+	ctx context.Context,
+	sessionID string,
+	workoutExerciseID string,
+	set *workout.WorkoutSet,
+) (*workout.WorkoutSession, error) {
+	if set == nil || set.ID == "" {
+		return nil, domainerr.ErrInvalidInput
+	}
+
+	err := u.uow.Do(ctx, func(r domainrepo.Registry) error {
+		session, err := r.Workout().GetSessionByID(ctx, sessionID)
+		if err != nil {
+			return err
+		}
+		if findWorkoutExercise(session, workoutExerciseID) == nil {
+			return domainerr.ErrNotFound
+		}
+		if err := r.Workout().AddSet(ctx, workoutExerciseID, set); err != nil {
+			return err
+		}
+		return u.replayIfCompleted(ctx, r, *session, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return u.workoutRepo.GetSessionByID(ctx, sessionID)
+}
+
+func (u *workoutUsecase) UpdateWorkoutSet(
+	ctx context.Context,
+	sessionID string,
+	set *workout.WorkoutSet,
+) (*workout.WorkoutSession, error) {
+	if set == nil || set.ID == "" {
+		return nil, domainerr.ErrInvalidInput
+	}
+
+	err := u.uow.Do(ctx, func(r domainrepo.Registry) error {
+		session, err := r.Workout().GetSessionByID(ctx, sessionID)
+		if err != nil {
+			return err
+		}
+		if !sessionHasSet(session, set.ID) {
+			return domainerr.ErrNotFound
+		}
+		if err := r.Workout().UpdateSet(ctx, set); err != nil {
+			return err
+		}
+		return u.replayIfCompleted(ctx, r, *session, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return u.workoutRepo.GetSessionByID(ctx, sessionID)
+}
+
+func (u *workoutUsecase) DeleteWorkoutSet(
+	ctx context.Context,
+	sessionID string,
+	setID string,
+) (*workout.WorkoutSession, error) {
+	err := u.uow.Do(ctx, func(r domainrepo.Registry) error {
+		session, err := r.Workout().GetSessionByID(ctx, sessionID)
+		if err != nil {
+			return err
+		}
+		if !sessionHasSet(session, setID) {
+			return domainerr.ErrNotFound
+		}
+		if err := r.Workout().DeleteSet(ctx, setID); err != nil {
+			return err
+		}
+		return u.replayIfCompleted(ctx, r, *session, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return u.workoutRepo.GetSessionByID(ctx, sessionID)
+}
+
+func applyWorkoutSessionPatch(session *workout.WorkoutSession, patch workout.WorkoutSessionPatch) error {
+	if patch.SplitDayID != nil {
+		if *patch.SplitDayID == "" {
+			session.SplitDayID = nil
+		} else {
+			id := *patch.SplitDayID
+			session.SplitDayID = &id
+		}
+	}
+	if patch.SessionDate != nil {
+		session.SessionDate = *patch.SessionDate
+	}
+	if patch.DurationMin != nil {
+		session.DurationMin = *patch.DurationMin
+	}
+	if patch.Notes != nil {
+		session.Notes = *patch.Notes
+	}
+
+	for _, removeID := range patch.RemoveExerciseIDs {
+		idx := -1
+		for i := range session.Exercises {
+			if session.Exercises[i].ID == removeID {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			return domainerr.ErrNotFound
+		}
+		session.Exercises = append(session.Exercises[:idx], session.Exercises[idx+1:]...)
+	}
+
+	now := time.Now()
+	for _, exPatch := range patch.Exercises {
+		if exPatch.ID == "" {
+			// New exercise entry.
+			if exPatch.ExerciseID == "" || len(exPatch.Sets) == 0 {
+				return domainerr.ErrInvalidInput
+			}
+			we := workout.WorkoutExercise{ID: uuid.NewString(), ExerciseID: exPatch.ExerciseID}
+			for _, set := range exPatch.Sets {
+				set.ID = uuid.NewString()
+				set.CreatedAt = now
+				we.Sets = append(we.Sets, set)
+			}
+			session.Exercises = append(session.Exercises, we)
+			continue
+		}
+
+		we := findWorkoutExercise(session, exPatch.ID)
+		if we == nil {
+			return domainerr.ErrNotFound
+		}
+		if exPatch.ExerciseID != "" {
+			we.ExerciseID = exPatch.ExerciseID
+		}
+
+		for _, removeID := range exPatch.RemoveSetIDs {
+			idx := -1
+			for i := range we.Sets {
+				if we.Sets[i].ID == removeID {
+					idx = i
+					break
+				}
+			}
+			if idx < 0 {
+				return domainerr.ErrNotFound
+			}
+			we.Sets = append(we.Sets[:idx], we.Sets[idx+1:]...)
+		}
+
+		for _, set := range exPatch.Sets {
+			if set.ID == "" {
+				set.ID = uuid.NewString()
+				set.CreatedAt = now
+				we.Sets = append(we.Sets, set)
+				continue
+			}
+			found := false
+			for i := range we.Sets {
+				if we.Sets[i].ID == set.ID {
+					set.CreatedAt = we.Sets[i].CreatedAt
+					we.Sets[i] = set
+					found = true
+					break
+				}
+			}
+			if !found {
+				return domainerr.ErrNotFound
+			}
+		}
+	}
+
+	// An exercise entry without sets is meaningless; drop it.
+	kept := session.Exercises[:0]
+	for _, ex := range session.Exercises {
+		if len(ex.Sets) > 0 {
+			kept = append(kept, ex)
+		}
+	}
+	session.Exercises = kept
+
+	return nil
+}
+
+// cloneSession copies s deeply enough that patching the copy's exercises
+// and sets leaves s untouched.
+func cloneSession(s workout.WorkoutSession) workout.WorkoutSession {
+	s.Exercises = slices.Clone(s.Exercises)
+	for i := range s.Exercises {
+		s.Exercises[i].Sets = slices.Clone(s.Exercises[i].Sets)
+	}
+	return s
+}
+
+func findWorkoutExercise(session *workout.WorkoutSession, workoutExerciseID string) *workout.WorkoutExercise {
+	for i := range session.Exercises {
+		if session.Exercises[i].ID == workoutExerciseID {
+			return &session.Exercises[i]
+		}
+	}
+	return nil
+}
+
+func sessionHasSet(session *workout.WorkoutSession, setID string) bool {
+	for _, ex := range session.Exercises {
+		for _, set := range ex.Sets {
+			if set.ID == setID {
+				return true
+			}
+		}
+	}
+	return false
 }
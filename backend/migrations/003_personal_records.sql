@@ -0,0 +1,22 @@
+-- Personal records per user/exercise. reps_at_weight rows are keyed by
+-- weight_key; every other record type uses weight_key = 0.
+
+CREATE TABLE IF NOT EXISTS personal_records (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    exercise_id UUID NOT NULL REFERENCES exercises(id) ON DELETE CASCADE,
+    record_type VARCHAR(30) NOT NULL, -- heaviest_weight | reps_at_weight | estimated_1rm | session_volume
+    weight_key NUMERIC(6,2) NOT NULL DEFAULT 0,
+    value NUMERIC(10,2) NOT NULL,
+    weight NUMERIC(6,2) NOT NULL DEFAULT 0,
+    reps INT NOT NULL DEFAULT 0,
+    formula VARCHAR(20) NULL,
+    workout_session_id UUID NULL REFERENCES workout_sessions(id) ON DELETE SET NULL,
+    workout_set_id UUID NULL REFERENCES workout_sets(id) ON DELETE SET NULL,
+    achieved_at DATE NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    UNIQUE (user_id, exercise_id, record_type, weight_key)
+);
+
+CREATE INDEX IF NOT EXISTS personal_records_user_exercise_idx ON personal_records(user_id, exercise_id);
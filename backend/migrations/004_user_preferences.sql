@@ -0,0 +1,5 @@
+-- Per-user training preferences.
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS load_model VARCHAR(30) NOT NULL DEFAULT 'tonnage',
+    ADD COLUMN IF NOT EXISTS body_weight_kg NUMERIC(5,2) NULL;
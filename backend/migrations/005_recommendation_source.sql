@@ -0,0 +1,9 @@
+-- Which engine produced a recommendation: 'ai' or 'rules' (NULL for manual entries).
+
+ALTER TABLE planner_recommendations
+    ADD COLUMN IF NOT EXISTS source VARCHAR(10) NULL;
+
+UPDATE planner_recommendations
+SET source = 'ai'
+WHERE source IS NULL
+  AND recommendation_type IN ('ai_coaching', 'progressive_overload');
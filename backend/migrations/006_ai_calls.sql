@@ -0,0 +1,23 @@
+-- Audit log of every model call made by the AI orchestrator.
+
+CREATE TABLE IF NOT EXISTS ai_calls (
+    id UUID PRIMARY KEY,
+    user_id UUID NULL REFERENCES users(id) ON DELETE SET NULL,
+    operation VARCHAR(50) NOT NULL,
+    attempt INT NOT NULL DEFAULT 0, -- 0 = first answer, n = nth repair
+    provider VARCHAR(30) NULL,
+    model VARCHAR(100) NULL,
+    prompt_hash CHAR(64) NOT NULL, -- sha256 hex
+    prompt_version VARCHAR(30) NULL,
+    response TEXT NULL,
+    outcome VARCHAR(20) NOT NULL, -- ok | invalid_output | error
+    error TEXT NULL,
+    latency_ms INT NOT NULL DEFAULT 0,
+    prompt_tokens INT NOT NULL DEFAULT 0,
+    completion_tokens INT NOT NULL DEFAULT 0,
+    total_tokens INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS ai_calls_created_idx ON ai_calls(created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS ai_calls_user_created_idx ON ai_calls(user_id, created_at DESC);
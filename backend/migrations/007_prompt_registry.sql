@@ -0,0 +1,16 @@
+-- Prompt templates managed outside the binary. Rows override the built-in
+-- template with the same name and version; versions of one prompt share
+-- its users in proportion to weight (0 = kept but not assigned).
+
+CREATE TABLE IF NOT EXISTS prompt_templates (
+    name VARCHAR(50) NOT NULL,
+    version VARCHAR(30) NOT NULL,
+    body TEXT NOT NULL,
+    weight INT NOT NULL DEFAULT 0 CHECK (weight >= 0),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (name, version)
+);
+
+-- Prompt version behind an AI recommendation (NULL for rules and manual entries).
+ALTER TABLE planner_recommendations
+    ADD COLUMN IF NOT EXISTS prompt_variant VARCHAR(30) NULL;
@@ -0,0 +1,7 @@
+-- How AI answers sound for each user: persona, language and profanity.
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS coach_persona VARCHAR(20) NOT NULL DEFAULT 'supportive', -- brutal | supportive | clinical | custom
+    ADD COLUMN IF NOT EXISTS custom_persona TEXT NULL, -- used when coach_persona = 'custom'
+    ADD COLUMN IF NOT EXISTS language VARCHAR(35) NOT NULL DEFAULT 'en', -- BCP 47 tag
+    ADD COLUMN IF NOT EXISTS profanity BOOLEAN NOT NULL DEFAULT FALSE;
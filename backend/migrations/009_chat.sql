@@ -0,0 +1,23 @@
+-- Conversational AI coach: threads and their messages.
+
+CREATE TABLE IF NOT EXISTS chat_threads (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    title VARCHAR(120) NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS chat_threads_user_updated_idx ON chat_threads(user_id, updated_at DESC);
+
+CREATE TABLE IF NOT EXISTS chat_messages (
+    id UUID PRIMARY KEY,
+    thread_id UUID NOT NULL REFERENCES chat_threads(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL, -- user | assistant
+    content TEXT NOT NULL,
+    citations JSONB NOT NULL DEFAULT '[]', -- [{kind, ref, label}]
+    source VARCHAR(10) NULL, -- ai | rules (assistant messages)
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS chat_messages_thread_created_idx ON chat_messages(thread_id, created_at);
@@ -0,0 +1,18 @@
+-- Changes proposed by the AI agent's write tools, applied only once the user
+-- confirms them.
+
+CREATE TABLE IF NOT EXISTS agent_actions (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    tool VARCHAR(50) NOT NULL,
+    arguments JSONB NOT NULL,
+    summary TEXT NOT NULL,
+    changes JSONB NOT NULL DEFAULT '[]', -- [{path, before, after}]
+    base_version VARCHAR(64) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending | applied | rejected
+    created_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    resolved_at TIMESTAMP NULL
+);
+
+CREATE INDEX IF NOT EXISTS agent_actions_user_created_idx ON agent_actions(user_id, created_at DESC);
@@ -0,0 +1,9 @@
+-- What generated splits have to fit for each user.
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS experience_level VARCHAR(20) NOT NULL DEFAULT 'intermediate', -- beginner | intermediate | advanced
+    ADD COLUMN IF NOT EXISTS equipment TEXT[] NOT NULL DEFAULT '{}', -- empty = full gym
+    ADD COLUMN IF NOT EXISTS max_session_minutes INTEGER NULL,
+    ADD COLUMN IF NOT EXISTS injuries TEXT NULL,
+    ADD COLUMN IF NOT EXISTS excluded_movements TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS preferred_days TEXT[] NOT NULL DEFAULT '{}'; -- mon..sun
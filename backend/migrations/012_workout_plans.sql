@@ -0,0 +1,21 @@
+-- Generated workout plans, and sessions started from them. A draft session
+-- is left out of load, analytics and records until it is finished.
+
+CREATE TABLE IF NOT EXISTS workout_plans (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    split_day_id UUID NULL REFERENCES split_days(id) ON DELETE SET NULL,
+    split_day_name VARCHAR(100) NOT NULL DEFAULT '',
+    fatigue INT NOT NULL,
+    exercises JSONB NOT NULL DEFAULT '[]', -- [{exercise_id, name, sets, rep_range, reps_min, reps_max, weight}]
+    source VARCHAR(10) NOT NULL, -- ai | rules
+    prompt_version VARCHAR(30) NULL,
+    session_id UUID NULL REFERENCES workout_sessions(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS workout_plans_user_created_idx ON workout_plans(user_id, created_at DESC);
+
+ALTER TABLE workout_sessions
+    ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'completed', -- draft | completed
+    ADD COLUMN IF NOT EXISTS plan_id UUID NULL REFERENCES workout_plans(id) ON DELETE SET NULL;
@@ -0,0 +1,31 @@
+-- Email verification and single-use account tokens (password reset, email
+-- verification). Tokens are stored as SHA-256 hashes, like admin invites.
+
+-- Accounts created before verification existed keep their access. The
+-- backfill only runs when the column is added, so re-applying this file
+-- does not verify accounts that signed up since.
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_schema = current_schema()
+          AND table_name = 'users'
+          AND column_name = 'email_verified_at'
+    ) THEN
+        ALTER TABLE users ADD COLUMN email_verified_at TIMESTAMP NULL;
+        UPDATE users SET email_verified_at = created_at;
+    END IF;
+END
+$$;
+
+CREATE TABLE IF NOT EXISTS account_tokens (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    purpose VARCHAR(32) NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS account_tokens_user_purpose_idx ON account_tokens(user_id, purpose) WHERE used_at IS NULL;
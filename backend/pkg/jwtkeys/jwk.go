@@ -0,0 +1,109 @@
+package jwtkeys
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// FromJWKS returns a verify-only keyring for the signing keys of a JWKS,
+// e.g. the one an OpenID provider publishes. Encryption keys and key types
+// this package cannot verify with are skipped.
+func FromJWKS(set JWKS) (*Keyring, error) {
+	kr := &Keyring{keys: make(map[string]*key, len(set.Keys))}
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		pub, err := jwk.PublicKey()
+		if err != nil {
+			continue
+		}
+		k, err := newKey(jwk.Kid, pub)
+		if err != nil {
+			continue
+		}
+		// RSA keys may be published for another RS* or PS* algorithm.
+		if jwk.Alg != "" && jwk.Alg != k.method.Alg() {
+			continue
+		}
+		kr.keys[jwk.Kid] = k
+	}
+	if len(kr.keys) == 0 {
+		return nil, errors.New("jwtkeys: no usable signing keys in JWKS")
+	}
+	return kr, nil
+}
+
+// PublicKey decodes an RSA, EC or Ed25519 JWK.
+func (j JWK) PublicKey() (crypto.PublicKey, error) {
+	switch j.Kty {
+	case "RSA":
+		n, err := unb64(j.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := unb64(j.E)
+		if err != nil {
+			return nil, err
+		}
+		if len(e) == 0 || len(e) > 4 {
+			return nil, errors.New("jwtkeys: invalid RSA exponent")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch j.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("jwtkeys: unsupported EC curve %q", j.Crv)
+		}
+		x, err := unb64(j.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := unb64(j.Y)
+		if err != nil {
+			return nil, err
+		}
+		pub := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		// ECDH conversion rejects points that are not on the curve.
+		if _, err := pub.ECDH(); err != nil {
+			return nil, fmt.Errorf("jwtkeys: invalid EC key: %w", err)
+		}
+		return pub, nil
+	case "OKP":
+		if j.Crv != "Ed25519" {
+			return nil, fmt.Errorf("jwtkeys: unsupported OKP curve %q", j.Crv)
+		}
+		x, err := unb64(j.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("jwtkeys: invalid Ed25519 key")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("jwtkeys: unsupported key type %q", j.Kty)
+	}
+}
+
+func unb64(s string) ([]byte, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("jwtkeys: invalid base64url: %w", err)
+	}
+	return b, nil
+}
@@ -0,0 +1,276 @@
+// Package jwtkeys holds the keys used to sign and verify access tokens.
+//
+// Asymmetric keys (RS256, ES256, EdDSA) are loaded from a directory of PEM
+// files; the file name without ".pem" (or ".pub.pem") is the key id
+// ("kid"). One private key is active and signs new tokens; every key in the
+// directory is accepted for verification and published as a JWKS so other
+// services can verify tokens without sharing a secret. A keyring without a
+// directory falls back to a shared HS256 secret, meant for local
+// development only. FromJWKS builds a verify-only keyring from another
+// issuer's published keys.
+package jwtkeys
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const minRSABits = 2048
+
+var (
+	ErrUnknownKey  = errors.New("jwtkeys: unknown key id")
+	ErrAlgMismatch = errors.New("jwtkeys: signing method does not match key")
+	// ErrNoSigningKey is returned by Sign on a verify-only keyring.
+	ErrNoSigningKey = errors.New("jwtkeys: keyring has no signing key")
+)
+
+type key struct {
+	kid     string
+	method  jwt.SigningMethod
+	private crypto.Signer
+	public  crypto.PublicKey
+}
+
+type Keyring struct {
+	active *key
+	keys   map[string]*key
+	hmac   []byte
+}
+
+// NewHMAC returns a keyring that signs and verifies with a shared secret.
+func NewHMAC(secret string) (*Keyring, error) {
+	if secret == "" {
+		return nil, errors.New("jwtkeys: empty HMAC secret")
+	}
+	return &Keyring{hmac: []byte(secret)}, nil
+}
+
+// LoadDir loads every *.pem file in dir. activeKID selects the signing key;
+// it may be empty when the directory holds exactly one private key.
+func LoadDir(dir, activeKID string) (*Keyring, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("jwtkeys: no .pem files in %s", dir)
+	}
+	sort.Strings(paths)
+
+	kr := &Keyring{keys: make(map[string]*key, len(paths))}
+	var privateKIDs []string
+	for _, path := range paths {
+		kid := strings.TrimSuffix(strings.TrimSuffix(filepath.Base(path), ".pem"), ".pub")
+		if _, dup := kr.keys[kid]; dup {
+			return nil, fmt.Errorf("jwtkeys: duplicate key id %q", kid)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		k, err := parseKey(kid, data)
+		if err != nil {
+			return nil, fmt.Errorf("jwtkeys: %s: %w", filepath.Base(path), err)
+		}
+		kr.keys[kid] = k
+		if k.private != nil {
+			privateKIDs = append(privateKIDs, kid)
+		}
+	}
+
+	if activeKID == "" {
+		if len(privateKIDs) != 1 {
+			return nil, fmt.Errorf("jwtkeys: %d private keys in %s, set the active key id", len(privateKIDs), dir)
+		}
+		activeKID = privateKIDs[0]
+	}
+	active, ok := kr.keys[activeKID]
+	if !ok {
+		return nil, fmt.Errorf("jwtkeys: active key %q not found in %s", activeKID, dir)
+	}
+	if active.private == nil {
+		return nil, fmt.Errorf("jwtkeys: active key %q has no private key", activeKID)
+	}
+	kr.active = active
+	return kr, nil
+}
+
+func parseKey(kid string, data []byte) (*key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block")
+	}
+
+	var parsed any
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return newKey(kid, parsed)
+}
+
+func newKey(kid string, parsed any) (*key, error) {
+	k := &key{kid: kid}
+	switch v := parsed.(type) {
+	case *rsa.PrivateKey:
+		k.method, k.private, k.public = jwt.SigningMethodRS256, v, &v.PublicKey
+	case *rsa.PublicKey:
+		k.method, k.public = jwt.SigningMethodRS256, v
+	case *ecdsa.PrivateKey:
+		k.private, k.public = v, &v.PublicKey
+	case *ecdsa.PublicKey:
+		k.public = v
+	case ed25519.PrivateKey:
+		k.method, k.private, k.public = jwt.SigningMethodEdDSA, v, v.Public()
+	case ed25519.PublicKey:
+		k.method, k.public = jwt.SigningMethodEdDSA, v
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", parsed)
+	}
+	switch pub := k.public.(type) {
+	case *rsa.PublicKey:
+		if pub.N.BitLen() < minRSABits {
+			return nil, fmt.Errorf("RSA key must be at least %d bits", minRSABits)
+		}
+	case *ecdsa.PublicKey:
+		method, ok := ecdsaMethods[pub.Curve]
+		if !ok {
+			return nil, errors.New("unsupported EC curve")
+		}
+		k.method = method
+	}
+	return k, nil
+}
+
+var ecdsaMethods = map[elliptic.Curve]jwt.SigningMethod{
+	elliptic.P256(): jwt.SigningMethodES256,
+	elliptic.P384(): jwt.SigningMethodES384,
+	elliptic.P521(): jwt.SigningMethodES512,
+}
+
+// New returns a keyring that signs with signer under kid, for keys that
+// never touch the disk (e.g. a throwaway development issuer).
+func New(kid string, signer crypto.Signer) (*Keyring, error) {
+	k, err := newKey(kid, signer)
+	if err != nil {
+		return nil, fmt.Errorf("jwtkeys: %w", err)
+	}
+	return &Keyring{active: k, keys: map[string]*key{kid: k}}, nil
+}
+
+// Sign signs claims with the active key and stamps its kid in the header.
+func (kr *Keyring) Sign(claims jwt.Claims) (string, error) {
+	if kr.hmac != nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(kr.hmac)
+	}
+	if kr.active == nil {
+		return "", ErrNoSigningKey
+	}
+	token := jwt.NewWithClaims(kr.active.method, claims)
+	token.Header["kid"] = kr.active.kid
+	return token.SignedString(kr.active.private)
+}
+
+// Keyfunc resolves the verification key of a token by its kid header. The
+// signing method must match the key, so an RSA public key can never be
+// replayed as an HMAC secret.
+func (kr *Keyring) Keyfunc(token *jwt.Token) (any, error) {
+	if kr.hmac != nil {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, ErrAlgMismatch
+		}
+		return kr.hmac, nil
+	}
+	kid, _ := token.Header["kid"].(string)
+	k, ok := kr.keys[kid]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	if token.Method.Alg() != k.method.Alg() {
+		return nil, ErrAlgMismatch
+	}
+	return k.public, nil
+}
+
+// ActiveKID is the kid of the signing key, empty for an HMAC keyring.
+func (kr *Keyring) ActiveKID() string {
+	if kr.active == nil {
+		return ""
+	}
+	return kr.active.kid
+}
+
+// JWK is a public key in RFC 7517 form.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public verification keys, ordered by kid. An HMAC
+// keyring publishes nothing.
+func (kr *Keyring) JWKS() JWKS {
+	set := JWKS{Keys: make([]JWK, 0, len(kr.keys))}
+	for _, k := range kr.keys {
+		jwk := JWK{Kid: k.kid, Use: "sig", Alg: k.method.Alg()}
+		switch pub := k.public.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = b64(pub.N.Bytes())
+			jwk.E = b64(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = b64(pub)
+		case *ecdsa.PublicKey:
+			size := (pub.Curve.Params().BitSize + 7) / 8
+			jwk.Kty = "EC"
+			jwk.Crv = pub.Curve.Params().Name
+			jwk.X = b64(pub.X.FillBytes(make([]byte, size)))
+			jwk.Y = b64(pub.Y.FillBytes(make([]byte, size)))
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	sort.Slice(set.Keys, func(i, j int) bool { return set.Keys[i].Kid < set.Keys[j].Kid })
+	return set
+}
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,312 @@
+package jwtkeys
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func newRSA(t *testing.T, bits int) *rsa.PrivateKey {
+	t.Helper()
+	k, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k
+}
+
+func newEC(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	k, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k
+}
+
+func newEd25519(t *testing.T) ed25519.PrivateKey {
+	t.Helper()
+	_, k, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k
+}
+
+// writePrivate and writePublic store a key as PEM under dir/name.
+func writePrivate(t *testing.T, dir, name string, k crypto.Signer) {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(k)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writePEM(t, filepath.Join(dir, name), "PRIVATE KEY", der)
+}
+
+func writePublic(t *testing.T, dir, name string, k crypto.PublicKey) {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(k)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writePEM(t, filepath.Join(dir, name), "PUBLIC KEY", der)
+}
+
+func writePEM(t *testing.T, path, typ string, der []byte) {
+	t.Helper()
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func verify(kr *Keyring, token string) error {
+	_, err := jwt.Parse(token, kr.Keyfunc)
+	return err
+}
+
+func TestLoadDirSelectsTheActiveKey(t *testing.T) {
+	dir := t.TempDir()
+	writePrivate(t, dir, "2026-01.pem", newEd25519(t))
+	writePrivate(t, dir, "2026-02.pem", newEC(t))
+	writePublic(t, dir, "2025-12.pub.pem", &newRSA(t, 2048).PublicKey)
+
+	tests := []struct {
+		name      string
+		activeKID string
+		wantErr   string
+	}{
+		{name: "chosen private key", activeKID: "2026-02"},
+		{name: "several private keys need a choice", activeKID: "", wantErr: "2 private keys"},
+		{name: "unknown kid", activeKID: "2027-01", wantErr: "not found"},
+		{name: "public key cannot sign", activeKID: "2025-12", wantErr: "has no private key"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			kr, err := LoadDir(dir, tc.activeKID)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("LoadDir error = %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadDir: %v", err)
+			}
+			if kr.ActiveKID() != tc.activeKID {
+				t.Errorf("ActiveKID = %q, want %q", kr.ActiveKID(), tc.activeKID)
+			}
+			token, err := kr.Sign(jwt.MapClaims{"sub": "u1"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			parsed, err := jwt.Parse(token, kr.Keyfunc)
+			if err != nil || parsed.Header["kid"] != tc.activeKID || parsed.Method.Alg() != "ES256" {
+				t.Errorf("signed token = %v, %v, want ES256 under kid %s", parsed.Header, err, tc.activeKID)
+			}
+			if len(kr.JWKS().Keys) != 3 {
+				t.Errorf("JWKS has %d keys, want all 3 for verification", len(kr.JWKS().Keys))
+			}
+		})
+	}
+}
+
+func TestLoadDirWithOnePrivateKeyNeedsNoKID(t *testing.T) {
+	dir := t.TempDir()
+	writePrivate(t, dir, "only.pem", newEd25519(t))
+	writePublic(t, dir, "retired.pub.pem", newEd25519(t).Public())
+
+	kr, err := LoadDir(dir, "")
+	if err != nil {
+		t.Fatalf("LoadDir: %v", err)
+	}
+	if kr.ActiveKID() != "only" {
+		t.Errorf("ActiveKID = %q, want only", kr.ActiveKID())
+	}
+}
+
+func TestLoadDirRejectsBadKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(t *testing.T, dir string)
+		wantErr string
+	}{
+		{
+			name: "duplicate kid",
+			setup: func(t *testing.T, dir string) {
+				k := newEd25519(t)
+				writePrivate(t, dir, "k1.pem", k)
+				writePublic(t, dir, "k1.pub.pem", k.Public())
+			},
+			wantErr: `duplicate key id "k1"`,
+		},
+		{
+			name:    "RSA under 2048 bits",
+			setup:   func(t *testing.T, dir string) { writePrivate(t, dir, "weak.pem", newRSA(t, 1024)) },
+			wantErr: "at least 2048 bits",
+		},
+		{
+			name:    "RSA public key under 2048 bits",
+			setup:   func(t *testing.T, dir string) { writePublic(t, dir, "weak.pub.pem", &newRSA(t, 1024).PublicKey) },
+			wantErr: "at least 2048 bits",
+		},
+		{
+			name: "not PEM",
+			setup: func(t *testing.T, dir string) {
+				if err := os.WriteFile(filepath.Join(dir, "junk.pem"), []byte("secret"), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantErr: "no PEM block",
+		},
+		{
+			name:    "empty directory",
+			setup:   func(*testing.T, string) {},
+			wantErr: "no .pem files",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			tc.setup(t, dir)
+			if _, err := LoadDir(dir, ""); err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("LoadDir error = %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestKeyfuncRejectsAlgorithmMismatch(t *testing.T) {
+	rsaKey := newRSA(t, 2048)
+	kr, err := New("rsa-1", rsaKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+
+	// The classic confusion: an HS256 token keyed with the public RSA key
+	// under the RSA kid.
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "admin"})
+	forged.Header["kid"] = "rsa-1"
+	hs, err := forged.SignedString(pubPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := verify(kr, hs); !errors.Is(err, ErrAlgMismatch) {
+		t.Errorf("HS256 token against an RSA kid: error = %v, want ErrAlgMismatch", err)
+	}
+
+	// PS256 uses the same key type but is not the key's algorithm.
+	ps := jwt.NewWithClaims(jwt.SigningMethodPS256, jwt.MapClaims{"sub": "u1"})
+	ps.Header["kid"] = "rsa-1"
+	psSigned, err := ps.SignedString(rsaKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := verify(kr, psSigned); !errors.Is(err, ErrAlgMismatch) {
+		t.Errorf("PS256 token against an RS256 kid: error = %v, want ErrAlgMismatch", err)
+	}
+
+	unknown := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{"sub": "u1"})
+	unknown.Header["kid"] = "rsa-2"
+	unknownSigned, err := unknown.SignedString(rsaKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := verify(kr, unknownSigned); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("unknown kid: error = %v, want ErrUnknownKey", err)
+	}
+
+	hmac, err := NewHMAC("dev-secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rs, err := kr.Sign(jwt.MapClaims{"sub": "u1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := verify(hmac, rs); !errors.Is(err, ErrAlgMismatch) {
+		t.Errorf("RS256 token against an HMAC keyring: error = %v, want ErrAlgMismatch", err)
+	}
+}
+
+func TestJWKSRoundTrip(t *testing.T) {
+	signers := map[string]crypto.Signer{
+		"rsa":     newRSA(t, 2048),
+		"ec":      newEC(t),
+		"ed25519": newEd25519(t),
+	}
+	published := JWKS{}
+	tokens := map[string]string{}
+	for kid, signer := range signers {
+		kr, err := New(kid, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		published.Keys = append(published.Keys, kr.JWKS().Keys...)
+		if tokens[kid], err = kr.Sign(jwt.MapClaims{"sub": kid}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	verifier, err := FromJWKS(published)
+	if err != nil {
+		t.Fatalf("FromJWKS: %v", err)
+	}
+	for kid, token := range tokens {
+		if err := verify(verifier, token); err != nil {
+			t.Errorf("%s token does not verify against the published keys: %v", kid, err)
+		}
+	}
+	// Republishing gives back the same keys, ordered by kid.
+	sort.Slice(published.Keys, func(i, j int) bool { return published.Keys[i].Kid < published.Keys[j].Kid })
+	if got := verifier.JWKS(); !slices.Equal(got.Keys, published.Keys) {
+		t.Errorf("JWKS of the verifier = %+v, want %+v", got.Keys, published.Keys)
+	}
+	if _, err := verifier.Sign(jwt.MapClaims{}); !errors.Is(err, ErrNoSigningKey) {
+		t.Errorf("Sign on a verify-only keyring: error = %v, want ErrNoSigningKey", err)
+	}
+}
+
+func TestFromJWKSSkipsUnusableKeys(t *testing.T) {
+	kr, err := New("ok", newEd25519(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	good := kr.JWKS().Keys[0]
+	enc := good
+	enc.Kid, enc.Use = "enc", "enc"
+	otherAlg := good
+	otherAlg.Kid, otherAlg.Alg = "other-alg", "ES256"
+	broken := good
+	broken.Kid, broken.X = "broken", "not base64!"
+
+	verifier, err := FromJWKS(JWKS{Keys: []JWK{good, enc, otherAlg, broken}})
+	if err != nil {
+		t.Fatalf("FromJWKS: %v", err)
+	}
+	if got := verifier.JWKS().Keys; len(got) != 1 || got[0].Kid != "ok" {
+		t.Errorf("usable keys = %+v, want only ok", got)
+	}
+
+	if _, err := FromJWKS(JWKS{Keys: []JWK{enc, broken}}); err == nil {
+		t.Error("FromJWKS accepted a set without a usable key")
+	}
+}
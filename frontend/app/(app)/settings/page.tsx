@@ -15,7 +15,8 @@ import {
   SelectValue,
 } from "@/components/ui/select";
 import { Loader2, LogOut } from "lucide-react";
-import { clearAuth, getAuthState } from "@/lib/auth";
+import { api, logout } from "@/lib/api";
+import { getAuthState } from "@/lib/auth";
 import { toast } from "sonner";
 
 interface Health {
@@ -31,6 +32,7 @@ export default function SettingsPage() {
   const [isValidating, setIsValidating] = useState(false);
   const [health, setHealth] = useState<Health | null>(null);
   const [isCheckingHealth, setIsCheckingHealth] = useState(false);
+  const [isSendingVerification, setIsSendingVerification] = useState(false);
 
   useEffect(() => {
     const auth = getAuthState();
@@ -106,8 +108,24 @@ export default function SettingsPage() {
     }
   };
 
-  const handleLogout = () => {
-    clearAuth();
+  const handleResendVerification = async () => {
+    setIsSendingVerification(true);
+    try {
+      await api.post("/api/v1/auth/email/verification");
+      toast.success("Verification email sent");
+    } catch (err) {
+      const message =
+        err instanceof Error && err.message === "conflict"
+          ? "Your email is already verified"
+          : "Failed to send verification email";
+      toast.error(message);
+    } finally {
+      setIsSendingVerification(false);
+    }
+  };
+
+  const handleLogout = async () => {
+    await logout();
     toast.success("Logged out successfully");
     router.push("/login");
   };
@@ -182,6 +200,18 @@ export default function SettingsPage() {
               )}
               Validate Token
             </Button>
+
+            <Button
+              variant="outline"
+              onClick={handleResendVerification}
+              disabled={isSendingVerification}
+              className="w-full"
+            >
+              {isSendingVerification && (
+                <Loader2 className="mr-2 h-4 w-4 animate-spin" />
+              )}
+              Resend Verification Email
+            </Button>
           </CardContent>
         </Card>
 
@@ -0,0 +1,10 @@
+import { PasswordResetForm } from "@/components/password-reset-form";
+
+export const metadata = {
+  title: "Forgot Password - S.P.A.R.T.A",
+  description: "Reset your S.P.A.R.T.A password",
+};
+
+export default function ForgotPasswordPage() {
+  return <PasswordResetForm mode="request" />;
+}
@@ -0,0 +1,25 @@
+import { OAuthCallback } from "@/components/oauth-callback";
+
+export const metadata = {
+  title: "Signing in - S.P.A.R.T.A",
+  description: "Finishing sign-in with an external account",
+};
+
+export default async function OAuthCallbackPage({
+  params,
+  searchParams,
+}: {
+  params: Promise<{ provider: string }>;
+  searchParams: Promise<{ code?: string; state?: string; error?: string }>;
+}) {
+  const { provider } = await params;
+  const { code, state, error } = await searchParams;
+  return (
+    <OAuthCallback
+      provider={provider}
+      code={code ?? ""}
+      state={state ?? ""}
+      error={error ?? ""}
+    />
+  );
+}
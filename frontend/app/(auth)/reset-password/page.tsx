@@ -0,0 +1,15 @@
+import { PasswordResetForm } from "@/components/password-reset-form";
+
+export const metadata = {
+  title: "Reset Password - S.P.A.R.T.A",
+  description: "Choose a new S.P.A.R.T.A password",
+};
+
+export default async function ResetPasswordPage({
+  searchParams,
+}: {
+  searchParams: Promise<{ token?: string }>;
+}) {
+  const { token } = await searchParams;
+  return <PasswordResetForm mode="reset" token={token ?? ""} />;
+}
@@ -0,0 +1,15 @@
+import { VerifyEmail } from "@/components/verify-email";
+
+export const metadata = {
+  title: "Verify Email - S.P.A.R.T.A",
+  description: "Confirm your S.P.A.R.T.A email address",
+};
+
+export default async function VerifyEmailPage({
+  searchParams,
+}: {
+  searchParams: Promise<{ token?: string }>;
+}) {
+  const { token } = await searchParams;
+  return <VerifyEmail token={token ?? ""} />;
+}
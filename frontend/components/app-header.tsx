@@ -11,7 +11,7 @@ import {
   DropdownMenuSeparator,
 } from "@/components/ui/dropdown-menu";
 import { Moon, Sun, LogOut, Smartphone, Menu } from "lucide-react";
-import { clearAuth } from "@/lib/auth";
+import { logout } from "@/lib/api";
 import { toast } from "sonner";
 import { SpartanHelmetIcon } from "@/components/spartan-helmet-icon";
 
@@ -27,8 +27,8 @@ export function AppHeader({
   const router = useRouter();
   const { theme, setTheme } = useTheme();
 
-  const handleLogout = () => {
-    clearAuth();
+  const handleLogout = async () => {
+    await logout();
     toast.success("Logged out successfully");
     router.push("/login");
   };
@@ -8,6 +8,7 @@ import { Label } from "@/components/ui/label";
 import { Loader2 } from "lucide-react";
 import { api } from "@/lib/api";
 import { setAuthToken } from "@/lib/auth";
+import { OAuthButtons } from "@/components/oauth-buttons";
 import { toast } from "sonner";
 import Link from "next/link";
 
@@ -19,6 +20,7 @@ interface AuthResponse {
   token: string;
   user_id: string;
   role: string;
+  refresh_token: string;
 }
 
 export function AuthForm({ mode }: AuthFormProps) {
@@ -60,7 +62,7 @@ export function AuthForm({ mode }: AuthFormProps) {
 
       const response = await api.post<AuthResponse>(endpoint, body);
 
-      setAuthToken(response.token, response.user_id);
+      setAuthToken(response.token, response.user_id, response.refresh_token);
       toast.success(
         mode === "login"
           ? "Logged in successfully"
@@ -117,7 +119,17 @@ export function AuthForm({ mode }: AuthFormProps) {
       </div>
 
       <div className="space-y-2">
-        <Label htmlFor="password">Password</Label>
+        <div className="flex items-center justify-between">
+          <Label htmlFor="password">Password</Label>
+          {mode === "login" && (
+            <Link
+              href="/forgot-password"
+              className="text-sm text-primary hover:underline"
+            >
+              Forgot password?
+            </Link>
+          )}
+        </div>
         <Input
           id="password"
           type="password"
@@ -139,6 +151,8 @@ export function AuthForm({ mode }: AuthFormProps) {
         {mode === "login" ? "Sign In" : "Create Account"}
       </Button>
 
+      <OAuthButtons />
+
       <div className="text-center text-sm text-muted-foreground">
         {mode === "login" ? (
           <>
@@ -0,0 +1,73 @@
+"use client";
+
+import { useEffect, useState } from "react";
+import { Button } from "@/components/ui/button";
+import { Loader2 } from "lucide-react";
+import { api } from "@/lib/api";
+import { toast } from "sonner";
+
+/** sessionStorage key for the state of the sign-in in progress. */
+export const OAUTH_STATE_KEY = "oauth_state";
+
+const PROVIDER_LABELS: Record<string, string> = {
+  google: "Google",
+  github: "GitHub",
+};
+
+function providerLabel(name: string) {
+  return PROVIDER_LABELS[name] ?? name.charAt(0).toUpperCase() + name.slice(1);
+}
+
+export function OAuthButtons() {
+  const [providers, setProviders] = useState<string[]>([]);
+  const [pending, setPending] = useState<string | null>(null);
+
+  useEffect(() => {
+    api
+      .get<{ providers: string[] }>("/api/v1/auth/oauth/providers")
+      .then((res) => setProviders(res.providers ?? []))
+      .catch(() => setProviders([]));
+  }, []);
+
+  if (providers.length === 0) return null;
+
+  const start = async (provider: string) => {
+    setPending(provider);
+    try {
+      const res = await api.post<{ url: string; state: string }>(
+        `/api/v1/auth/oauth/${encodeURIComponent(provider)}/start`,
+      );
+      // The callback page checks the provider sends this state back.
+      sessionStorage.setItem(OAUTH_STATE_KEY, res.state);
+      window.location.assign(res.url);
+    } catch (err) {
+      toast.error(err instanceof Error ? err.message : "Sign-in failed");
+      setPending(null);
+    }
+  };
+
+  return (
+    <div className="space-y-3">
+      <div className="flex items-center gap-3 text-xs uppercase text-muted-foreground">
+        <div className="h-px flex-1 bg-border" />
+        or
+        <div className="h-px flex-1 bg-border" />
+      </div>
+      {providers.map((provider) => (
+        <Button
+          key={provider}
+          type="button"
+          variant="outline"
+          className="w-full"
+          disabled={pending !== null}
+          onClick={() => start(provider)}
+        >
+          {pending === provider && (
+            <Loader2 className="mr-2 h-4 w-4 animate-spin" />
+          )}
+          Continue with {providerLabel(provider)}
+        </Button>
+      ))}
+    </div>
+  );
+}
@@ -0,0 +1,89 @@
+"use client";
+
+import { useEffect, useRef, useState } from "react";
+import { useRouter } from "next/navigation";
+import { Loader2 } from "lucide-react";
+import { api } from "@/lib/api";
+import { setAuthToken } from "@/lib/auth";
+import { OAUTH_STATE_KEY } from "@/components/oauth-buttons";
+import Link from "next/link";
+
+interface AuthResponse {
+  token: string;
+  user_id: string;
+  refresh_token: string;
+}
+
+interface OAuthCallbackProps {
+  provider: string;
+  code: string;
+  state: string;
+  error: string;
+}
+
+export function OAuthCallback({
+  provider,
+  code,
+  state,
+  error,
+}: OAuthCallbackProps) {
+  const router = useRouter();
+  const [failure, setFailure] = useState("");
+  // Codes are single-use: guard against the effect running twice.
+  const started = useRef(false);
+
+  useEffect(() => {
+    if (started.current) return;
+    started.current = true;
+
+    const expected = sessionStorage.getItem(OAUTH_STATE_KEY);
+    sessionStorage.removeItem(OAUTH_STATE_KEY);
+    if (error || !code) {
+      setFailure("Sign-in was cancelled or refused by the provider.");
+      return;
+    }
+    // Only finish sign-ins this browser started.
+    if (!state || state !== expected) {
+      setFailure("This sign-in link was not started here. Please try again.");
+      return;
+    }
+
+    (async () => {
+      try {
+        const res = await api.post<AuthResponse>(
+          `/api/v1/auth/oauth/${encodeURIComponent(provider)}/callback`,
+          { code, state },
+        );
+        setAuthToken(res.token, res.user_id, res.refresh_token);
+        router.replace("/app");
+      } catch (err) {
+        setFailure(
+          err instanceof Error && err.message === "invalid input"
+            ? "The provider did not share a verified email address."
+            : "Sign-in failed. Please try again.",
+        );
+      }
+    })();
+  }, [provider, code, state, error, router]);
+
+  return (
+    <div className="space-y-6 text-center">
+      {failure ? (
+        <>
+          <h1 className="text-3xl font-bold text-foreground">
+            Sign-in failed
+          </h1>
+          <p className="text-muted-foreground">{failure}</p>
+          <Link href="/login" className="text-sm text-primary hover:underline">
+            Back to sign in
+          </Link>
+        </>
+      ) : (
+        <>
+          <Loader2 className="mx-auto h-8 w-8 animate-spin text-muted-foreground" />
+          <p className="text-muted-foreground">Signing you in…</p>
+        </>
+      )}
+    </div>
+  );
+}
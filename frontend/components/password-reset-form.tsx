@@ -0,0 +1,141 @@
+"use client";
+
+import { useState } from "react";
+import { useRouter } from "next/navigation";
+import { Button } from "@/components/ui/button";
+import { Input } from "@/components/ui/input";
+import { Label } from "@/components/ui/label";
+import { Loader2 } from "lucide-react";
+import { api } from "@/lib/api";
+import { toast } from "sonner";
+import Link from "next/link";
+
+interface PasswordResetFormProps {
+  mode: "request" | "reset";
+  token?: string;
+}
+
+export function PasswordResetForm({ mode, token = "" }: PasswordResetFormProps) {
+  const router = useRouter();
+  const [isLoading, setIsLoading] = useState(false);
+  const [email, setEmail] = useState("");
+  const [password, setPassword] = useState("");
+  const [sent, setSent] = useState(false);
+  const [error, setError] = useState("");
+
+  const handleSubmit = async (e: React.FormEvent) => {
+    e.preventDefault();
+    setError("");
+
+    if (mode === "request" && !email) {
+      setError("Email is required");
+      return;
+    }
+    if (mode === "reset") {
+      if (!token) {
+        setError("This reset link is incomplete");
+        return;
+      }
+      if (password.length < 8) {
+        setError("Password must be at least 8 characters");
+        return;
+      }
+    }
+
+    setIsLoading(true);
+    try {
+      if (mode === "request") {
+        await api.post("/api/v1/auth/password/forgot", { email });
+        setSent(true);
+      } else {
+        await api.post("/api/v1/auth/password/reset", { token, password });
+        toast.success("Password changed, please sign in");
+        router.push("/login");
+      }
+    } catch (err) {
+      const message =
+        mode === "reset"
+          ? "This reset link is invalid or has expired"
+          : err instanceof Error
+            ? err.message
+            : "Request failed";
+      setError(message);
+      toast.error(message);
+    } finally {
+      setIsLoading(false);
+    }
+  };
+
+  if (sent) {
+    return (
+      <div className="space-y-6 text-center">
+        <h1 className="text-3xl font-bold text-foreground">Check your inbox</h1>
+        <p className="text-muted-foreground">
+          If an account exists for {email}, we sent a link to reset its
+          password.
+        </p>
+        <Link href="/login" className="text-sm text-primary hover:underline">
+          Back to sign in
+        </Link>
+      </div>
+    );
+  }
+
+  return (
+    <form onSubmit={handleSubmit} className="space-y-6">
+      <div className="text-center">
+        <h1 className="text-3xl font-bold text-foreground mb-2">
+          {mode === "request" ? "Forgot Password" : "Choose a New Password"}
+        </h1>
+        <p className="text-muted-foreground">
+          {mode === "request"
+            ? "We will email you a link to reset it"
+            : "You will be signed out on every device"}
+        </p>
+      </div>
+
+      {mode === "request" ? (
+        <div className="space-y-2">
+          <Label htmlFor="email">Email</Label>
+          <Input
+            id="email"
+            type="email"
+            placeholder="your@email.com"
+            value={email}
+            onChange={(e) => setEmail(e.target.value)}
+            disabled={isLoading}
+          />
+        </div>
+      ) : (
+        <div className="space-y-2">
+          <Label htmlFor="password">New Password</Label>
+          <Input
+            id="password"
+            type="password"
+            placeholder="••••••••"
+            value={password}
+            onChange={(e) => setPassword(e.target.value)}
+            disabled={isLoading}
+          />
+        </div>
+      )}
+
+      {error && (
+        <div className="p-3 bg-destructive/10 text-destructive rounded-md text-sm">
+          {error}
+        </div>
+      )}
+
+      <Button type="submit" className="w-full" disabled={isLoading}>
+        {isLoading && <Loader2 className="mr-2 h-4 w-4 animate-spin" />}
+        {mode === "request" ? "Send Reset Link" : "Reset Password"}
+      </Button>
+
+      <div className="text-center text-sm text-muted-foreground">
+        <Link href="/login" className="text-primary hover:underline">
+          Back to sign in
+        </Link>
+      </div>
+    </form>
+  );
+}
@@ -0,0 +1,67 @@
+"use client";
+
+import { useEffect, useRef, useState } from "react";
+import { Loader2 } from "lucide-react";
+import { api, refreshSession } from "@/lib/api";
+import { isAuthenticated } from "@/lib/auth";
+import Link from "next/link";
+
+type Status = "verifying" | "verified" | "failed";
+
+export function VerifyEmail({ token }: { token: string }) {
+  const [status, setStatus] = useState<Status>(token ? "verifying" : "failed");
+  // Tokens are single-use: guard against the effect running twice.
+  const started = useRef(false);
+
+  useEffect(() => {
+    if (!token || started.current) return;
+    started.current = true;
+
+    (async () => {
+      try {
+        await api.post("/api/v1/auth/email/verify", { token });
+        // The access token still says "unverified" until it is refreshed.
+        if (isAuthenticated()) await refreshSession();
+        setStatus("verified");
+      } catch {
+        setStatus("failed");
+      }
+    })();
+  }, [token]);
+
+  return (
+    <div className="space-y-6 text-center">
+      {status === "verifying" && (
+        <>
+          <Loader2 className="mx-auto h-8 w-8 animate-spin text-muted-foreground" />
+          <p className="text-muted-foreground">Verifying your email…</p>
+        </>
+      )}
+      {status === "verified" && (
+        <>
+          <h1 className="text-3xl font-bold text-foreground">Email verified</h1>
+          <p className="text-muted-foreground">
+            The AI coach is now unlocked.
+          </p>
+        </>
+      )}
+      {status === "failed" && (
+        <>
+          <h1 className="text-3xl font-bold text-foreground">
+            Link not valid
+          </h1>
+          <p className="text-muted-foreground">
+            This verification link is invalid or has expired. Request a new
+            one from Settings.
+          </p>
+        </>
+      )}
+      <Link
+        href={isAuthenticated() ? "/app" : "/login"}
+        className="text-sm text-primary hover:underline"
+      >
+        Continue
+      </Link>
+    </div>
+  );
+}
@@ -36,8 +36,17 @@ function normalizeToken(input: string): string {
   return token;
 }
 
+interface RefreshResponse {
+  token: string;
+  user_id: string;
+  refresh_token: string;
+}
+
 class ApiClient {
   private baseUrl: string;
+  // One refresh at a time: refresh tokens are single-use, so concurrent
+  // requests hitting 401 must share the same exchange.
+  private refreshing: Promise<boolean> | null = null;
 
   constructor(
     baseUrl: string = process.env.NEXT_PUBLIC_API_BASE_URL ||
@@ -73,9 +82,44 @@ class ApiClient {
     }
   }
 
+  async refreshSession(): Promise<boolean> {
+    if (this.refreshing) return this.refreshing;
+
+    this.refreshing = (async () => {
+      const { getRefreshToken, setAuthToken } = await import("@/lib/auth");
+      const refreshToken = getRefreshToken();
+      if (!refreshToken) return false;
+      try {
+        const response = await fetch(`${this.baseUrl}/api/v1/auth/refresh`, {
+          method: "POST",
+          headers: { "Content-Type": "application/json" },
+          body: JSON.stringify({ refresh_token: refreshToken }),
+        });
+        if (!response.ok) return false;
+        const json = (await response.json()) as ApiResponse<RefreshResponse>;
+        if (!json.data) return false;
+        setAuthToken(
+          json.data.token,
+          json.data.user_id,
+          json.data.refresh_token,
+        );
+        return true;
+      } catch {
+        return false;
+      }
+    })();
+
+    try {
+      return await this.refreshing;
+    } finally {
+      this.refreshing = null;
+    }
+  }
+
   private async request<T>(
     endpoint: string,
     options: RequestInit = {},
+    retried = false,
   ): Promise<T> {
     const url = `${this.baseUrl}${endpoint}`;
     const token = this.getToken();
@@ -89,6 +133,36 @@ class ApiClient {
       headers,
     });
 
+    // Access tokens are short-lived: exchange the refresh token once and
+    // replay the request before giving up on the session.
+    if (
+      response.status === 401 &&
+      !retried &&
+      !endpoint.startsWith("/api/v1/auth/") &&
+      (await this.refreshSession())
+    ) {
+      return this.request<T>(endpoint, options, true);
+    }
+
+    // The verified flag lives in the access token: after verifying, a
+    // refreshed token is enough to get through.
+    if (
+      response.status === 403 &&
+      !retried &&
+      !endpoint.startsWith("/api/v1/auth/")
+    ) {
+      const body = (await response
+        .clone()
+        .json()
+        .catch(() => null)) as ApiResponse<T> | null;
+      if (
+        body?.message === "email not verified" &&
+        (await this.refreshSession())
+      ) {
+        return this.request<T>(endpoint, options, true);
+      }
+    }
+
     let json: ApiResponse<T>;
     try {
       json = (await response.json()) as ApiResponse<T>;
@@ -144,3 +218,24 @@ class ApiClient {
 }
 
 export const api = new ApiClient();
+
+/**
+ * Exchanges the refresh token for a new pair, e.g. to pick up claims that
+ * changed server-side.
+ */
+export function refreshSession(): Promise<boolean> {
+  return api.refreshSession();
+}
+
+/**
+ * Revokes the current session on the server, then clears local auth state.
+ */
+export async function logout() {
+  try {
+    await api.post("/api/v1/auth/logout");
+  } catch {
+    // Already signed out server-side; clearing local state is enough.
+  }
+  const { clearAuth } = await import("@/lib/auth");
+  clearAuth();
+}
@@ -9,6 +9,7 @@ interface AuthState {
 
 const STORAGE_KEY = "auth_token";
 const USER_ID_KEY = "auth_user_id";
+const REFRESH_KEY = "auth_refresh_token";
 
 function setCookie(name: string, value: string) {
   const secure =
@@ -20,14 +21,24 @@ function deleteCookie(name: string) {
   document.cookie = `${name}=; Path=/; Expires=Thu, 01 Jan 1970 00:00:00 GMT; SameSite=Lax`;
 }
 
-export function setAuthToken(token: string, userId: string) {
+export function setAuthToken(
+  token: string,
+  userId: string,
+  refreshToken?: string,
+) {
   if (typeof window !== "undefined") {
     localStorage.setItem(STORAGE_KEY, token);
     localStorage.setItem(USER_ID_KEY, userId);
+    if (refreshToken) localStorage.setItem(REFRESH_KEY, refreshToken);
     setCookie(STORAGE_KEY, token);
   }
 }
 
+export function getRefreshToken(): string | null {
+  if (typeof window === "undefined") return null;
+  return localStorage.getItem(REFRESH_KEY);
+}
+
 export function getAuthToken(): string | null {
   if (typeof window === "undefined") return null;
   return localStorage.getItem(STORAGE_KEY);
@@ -51,6 +62,7 @@ export function clearAuth() {
   if (typeof window !== "undefined") {
     localStorage.removeItem(STORAGE_KEY);
     localStorage.removeItem(USER_ID_KEY);
+    localStorage.removeItem(REFRESH_KEY);
     deleteCookie(STORAGE_KEY);
   }
 }